@@ -0,0 +1,111 @@
+package abi
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// ConstructorName is the name of the function called when a contract is deployed or upgraded
+const ConstructorName = "init"
+
+const (
+	structKind = "struct"
+	enumKind   = "enum"
+)
+
+// ABI holds the parts of a standard contract ABI JSON used by the codec and the decoders
+type ABI struct {
+	Name        string                     `json:"name"`
+	Constructor *EndpointDescription       `json:"constructor"`
+	Endpoints   []*EndpointDescription     `json:"endpoints"`
+	Events      []*EventDescription        `json:"events"`
+	Types       map[string]*TypeDefinition `json:"types"`
+}
+
+// EndpointParameter describes an input or an output of an endpoint; its type can be a multi-value
+// type, such as "optional<u64>", "variadic<Address>" or "multi<u8,BigUint>", which spans several arguments
+type EndpointParameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	MultiArg    bool   `json:"multi_arg,omitempty"`
+	MultiResult bool   `json:"multi_result,omitempty"`
+}
+
+// EndpointDescription describes an endpoint of the contract, or its constructor
+type EndpointDescription struct {
+	Name            string               `json:"name"`
+	Mutability      string               `json:"mutability,omitempty"`
+	PayableInTokens []string             `json:"payableInTokens,omitempty"`
+	Inputs          []*EndpointParameter `json:"inputs"`
+	Outputs         []*EndpointParameter `json:"outputs"`
+}
+
+// FieldDefinition describes a field of a struct or of an enum variant
+type FieldDefinition struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// VariantDefinition describes a variant of an enum, encoded as its discriminant followed by its fields
+type VariantDefinition struct {
+	Name         string             `json:"name"`
+	Discriminant int                `json:"discriminant"`
+	Fields       []*FieldDefinition `json:"fields,omitempty"`
+}
+
+// TypeDefinition describes a custom type of the contract, either a struct or an enum
+type TypeDefinition struct {
+	Type     string               `json:"type"`
+	Fields   []*FieldDefinition   `json:"fields,omitempty"`
+	Variants []*VariantDefinition `json:"variants,omitempty"`
+}
+
+// EventInput describes a field of an event; indexed fields are emitted as topics,
+// the others are encoded into the data of the log entry
+type EventInput struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+// EventDescription describes an event emitted by the contract
+type EventDescription struct {
+	Identifier string        `json:"identifier"`
+	Inputs     []*EventInput `json:"inputs"`
+}
+
+// ParseABI parses a contract ABI from its JSON representation
+func ParseABI(data []byte) (*ABI, error) {
+	abi := &ABI{}
+	err := json.Unmarshal(data, abi)
+	if err != nil {
+		return nil, err
+	}
+
+	return abi, nil
+}
+
+// LoadABI reads and parses the contract ABI JSON file at the given path
+func LoadABI(path string) (*ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseABI(data)
+}
+
+// Endpoint returns the description of the endpoint with the given name; the constructor is found as "init"
+func (abi *ABI) Endpoint(name string) (*EndpointDescription, bool) {
+	for _, endpoint := range abi.Endpoints {
+		if endpoint.Name == name {
+			return endpoint, true
+		}
+	}
+
+	if name == ConstructorName && abi.Constructor != nil {
+		return abi.Constructor, true
+	}
+
+	return nil, false
+}
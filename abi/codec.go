@@ -0,0 +1,217 @@
+package abi
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	optionalType = "optional"
+	variadicType = "variadic"
+	multiType    = "multi"
+)
+
+var builtInCodec = NewBuiltInCodec()
+
+// Codec encodes and decodes the values of the types described by an ABI, its structs and enums included
+type Codec struct {
+	types map[string]*TypeDefinition
+}
+
+// NewBuiltInCodec creates a Codec which only knows the built-in types, such as "u64", "BigUint" or "List<Address>"
+func NewBuiltInCodec() *Codec {
+	return &Codec{
+		types: make(map[string]*TypeDefinition),
+	}
+}
+
+// NewCodec creates a Codec for the types of the given ABI; it checks the custom types
+// and the types of the endpoint inputs and outputs
+func NewCodec(abi *ABI) (*Codec, error) {
+	if abi == nil {
+		return nil, ErrNilABI
+	}
+
+	codec := NewBuiltInCodec()
+	names := make([]string, 0, len(abi.Types))
+	for name, definition := range abi.Types {
+		if definition == nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTypeDefinition, name)
+		}
+		codec.types[name] = definition
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		err := codec.checkTypeDefinition(codec.types[name])
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+	}
+
+	endpoints := abi.Endpoints
+	if abi.Constructor != nil {
+		endpoints = append([]*EndpointDescription{abi.Constructor}, endpoints...)
+	}
+	for _, endpoint := range endpoints {
+		err := codec.checkEndpoint(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", endpoint.Name, err)
+		}
+	}
+
+	return codec, nil
+}
+
+func (codec *Codec) checkTypeDefinition(definition *TypeDefinition) error {
+	switch definition.Type {
+	case structKind:
+		return codec.checkFields(definition.Fields)
+	case enumKind:
+		names := make(map[string]struct{})
+		discriminants := make(map[int]struct{})
+		for _, variant := range definition.Variants {
+			_, duplicateName := names[variant.Name]
+			_, duplicateDiscriminant := discriminants[variant.Discriminant]
+			if duplicateName || duplicateDiscriminant {
+				return fmt.Errorf("%w: duplicate variant %s", ErrInvalidTypeDefinition, variant.Name)
+			}
+			if variant.Discriminant < 0 || variant.Discriminant > 255 {
+				return fmt.Errorf("%w: discriminant of variant %s", ErrInvalidTypeDefinition, variant.Name)
+			}
+			names[variant.Name] = struct{}{}
+			discriminants[variant.Discriminant] = struct{}{}
+
+			err := codec.checkFields(variant.Fields)
+			if err != nil {
+				return fmt.Errorf("variant %s: %w", variant.Name, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown kind %s", ErrInvalidTypeDefinition, definition.Type)
+	}
+}
+
+func (codec *Codec) checkFields(fields []*FieldDefinition) error {
+	for _, field := range fields {
+		description, err := ParseTypeDescription(field.Type)
+		if err != nil {
+			return err
+		}
+
+		err = codec.checkType(description)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (codec *Codec) checkEndpoint(endpoint *EndpointDescription) error {
+	parameters := append(append([]*EndpointParameter{}, endpoint.Inputs...), endpoint.Outputs...)
+	for _, parameter := range parameters {
+		description, err := ParseTypeDescription(parameter.Type)
+		if err != nil {
+			return err
+		}
+
+		err = codec.checkMultiValueType(description)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", parameter.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// checkMultiValueType checks the types of the endpoint inputs and outputs, which can span several arguments
+func (codec *Codec) checkMultiValueType(description *TypeDescription) error {
+	switch description.Name {
+	case optionalType, variadicType:
+		itemType, err := singleTypeArgument(description)
+		if err != nil {
+			return err
+		}
+		return codec.checkMultiValueType(itemType)
+	case multiType:
+		if len(description.Args) == 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidTypeName, description)
+		}
+		for _, itemType := range description.Args {
+			err := codec.checkMultiValueType(itemType)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return codec.checkType(description)
+	}
+}
+
+func (codec *Codec) checkType(description *TypeDescription) error {
+	name := description.Name
+	_, isUnsigned := unsignedSizes[name]
+	_, isSigned := signedSizes[name]
+	_, isFixedBytes := fixedBytesSizes[name]
+	_, isBytes := bytesTypes[name]
+	_, isString := stringTypes[name]
+	_, isCustom := codec.types[name]
+	isScalar := isUnsigned || isSigned || isFixedBytes || isBytes || isString || isCustom ||
+		name == "BigUint" || name == "BigInt" || name == "bool"
+
+	switch {
+	case isScalar:
+		if len(description.Args) > 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidTypeName, description)
+		}
+		return nil
+	case name == "Option", name == "List", name == "Vec", strings.HasPrefix(name, "array"):
+		itemType, err := singleTypeArgument(description)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(name, "array") {
+			_, err = strconv.Atoi(strings.TrimPrefix(name, "array"))
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrUnsupportedType, description)
+			}
+		}
+		return codec.checkType(itemType)
+	case name == "tuple":
+		for _, itemType := range description.Args {
+			err := codec.checkType(itemType)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, description)
+	}
+}
+
+func variantByDiscriminant(definition *TypeDefinition, discriminant int) (*VariantDefinition, bool) {
+	for _, variant := range definition.Variants {
+		if variant.Discriminant == discriminant {
+			return variant, true
+		}
+	}
+
+	return nil, false
+}
+
+func variantByName(definition *TypeDefinition, name string) (*VariantDefinition, bool) {
+	for _, variant := range definition.Variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+
+	return nil, false
+}
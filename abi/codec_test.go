@@ -0,0 +1,276 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCodecABI = `{
+	"name": "Auction",
+	"constructor": {
+		"inputs": [{"name": "minBid", "type": "BigUint"}],
+		"outputs": []
+	},
+	"endpoints": [
+		{
+			"name": "bid",
+			"mutability": "mutable",
+			"inputs": [
+				{"name": "bid", "type": "Bid"},
+				{"name": "note", "type": "optional<bytes>"}
+			],
+			"outputs": [{"type": "Status"}]
+		},
+		{
+			"name": "getBids",
+			"mutability": "readonly",
+			"inputs": [{"name": "bidders", "type": "variadic<Address>", "multi_arg": true}],
+			"outputs": [{"type": "variadic<multi<Address,BigUint>>", "multi_result": true}]
+		}
+	],
+	"events": [
+		{
+			"identifier": "newBid",
+			"inputs": [
+				{"name": "bidder", "type": "Address", "indexed": true},
+				{"name": "bid", "type": "Bid"}
+			]
+		}
+	],
+	"types": {
+		"Bid": {
+			"type": "struct",
+			"fields": [
+				{"name": "amount", "type": "BigUint"},
+				{"name": "deadline", "type": "u64"},
+				{"name": "status", "type": "Status"},
+				{"name": "memo", "type": "Option<utf-8 string>"}
+			]
+		},
+		"Status": {
+			"type": "enum",
+			"variants": [
+				{"name": "Open", "discriminant": 0},
+				{"name": "Closed", "discriminant": 1},
+				{"name": "Won", "discriminant": 2, "fields": [{"name": "by", "type": "Address"}, {"name": "round", "type": "u8"}]}
+			]
+		}
+	}
+}`
+
+func createTestCodec(t *testing.T) (*ABI, *Codec) {
+	abi, err := ParseABI([]byte(testCodecABI))
+	require.Nil(t, err)
+
+	codec, err := NewCodec(abi)
+	require.Nil(t, err)
+	return abi, codec
+}
+
+func requireEncoded(t *testing.T, codec *Codec, typeName string, value interface{}, topLevel []byte, nested []byte) {
+	description, err := ParseTypeDescription(typeName)
+	require.Nil(t, err)
+
+	encoded, err := codec.EncodeTopLevel(description, value)
+	require.Nil(t, err)
+	require.Equal(t, topLevel, encoded, "top level "+typeName)
+
+	encoded, err = codec.EncodeNested(description, value)
+	require.Nil(t, err)
+	require.Equal(t, nested, encoded, "nested "+typeName)
+}
+
+// requireJSONRoundTrip decodes the data, renders it in JSON and encodes the JSON value back
+func requireJSONRoundTrip(t *testing.T, codec *Codec, typeName string, data []byte) {
+	description, err := ParseTypeDescription(typeName)
+	require.Nil(t, err)
+
+	decoded, err := codec.DecodeTopLevel(description, data)
+	require.Nil(t, err)
+
+	asJSON, err := json.Marshal(JSONValue(decoded))
+	require.Nil(t, err)
+
+	decoder := json.NewDecoder(bytes.NewReader(asJSON))
+	decoder.UseNumber()
+	var value interface{}
+	require.Nil(t, decoder.Decode(&value))
+
+	encoded, err := codec.EncodeTopLevel(description, value)
+	require.Nil(t, err)
+	require.Equal(t, data, encoded, string(asJSON))
+}
+
+func TestNewCodec_Errors(t *testing.T) {
+	codec, err := NewCodec(nil)
+	require.Nil(t, codec)
+	require.Equal(t, ErrNilABI, err)
+
+	abi := &ABI{Types: map[string]*TypeDefinition{"A": {Type: "union"}}}
+	_, err = NewCodec(abi)
+	require.True(t, errors.Is(err, ErrInvalidTypeDefinition))
+
+	abi = &ABI{Types: map[string]*TypeDefinition{"A": {Type: "struct", Fields: []*FieldDefinition{{Name: "b", Type: "B"}}}}}
+	_, err = NewCodec(abi)
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+
+	abi = &ABI{Types: map[string]*TypeDefinition{"A": {Type: "enum", Variants: []*VariantDefinition{{Name: "X"}, {Name: "Y"}}}}}
+	_, err = NewCodec(abi)
+	require.True(t, errors.Is(err, ErrInvalidTypeDefinition))
+
+	abi = &ABI{Endpoints: []*EndpointDescription{{Name: "f", Inputs: []*EndpointParameter{{Name: "a", Type: "List<optional<u8>>"}}}}}
+	_, err = NewCodec(abi)
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func TestCodec_EncodeBuiltInTypes(t *testing.T) {
+	codec := NewBuiltInCodec()
+
+	requireEncoded(t, codec, "u8", uint64(0), []byte{}, []byte{0x00})
+	requireEncoded(t, codec, "u32", json.Number("256"), []byte{0x01, 0x00}, []byte{0x00, 0x00, 0x01, 0x00})
+	requireEncoded(t, codec, "u64", "0x1_00", []byte{0x01, 0x00}, []byte{0, 0, 0, 0, 0, 0, 0x01, 0x00})
+	requireEncoded(t, codec, "i16", int64(-1), []byte{0xFF}, []byte{0xFF, 0xFF})
+	requireEncoded(t, codec, "i32", float64(128), []byte{0x00, 0x80}, []byte{0x00, 0x00, 0x00, 0x80})
+	requireEncoded(t, codec, "BigUint", "1000", []byte{0x03, 0xE8}, []byte{0x00, 0x00, 0x00, 0x02, 0x03, 0xE8})
+	requireEncoded(t, codec, "BigInt", big.NewInt(-256), []byte{0xFF, 0x00}, []byte{0x00, 0x00, 0x00, 0x02, 0xFF, 0x00})
+	requireEncoded(t, codec, "bool", false, []byte{}, []byte{0x00})
+	requireEncoded(t, codec, "bool", true, []byte{0x01}, []byte{0x01})
+	requireEncoded(t, codec, "bytes", "0x0102", []byte{0x01, 0x02}, []byte{0x00, 0x00, 0x00, 0x02, 0x01, 0x02})
+	requireEncoded(t, codec, "TokenIdentifier", "TKN-123456", []byte("TKN-123456"), append([]byte{0x00, 0x00, 0x00, 0x0A}, "TKN-123456"...))
+	requireEncoded(t, codec, "Option<u16>", nil, []byte{}, []byte{0x00})
+	requireEncoded(t, codec, "Option<u16>", 7, []byte{0x01, 0x00, 0x07}, []byte{0x01, 0x00, 0x07})
+	requireEncoded(t, codec, "List<u16>", []interface{}{1, 2}, []byte{0x00, 0x01, 0x00, 0x02}, []byte{0x00, 0x00, 0x00, 0x02, 0x00, 0x01, 0x00, 0x02})
+	requireEncoded(t, codec, "List<u8>", "0a0b", []byte{0x0A, 0x0B}, []byte{0x00, 0x00, 0x00, 0x02, 0x0A, 0x0B})
+	requireEncoded(t, codec, "array2<u8>", []byte{0x01, 0x02}, []byte{0x01, 0x02}, []byte{0x01, 0x02})
+	requireEncoded(t, codec, "tuple<u8,bytes>", []interface{}{5, "78"}, []byte{0x05, 0x00, 0x00, 0x00, 0x01, 'x'}, []byte{0x05, 0x00, 0x00, 0x00, 0x01, 'x'})
+
+	address := make([]byte, addressSize)
+	address[0] = 0x01
+	address[31] = 0xE1
+	requireEncoded(t, codec, "Address", "0x01000000000000000000000000000000000000000000000000000000000000e1", address, address)
+
+	alice := []byte{0x01, 0x39, 0x47, 0x2e, 0xff, 0x68, 0x86, 0x77, 0x1a, 0x98, 0x2f, 0x30, 0x83, 0xda, 0x5d, 0x42,
+		0x1f, 0x24, 0xc2, 0x91, 0x81, 0xe6, 0x38, 0x88, 0x22, 0x8d, 0xc8, 0x1c, 0xa6, 0x0d, 0x69, 0xe1}
+	requireEncoded(t, codec, "Address", "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", alice, alice)
+}
+
+func TestCodec_EncodeErrors(t *testing.T) {
+	codec := NewBuiltInCodec()
+
+	description, _ := ParseTypeDescription("u8")
+	_, err := codec.EncodeTopLevel(description, 256)
+	require.True(t, errors.Is(err, ErrIntegerOverflow))
+
+	_, err = codec.EncodeTopLevel(description, -1)
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	_, err = codec.EncodeTopLevel(description, 1.5)
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	description, _ = ParseTypeDescription("i8")
+	_, err = codec.EncodeNested(description, 128)
+	require.True(t, errors.Is(err, ErrIntegerOverflow))
+
+	description, _ = ParseTypeDescription("Address")
+	_, err = codec.EncodeTopLevel(description, "0102")
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	description, _ = ParseTypeDescription("array3<u8>")
+	_, err = codec.EncodeTopLevel(description, "0102")
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	description, _ = ParseTypeDescription("optional<u8>")
+	_, err = codec.EncodeTopLevel(description, 1)
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func TestCodec_CustomTypes(t *testing.T) {
+	_, codec := createTestCodec(t)
+
+	requireEncoded(t, codec, "Status", "Open", []byte{}, []byte{0x00})
+	requireEncoded(t, codec, "Status", "Closed", []byte{0x01}, []byte{0x01})
+
+	address := makeTestAddress(7)
+	won := map[string]interface{}{
+		"Won": map[string]interface{}{"by": address, "round": 3},
+	}
+	expected := append(append([]byte{0x02}, address...), 0x03)
+	requireEncoded(t, codec, "Status", won, expected, expected)
+
+	bid := map[string]interface{}{
+		"amount":   "1000",
+		"deadline": 5,
+		"status":   "Closed",
+		"memo":     "hi",
+	}
+	expected = []byte{
+		0x00, 0x00, 0x00, 0x02, 0x03, 0xE8,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05,
+		0x01,
+		0x01, 0x00, 0x00, 0x00, 0x02, 'h', 'i',
+	}
+	requireEncoded(t, codec, "Bid", bid, expected, expected)
+
+	description, _ := ParseTypeDescription("Bid")
+	decoded, err := codec.DecodeTopLevel(description, expected)
+	require.Nil(t, err)
+	structValue := decoded.(*StructValue)
+	require.Equal(t, "Bid", structValue.Type)
+	amount, _ := structValue.Field("amount")
+	require.Equal(t, big.NewInt(1000), amount)
+	status, _ := structValue.Field("status")
+	require.Equal(t, "Closed", status.(*EnumValue).Variant)
+
+	asJSON, err := json.Marshal(decoded)
+	require.Nil(t, err)
+	require.Equal(t, `{"amount":"1000","deadline":5,"status":"Closed","memo":"hi"}`, string(asJSON))
+
+	// the decoded values are accepted by the encoder as they are
+	encoded, err := codec.EncodeTopLevel(description, decoded)
+	require.Nil(t, err)
+	require.Equal(t, expected, encoded)
+
+	delete(bid, "memo")
+	_, err = codec.EncodeTopLevel(description, bid)
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	description, _ = ParseTypeDescription("Status")
+	_, err = codec.EncodeTopLevel(description, "Lost")
+	require.True(t, errors.Is(err, ErrUnknownVariant))
+
+	_, err = codec.DecodeTopLevel(description, []byte{0x05})
+	require.True(t, errors.Is(err, ErrUnknownVariant))
+
+	decoded, err = codec.DecodeTopLevel(description, []byte{})
+	require.Nil(t, err)
+	require.Equal(t, "Open", decoded.(*EnumValue).Variant)
+}
+
+func TestCodec_JSONRoundTrip(t *testing.T) {
+	_, codec := createTestCodec(t)
+
+	requireJSONRoundTrip(t, codec, "u64", []byte{0x01, 0x00})
+	requireJSONRoundTrip(t, codec, "i64", []byte{0xFF, 0x00})
+	requireJSONRoundTrip(t, codec, "BigUint", bytes.Repeat([]byte{0xFF}, 20))
+	requireJSONRoundTrip(t, codec, "BigInt", []byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	requireJSONRoundTrip(t, codec, "bytes", []byte{0x00, 0x01})
+	requireJSONRoundTrip(t, codec, "Address", makeTestAddress(1))
+	requireJSONRoundTrip(t, codec, "List<Option<u8>>", []byte{0x00, 0x01, 0x07})
+	requireJSONRoundTrip(t, codec, "array2<u8>", []byte{0x01, 0x02})
+	requireJSONRoundTrip(t, codec, "tuple<bool,utf-8 string>", []byte{0x01, 0x00, 0x00, 0x00, 0x01, '<'})
+
+	won := append(append([]byte{0x02}, makeTestAddress(2)...), 0x01)
+	requireJSONRoundTrip(t, codec, "Status", won)
+	requireJSONRoundTrip(t, codec, "List<Status>", append([]byte{0x00, 0x01}, won...))
+	requireJSONRoundTrip(t, codec, "Bid", []byte{
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05,
+		0x00,
+		0x00,
+	})
+}
@@ -0,0 +1,461 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	lengthPrefixSize = 4
+	addressSize      = 32
+	optionTagNone    = 0
+	optionTagSome    = 1
+)
+
+var unsignedSizes = map[string]int{
+	"u8":    1,
+	"u16":   2,
+	"u32":   4,
+	"u64":   8,
+	"usize": 4,
+}
+
+var signedSizes = map[string]int{
+	"i8":    1,
+	"i16":   2,
+	"i32":   4,
+	"i64":   8,
+	"isize": 4,
+}
+
+var fixedBytesSizes = map[string]int{
+	"Address": addressSize,
+	"H256":    addressSize,
+}
+
+var bytesTypes = map[string]struct{}{
+	"bytes":         {},
+	"ManagedBuffer": {},
+	"BoxedBytes":    {},
+}
+
+var stringTypes = map[string]struct{}{
+	"utf-8 string":              {},
+	"TokenIdentifier":           {},
+	"EgldOrEsdtTokenIdentifier": {},
+}
+
+// DecodeTopLevel decodes a value encoded on its own, as in a return data item or an event topic:
+// numbers drop their leading zeros and buffers are not prefixed by their length
+func DecodeTopLevel(description *TypeDescription, data []byte) (interface{}, error) {
+	return builtInCodec.DecodeTopLevel(description, data)
+}
+
+// DecodeNested decodes a value encoded as part of a larger structure, where numbers
+// have their full size and buffers and lists are prefixed by their length; it returns
+// the decoded value and the remaining bytes
+func DecodeNested(description *TypeDescription, data []byte) (interface{}, []byte, error) {
+	return builtInCodec.DecodeNested(description, data)
+}
+
+// DecodeTopLevel decodes a value encoded on its own, custom types included
+func (codec *Codec) DecodeTopLevel(description *TypeDescription, data []byte) (interface{}, error) {
+	name := description.Name
+
+	if size, ok := unsignedSizes[name]; ok {
+		value := big.NewInt(0).SetBytes(data)
+		if value.BitLen() > size*8 {
+			return nil, fmt.Errorf("%w: %s", ErrIntegerOverflow, name)
+		}
+		return value.Uint64(), nil
+	}
+
+	if size, ok := signedSizes[name]; ok {
+		if len(data) > size {
+			return nil, fmt.Errorf("%w: %s", ErrIntegerOverflow, name)
+		}
+		return decodeSigned(data).Int64(), nil
+	}
+
+	if _, ok := bytesTypes[name]; ok {
+		return cloneBytes(data), nil
+	}
+
+	if _, ok := stringTypes[name]; ok {
+		return string(data), nil
+	}
+
+	switch name {
+	case "BigUint":
+		return big.NewInt(0).SetBytes(data), nil
+	case "BigInt":
+		return decodeSigned(data), nil
+	case "bool":
+		return decodeBool(data)
+	case "Option":
+		if len(data) == 0 {
+			return nil, nil
+		}
+		return codec.decodeAllNested(description, data)
+	case "List", "Vec":
+		return codec.decodeTopLevelList(description, data)
+	}
+
+	// the first variant of an enum, if it has no fields, is encoded as nothing
+	definition, isCustom := codec.types[name]
+	if isCustom && definition.Type == enumKind && len(data) == 0 {
+		data = []byte{0}
+	}
+
+	return codec.decodeAllNested(description, data)
+}
+
+// DecodeNested decodes a nested encoded value, custom types included, and returns the remaining bytes
+func (codec *Codec) DecodeNested(description *TypeDescription, data []byte) (interface{}, []byte, error) {
+	reader := codec.newNestedReader(data)
+	value, err := reader.decode(description)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, reader.data[reader.offset:], nil
+}
+
+func (codec *Codec) decodeAllNested(description *TypeDescription, data []byte) (interface{}, error) {
+	value, rest, err := codec.DecodeNested(description, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%w: %d bytes after %s", ErrTrailingData, len(rest), description)
+	}
+
+	return value, nil
+}
+
+func (codec *Codec) decodeTopLevelList(description *TypeDescription, data []byte) (interface{}, error) {
+	itemType, err := singleTypeArgument(description)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := codec.newNestedReader(data)
+	items := make([]interface{}, 0)
+	for reader.offset < len(reader.data) {
+		item, err := reader.decode(itemType)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+type nestedReader struct {
+	codec  *Codec
+	data   []byte
+	offset int
+}
+
+func (codec *Codec) newNestedReader(data []byte) *nestedReader {
+	return &nestedReader{
+		codec: codec,
+		data:  data,
+	}
+}
+
+func (reader *nestedReader) read(length int) ([]byte, error) {
+	if length < 0 || reader.offset+length > len(reader.data) {
+		return nil, ErrUnexpectedEnd
+	}
+
+	bytes := reader.data[reader.offset : reader.offset+length]
+	reader.offset += length
+	return bytes, nil
+}
+
+func (reader *nestedReader) readLength() (int, error) {
+	bytes, err := reader.read(lengthPrefixSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(binary.BigEndian.Uint32(bytes)), nil
+}
+
+func (reader *nestedReader) readLengthPrefixed() ([]byte, error) {
+	length, err := reader.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	return reader.read(length)
+}
+
+func (reader *nestedReader) decode(description *TypeDescription) (interface{}, error) {
+	name := description.Name
+
+	if size, ok := unsignedSizes[name]; ok {
+		bytes, err := reader.read(size)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(0).SetBytes(bytes).Uint64(), nil
+	}
+
+	if size, ok := signedSizes[name]; ok {
+		bytes, err := reader.read(size)
+		if err != nil {
+			return nil, err
+		}
+		return decodeSigned(bytes).Int64(), nil
+	}
+
+	if size, ok := fixedBytesSizes[name]; ok {
+		bytes, err := reader.read(size)
+		if err != nil {
+			return nil, err
+		}
+		return cloneBytes(bytes), nil
+	}
+
+	if _, ok := bytesTypes[name]; ok {
+		bytes, err := reader.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		return cloneBytes(bytes), nil
+	}
+
+	if _, ok := stringTypes[name]; ok {
+		bytes, err := reader.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		return string(bytes), nil
+	}
+
+	switch name {
+	case "BigUint":
+		bytes, err := reader.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(0).SetBytes(bytes), nil
+	case "BigInt":
+		bytes, err := reader.readLengthPrefixed()
+		if err != nil {
+			return nil, err
+		}
+		return decodeSigned(bytes), nil
+	case "bool":
+		bytes, err := reader.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return decodeBool(bytes)
+	case "Option":
+		return reader.decodeOption(description)
+	case "List", "Vec":
+		return reader.decodeList(description)
+	case "tuple":
+		return reader.decodeItems(description.Args)
+	}
+
+	if strings.HasPrefix(name, "array") {
+		return reader.decodeArray(description)
+	}
+
+	definition, isCustom := reader.codec.types[name]
+	if isCustom && definition.Type == structKind {
+		return reader.decodeStruct(name, definition)
+	}
+	if isCustom && definition.Type == enumKind {
+		return reader.decodeEnum(name, definition)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, description)
+}
+
+func (reader *nestedReader) decodeStruct(name string, definition *TypeDefinition) (interface{}, error) {
+	fields, err := reader.decodeFields(definition.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("struct %s: %w", name, err)
+	}
+
+	return &StructValue{
+		Type:   name,
+		Fields: fields,
+	}, nil
+}
+
+func (reader *nestedReader) decodeEnum(name string, definition *TypeDefinition) (interface{}, error) {
+	discriminant, err := reader.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, ok := variantByDiscriminant(definition, int(discriminant[0]))
+	if !ok {
+		return nil, fmt.Errorf("%w: %s, discriminant %d", ErrUnknownVariant, name, discriminant[0])
+	}
+
+	fields, err := reader.decodeFields(variant.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("enum %s, variant %s: %w", name, variant.Name, err)
+	}
+
+	return &EnumValue{
+		Type:         name,
+		Variant:      variant.Name,
+		Discriminant: variant.Discriminant,
+		Fields:       fields,
+	}, nil
+}
+
+func (reader *nestedReader) decodeFields(definitions []*FieldDefinition) ([]*FieldValue, error) {
+	fields := make([]*FieldValue, 0, len(definitions))
+	for _, definition := range definitions {
+		description, err := ParseTypeDescription(definition.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := reader.decode(description)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", definition.Name, err)
+		}
+		fields = append(fields, &FieldValue{
+			Name:  definition.Name,
+			Value: value,
+		})
+	}
+
+	return fields, nil
+}
+
+func (reader *nestedReader) decodeOption(description *TypeDescription) (interface{}, error) {
+	itemType, err := singleTypeArgument(description)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := reader.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag[0] {
+	case optionTagNone:
+		return nil, nil
+	case optionTagSome:
+		return reader.decode(itemType)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidOptionTag, tag[0])
+	}
+}
+
+func (reader *nestedReader) decodeList(description *TypeDescription) (interface{}, error) {
+	itemType, err := singleTypeArgument(description)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := reader.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, 0)
+	for i := 0; i < length; i++ {
+		item, err := reader.decode(itemType)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (reader *nestedReader) decodeArray(description *TypeDescription) (interface{}, error) {
+	length, err := strconv.Atoi(strings.TrimPrefix(description.Name, "array"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, description)
+	}
+
+	itemType, err := singleTypeArgument(description)
+	if err != nil {
+		return nil, err
+	}
+
+	// arrays of bytes are returned as byte slices rather than as lists of numbers
+	if itemType.Name == "u8" {
+		bytes, err := reader.read(length)
+		if err != nil {
+			return nil, err
+		}
+		return cloneBytes(bytes), nil
+	}
+
+	items := make([]*TypeDescription, length)
+	for i := range items {
+		items[i] = itemType
+	}
+
+	return reader.decodeItems(items)
+}
+
+func (reader *nestedReader) decodeItems(itemTypes []*TypeDescription) ([]interface{}, error) {
+	items := make([]interface{}, len(itemTypes))
+	for i, itemType := range itemTypes {
+		item, err := reader.decode(itemType)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+
+	return items, nil
+}
+
+func singleTypeArgument(description *TypeDescription) (*TypeDescription, error) {
+	if len(description.Args) != 1 {
+		return nil, fmt.Errorf("%w: %s expects one type argument", ErrInvalidTypeName, description)
+	}
+
+	return description.Args[0], nil
+}
+
+func decodeSigned(data []byte) *big.Int {
+	value := big.NewInt(0).SetBytes(data)
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		modulus := big.NewInt(0).Lsh(big.NewInt(1), uint(len(data)*8))
+		value.Sub(value, modulus)
+	}
+
+	return value
+}
+
+func decodeBool(data []byte) (bool, error) {
+	switch {
+	case len(data) == 0:
+		return false, nil
+	case len(data) == 1 && data[0] == 0:
+		return false, nil
+	case len(data) == 1 && data[0] == 1:
+		return true, nil
+	default:
+		return false, ErrInvalidBool
+	}
+}
+
+func cloneBytes(data []byte) []byte {
+	clone := make([]byte, len(data))
+	copy(clone, data)
+	return clone
+}
@@ -0,0 +1,83 @@
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireTopLevel(t *testing.T, typeName string, data []byte, expected interface{}) {
+	description, err := ParseTypeDescription(typeName)
+	require.Nil(t, err)
+
+	value, err := DecodeTopLevel(description, data)
+	require.Nil(t, err)
+	require.Equal(t, expected, value)
+}
+
+func TestParseTypeDescription(t *testing.T) {
+	description, err := ParseTypeDescription("List<Option<tuple<u64, Address>>>")
+	require.Nil(t, err)
+	require.Equal(t, "List", description.Name)
+	require.Equal(t, "List<Option<tuple<u64,Address>>>", description.String())
+
+	description, err = ParseTypeDescription("utf-8 string")
+	require.Nil(t, err)
+	require.Equal(t, "utf-8 string", description.Name)
+
+	_, err = ParseTypeDescription("List<u64")
+	require.True(t, errors.Is(err, ErrInvalidTypeName))
+
+	_, err = ParseTypeDescription("List<u64>>")
+	require.True(t, errors.Is(err, ErrInvalidTypeName))
+}
+
+func TestDecodeTopLevel(t *testing.T) {
+	requireTopLevel(t, "u64", []byte{}, uint64(0))
+	requireTopLevel(t, "u64", []byte{0x01, 0x00}, uint64(256))
+	requireTopLevel(t, "i32", []byte{0xFF}, int64(-1))
+	requireTopLevel(t, "BigUint", []byte{0x01, 0x00}, big.NewInt(256))
+	requireTopLevel(t, "BigInt", []byte{0xFF, 0x00}, big.NewInt(-256))
+	requireTopLevel(t, "bool", []byte{0x01}, true)
+	requireTopLevel(t, "bool", []byte{}, false)
+	requireTopLevel(t, "TokenIdentifier", []byte("TOKEN-123456"), "TOKEN-123456")
+	requireTopLevel(t, "bytes", []byte("abc"), []byte("abc"))
+	requireTopLevel(t, "Option<u32>", []byte{}, nil)
+	requireTopLevel(t, "Option<u32>", []byte{0x01, 0x00, 0x00, 0x00, 0x07}, uint64(7))
+	requireTopLevel(t, "List<u16>", []byte{0x00, 0x01, 0x00, 0x02}, []interface{}{uint64(1), uint64(2)})
+	requireTopLevel(t, "tuple<u8,bytes>", []byte{0x05, 0x00, 0x00, 0x00, 0x01, 'x'}, []interface{}{uint64(5), []byte("x")})
+	requireTopLevel(t, "array2<u8>", []byte{0x01, 0x02}, []byte{0x01, 0x02})
+
+	description, _ := ParseTypeDescription("u8")
+	_, err := DecodeTopLevel(description, []byte{0x01, 0x00})
+	require.True(t, errors.Is(err, ErrIntegerOverflow))
+
+	description, _ = ParseTypeDescription("bool")
+	_, err = DecodeTopLevel(description, []byte{0x02})
+	require.True(t, errors.Is(err, ErrInvalidBool))
+
+	description, _ = ParseTypeDescription("Address")
+	_, err = DecodeTopLevel(description, make([]byte, 31))
+	require.True(t, errors.Is(err, ErrUnexpectedEnd))
+
+	_, err = DecodeTopLevel(description, make([]byte, 33))
+	require.True(t, errors.Is(err, ErrTrailingData))
+}
+
+func TestDecodeNested(t *testing.T) {
+	description, _ := ParseTypeDescription("BigUint")
+	value, rest, err := DecodeNested(description, []byte{0x00, 0x00, 0x00, 0x01, 0x0A, 0xFF})
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(10), value)
+	require.Equal(t, []byte{0xFF}, rest)
+
+	description, _ = ParseTypeDescription("Option<u8>")
+	_, _, err = DecodeNested(description, []byte{0x02})
+	require.True(t, errors.Is(err, ErrInvalidOptionTag))
+
+	description, _ = ParseTypeDescription("MyStruct")
+	_, _, err = DecodeNested(description, []byte{0x00})
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+}
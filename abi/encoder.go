@@ -0,0 +1,597 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+const bech32AddressPrefix = "erd"
+
+// EncodeTopLevel encodes a value on its own, as in a call argument or a storage value.
+// It accepts the values returned by the decoder, as well as their JSON forms (see JSONValue):
+// numbers as Go integers, json.Number or strings (decimal, or hex starting with "0x"),
+// bytes as []byte or hex strings, addresses also as bech32 strings, None as nil,
+// lists and tuples as []interface{}, structs as *StructValue or map[string]interface{}
+// and enums as *EnumValue, as the name of a variant without fields or as {"<variant>": {<fields>}}.
+func (codec *Codec) EncodeTopLevel(description *TypeDescription, value interface{}) ([]byte, error) {
+	name := description.Name
+
+	if size, ok := unsignedSizes[name]; ok {
+		number, err := toUnsigned(value, size, description)
+		if err != nil {
+			return nil, err
+		}
+		return number.Bytes(), nil
+	}
+
+	if size, ok := signedSizes[name]; ok {
+		number, err := toSigned(value, size, description)
+		if err != nil {
+			return nil, err
+		}
+		return twos.ToBytes(number), nil
+	}
+
+	if _, ok := bytesTypes[name]; ok {
+		return toBytes(value, description)
+	}
+
+	if _, ok := stringTypes[name]; ok {
+		return toStringBytes(value, description)
+	}
+
+	switch name {
+	case "BigUint":
+		number, err := toUnsigned(value, 0, description)
+		if err != nil {
+			return nil, err
+		}
+		return number.Bytes(), nil
+	case "BigInt":
+		number, err := toBigInt(value, description)
+		if err != nil {
+			return nil, err
+		}
+		return twos.ToBytes(number), nil
+	case "bool":
+		flag, err := toBool(value, description)
+		if err != nil {
+			return nil, err
+		}
+		if flag {
+			return []byte{1}, nil
+		}
+		return []byte{}, nil
+	case "Option":
+		if value == nil {
+			return []byte{}, nil
+		}
+	case "List", "Vec":
+		itemType, err := singleTypeArgument(description)
+		if err != nil {
+			return nil, err
+		}
+		items, err := toItems(value, itemType, description)
+		if err != nil {
+			return nil, err
+		}
+		var buffer bytes.Buffer
+		for _, item := range items {
+			err = codec.encodeNested(&buffer, itemType, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buffer.Bytes(), nil
+	}
+
+	encoded, err := codec.EncodeNested(description, value)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first variant of an enum, if it has no fields, is encoded as nothing
+	definition, isCustom := codec.types[name]
+	if isCustom && definition.Type == enumKind && bytes.Equal(encoded, []byte{0}) {
+		return []byte{}, nil
+	}
+
+	return encoded, nil
+}
+
+// EncodeNested encodes a value as part of a larger structure, see EncodeTopLevel for the accepted values
+func (codec *Codec) EncodeNested(description *TypeDescription, value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := codec.encodeNested(&buffer, description, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (codec *Codec) encodeNested(buffer *bytes.Buffer, description *TypeDescription, value interface{}) error {
+	name := description.Name
+
+	if size, ok := unsignedSizes[name]; ok {
+		number, err := toUnsigned(value, size, description)
+		if err != nil {
+			return err
+		}
+		buffer.Write(number.FillBytes(make([]byte, size)))
+		return nil
+	}
+
+	if size, ok := signedSizes[name]; ok {
+		number, err := toSigned(value, size, description)
+		if err != nil {
+			return err
+		}
+		encoded, err := twos.ToBytesOfLength(number, size)
+		if err != nil {
+			return err
+		}
+		buffer.Write(encoded)
+		return nil
+	}
+
+	if size, ok := fixedBytesSizes[name]; ok {
+		encoded, err := toFixedBytes(value, size, description)
+		if err != nil {
+			return err
+		}
+		buffer.Write(encoded)
+		return nil
+	}
+
+	if _, ok := bytesTypes[name]; ok {
+		encoded, err := toBytes(value, description)
+		if err != nil {
+			return err
+		}
+		writeLengthPrefixed(buffer, encoded)
+		return nil
+	}
+
+	if _, ok := stringTypes[name]; ok {
+		encoded, err := toStringBytes(value, description)
+		if err != nil {
+			return err
+		}
+		writeLengthPrefixed(buffer, encoded)
+		return nil
+	}
+
+	switch name {
+	case "BigUint":
+		number, err := toUnsigned(value, 0, description)
+		if err != nil {
+			return err
+		}
+		writeLengthPrefixed(buffer, number.Bytes())
+		return nil
+	case "BigInt":
+		number, err := toBigInt(value, description)
+		if err != nil {
+			return err
+		}
+		writeLengthPrefixed(buffer, twos.ToBytes(number))
+		return nil
+	case "bool":
+		flag, err := toBool(value, description)
+		if err != nil {
+			return err
+		}
+		if flag {
+			buffer.WriteByte(1)
+		} else {
+			buffer.WriteByte(0)
+		}
+		return nil
+	case "Option":
+		return codec.encodeOption(buffer, description, value)
+	case "List", "Vec":
+		return codec.encodeList(buffer, description, value)
+	case "tuple":
+		items, err := toItems(value, nil, description)
+		if err != nil {
+			return err
+		}
+		return codec.encodeItems(buffer, description.Args, items, description)
+	}
+
+	if strings.HasPrefix(name, "array") {
+		return codec.encodeArray(buffer, description, value)
+	}
+
+	definition, isCustom := codec.types[name]
+	if isCustom && definition.Type == structKind {
+		return codec.encodeStruct(buffer, name, definition, value)
+	}
+	if isCustom && definition.Type == enumKind {
+		return codec.encodeEnum(buffer, name, definition, value)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnsupportedType, description)
+}
+
+func (codec *Codec) encodeOption(buffer *bytes.Buffer, description *TypeDescription, value interface{}) error {
+	itemType, err := singleTypeArgument(description)
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		buffer.WriteByte(optionTagNone)
+		return nil
+	}
+
+	buffer.WriteByte(optionTagSome)
+	return codec.encodeNested(buffer, itemType, value)
+}
+
+func (codec *Codec) encodeList(buffer *bytes.Buffer, description *TypeDescription, value interface{}) error {
+	itemType, err := singleTypeArgument(description)
+	if err != nil {
+		return err
+	}
+
+	items, err := toItems(value, itemType, description)
+	if err != nil {
+		return err
+	}
+
+	writeLength(buffer, len(items))
+	for _, item := range items {
+		err = codec.encodeNested(buffer, itemType, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (codec *Codec) encodeArray(buffer *bytes.Buffer, description *TypeDescription, value interface{}) error {
+	length, err := strconv.Atoi(strings.TrimPrefix(description.Name, "array"))
+	if err != nil || length < 0 {
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, description)
+	}
+
+	itemType, err := singleTypeArgument(description)
+	if err != nil {
+		return err
+	}
+
+	items, err := toItems(value, itemType, description)
+	if err != nil {
+		return err
+	}
+
+	return codec.encodeItems(buffer, repeatType(itemType, length), items, description)
+}
+
+func (codec *Codec) encodeItems(buffer *bytes.Buffer, itemTypes []*TypeDescription, items []interface{}, description *TypeDescription) error {
+	if len(items) != len(itemTypes) {
+		return fmt.Errorf("%w: %s expects %d items, got %d", ErrInvalidValue, description, len(itemTypes), len(items))
+	}
+
+	for i, itemType := range itemTypes {
+		err := codec.encodeNested(buffer, itemType, items[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (codec *Codec) encodeStruct(buffer *bytes.Buffer, name string, definition *TypeDefinition, value interface{}) error {
+	var fieldValues func(string) (interface{}, bool)
+	var numFields int
+	switch typedValue := value.(type) {
+	case *StructValue:
+		fieldValues = typedValue.Field
+		numFields = len(typedValue.Fields)
+	case map[string]interface{}:
+		fieldValues = fromMap(typedValue)
+		numFields = len(typedValue)
+	default:
+		return fmt.Errorf("%w: %v as struct %s", ErrInvalidValue, value, name)
+	}
+
+	err := codec.encodeFields(buffer, definition.Fields, fieldValues, numFields)
+	if err != nil {
+		return fmt.Errorf("struct %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (codec *Codec) encodeEnum(buffer *bytes.Buffer, name string, definition *TypeDefinition, value interface{}) error {
+	variantName, fieldValues, numFields, err := toVariant(value)
+	if err != nil {
+		return fmt.Errorf("%w: %v as enum %s", err, value, name)
+	}
+
+	variant, ok := variantByName(definition, variantName)
+	if !ok {
+		return fmt.Errorf("%w: %s, variant %s", ErrUnknownVariant, name, variantName)
+	}
+
+	buffer.WriteByte(byte(variant.Discriminant))
+	err = codec.encodeFields(buffer, variant.Fields, fieldValues, numFields)
+	if err != nil {
+		return fmt.Errorf("enum %s, variant %s: %w", name, variant.Name, err)
+	}
+
+	return nil
+}
+
+func (codec *Codec) encodeFields(
+	buffer *bytes.Buffer,
+	definitions []*FieldDefinition,
+	fieldValues func(string) (interface{}, bool),
+	numFields int,
+) error {
+	if numFields > len(definitions) {
+		return fmt.Errorf("%w: %d fields expected, got %d", ErrInvalidValue, len(definitions), numFields)
+	}
+
+	for _, definition := range definitions {
+		description, err := ParseTypeDescription(definition.Type)
+		if err != nil {
+			return err
+		}
+
+		fieldValue, ok := fieldValues(definition.Name)
+		if !ok {
+			return fmt.Errorf("%w: missing field %s", ErrInvalidValue, definition.Name)
+		}
+
+		err = codec.encodeNested(buffer, description, fieldValue)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", definition.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func toVariant(value interface{}) (string, func(string) (interface{}, bool), int, error) {
+	noFields := func(string) (interface{}, bool) {
+		return nil, false
+	}
+
+	switch typedValue := value.(type) {
+	case *EnumValue:
+		return typedValue.Variant, typedValue.Field, len(typedValue.Fields), nil
+	case string:
+		return typedValue, noFields, 0, nil
+	case map[string]interface{}:
+		if len(typedValue) != 1 {
+			return "", nil, 0, ErrInvalidValue
+		}
+		for variantName, fields := range typedValue {
+			if fields == nil {
+				return variantName, noFields, 0, nil
+			}
+			fieldsMap, isMap := fields.(map[string]interface{})
+			if !isMap {
+				return "", nil, 0, ErrInvalidValue
+			}
+			return variantName, fromMap(fieldsMap), len(fieldsMap), nil
+		}
+	}
+
+	return "", nil, 0, ErrInvalidValue
+}
+
+func fromMap(values map[string]interface{}) func(string) (interface{}, bool) {
+	return func(name string) (interface{}, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func writeLength(buffer *bytes.Buffer, length int) {
+	encodedLength := make([]byte, lengthPrefixSize)
+	binary.BigEndian.PutUint32(encodedLength, uint32(length))
+	buffer.Write(encodedLength)
+}
+
+func writeLengthPrefixed(buffer *bytes.Buffer, data []byte) {
+	writeLength(buffer, len(data))
+	buffer.Write(data)
+}
+
+func invalidValue(value interface{}, description *TypeDescription) error {
+	return fmt.Errorf("%w: %v as %s", ErrInvalidValue, value, description)
+}
+
+func toBigInt(value interface{}, description *TypeDescription) (*big.Int, error) {
+	switch typedValue := value.(type) {
+	case *big.Int:
+		if typedValue == nil {
+			return nil, invalidValue(value, description)
+		}
+		return big.NewInt(0).Set(typedValue), nil
+	case int:
+		return big.NewInt(int64(typedValue)), nil
+	case int64:
+		return big.NewInt(typedValue), nil
+	case uint64:
+		return big.NewInt(0).SetUint64(typedValue), nil
+	case float64:
+		if typedValue != math.Trunc(typedValue) || math.Abs(typedValue) > 1<<53 {
+			return nil, invalidValue(value, description)
+		}
+		return big.NewInt(int64(typedValue)), nil
+	case json.Number:
+		return parseBigInt(string(typedValue), description)
+	case string:
+		return parseBigInt(typedValue, description)
+	default:
+		return nil, invalidValue(value, description)
+	}
+}
+
+// parseBigInt parses decimal or hex ("0x...") numbers, optionally signed, with their digits optionally grouped by "_"
+func parseBigInt(str string, description *TypeDescription) (*big.Int, error) {
+	digits := strings.ReplaceAll(str, "_", "")
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimLeft(digits, "+-")
+
+	base := 10
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		base = 16
+		digits = digits[2:]
+	}
+
+	number, ok := big.NewInt(0).SetString(digits, base)
+	if !ok {
+		return nil, invalidValue(str, description)
+	}
+	if negative {
+		number.Neg(number)
+	}
+
+	return number, nil
+}
+
+// toUnsigned converts the value to a non-negative number of at most size bytes; size 0 means unbounded
+func toUnsigned(value interface{}, size int, description *TypeDescription) (*big.Int, error) {
+	number, err := toBigInt(value, description)
+	if err != nil {
+		return nil, err
+	}
+	if number.Sign() < 0 {
+		return nil, invalidValue(value, description)
+	}
+	if size > 0 && number.BitLen() > size*8 {
+		return nil, fmt.Errorf("%w: %v as %s", ErrIntegerOverflow, value, description)
+	}
+
+	return number, nil
+}
+
+func toSigned(value interface{}, size int, description *TypeDescription) (*big.Int, error) {
+	number, err := toBigInt(value, description)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := big.NewInt(0).Lsh(big.NewInt(1), uint(size*8-1))
+	minimum := big.NewInt(0).Neg(limit)
+	if number.Cmp(minimum) < 0 || number.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%w: %v as %s", ErrIntegerOverflow, value, description)
+	}
+
+	return number, nil
+}
+
+func toBool(value interface{}, description *TypeDescription) (bool, error) {
+	switch value {
+	case true, "true":
+		return true, nil
+	case false, "false":
+		return false, nil
+	default:
+		return false, invalidValue(value, description)
+	}
+}
+
+func toBytes(value interface{}, description *TypeDescription) ([]byte, error) {
+	switch typedValue := value.(type) {
+	case []byte:
+		return cloneBytes(typedValue), nil
+	case string:
+		digits := strings.TrimPrefix(strings.TrimPrefix(typedValue, "0x"), "0X")
+		decoded, err := hex.DecodeString(digits)
+		if err != nil {
+			return nil, invalidValue(value, description)
+		}
+		return decoded, nil
+	default:
+		return nil, invalidValue(value, description)
+	}
+}
+
+func toStringBytes(value interface{}, description *TypeDescription) ([]byte, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return []byte(typedValue), nil
+	case []byte:
+		return cloneBytes(typedValue), nil
+	default:
+		return nil, invalidValue(value, description)
+	}
+}
+
+func toFixedBytes(value interface{}, size int, description *TypeDescription) ([]byte, error) {
+	var encoded []byte
+	var err error
+
+	str, isString := value.(string)
+	if isString && strings.HasPrefix(str, bech32AddressPrefix+"1") {
+		encoded, err = decodeBech32(str, size)
+	} else {
+		encoded, err = toBytes(value, description)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encoded) != size {
+		return nil, invalidValue(value, description)
+	}
+
+	return encoded, nil
+}
+
+func decodeBech32(address string, size int) ([]byte, error) {
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(size, bech32AddressPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := converter.Decode(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidValue, address, err)
+	}
+
+	return decoded, nil
+}
+
+// toItems converts the value to a list of items; lists of bytes can also be given as []byte or hex strings
+func toItems(value interface{}, itemType *TypeDescription, description *TypeDescription) ([]interface{}, error) {
+	switch typedValue := value.(type) {
+	case []interface{}:
+		return typedValue, nil
+	case []byte, string:
+		if itemType == nil || itemType.Name != "u8" {
+			return nil, invalidValue(value, description)
+		}
+		data, err := toBytes(typedValue, description)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(data))
+		for i, b := range data {
+			items[i] = uint64(b)
+		}
+		return items, nil
+	default:
+		return nil, invalidValue(value, description)
+	}
+}
@@ -0,0 +1,173 @@
+package abi
+
+import "fmt"
+
+// EncodeArguments encodes the arguments of a call to the given endpoint, one value for each input.
+// The trailing "optional<...>" inputs can be left out or given as nil, a "variadic<...>" input takes
+// a list of values and a "multi<...>" input a list with one value for each of its types.
+func (codec *Codec) EncodeArguments(endpoint *EndpointDescription, values []interface{}) ([][]byte, error) {
+	if len(values) > len(endpoint.Inputs) {
+		return nil, fmt.Errorf("%w: endpoint %s, expected at most %d, got %d",
+			ErrArgumentCountMismatch, endpoint.Name, len(endpoint.Inputs), len(values))
+	}
+
+	arguments := make([][]byte, 0, len(values))
+	for i, input := range endpoint.Inputs {
+		description, err := ParseTypeDescription(input.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if i >= len(values) {
+			if description.Name != optionalType {
+				return nil, fmt.Errorf("%w: endpoint %s, missing input %s",
+					ErrArgumentCountMismatch, endpoint.Name, input.Name)
+			}
+			continue
+		}
+
+		encoded, err := codec.encodeMultiValue(description, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s, input %s: %w", endpoint.Name, input.Name, err)
+		}
+		arguments = append(arguments, encoded...)
+	}
+
+	return arguments, nil
+}
+
+func (codec *Codec) encodeMultiValue(description *TypeDescription, value interface{}) ([][]byte, error) {
+	switch description.Name {
+	case optionalType:
+		if value == nil {
+			return nil, nil
+		}
+		itemType, err := singleTypeArgument(description)
+		if err != nil {
+			return nil, err
+		}
+		return codec.encodeMultiValue(itemType, value)
+	case variadicType:
+		itemType, err := singleTypeArgument(description)
+		if err != nil {
+			return nil, err
+		}
+		items, err := toItems(value, nil, description)
+		if err != nil {
+			return nil, err
+		}
+		return codec.encodeMultiValues(repeatType(itemType, len(items)), items)
+	case multiType:
+		items, err := toItems(value, nil, description)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) != len(description.Args) {
+			return nil, fmt.Errorf("%w: %s expects %d items, got %d", ErrInvalidValue, description, len(description.Args), len(items))
+		}
+		return codec.encodeMultiValues(description.Args, items)
+	default:
+		encoded, err := codec.EncodeTopLevel(description, value)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{encoded}, nil
+	}
+}
+
+func (codec *Codec) encodeMultiValues(itemTypes []*TypeDescription, items []interface{}) ([][]byte, error) {
+	arguments := make([][]byte, 0, len(items))
+	for i, itemType := range itemTypes {
+		encoded, err := codec.encodeMultiValue(itemType, items[i])
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, encoded...)
+	}
+
+	return arguments, nil
+}
+
+// DecodeResults decodes the return data of a call to the given endpoint, one value for each output;
+// a missing "optional<...>" output is decoded as nil, while the "variadic<...>" and "multi<...>" outputs
+// are decoded as lists
+func (codec *Codec) DecodeResults(endpoint *EndpointDescription, returnData [][]byte) ([]interface{}, error) {
+	results := make([]interface{}, 0, len(endpoint.Outputs))
+	index := 0
+	for _, output := range endpoint.Outputs {
+		description, err := ParseTypeDescription(output.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := codec.decodeMultiValue(description, returnData, &index)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s, output %s: %w", endpoint.Name, output.Name, err)
+		}
+		results = append(results, value)
+	}
+
+	if index < len(returnData) {
+		return nil, fmt.Errorf("%w: endpoint %s, %d results left over",
+			ErrResultCountMismatch, endpoint.Name, len(returnData)-index)
+	}
+
+	return results, nil
+}
+
+func (codec *Codec) decodeMultiValue(description *TypeDescription, returnData [][]byte, index *int) (interface{}, error) {
+	switch description.Name {
+	case optionalType:
+		if *index >= len(returnData) {
+			return nil, nil
+		}
+		itemType, err := singleTypeArgument(description)
+		if err != nil {
+			return nil, err
+		}
+		return codec.decodeMultiValue(itemType, returnData, index)
+	case variadicType:
+		itemType, err := singleTypeArgument(description)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0)
+		for *index < len(returnData) {
+			item, err := codec.decodeMultiValue(itemType, returnData, index)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case multiType:
+		items := make([]interface{}, 0, len(description.Args))
+		for _, itemType := range description.Args {
+			item, err := codec.decodeMultiValue(itemType, returnData, index)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		if *index >= len(returnData) {
+			return nil, fmt.Errorf("%w: missing %s", ErrResultCountMismatch, description)
+		}
+		value, err := codec.DecodeTopLevel(description, returnData[*index])
+		if err != nil {
+			return nil, err
+		}
+		*index++
+		return value, nil
+	}
+}
+
+func repeatType(itemType *TypeDescription, count int) []*TypeDescription {
+	itemTypes := make([]*TypeDescription, count)
+	for i := range itemTypes {
+		itemTypes[i] = itemType
+	}
+
+	return itemTypes
+}
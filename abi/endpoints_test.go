@@ -0,0 +1,109 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABI_Endpoint(t *testing.T) {
+	abi, _ := createTestCodec(t)
+
+	endpoint, ok := abi.Endpoint("bid")
+	require.True(t, ok)
+	require.Equal(t, "mutable", endpoint.Mutability)
+
+	endpoint, ok = abi.Endpoint(ConstructorName)
+	require.True(t, ok)
+	require.Equal(t, abi.Constructor, endpoint)
+
+	_, ok = abi.Endpoint("missing")
+	require.False(t, ok)
+}
+
+func TestCodec_EncodeArguments(t *testing.T) {
+	abi, codec := createTestCodec(t)
+	bid, _ := abi.Endpoint("bid")
+
+	arguments, err := codec.EncodeArguments(bid, []interface{}{
+		map[string]interface{}{"amount": 1, "deadline": 2, "status": "Open", "memo": nil},
+	})
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0}}, arguments)
+
+	arguments, err = codec.EncodeArguments(bid, []interface{}{
+		map[string]interface{}{"amount": 1, "deadline": 2, "status": "Open", "memo": nil},
+		"ff",
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(arguments))
+	require.Equal(t, []byte{0xFF}, arguments[1])
+
+	_, err = codec.EncodeArguments(bid, []interface{}{})
+	require.True(t, errors.Is(err, ErrArgumentCountMismatch))
+
+	_, err = codec.EncodeArguments(bid, []interface{}{nil, nil, nil})
+	require.True(t, errors.Is(err, ErrArgumentCountMismatch))
+
+	getBids, _ := abi.Endpoint("getBids")
+	arguments, err = codec.EncodeArguments(getBids, []interface{}{
+		[]interface{}{makeTestAddress(1), makeTestAddress(2)},
+	})
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{makeTestAddress(1), makeTestAddress(2)}, arguments)
+}
+
+func TestCodec_DecodeResults(t *testing.T) {
+	abi, codec := createTestCodec(t)
+	getBids, _ := abi.Endpoint("getBids")
+
+	results, err := codec.DecodeResults(getBids, [][]byte{makeTestAddress(1), {0x0A}, makeTestAddress(2), {}})
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		[]interface{}{
+			[]interface{}{makeTestAddress(1), big.NewInt(10)},
+			[]interface{}{makeTestAddress(2), big.NewInt(0)},
+		},
+	}, results)
+
+	_, err = codec.DecodeResults(getBids, [][]byte{makeTestAddress(1)})
+	require.True(t, errors.Is(err, ErrResultCountMismatch))
+
+	bid, _ := abi.Endpoint("bid")
+	results, err = codec.DecodeResults(bid, [][]byte{{0x01}})
+	require.Nil(t, err)
+	require.Equal(t, "Closed", results[0].(*EnumValue).Variant)
+
+	_, err = codec.DecodeResults(bid, [][]byte{{0x01}, {0x01}})
+	require.True(t, errors.Is(err, ErrResultCountMismatch))
+}
+
+func TestEventDecoder_CustomTypes(t *testing.T) {
+	abi, _ := createTestCodec(t)
+	decoder, err := NewEventDecoder(abi)
+	require.Nil(t, err)
+
+	event, err := decoder.DecodeLog(&vmcommon.LogEntry{
+		Identifier: []byte("newBid"),
+		Address:    makeTestAddress(9),
+		Topics:     [][]byte{makeTestAddress(1)},
+		Data:       [][]byte{{0, 0, 0, 1, 5, 0, 0, 0, 0, 0, 0, 0, 2, 1, 0}},
+	})
+	require.Nil(t, err)
+
+	bid, ok := event.Field("bid")
+	require.True(t, ok)
+	amount, _ := bid.(*StructValue).Field("amount")
+	require.Equal(t, big.NewInt(5), amount)
+
+	asJSON, err := json.Marshal(event.Fields)
+	require.Nil(t, err)
+	require.Equal(t, `[{"name":"bidder","type":"Address","indexed":true,"value":"`+
+		hex.EncodeToString(makeTestAddress(1))+`"},`+
+		`{"name":"bid","type":"Bid","value":{"amount":"5","deadline":2,"status":"Closed","memo":null}}]`, string(asJSON))
+}
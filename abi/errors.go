@@ -0,0 +1,54 @@
+package abi
+
+import "errors"
+
+// ErrInvalidTypeName signals that an ABI type name could not be parsed
+var ErrInvalidTypeName = errors.New("invalid ABI type name")
+
+// ErrUnsupportedType signals that an ABI type is not known to the codec
+var ErrUnsupportedType = errors.New("unsupported ABI type")
+
+// ErrUnexpectedEnd signals that the encoded data ended before the value was complete
+var ErrUnexpectedEnd = errors.New("unexpected end of encoded data")
+
+// ErrTrailingData signals that bytes were left over after decoding a value
+var ErrTrailingData = errors.New("trailing bytes after the encoded value")
+
+// ErrInvalidBool signals that an encoded boolean is neither 0 nor 1
+var ErrInvalidBool = errors.New("invalid encoded boolean")
+
+// ErrInvalidOptionTag signals that an encoded Option starts with a tag other than 0 or 1
+var ErrInvalidOptionTag = errors.New("invalid encoded Option tag")
+
+// ErrIntegerOverflow signals that an encoded integer does not fit in its declared type
+var ErrIntegerOverflow = errors.New("encoded integer overflows its type")
+
+// ErrNilABI signals that a nil ABI has been provided
+var ErrNilABI = errors.New("nil ABI")
+
+// ErrDuplicateEvent signals that the ABI describes several events with the same identifier
+var ErrDuplicateEvent = errors.New("duplicate event identifier")
+
+// ErrUnknownEvent signals that a log entry has an identifier not described by the ABI
+var ErrUnknownEvent = errors.New("unknown event identifier")
+
+// ErrTopicCountMismatch signals that a log entry does not have one topic for each indexed event input
+var ErrTopicCountMismatch = errors.New("number of topics does not match the indexed event inputs")
+
+// ErrUnknownEndpoint signals that the ABI does not describe the requested endpoint
+var ErrUnknownEndpoint = errors.New("unknown endpoint")
+
+// ErrInvalidTypeDefinition signals that a struct or an enum of the ABI is not well defined
+var ErrInvalidTypeDefinition = errors.New("invalid ABI type definition")
+
+// ErrInvalidValue signals that a value cannot be encoded as the requested type
+var ErrInvalidValue = errors.New("invalid value for the ABI type")
+
+// ErrUnknownVariant signals that an enum has no variant with the given name or discriminant
+var ErrUnknownVariant = errors.New("unknown enum variant")
+
+// ErrArgumentCountMismatch signals that the number of arguments does not match the endpoint inputs
+var ErrArgumentCountMismatch = errors.New("number of arguments does not match the endpoint inputs")
+
+// ErrResultCountMismatch signals that the number of results does not match the endpoint outputs
+var ErrResultCountMismatch = errors.New("number of results does not match the endpoint outputs")
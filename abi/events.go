@@ -0,0 +1,219 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// EventField is a decoded field of an event
+type EventField struct {
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Indexed bool        `json:"indexed,omitempty"`
+	Value   interface{} `json:"value"`
+}
+
+// MarshalJSON renders the value of the field in its JSON form, see JSONValue
+func (field *EventField) MarshalJSON() ([]byte, error) {
+	type plainEventField EventField
+	converted := plainEventField(*field)
+	converted.Value = JSONValue(field.Value)
+	return json.Marshal(&converted)
+}
+
+// Event is a log entry decoded according to its ABI event description
+type Event struct {
+	Address    []byte        `json:"address"`
+	Identifier string        `json:"identifier"`
+	Fields     []*EventField `json:"fields"`
+}
+
+// Field returns the value of the field with the given name
+func (event *Event) Field(name string) (interface{}, bool) {
+	for _, field := range event.Fields {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+type eventInputType struct {
+	input       *EventInput
+	description *TypeDescription
+}
+
+type eventLayout struct {
+	identifier string
+	inputs     []*eventInputType
+	numIndexed int
+	numData    int
+}
+
+// EventDecoder turns log entries into typed events, using the event descriptions of an ABI
+type EventDecoder struct {
+	codec   *Codec
+	layouts map[string]*eventLayout
+}
+
+// NewEventDecoder creates a new EventDecoder for the events of the given ABI
+func NewEventDecoder(abi *ABI) (*EventDecoder, error) {
+	codec, err := NewCodec(abi)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := &EventDecoder{
+		codec:   codec,
+		layouts: make(map[string]*eventLayout),
+	}
+
+	for _, event := range abi.Events {
+		_, exists := decoder.layouts[event.Identifier]
+		if exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateEvent, event.Identifier)
+		}
+
+		layout, err := codec.newEventLayout(event)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", event.Identifier, err)
+		}
+		decoder.layouts[event.Identifier] = layout
+	}
+
+	return decoder, nil
+}
+
+func (codec *Codec) newEventLayout(event *EventDescription) (*eventLayout, error) {
+	layout := &eventLayout{
+		identifier: event.Identifier,
+		inputs:     make([]*eventInputType, 0, len(event.Inputs)),
+	}
+
+	for _, input := range event.Inputs {
+		description, err := ParseTypeDescription(input.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		err = codec.checkType(description)
+		if err != nil {
+			return nil, err
+		}
+
+		layout.inputs = append(layout.inputs, &eventInputType{
+			input:       input,
+			description: description,
+		})
+		if input.Indexed {
+			layout.numIndexed++
+		} else {
+			layout.numData++
+		}
+	}
+
+	return layout, nil
+}
+
+// HasEvent returns true if the ABI describes an event with the given identifier
+func (decoder *EventDecoder) HasEvent(identifier string) bool {
+	_, exists := decoder.layouts[identifier]
+	return exists
+}
+
+// DecodeLog decodes a single log entry; the topics hold the indexed fields in order,
+// while the data holds the other fields, top-level encoded if there is only one of
+// them, nested encoded one after the other otherwise
+func (decoder *EventDecoder) DecodeLog(entry *vmcommon.LogEntry) (*Event, error) {
+	identifier := string(entry.Identifier)
+	layout, exists := decoder.layouts[identifier]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, identifier)
+	}
+
+	if len(entry.Topics) != layout.numIndexed {
+		return nil, fmt.Errorf("%w: event %s, expected %d, got %d",
+			ErrTopicCountMismatch, identifier, layout.numIndexed, len(entry.Topics))
+	}
+
+	event := &Event{
+		Address:    entry.Address,
+		Identifier: identifier,
+		Fields:     make([]*EventField, 0, len(layout.inputs)),
+	}
+
+	data := bytes.Join(entry.Data, nil)
+	reader := decoder.codec.newNestedReader(data)
+	topicIndex := 0
+	for _, inputType := range layout.inputs {
+		value, err := decoder.decodeEventField(layout, inputType, entry.Topics, &topicIndex, data, reader)
+		if err != nil {
+			return nil, fmt.Errorf("event %s, field %s: %w", identifier, inputType.input.Name, err)
+		}
+
+		event.Fields = append(event.Fields, &EventField{
+			Name:    inputType.input.Name,
+			Type:    inputType.input.Type,
+			Indexed: inputType.input.Indexed,
+			Value:   value,
+		})
+	}
+
+	if layout.numData != 1 && reader.offset < len(data) {
+		return nil, fmt.Errorf("event %s: %w: %d bytes", identifier, ErrTrailingData, len(data)-reader.offset)
+	}
+
+	return event, nil
+}
+
+func (decoder *EventDecoder) decodeEventField(
+	layout *eventLayout,
+	inputType *eventInputType,
+	topics [][]byte,
+	topicIndex *int,
+	data []byte,
+	reader *nestedReader,
+) (interface{}, error) {
+	if inputType.input.Indexed {
+		topic := topics[*topicIndex]
+		*topicIndex++
+		return decoder.codec.DecodeTopLevel(inputType.description, topic)
+	}
+
+	if layout.numData == 1 {
+		return decoder.codec.DecodeTopLevel(inputType.description, data)
+	}
+
+	return reader.decode(inputType.description)
+}
+
+// DecodeLogs decodes the log entries described by the ABI and skips the others,
+// such as the logs written by the built-in functions
+func (decoder *EventDecoder) DecodeLogs(logs []*vmcommon.LogEntry) ([]*Event, error) {
+	events := make([]*Event, 0, len(logs))
+	for _, entry := range logs {
+		if !decoder.HasEvent(string(entry.Identifier)) {
+			continue
+		}
+
+		event, err := decoder.DecodeLog(entry)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// DecodeVMOutput decodes the events found in the logs of the given VMOutput
+func (decoder *EventDecoder) DecodeVMOutput(vmOutput *vmcommon.VMOutput) ([]*Event, error) {
+	if vmOutput == nil {
+		return make([]*Event, 0), nil
+	}
+
+	return decoder.DecodeLogs(vmOutput.Logs)
+}
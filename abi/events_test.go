@@ -0,0 +1,171 @@
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `{
+	"name": "Token",
+	"endpoints": [],
+	"events": [
+		{
+			"identifier": "transfer",
+			"inputs": [
+				{"name": "from", "type": "Address", "indexed": true},
+				{"name": "to", "type": "Address", "indexed": true},
+				{"name": "amount", "type": "BigUint"}
+			]
+		},
+		{
+			"identifier": "rename",
+			"inputs": [
+				{"name": "epoch", "type": "u32", "indexed": true},
+				{"name": "name", "type": "utf-8 string"},
+				{"name": "ticker", "type": "TokenIdentifier"}
+			]
+		}
+	]
+}`
+
+func makeTestAddress(last byte) []byte {
+	address := make([]byte, addressSize)
+	address[addressSize-1] = last
+	return address
+}
+
+func createTestEventDecoder(t *testing.T) *EventDecoder {
+	abi, err := ParseABI([]byte(testABI))
+	require.Nil(t, err)
+
+	decoder, err := NewEventDecoder(abi)
+	require.Nil(t, err)
+	return decoder
+}
+
+func TestNewEventDecoder_Errors(t *testing.T) {
+	decoder, err := NewEventDecoder(nil)
+	require.Nil(t, decoder)
+	require.Equal(t, ErrNilABI, err)
+
+	abi := &ABI{
+		Events: []*EventDescription{
+			{Identifier: "a"},
+			{Identifier: "a"},
+		},
+	}
+	_, err = NewEventDecoder(abi)
+	require.True(t, errors.Is(err, ErrDuplicateEvent))
+
+	abi = &ABI{
+		Events: []*EventDescription{
+			{Identifier: "a", Inputs: []*EventInput{{Name: "x", Type: "UnknownStruct"}}},
+		},
+	}
+	_, err = NewEventDecoder(abi)
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func TestEventDecoder_DecodeLog(t *testing.T) {
+	decoder := createTestEventDecoder(t)
+
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte("transfer"),
+		Address:    makeTestAddress(9),
+		Topics:     [][]byte{makeTestAddress(1), makeTestAddress(2)},
+		Data:       [][]byte{{0x03, 0xE8}},
+	}
+
+	event, err := decoder.DecodeLog(entry)
+	require.Nil(t, err)
+	require.Equal(t, "transfer", event.Identifier)
+	require.Equal(t, makeTestAddress(9), event.Address)
+	require.Len(t, event.Fields, 3)
+
+	from, found := event.Field("from")
+	require.True(t, found)
+	require.Equal(t, makeTestAddress(1), from)
+
+	amount, found := event.Field("amount")
+	require.True(t, found)
+	require.Equal(t, big.NewInt(1000), amount)
+
+	_, found = event.Field("missing")
+	require.False(t, found)
+}
+
+func TestEventDecoder_DecodeLogMultipleDataFields(t *testing.T) {
+	decoder := createTestEventDecoder(t)
+
+	data := []byte{0x00, 0x00, 0x00, 0x03, 'f', 'o', 'o'}
+	data = append(data, 0x00, 0x00, 0x00, 0x03, 'F', 'O', 'O')
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte("rename"),
+		Topics:     [][]byte{{0x05}},
+		Data:       [][]byte{data},
+	}
+
+	event, err := decoder.DecodeLog(entry)
+	require.Nil(t, err)
+
+	epoch, _ := event.Field("epoch")
+	require.Equal(t, uint64(5), epoch)
+	name, _ := event.Field("name")
+	require.Equal(t, "foo", name)
+	ticker, _ := event.Field("ticker")
+	require.Equal(t, "FOO", ticker)
+
+	entry.Data = [][]byte{append(data, 0x00)}
+	_, err = decoder.DecodeLog(entry)
+	require.True(t, errors.Is(err, ErrTrailingData))
+}
+
+func TestEventDecoder_DecodeLogErrors(t *testing.T) {
+	decoder := createTestEventDecoder(t)
+
+	_, err := decoder.DecodeLog(&vmcommon.LogEntry{Identifier: []byte("unknown")})
+	require.True(t, errors.Is(err, ErrUnknownEvent))
+
+	_, err = decoder.DecodeLog(&vmcommon.LogEntry{
+		Identifier: []byte("transfer"),
+		Topics:     [][]byte{makeTestAddress(1)},
+	})
+	require.True(t, errors.Is(err, ErrTopicCountMismatch))
+
+	_, err = decoder.DecodeLog(&vmcommon.LogEntry{
+		Identifier: []byte("transfer"),
+		Topics:     [][]byte{makeTestAddress(1), {0x01}},
+	})
+	require.True(t, errors.Is(err, ErrUnexpectedEnd))
+}
+
+func TestEventDecoder_DecodeVMOutput(t *testing.T) {
+	decoder := createTestEventDecoder(t)
+
+	vmOutput := &vmcommon.VMOutput{
+		Logs: []*vmcommon.LogEntry{
+			{
+				Identifier: []byte("ESDTTransfer"),
+				Topics:     [][]byte{[]byte("TOKEN-123456")},
+			},
+			{
+				Identifier: []byte("transfer"),
+				Topics:     [][]byte{makeTestAddress(1), makeTestAddress(2)},
+				Data:       [][]byte{{0x01}},
+			},
+		},
+	}
+
+	events, err := decoder.DecodeVMOutput(vmOutput)
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "transfer", events[0].Identifier)
+
+	events, err = decoder.DecodeVMOutput(nil)
+	require.Nil(t, err)
+	require.Empty(t, events)
+}
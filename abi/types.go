@@ -0,0 +1,79 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeDescription is a parsed ABI type name, such as "List<Option<u64>>"
+type TypeDescription struct {
+	Name string
+	Args []*TypeDescription
+}
+
+// ParseTypeDescription parses an ABI type name, including its generic arguments
+func ParseTypeDescription(typeName string) (*TypeDescription, error) {
+	description, rest, err := parseTypeDescription(strings.TrimSpace(typeName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, typeName)
+	}
+	if len(strings.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTypeName, typeName)
+	}
+
+	return description, nil
+}
+
+func parseTypeDescription(input string) (*TypeDescription, string, error) {
+	end := strings.IndexAny(input, "<>,")
+	if end < 0 {
+		end = len(input)
+	}
+
+	name := strings.TrimSpace(input[:end])
+	if len(name) == 0 {
+		return nil, "", ErrInvalidTypeName
+	}
+
+	description := &TypeDescription{
+		Name: name,
+	}
+	rest := input[end:]
+	if !strings.HasPrefix(rest, "<") {
+		return description, rest, nil
+	}
+
+	rest = rest[1:]
+	for {
+		arg, remaining, err := parseTypeDescription(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, "", err
+		}
+		description.Args = append(description.Args, arg)
+
+		remaining = strings.TrimSpace(remaining)
+		if strings.HasPrefix(remaining, ",") {
+			rest = remaining[1:]
+			continue
+		}
+		if strings.HasPrefix(remaining, ">") {
+			return description, remaining[1:], nil
+		}
+
+		return nil, "", ErrInvalidTypeName
+	}
+}
+
+// String renders the type description back into an ABI type name
+func (description *TypeDescription) String() string {
+	if len(description.Args) == 0 {
+		return description.Name
+	}
+
+	args := make([]string, len(description.Args))
+	for i, arg := range description.Args {
+		args[i] = arg.String()
+	}
+
+	return fmt.Sprintf("%s<%s>", description.Name, strings.Join(args, ","))
+}
@@ -0,0 +1,122 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+)
+
+// FieldValue is a decoded field of a struct or of an enum variant
+type FieldValue struct {
+	Name  string
+	Value interface{}
+}
+
+// StructValue is a decoded struct, its fields in the order of the ABI
+type StructValue struct {
+	Type   string
+	Fields []*FieldValue
+}
+
+// Field returns the value of the field with the given name
+func (value *StructValue) Field(name string) (interface{}, bool) {
+	return findField(value.Fields, name)
+}
+
+// MarshalJSON renders the struct as a JSON object, keeping the order of the fields
+func (value *StructValue) MarshalJSON() ([]byte, error) {
+	return marshalFields(value.Fields)
+}
+
+// EnumValue is a decoded enum variant, with its fields, if any
+type EnumValue struct {
+	Type         string
+	Variant      string
+	Discriminant int
+	Fields       []*FieldValue
+}
+
+// Field returns the value of the variant field with the given name
+func (value *EnumValue) Field(name string) (interface{}, bool) {
+	return findField(value.Fields, name)
+}
+
+// MarshalJSON renders a variant without fields as its name, and any other as {"<name>": {<fields>}}
+func (value *EnumValue) MarshalJSON() ([]byte, error) {
+	name, err := json.Marshal(value.Variant)
+	if err != nil {
+		return nil, err
+	}
+	if len(value.Fields) == 0 {
+		return name, nil
+	}
+
+	fields, err := marshalFields(value.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	buffer.Write(name)
+	buffer.WriteByte(':')
+	buffer.Write(fields)
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// JSONValue converts a decoded value to the form it takes in JSON, which is also accepted by the encoder:
+// byte slices become hex strings and big integers become decimal strings, so that they keep their precision
+func JSONValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case []byte:
+		return hex.EncodeToString(typedValue)
+	case *big.Int:
+		return typedValue.String()
+	case []interface{}:
+		items := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			items[i] = JSONValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+func marshalFields(fields []*FieldValue) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(JSONValue(field.Value))
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+func findField(fields []*FieldValue, name string) (interface{}, bool) {
+	for _, field := range fields {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/floatscan"
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals that no floating-point usage was found
+	ErrCodeSuccess = iota
+	// ErrCodeFloatingPointFound signals that the contract uses floating-point values
+	ErrCodeFloatingPointFound
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+)
+
+var errFloatingPointFound = errors.New("floating-point usage found")
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "floatscan"
+	app.Usage = "reports the floating-point opcodes and types used by a WASM contract"
+	app.ArgsUsage = "<contract.wasm>"
+
+	outputJSON := false
+	app.Flags = []cli.Flag{
+		cli.BoolFlag{
+			Name:        "json",
+			Usage:       "print the report as JSON",
+			Destination: &outputJSON,
+		},
+	}
+
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() != 1 {
+			return fmt.Errorf("one argument expected - the path to the .wasm file")
+		}
+
+		return scanFile(context.Args().First(), outputJSON)
+	}
+
+	err := app.Run(os.Args)
+	if errors.Is(err, errFloatingPointFound) {
+		os.Exit(ErrCodeFloatingPointFound)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(ErrCodeSuccess)
+}
+
+func scanFile(path string, outputJSON bool) error {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	report, err := floatscan.ScanCode(code)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		serialized, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(serialized))
+	} else {
+		fmt.Print(report.String())
+	}
+
+	if report.HasFloatingPoint() {
+		return errFloatingPointFound
+	}
+
+	return nil
+}
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	am "github.com/multiversx/mx-chain-vm-v1_2-go/scenarioexec"
 	mc "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/controller"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/coverage"
 )
 
 func resolveArgument(exeDir string, arg string) (string, bool, error) {
@@ -23,6 +29,74 @@ func resolveArgument(exeDir string, arg string) (string, bool, error) {
 	return arg, fi.IsDir(), nil
 }
 
+func splitPatterns(patterns string) []string {
+	if len(patterns) == 0 {
+		return nil
+	}
+	return strings.Split(patterns, ",")
+}
+
+func writeReport(reportPath string, write func(writer io.Writer) error) error {
+	file, err := os.Create(reportPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return write(file)
+}
+
+func writeCoverage(collector *coverage.Collector, outputDir string) error {
+	err := os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	report := collector.Report()
+	err = writeReport(filepath.Join(outputDir, "coverage.json"), report.WriteJSON)
+	if err != nil {
+		return err
+	}
+
+	err = writeReport(filepath.Join(outputDir, "coverage.html"), report.WriteHTML)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("coverage report written to %s\n", outputDir)
+	return nil
+}
+
+func runDirectory(dirPath string, executorFactory mc.ScenarioExecutorFactory, options mc.ParallelRunOptions, junitPath string, jsonPath string) error {
+	runner, err := mc.NewParallelScenarioRunner(executorFactory, mc.NewDefaultFileResolver(), options)
+	if err != nil {
+		return err
+	}
+
+	report, err := runner.RunAllScenariosInDirectory(dirPath, "")
+	if err != nil {
+		return err
+	}
+
+	if len(junitPath) > 0 {
+		err = writeReport(junitPath, func(writer io.Writer) error {
+			return report.WriteJUnit(writer, filepath.Base(dirPath))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(jsonPath) > 0 {
+		err = writeReport(jsonPath, report.WriteJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	return report.Err()
+}
+
 func main() {
 	// directory of this executable
 	exeDir, err := os.Getwd()
@@ -31,50 +105,106 @@ func main() {
 		os.Exit(1)
 	}
 
-	// argument
-	if len(os.Args) != 2 {
-		panic("One argument expected - the path to the json test.")
+	// arguments
+	enableEpochsPath := flag.String("enable-epochs", "", "TOML file with the activation epochs of the VM flags")
+	numWorkers := flag.Int("workers", runtime.NumCPU(), "number of scenarios of a directory run in parallel, each on its own VM")
+	includePatterns := flag.String("include", "", "comma separated glob patterns, relative to the directory, of the only scenarios to run")
+	excludePatterns := flag.String("exclude", "", "comma separated glob patterns, relative to the directory, of the scenarios to skip")
+	failFast := flag.Bool("fail-fast", false, "stop starting new scenarios after the first failure")
+	junitPath := flag.String("junit", "", "file receiving a JUnit XML report of the scenarios of a directory")
+	jsonPath := flag.String("json", "", "file receiving a JSON report of the scenarios of a directory")
+	collectCoverage := flag.Bool("coverage", false, "collect the coverage of the exports, functions and VM hooks of the contracts run; the instrumentation is metered, so tests with tight gas limits may run out of gas")
+	coverageDir := flag.String("coverage-out", "coverage", "directory receiving coverage.json and coverage.html, with -coverage")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		panic("One argument expected - the path to the json test or to the json/yaml scenario.")
 	}
-	jsonFilePath, isDir, err := resolveArgument(exeDir, os.Args[1])
+	jsonFilePath, isDir, err := resolveArgument(exeDir, flag.Arg(0))
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	var enableEpochs *config.EnableEpochs
+	if len(*enableEpochsPath) > 0 {
+		enableEpochsConfig, err := config.LoadEnableEpochsConfig(*enableEpochsPath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		enableEpochs = &enableEpochsConfig.EnableEpochs
+	}
+
 	// init
+	var collector *coverage.Collector
+	var coverageCollector vmhost.CoverageCollector
+	if *collectCoverage {
+		collector = coverage.NewCollector()
+		coverageCollector = collector
+	}
+
 	scenarioexecPath := filepath.Join(exeDir, "../scenarioexec")
-	executor, err := am.NewVMTestExecutor(scenarioexecPath)
+	newExecutor := func() (*am.VMTestExecutor, error) {
+		return am.NewVMTestExecutorWithArgs(scenarioexecPath, am.VMTestExecutorArgs{
+			EnableEpochs:      enableEpochs,
+			CoverageCollector: coverageCollector,
+		})
+	}
+
+	if isDir {
+		err = runDirectory(
+			jsonFilePath,
+			func() (mc.ScenarioExecutor, error) {
+				return newExecutor()
+			},
+			mc.ParallelRunOptions{
+				NumWorkers:      *numWorkers,
+				IncludePatterns: splitPatterns(*includePatterns),
+				ExcludePatterns: splitPatterns(*excludePatterns),
+				FailFast:        *failFast,
+				Output:          os.Stdout,
+			},
+			*junitPath,
+			*jsonPath)
+	} else {
+		err = runFile(jsonFilePath, newExecutor)
+	}
+
+	if collector != nil {
+		coverageErr := writeCoverage(collector, *coverageDir)
+		if coverageErr != nil {
+			fmt.Printf("could not write the coverage report: %s\n", coverageErr.Error())
+		}
+	}
+
+	printResult(err)
+}
+
+func runFile(jsonFilePath string, newExecutor func() (*am.VMTestExecutor, error)) error {
+	executor, err := newExecutor()
 	if err != nil {
 		panic("Could not instantiate VM VM")
 	}
 
 	// execute
 	switch {
-	case isDir:
+	case strings.HasSuffix(jsonFilePath, mc.ScenarioJSONSuffix),
+		strings.HasSuffix(jsonFilePath, mc.ScenarioYAMLSuffix):
 		runner := mc.NewScenarioRunner(
 			executor,
 			mc.NewDefaultFileResolver(),
 		)
-		err = runner.RunAllJSONScenariosInDirectory(
-			jsonFilePath,
-			"",
-			".scen.json",
-			[]string{})
-	case strings.HasSuffix(jsonFilePath, ".scen.json"):
-		runner := mc.NewScenarioRunner(
-			executor,
-			mc.NewDefaultFileResolver(),
-		)
-		err = runner.RunSingleJSONScenario(jsonFilePath)
+		return runner.RunSingleJSONScenario(jsonFilePath)
 	default:
 		runner := mc.NewTestRunner(
 			executor,
 			mc.NewDefaultFileResolver(),
 		)
-		err = runner.RunSingleJSONTest(jsonFilePath)
+		return runner.RunSingleJSONTest(jsonFilePath)
 	}
+}
 
-	// print result
+func printResult(err error) {
 	if err == nil {
 		fmt.Println("SUCCESS")
 	} else {
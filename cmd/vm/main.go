@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-logger-go/pipes"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/vmpart"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
+)
+
+// The file descriptors of the pipes passed by the VMDriver, in the order of its ExtraFiles
+const (
+	fileDescriptorVMInit         = 3
+	fileDescriptorNodeToVM       = 4
+	fileDescriptorVMToNode       = 5
+	fileDescriptorReadLogProfile = 6
+	fileDescriptorLogToNode      = 7
+)
+
+var log = logger.GetOrCreate("vm")
+
+// appBuild is set at build time, see the "vm" target of the Makefile
+var appBuild = "undefined"
+
+func main() {
+	listenAddress := flag.String(
+		"listen",
+		"",
+		"serve the Nodes connecting at the given address, unix://<path> or tcp://<host>:<port>, "+
+			"instead of running as the child process of a Node; the connections are not authenticated, "+
+			"thus the TCP addresses are restricted to the loopback ones, unless -listen-remote is given")
+	listenRemote := flag.Bool(
+		"listen-remote",
+		false,
+		"allow -listen at a TCP address reachable from other machines, to be protected by other means (e.g. a firewall)")
+	recordingPath := flag.String(
+		"record",
+		"",
+		"append the messages exchanged with the Node to the given replay file, see cmd/vmreplay")
+	flag.Parse()
+
+	recorder, err := createRecorder(*recordingPath)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(common.ErrCodeCannotCreateFile)
+	}
+
+	var errCode int
+	var errMessage string
+	if len(*listenAddress) > 0 {
+		errCode, errMessage = doServe(*listenAddress, *listenRemote, recorder)
+	} else {
+		errCode, errMessage = doMain(recorder)
+	}
+
+	if errCode != common.ErrCodeSuccess {
+		_, _ = fmt.Fprintln(os.Stderr, errMessage)
+		os.Exit(errCode)
+	}
+}
+
+// createRecorder returns nil when no replay file is given
+func createRecorder(recordingPath string) (*common.DialogueRecorder, error) {
+	if len(recordingPath) == 0 {
+		return nil, nil
+	}
+
+	recordingFile, err := os.OpenFile(recordingPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open replay file: %w", err)
+	}
+
+	return common.NewDialogueRecorder(recordingFile, common.PartVM), nil
+}
+
+// doMain returns (error code, error message)
+func doMain(recorder *common.DialogueRecorder) (int, string) {
+	vmInitFile := getPipeFile(fileDescriptorVMInit)
+	if vmInitFile == nil {
+		return common.ErrCodeCannotCreateFile, "Cannot get pipe file: [vmInitFile]"
+	}
+
+	nodeToVMFile := getPipeFile(fileDescriptorNodeToVM)
+	if nodeToVMFile == nil {
+		return common.ErrCodeCannotCreateFile, "Cannot get pipe file: [nodeToVMFile]"
+	}
+
+	vmToNodeFile := getPipeFile(fileDescriptorVMToNode)
+	if vmToNodeFile == nil {
+		return common.ErrCodeCannotCreateFile, "Cannot get pipe file: [vmToNodeFile]"
+	}
+
+	readLogProfileFile := getPipeFile(fileDescriptorReadLogProfile)
+	if readLogProfileFile == nil {
+		return common.ErrCodeCannotCreateFile, "Cannot get pipe file: [readLogProfileFile]"
+	}
+
+	logToNodeFile := getPipeFile(fileDescriptorLogToNode)
+	if logToNodeFile == nil {
+		return common.ErrCodeCannotCreateFile, "Cannot get pipe file: [logToNodeFile]"
+	}
+
+	vmArguments, err := common.GetVMArguments(vmInitFile)
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot receive VM arguments: %v", err)
+	}
+
+	capabilities, err := common.AcceptHandshake(nodeToVMFile, vmToNodeFile, common.NewHandshake(), vmhost.VMVersion)
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Handshake failed: %v", err)
+	}
+
+	messagesMarshalizer := marshaling.CreateMarshalizer(vmArguments.MessagesMarshalizer)
+	logsMarshalizer := marshaling.CreateMarshalizer(vmArguments.LogsMarshalizer)
+
+	logsPart, err := pipes.NewChildPart(readLogProfileFile, logToNodeFile, logsMarshalizer)
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot create logs part: %v", err)
+	}
+
+	err = logsPart.StartLoop()
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot start logs loop: %v", err)
+	}
+
+	defer logsPart.StopLoop()
+
+	vmHostParameters := &vmArguments.VMHostParameters
+	vmHostParameters.EnableEpochsHandler = hostCore.NewEnableEpochsHandler(vmArguments.EnableEpochs)
+
+	part, err := vmpart.NewVMPart(
+		vmhost.VMVersion,
+		nodeToVMFile,
+		vmToNodeFile,
+		vmHostParameters,
+		messagesMarshalizer,
+	)
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot create VMPart: %v", err)
+	}
+
+	part.EnableCapabilities(capabilities)
+	part.SetDialogueTimings(vmArguments.HeartbeatPeriod, vmArguments.HookReplyTimeout)
+
+	if recorder != nil {
+		err = recorder.BeginSession(common.ReplaySession{VMArguments: *vmArguments, Capabilities: capabilities})
+		if err != nil {
+			return common.ErrCodeCannotCreateFile, fmt.Sprintf("Cannot record: %v", err)
+		}
+
+		part.EnableRecording(recorder)
+	}
+
+	log.Info("VM started", "version", vmhost.VMVersion, "build", appBuild)
+
+	err = part.StartLoop()
+	if err == common.ErrStopPerNodeRequest {
+		return common.ErrCodeSuccess, ""
+	}
+
+	return common.ErrCodeTerminated, fmt.Sprintf("Ended VM loop: %v", err)
+}
+
+// doServe runs VM as a standalone process, until it is interrupted; the logs are written to the standard output
+func doServe(listenAddress string, listenRemote bool, recorder *common.DialogueRecorder) (int, string) {
+	if !listenRemote && !common.IsLoopbackTransportAddress(listenAddress) {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot listen at %s: not a loopback address, see -listen-remote", listenAddress)
+	}
+
+	listener, err := common.ListenTransport(listenAddress)
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot listen at %s: %v", listenAddress, err)
+	}
+
+	server := vmpart.NewVMServer(listener, vmhost.VMVersion)
+	if recorder != nil {
+		server.SetRecorder(recorder)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Info("VM server stopping")
+		_ = server.Close()
+	}()
+
+	log.Info("VM server started", "version", vmhost.VMVersion, "build", appBuild, "address", listenAddress)
+
+	err = server.Serve()
+	if err != nil {
+		return common.ErrCodeTerminated, fmt.Sprintf("Ended VM server: %v", err)
+	}
+
+	return common.ErrCodeSuccess, ""
+}
+
+func getPipeFile(fileDescriptor uintptr) *os.File {
+	return os.NewFile(fileDescriptor, fmt.Sprintf("/proc/self/fd/%d", fileDescriptor))
+}
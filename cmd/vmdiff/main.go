@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/difftest"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals that both configurations produced the same outputs
+	ErrCodeSuccess = iota
+	// ErrCodeDivergent signals that at least one transaction behaved differently
+	ErrCodeDivergent
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+)
+
+const scenarioGasSchedule = "scenario"
+
+type cliArguments struct {
+	BaselineFlags        string
+	CandidateFlags       string
+	BaselineGasSchedule  string
+	CandidateGasSchedule string
+	GasSchedulesDir      string
+	ScenarioexecPath     string
+	OutputJSON           bool
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "vmdiff"
+	app.Usage = "replays scenarios against two VM configurations and reports the transactions whose outputs differ"
+	app.ArgsUsage = "<scenario file or directory>..."
+
+	defaultFlags := defaultEnabledFlags()
+
+	args := &cliArguments{}
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:        "baseline-flags",
+			Value:       strings.Join(defaultFlags, ","),
+			Usage:       "comma-separated enable-epoch flags active in the baseline VM",
+			Destination: &args.BaselineFlags,
+		},
+		cli.StringFlag{
+			Name:        "candidate-flags",
+			Value:       strings.Join(defaultFlags, ","),
+			Usage:       "comma-separated enable-epoch flags active in the candidate VM",
+			Destination: &args.CandidateFlags,
+		},
+		cli.StringFlag{
+			Name:        "baseline-gas-schedule",
+			Value:       scenarioGasSchedule,
+			Usage:       "gas schedule of the baseline VM: scenario, dummy, v1, v2, v3 or the path to a TOML file",
+			Destination: &args.BaselineGasSchedule,
+		},
+		cli.StringFlag{
+			Name:        "candidate-gas-schedule",
+			Value:       scenarioGasSchedule,
+			Usage:       "gas schedule of the candidate VM: scenario, dummy, v1, v2, v3 or the path to a TOML file",
+			Destination: &args.CandidateGasSchedule,
+		},
+		cli.StringFlag{
+			Name:        "gas-schedules-dir",
+			Value:       "../../scenarioexec/gasSchedules",
+			Usage:       "directory holding the gas schedules selected by version",
+			Destination: &args.GasSchedulesDir,
+		},
+		cli.StringFlag{
+			Name:        "scenarioexec-path",
+			Value:       "../../scenarioexec",
+			Usage:       "path to the scenarioexec directory, used to load the gas schedules declared by the scenarios",
+			Destination: &args.ScenarioexecPath,
+		},
+		cli.BoolFlag{
+			Name:        "json",
+			Usage:       "print the report as JSON",
+			Destination: &args.OutputJSON,
+		},
+	}
+
+	exitCode := ErrCodeSuccess
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() == 0 {
+			return fmt.Errorf("at least one argument expected - the scenario files or directories to replay")
+		}
+
+		report, err := runHarness(context.Args(), args)
+		if err != nil {
+			return err
+		}
+
+		err = printReport(report, args.OutputJSON)
+		if err != nil {
+			return err
+		}
+
+		if report.HasDivergences() {
+			exitCode = ErrCodeDivergent
+		}
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(exitCode)
+}
+
+// defaultEnabledFlags returns the flags active from genesis in the default enable epochs
+func defaultEnabledFlags() []string {
+	handler := hostCore.NewEnableEpochsHandler(config.MakeDefaultEnableEpochs())
+
+	flags := make([]string, 0)
+	for _, flag := range hostCore.AllFlags() {
+		if handler.IsFlagEnabledInEpoch(flag, 0) {
+			flags = append(flags, string(flag))
+		}
+	}
+
+	return flags
+}
+
+func runHarness(paths []string, args *cliArguments) (*difftest.Report, error) {
+	baseline, err := newConfiguration("baseline", args.BaselineFlags, args.BaselineGasSchedule, args.GasSchedulesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := newConfiguration("candidate", args.CandidateFlags, args.CandidateGasSchedule, args.GasSchedulesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	harness, err := difftest.NewHarness(args.ScenarioexecPath, baseline, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	err = harness.RunScenarios(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return harness.Report(), nil
+}
+
+func newConfiguration(name string, flagList string, gasSchedule string, gasSchedulesDir string) (*difftest.Configuration, error) {
+	flags, err := difftest.ParseFlags(flagList)
+	if err != nil {
+		return nil, err
+	}
+
+	gasScheduleMap, err := loadGasSchedule(gasSchedule, gasSchedulesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &difftest.Configuration{
+		Name:         fmt.Sprintf("%s [flags: %s; gas schedule: %s]", name, flagList, gasSchedule),
+		EnabledFlags: flags,
+		GasSchedule:  gasScheduleMap,
+	}, nil
+}
+
+func loadGasSchedule(gasSchedule string, gasSchedulesDir string) (config.GasScheduleMap, error) {
+	if gasSchedule == scenarioGasSchedule {
+		return nil, nil
+	}
+
+	return hostCore.LoadGasScheduleByName(gasSchedule, gasSchedulesDir)
+}
+
+func printReport(report *difftest.Report, outputJSON bool) error {
+	if !outputJSON {
+		fmt.Print(report.String())
+		return nil
+	}
+
+	serialized, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(serialized))
+	return nil
+}
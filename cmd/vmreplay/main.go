@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/vmpart"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals that VM sent the recorded messages, in all the sessions
+	ErrCodeSuccess = iota
+	// ErrCodeDivergent signals that VM diverged from the recorded dialogue, in at least one session
+	ErrCodeDivergent
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+)
+
+type cliArguments struct {
+	Timeout int
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "vmreplay"
+	app.Usage = "replays the dialogues recorded by the Node (nodepart.Config.RecordingPath) or by VM (vm -record) against a fresh VM, " +
+		"and reports the sessions in which VM did not send the recorded hook calls and responses"
+	app.ArgsUsage = "<replay file>..."
+
+	args := &cliArguments{}
+	app.Flags = []cli.Flag{
+		cli.IntFlag{
+			Name:        "timeout",
+			Value:       10000,
+			Usage:       "milliseconds to wait for each message of VM",
+			Destination: &args.Timeout,
+		},
+	}
+
+	exitCode := ErrCodeSuccess
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() == 0 {
+			return fmt.Errorf("at least one argument expected - the replay files")
+		}
+
+		for _, path := range context.Args() {
+			divergent, err := replayFile(path, args.Timeout)
+			if err != nil {
+				return err
+			}
+
+			if divergent {
+				exitCode = ErrCodeDivergent
+			}
+		}
+
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(exitCode)
+}
+
+// replayFile returns whether VM diverged in any of the sessions of the file
+func replayFile(path string, timeout int) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	sessions, err := common.ReadReplayFile(file)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+
+	divergent := false
+	for index, session := range sessions {
+		err = vmpart.Replay(session, vmhost.VMVersion, timeout)
+		switch {
+		case err == nil:
+			fmt.Printf("%s: session %d: OK (%d messages)\n", path, index, len(session.Messages))
+		case errors.Is(err, common.ErrReplayDivergence):
+			fmt.Printf("%s: session %d: DIVERGED: %v\n", path, index, err)
+			divergent = true
+		default:
+			return false, fmt.Errorf("%s: session %d: %w", path, index, err)
+		}
+	}
+
+	return divergent, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmserver"
 	"github.com/urfave/cli"
 )
@@ -114,7 +115,30 @@ func initializeCLI(facade *vmserver.DebugFacade) *cli.App {
 		Destination: &args.AccountNonce,
 	}
 
-	app.Flags = []cli.Flag{}
+	// Global
+	flagEnableEpochs := cli.StringFlag{
+		Name:        "enable-epochs",
+		Usage:       "TOML file with the activation epochs of the VM flags",
+		Destination: &args.EnableEpochs,
+	}
+
+	app.Flags = []cli.Flag{
+		flagEnableEpochs,
+	}
+
+	app.Before = func(context *cli.Context) error {
+		if len(args.EnableEpochs) == 0 {
+			return nil
+		}
+
+		enableEpochsConfig, err := config.LoadEnableEpochsConfig(args.EnableEpochs)
+		if err != nil {
+			return err
+		}
+
+		facade.SetEnableEpochs(enableEpochsConfig.EnableEpochs)
+		return nil
+	}
 
 	app.Authors = []cli.Author{
 		{
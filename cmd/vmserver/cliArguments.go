@@ -6,6 +6,8 @@ import (
 )
 
 type cliArguments struct {
+	// Global arguments
+	EnableEpochs string
 	// Common arguments
 	ServerAddress string
 	Database      string
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"math"
+	"os"
+	"sort"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	worldmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/contexts"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/wasmparser"
+)
+
+const (
+	importStatusEEI     = "eei"
+	importStatusBuiltin = "protocol built-in"
+	importStatusUnknown = "unknown"
+)
+
+var inspectorVMType = []byte{5, 0}
+
+func loadEnableEpochs(args *cliArguments) (config.EnableEpochs, error) {
+	if len(args.EnableEpochs) == 0 {
+		return config.MakeDefaultEnableEpochs(), nil
+	}
+
+	enableEpochsConfig, err := config.LoadEnableEpochsConfig(args.EnableEpochs)
+	if err != nil {
+		return config.EnableEpochs{}, err
+	}
+
+	return enableEpochsConfig.EnableEpochs, nil
+}
+
+func loadRules(args *cliArguments) (*config.ContractValidationRules, error) {
+	if len(args.Rules) == 0 {
+		return nil, nil
+	}
+
+	return config.LoadContractValidationRules(args.Rules)
+}
+
+// newInspectionHost creates a host whose enable-epoch flags follow the given epoch
+func newInspectionHost(gasSchedule config.GasScheduleMap, enableEpochs config.EnableEpochs, epoch uint32) (vmhost.VMHost, error) {
+	// as in the scenario executor, the built-in functions keep the test costs,
+	// which the gas schedule files do not all define
+	world := worldmock.NewMockWorld()
+	err := world.InitBuiltinFunctions(config.MakeGasMapForTests())
+	if err != nil {
+		return nil, err
+	}
+	world.CurrentBlockInfo = &worldmock.BlockInfo{
+		BlockEpoch: epoch,
+	}
+
+	return hostCore.NewVMHost(world, &vmhost.VMHostParameters{
+		VMType:                   inspectorVMType,
+		BlockGasLimit:            math.MaxUint64,
+		GasSchedule:              gasSchedule,
+		ProtocolBuiltinFunctions: world.GetBuiltinFunctionNames(),
+		ProtectedKeyPrefix:       []byte(vmhost.ProtectedStoragePrefix),
+		EnableEpochsHandler:      hostCore.NewEnableEpochsHandler(enableEpochs),
+	})
+}
+
+func inspectFile(path string, args *cliArguments) (*inspectionReport, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	module, err := wasmparser.ParseModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	gasSchedule, err := hostCore.LoadGasScheduleByName(args.GasSchedule, args.GasSchedulesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	enableEpochs, err := loadEnableEpochs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := loadRules(args)
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := newInspectionHost(gasSchedule, enableEpochs, uint32(args.Epoch))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &inspectionReport{
+		File:     path,
+		CodeSize: len(code),
+		Valid:    true,
+	}
+
+	report.Imports = inspectImports(module, host)
+	report.Exports = inspectExports(module, host)
+
+	for _, result := range contexts.RunValidatorChecks(host, code, rules) {
+		check := &validatorCheck{
+			Check:   result.Check,
+			Passed:  result.Err == nil,
+			Skipped: result.Skipped,
+		}
+		if result.Err != nil {
+			check.Error = result.Err.Error()
+			report.Valid = false
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	for _, imp := range report.Imports {
+		if imp.Status != importStatusEEI {
+			report.Valid = false
+		}
+	}
+
+	report.DeployGas, err = estimateDeployGas(host, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func inspectImports(module *wasmparser.Module, host vmhost.VMHost) []*importEntry {
+	apiMethods := host.GetAPIMethods().Names()
+	builtinFunctions := host.GetProtocolBuiltinFunctions()
+
+	entries := make([]*importEntry, 0, len(module.Imports))
+	for _, imp := range module.Imports {
+		entry := &importEntry{
+			Module: imp.Module,
+			Name:   imp.Name,
+			Kind:   imp.Kind.String(),
+		}
+
+		if imp.Kind == wasmparser.ExternalFunction {
+			if imp.TypeIndex < uint32(len(module.Types)) {
+				entry.Signature = module.Types[imp.TypeIndex].String()
+			}
+			entry.Status = importStatus(imp.Name, apiMethods, builtinFunctions)
+		} else {
+			entry.Status = importStatusUnknown
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func importStatus(name string, apiMethods vmcommon.FunctionNames, builtinFunctions vmcommon.FunctionNames) string {
+	_, isAPIMethod := apiMethods[name]
+	if isAPIMethod {
+		return importStatusEEI
+	}
+
+	_, isBuiltin := builtinFunctions[name]
+	if isBuiltin {
+		return importStatusBuiltin
+	}
+
+	return importStatusUnknown
+}
+
+func inspectExports(module *wasmparser.Module, host vmhost.VMHost) []*exportEntry {
+	reserved := contexts.NewReservedFunctions(host.GetAPIMethods().Names(), host.GetProtocolBuiltinFunctions())
+
+	entries := make([]*exportEntry, 0, len(module.Exports))
+	for _, export := range module.Exports {
+		entry := &exportEntry{
+			Name: export.Name,
+			Kind: export.Kind.String(),
+		}
+
+		if export.Kind == wasmparser.ExternalFunction {
+			functionType, ok := module.GetFunctionType(export.Index)
+			if ok {
+				entry.Signature = functionType.String()
+			}
+			entry.Reserved = reserved.IsReserved(export.Name)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+func estimateDeployGas(host vmhost.VMHost, code []byte) (uint64, error) {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	vmInput := &vmcommon.VMInput{
+		GasProvided: math.MaxUint64,
+	}
+	runtime.SetVMInput(vmInput)
+	metering.InitStateFromContractCallInput(vmInput)
+
+	err := metering.DeductInitialGasForDirectDeployment(vmhost.CodeDeployInput{
+		ContractCode: code,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return vmInput.GasProvided - metering.GetGasForExecution(), nil
+}
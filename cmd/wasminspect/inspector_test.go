@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	counterPath    = "../../test/contracts/counter/output/counter.wasm"
+	floatCodePath  = "../../test/contracts/num-with-fp/output/num-with-fp.wasm"
+	testSchedules  = "../../scenarioexec/gasSchedules"
+	floatRejection = `[EnableEpochs]
+    FloatingPointRejectionEnableEpoch = 3
+`
+)
+
+func defaultTestArguments() *cliArguments {
+	return &cliArguments{
+		GasSchedule:     "v3",
+		GasSchedulesDir: testSchedules,
+	}
+}
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0644)
+	require.Nil(t, err)
+	return path
+}
+
+func findCheck(report *inspectionReport, name string) *validatorCheck {
+	for _, check := range report.Checks {
+		if check.Check == name {
+			return check
+		}
+	}
+	return nil
+}
+
+func TestInspectFile_ValidContract(t *testing.T) {
+	report, err := inspectFile(counterPath, defaultTestArguments())
+	require.Nil(t, err)
+
+	require.True(t, report.Valid)
+	require.NotEmpty(t, report.Imports)
+	for _, imp := range report.Imports {
+		require.Equal(t, importStatusEEI, imp.Status)
+	}
+	require.NotEmpty(t, report.Exports)
+	require.NotZero(t, report.DeployGas)
+
+	require.True(t, findCheck(report, "contract rules").Skipped)
+	require.True(t, findCheck(report, "deterministic code").Skipped)
+	require.True(t, findCheck(report, "exported functions").Passed)
+}
+
+func TestInspectFile_GasSchedulesDirRequiredByVersions(t *testing.T) {
+	args := defaultTestArguments()
+	args.GasSchedulesDir = ""
+	_, err := inspectFile(counterPath, args)
+	require.NotNil(t, err)
+
+	_, err = hostCore.LoadGasScheduleByName("dummy", args.GasSchedulesDir)
+	require.Nil(t, err)
+}
+
+func TestInspectFile_FloatingPointFollowsEpoch(t *testing.T) {
+	args := defaultTestArguments()
+	args.EnableEpochs = writeTestFile(t, "enableEpochs.toml", floatRejection)
+
+	args.Epoch = 2
+	report, err := inspectFile(floatCodePath, args)
+	require.Nil(t, err)
+	require.True(t, findCheck(report, "deterministic code").Skipped)
+
+	args.Epoch = 3
+	report, err = inspectFile(floatCodePath, args)
+	require.Nil(t, err)
+	require.False(t, report.Valid)
+	check := findCheck(report, "deterministic code")
+	require.False(t, check.Skipped)
+	require.False(t, check.Passed)
+	require.Contains(t, check.Error, "floating-point")
+
+	report, err = inspectFile(counterPath, args)
+	require.Nil(t, err)
+	require.True(t, report.Valid)
+	require.True(t, findCheck(report, "deterministic code").Passed)
+}
+
+func TestInspectFile_Rules(t *testing.T) {
+	args := defaultTestArguments()
+	args.Rules = writeTestFile(t, "rules.toml", `[ContractValidationRules]
+    MaxFunctions = 1
+`)
+
+	report, err := inspectFile(counterPath, args)
+	require.Nil(t, err)
+	require.False(t, report.Valid)
+	check := findCheck(report, "contract rules")
+	require.False(t, check.Skipped)
+	require.False(t, check.Passed)
+	require.Contains(t, check.Error, "functions")
+
+	args.Rules = writeTestFile(t, "rules.toml", `[ContractValidationRules]
+    MaxFunctions = 1000
+`)
+	report, err = inspectFile(counterPath, args)
+	require.Nil(t, err)
+	require.True(t, report.Valid)
+	require.True(t, findCheck(report, "contract rules").Passed)
+
+	args.Rules = "missing.toml"
+	_, err = inspectFile(counterPath, args)
+	require.NotNil(t, err)
+}
+
+func TestInspectionReport_String(t *testing.T) {
+	report := &inspectionReport{
+		File:     "contract.wasm",
+		CodeSize: 10,
+		Imports:  []*importEntry{{Module: "env", Name: "unknownHook", Kind: "func", Status: importStatusUnknown}},
+		Checks: []*validatorCheck{
+			{Check: "contract rules", Passed: true, Skipped: true},
+			{Check: "deterministic code", Passed: true},
+			{Check: "exported functions", Error: "invalid function name"},
+		},
+	}
+
+	text := report.String()
+	require.True(t, strings.Contains(text, "env.unknownHook  [unknown]"))
+	require.True(t, strings.Contains(text, "skip  contract rules"))
+	require.True(t, strings.Contains(text, "ok    deterministic code"))
+	require.True(t, strings.Contains(text, "FAIL  exported functions: invalid function name"))
+	require.True(t, strings.HasSuffix(text, "result: INVALID\n"))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals that the contract passes all checks
+	ErrCodeSuccess = iota
+	// ErrCodeChecksFailed signals that the contract fails at least one check
+	ErrCodeChecksFailed
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+)
+
+type cliArguments struct {
+	GasSchedule     string
+	GasSchedulesDir string
+	EnableEpochs    string
+	Rules           string
+	Epoch           uint
+	OutputJSON      bool
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "wasminspect"
+	app.Usage = "inspects a WASM contract: imports, exports, validation and deploy cost"
+	app.ArgsUsage = "<contract.wasm>"
+
+	args := &cliArguments{}
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:        "gas-schedule",
+			Value:       "v3",
+			Usage:       "gas schedule used to estimate the deploy cost: dummy, v1, v2, v3 or the path to a TOML file",
+			Destination: &args.GasSchedule,
+		},
+		cli.StringFlag{
+			Name:        "gas-schedules-dir",
+			Usage:       "directory holding the gas schedules selected by version, such as scenarioexec/gasSchedules; required with v1, v2 and v3",
+			Destination: &args.GasSchedulesDir,
+		},
+		cli.StringFlag{
+			Name:        "enable-epochs",
+			Usage:       "TOML file with the activation epochs of the VM flags; defaults to those of the tools",
+			Destination: &args.EnableEpochs,
+		},
+		cli.StringFlag{
+			Name:        "rules",
+			Usage:       "TOML file with the contract validation rules of the node; without it, the rules are not checked",
+			Destination: &args.Rules,
+		},
+		cli.UintFlag{
+			Name:        "epoch",
+			Usage:       "epoch in which the enable-epoch flags and the contract validation rules are evaluated",
+			Destination: &args.Epoch,
+		},
+		cli.BoolFlag{
+			Name:        "json",
+			Usage:       "print the report as JSON",
+			Destination: &args.OutputJSON,
+		},
+	}
+
+	exitCode := ErrCodeSuccess
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() != 1 {
+			return fmt.Errorf("one argument expected - the path to the .wasm file")
+		}
+
+		report, err := inspectFile(context.Args().First(), args)
+		if err != nil {
+			return err
+		}
+
+		err = printReport(report, args.OutputJSON)
+		if err != nil {
+			return err
+		}
+
+		if !report.Valid {
+			exitCode = ErrCodeChecksFailed
+		}
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(exitCode)
+}
+
+func printReport(report *inspectionReport, outputJSON bool) error {
+	if !outputJSON {
+		fmt.Print(report.String())
+		return nil
+	}
+
+	serialized, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(serialized))
+	return nil
+}
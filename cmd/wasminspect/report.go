@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type importEntry struct {
+	Module    string `json:"module"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Signature string `json:"signature,omitempty"`
+	Status    string `json:"status"`
+}
+
+type exportEntry struct {
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Signature string `json:"signature,omitempty"`
+	Reserved  bool   `json:"reserved"`
+}
+
+type validatorCheck struct {
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type inspectionReport struct {
+	File      string            `json:"file"`
+	CodeSize  int               `json:"codeSize"`
+	Imports   []*importEntry    `json:"imports"`
+	Exports   []*exportEntry    `json:"exports"`
+	Checks    []*validatorCheck `json:"checks"`
+	DeployGas uint64            `json:"deployGas"`
+	Valid     bool              `json:"valid"`
+}
+
+// String renders the report in a human-readable form
+func (report *inspectionReport) String() string {
+	buffer := &bytes.Buffer{}
+
+	_, _ = fmt.Fprintf(buffer, "file: %s (%d bytes)\n", report.File, report.CodeSize)
+
+	_, _ = fmt.Fprintf(buffer, "\nimports (%d):\n", len(report.Imports))
+	for _, imp := range report.Imports {
+		_, _ = fmt.Fprintf(buffer, "  %-8s %s.%s %s [%s]\n", imp.Kind, imp.Module, imp.Name, imp.Signature, imp.Status)
+	}
+
+	_, _ = fmt.Fprintf(buffer, "\nexports (%d):\n", len(report.Exports))
+	for _, export := range report.Exports {
+		reservedNote := ""
+		if export.Reserved {
+			reservedNote = " [reserved name]"
+		}
+		_, _ = fmt.Fprintf(buffer, "  %-8s %s %s%s\n", export.Kind, export.Name, export.Signature, reservedNote)
+	}
+
+	buffer.WriteString("\nvalidator checks:\n")
+	for _, check := range report.Checks {
+		switch {
+		case check.Skipped:
+			_, _ = fmt.Fprintf(buffer, "  skip  %s\n", check.Check)
+		case check.Passed:
+			_, _ = fmt.Fprintf(buffer, "  ok    %s\n", check.Check)
+		default:
+			_, _ = fmt.Fprintf(buffer, "  FAIL  %s: %s\n", check.Check, check.Error)
+		}
+	}
+
+	_, _ = fmt.Fprintf(buffer, "\nestimated deploy gas (excluding init): %d\n", report.DeployGas)
+
+	if report.Valid {
+		buffer.WriteString("result: VALID\n")
+	} else {
+		buffer.WriteString("result: INVALID\n")
+	}
+
+	return buffer.String()
+}
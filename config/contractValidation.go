@@ -0,0 +1,61 @@
+package config
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// AllowedImportsByEpoch holds the EEI functions a contract may import starting with the given epoch
+type AllowedImportsByEpoch struct {
+	EnableEpoch uint32
+	Names       []string
+}
+
+// ContractValidationRules holds the constraints verified on contract code at deploy and upgrade;
+// a zero limit means the corresponding constraint is not enforced
+type ContractValidationRules struct {
+	MaxCodeSize         uint64
+	MaxFunctions        uint32
+	MaxExports          uint32
+	MaxMemoryPages      uint32
+	MaxTableSize        uint32
+	AllowedImports      []AllowedImportsByEpoch
+	ForbidStartSection  bool
+	ForbidFloatingPoint bool
+}
+
+// ContractValidationRulesConfig is the structure of a contract validation rules TOML file
+type ContractValidationRulesConfig struct {
+	ContractValidationRules ContractValidationRules
+}
+
+// LoadContractValidationRules loads the rules from a TOML file with a [ContractValidationRules] section;
+// the limits missing from the file are 0, so they are not enforced
+func LoadContractValidationRules(filePath string) (*ContractValidationRules, error) {
+	rulesConfig := &ContractValidationRulesConfig{}
+	err := core.LoadTomlFile(rulesConfig, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rulesConfig.ContractValidationRules, nil
+}
+
+// AllowedImportsInEpoch returns the set of imports allowed in the given epoch,
+// or nil if no allowlist is configured
+func (rules *ContractValidationRules) AllowedImportsInEpoch(epoch uint32) map[string]struct{} {
+	if len(rules.AllowedImports) == 0 {
+		return nil
+	}
+
+	allowed := make(map[string]struct{})
+	for _, entry := range rules.AllowedImports {
+		if entry.EnableEpoch > epoch {
+			continue
+		}
+		for _, name := range entry.Names {
+			allowed[name] = struct{}{}
+		}
+	}
+
+	return allowed
+}
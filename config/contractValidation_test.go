@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadContractValidationRules(t *testing.T) {
+	rules, err := LoadContractValidationRules("./contractValidation.toml")
+	require.Nil(t, err)
+	require.Equal(t, &ContractValidationRules{
+		ForbidStartSection:  true,
+		ForbidFloatingPoint: true,
+	}, rules)
+
+	_, err = LoadContractValidationRules("./missing.toml")
+	require.NotNil(t, err)
+}
+
+func TestContractValidationRules_AllowedImportsInEpoch(t *testing.T) {
+	rules := &ContractValidationRules{}
+	require.Nil(t, rules.AllowedImportsInEpoch(0))
+
+	rules.AllowedImports = []AllowedImportsByEpoch{
+		{EnableEpoch: 0, Names: []string{"a"}},
+		{EnableEpoch: 5, Names: []string{"b"}},
+	}
+	require.Equal(t, map[string]struct{}{"a": {}}, rules.AllowedImportsInEpoch(4))
+	require.Equal(t, map[string]struct{}{"a": {}, "b": {}}, rules.AllowedImportsInEpoch(5))
+}
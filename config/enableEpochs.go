@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// DisabledEpoch is the activation epoch of a flag that never gets enabled
+const DisabledEpoch = uint32(math.MaxUint32)
+
+// EnableEpochs holds the epochs starting with which the VM flags are active
+type EnableEpochs struct {
+	SCDeployEnableEpoch                uint32
+	BuiltInFunctionsEnableEpoch        uint32
+	RepairCallbackEnableEpoch          uint32
+	AheadOfTimeGasUsageEnableEpoch     uint32
+	FloatingPointRejectionEnableEpoch  uint32
+	CodeMetadataEnforcementEnableEpoch uint32
+	MemoryAccountingEnableEpoch        uint32
+	EventLogEnableEpoch                uint32
+}
+
+// EnableEpochsConfig is the structure of an enable epochs TOML file
+type EnableEpochsConfig struct {
+	EnableEpochs EnableEpochs
+}
+
+// MakeDefaultEnableEpochs returns the activation epochs used by the tools when none are configured:
+// the deploy, built-in functions, repair callback and ahead-of-time gas usage flags are active
+// from genesis, while the later flags are disabled
+func MakeDefaultEnableEpochs() EnableEpochs {
+	return EnableEpochs{
+		SCDeployEnableEpoch:                0,
+		BuiltInFunctionsEnableEpoch:        0,
+		RepairCallbackEnableEpoch:          0,
+		AheadOfTimeGasUsageEnableEpoch:     0,
+		FloatingPointRejectionEnableEpoch:  DisabledEpoch,
+		CodeMetadataEnforcementEnableEpoch: DisabledEpoch,
+		MemoryAccountingEnableEpoch:        DisabledEpoch,
+		EventLogEnableEpoch:                DisabledEpoch,
+	}
+}
+
+// LoadEnableEpochsConfig loads the activation epochs from a TOML file with an [EnableEpochs] section;
+// the epochs missing from the file are those of MakeDefaultEnableEpochs, so that the flags
+// added after the file was written stay disabled
+func LoadEnableEpochsConfig(filePath string) (*EnableEpochsConfig, error) {
+	enableEpochsConfig := &EnableEpochsConfig{
+		EnableEpochs: MakeDefaultEnableEpochs(),
+	}
+	err := core.LoadTomlFile(enableEpochsConfig, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return enableEpochsConfig, nil
+}
+
+// SetEpoch sets the activation epoch with the given field name, such as "SCDeployEnableEpoch"
+func (enableEpochs *EnableEpochs) SetEpoch(name string, epoch uint32) error {
+	switch name {
+	case "SCDeployEnableEpoch":
+		enableEpochs.SCDeployEnableEpoch = epoch
+	case "BuiltInFunctionsEnableEpoch":
+		enableEpochs.BuiltInFunctionsEnableEpoch = epoch
+	case "RepairCallbackEnableEpoch":
+		enableEpochs.RepairCallbackEnableEpoch = epoch
+	case "AheadOfTimeGasUsageEnableEpoch":
+		enableEpochs.AheadOfTimeGasUsageEnableEpoch = epoch
+	case "FloatingPointRejectionEnableEpoch":
+		enableEpochs.FloatingPointRejectionEnableEpoch = epoch
+	case "CodeMetadataEnforcementEnableEpoch":
+		enableEpochs.CodeMetadataEnforcementEnableEpoch = epoch
+	case "MemoryAccountingEnableEpoch":
+		enableEpochs.MemoryAccountingEnableEpoch = epoch
+	case "EventLogEnableEpoch":
+		enableEpochs.EventLogEnableEpoch = epoch
+	default:
+		return fmt.Errorf("unknown enable epoch: %s", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEnableEpochsConfig(t *testing.T) {
+	enableEpochsConfig, err := LoadEnableEpochsConfig("./enableEpochs.toml")
+	require.Nil(t, err)
+	require.Equal(t, MakeDefaultEnableEpochs(), enableEpochsConfig.EnableEpochs)
+
+	_, err = LoadEnableEpochsConfig("./missing.toml")
+	require.NotNil(t, err)
+}
+
+func TestLoadEnableEpochsConfig_MissingEpochsAreTheDefaultOnes(t *testing.T) {
+	enableEpochsConfig, err := LoadEnableEpochsConfig("./testdata/enableEpochsLegacy.toml")
+	require.Nil(t, err)
+
+	expected := MakeDefaultEnableEpochs()
+	expected.SCDeployEnableEpoch = 1
+	expected.BuiltInFunctionsEnableEpoch = 2
+	expected.RepairCallbackEnableEpoch = 3
+	expected.AheadOfTimeGasUsageEnableEpoch = 4
+	require.Equal(t, expected, enableEpochsConfig.EnableEpochs)
+}
+
+func TestEnableEpochs_SetEpoch(t *testing.T) {
+	enableEpochs := MakeDefaultEnableEpochs()
+
+	err := enableEpochs.SetEpoch("RepairCallbackEnableEpoch", 5)
+	require.Nil(t, err)
+	require.Equal(t, uint32(5), enableEpochs.RepairCallbackEnableEpoch)
+
+	err = enableEpochs.SetEpoch("UnknownEnableEpoch", 5)
+	require.NotNil(t, err)
+}
@@ -9,6 +9,7 @@ type GasCost struct {
 	BaseOpsAPICost    BaseOpsAPICost
 	CryptoAPICost     CryptoAPICost
 	WASMOpcodeCost    WASMOpcodeCost
+	EventLogCost      EventLogCost
 }
 
 type BaseOperationCost struct {
@@ -19,6 +20,16 @@ type BaseOperationCost struct {
 	CompilePerByte    uint64
 	AoTPreparePerByte uint64
 	GetCode           uint64
+	MemoryGrowPerPage uint64
+}
+
+// EventLogCost holds the costs and the limits of the events emitted through emitEvent
+type EventLogCost struct {
+	EmitEvent     uint64
+	PerTopic      uint64
+	PerDataByte   uint64
+	MaxTopics     uint64
+	MaxDataLength uint64
 }
 
 type BaseOpsAPICost struct {
@@ -11,17 +11,20 @@ const GasValueForTests = 1
 
 var AsyncCallbackGasLockForTests = uint64(100_000)
 
+// MaxEventTopicsForTests is the number of topics an event may have in the test gas schedules
+const MaxEventTopicsForTests = 16
+
+// MaxEventDataLengthForTests is the size of the data an event may have in the test gas schedules
+const MaxEventDataLengthForTests = 10_000
+
 // GasScheduleMap (alias) is the map for gas schedule
 type GasScheduleMap = map[string]map[string]uint64
 
-func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
-	baseOps := &BaseOperationCost{}
-	err := mapstructure.Decode(gasMap["BaseOperationCost"], baseOps)
-	if err != nil {
-		return nil, err
-	}
+// DefaultMemoryGrowPerPage is the cost of a grown memory page for the gas schedules which predate it
+const DefaultMemoryGrowPerPage = 100000
 
-	err = checkForZeroUint64Fields(*baseOps)
+func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
+	baseOps, err := createBaseOperationCost(gasMap["BaseOperationCost"])
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +84,11 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		return nil, err
 	}
 
+	eventLogCosts, err := createEventLogCost(gasMap)
+	if err != nil {
+		return nil, err
+	}
+
 	gasCost := &GasCost{
 		BaseOperationCost: *baseOps,
 		BigIntAPICost:     *bigIntOps,
@@ -88,11 +96,67 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		BaseOpsAPICost:    *baseOpsAPI,
 		CryptoAPICost:     *cryptOps,
 		WASMOpcodeCost:    *opcodeCosts,
+		EventLogCost:      *eventLogCosts,
 	}
 
 	return gasCost, nil
 }
 
+// createBaseOperationCost decodes the base operation costs; MemoryGrowPerPage, which
+// the older gas schedules lack, gets its default value when missing
+func createBaseOperationCost(costs map[string]uint64) (*BaseOperationCost, error) {
+	baseOps := &BaseOperationCost{}
+	err := mapstructure.Decode(costs, baseOps)
+	if err != nil {
+		return nil, err
+	}
+
+	_, hasMemoryGrowCost := costs["MemoryGrowPerPage"]
+	if !hasMemoryGrowCost {
+		baseOps.MemoryGrowPerPage = DefaultMemoryGrowPerPage
+	}
+
+	err = checkForZeroUint64Fields(*baseOps)
+	if err != nil {
+		return nil, err
+	}
+
+	return baseOps, nil
+}
+
+// DefaultEventLogCost returns the event costs and limits for the gas schedules without an EventLogCost section
+func DefaultEventLogCost() EventLogCost {
+	return EventLogCost{
+		EmitEvent:     10000,
+		PerTopic:      5000,
+		PerDataByte:   10,
+		MaxTopics:     16,
+		MaxDataLength: 10000,
+	}
+}
+
+// createEventLogCost decodes the EventLogCost section, which the older gas schedules lack
+func createEventLogCost(gasMap GasScheduleMap) (*EventLogCost, error) {
+	costs, hasEventLogCost := gasMap["EventLogCost"]
+	if !hasEventLogCost {
+		defaultCosts := DefaultEventLogCost()
+		return &defaultCosts, nil
+	}
+
+	eventLogCosts := &EventLogCost{}
+	err := mapstructure.Decode(costs, eventLogCosts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*eventLogCosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventLogCosts, nil
+}
+
 func checkForZeroUint64Fields(arg interface{}) error {
 	v := reflect.ValueOf(arg)
 	for i := 0; i < v.NumField(); i++ {
@@ -123,6 +187,7 @@ func FillGasMap(gasMap GasScheduleMap, value, asyncCallbackGasLock uint64) GasSc
 	gasMap["BigIntAPICost"] = FillGasMap_BigIntAPICosts(value)
 	gasMap["CryptoAPICost"] = FillGasMap_CryptoAPICosts(value)
 	gasMap["WASMOpcodeCost"] = FillGasMap_WASMOpcodeValues(value)
+	gasMap["EventLogCost"] = FillGasMap_EventLogCosts(value)
 
 	return gasMap
 }
@@ -168,6 +233,7 @@ func FillGasMap_BaseOperationCosts(value uint64) map[string]uint64 {
 	gasMap["CompilePerByte"] = value
 	gasMap["AoTPreparePerByte"] = value
 	gasMap["GetCode"] = value
+	gasMap["MemoryGrowPerPage"] = value
 
 	return gasMap
 }
@@ -308,6 +374,17 @@ func FillGasMap_CryptoAPICosts(value uint64) map[string]uint64 {
 	return gasMap
 }
 
+func FillGasMap_EventLogCosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["EmitEvent"] = value
+	gasMap["PerTopic"] = value
+	gasMap["PerDataByte"] = value
+	gasMap["MaxTopics"] = MaxEventTopicsForTests
+	gasMap["MaxDataLength"] = MaxEventDataLengthForTests
+
+	return gasMap
+}
+
 func FillGasMap_WASMOpcodeValues(value uint64) map[string]uint64 {
 	gasMap := make(map[string]uint64)
 	gasMap["Unreachable"] = value
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type operations struct {
@@ -74,3 +76,66 @@ func TestDecode_ZeroGasCostError(t *testing.T) {
 	err = checkForZeroUint64Fields(*wasmCosts)
 	assert.Error(t, err)
 }
+
+func TestCreateGasConfig_EventLogCost(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	gasCost, err := CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(GasValueForTests), gasCost.EventLogCost.EmitEvent)
+	assert.Equal(t, uint64(MaxEventTopicsForTests), gasCost.EventLogCost.MaxTopics)
+	assert.Equal(t, uint64(MaxEventDataLengthForTests), gasCost.EventLogCost.MaxDataLength)
+
+	gasMap["EventLogCost"]["MaxTopics"] = 0
+	_, err = CreateGasConfig(gasMap)
+	assert.NotNil(t, err)
+
+	delete(gasMap, "EventLogCost")
+	gasCost, err = CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultEventLogCost(), gasCost.EventLogCost)
+}
+
+func TestCreateGasConfig_MemoryGrowPerPage(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	gasCost, err := CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(GasValueForTests), gasCost.BaseOperationCost.MemoryGrowPerPage)
+
+	delete(gasMap["BaseOperationCost"], "MemoryGrowPerPage")
+	gasCost, err = CreateGasConfig(gasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(DefaultMemoryGrowPerPage), gasCost.BaseOperationCost.MemoryGrowPerPage)
+
+	gasMap["BaseOperationCost"]["MemoryGrowPerPage"] = 0
+	_, err = CreateGasConfig(gasMap)
+	assert.NotNil(t, err)
+}
+
+func loadTestGasSchedule(t *testing.T, path string) GasScheduleMap {
+	tree, err := toml.LoadFile(path)
+	require.Nil(t, err)
+
+	gasMap := make(GasScheduleMap)
+	for section, costs := range tree.ToMap() {
+		gasMap[section] = make(map[string]uint64)
+		for name, cost := range costs.(map[string]interface{}) {
+			gasMap[section][name] = uint64(cost.(int64))
+		}
+	}
+
+	return gasMap
+}
+
+func TestCreateGasConfig_LegacySchedule(t *testing.T) {
+	gasMap := loadTestGasSchedule(t, "./testdata/gasScheduleV3Legacy.toml")
+	_, hasEventLogCost := gasMap["EventLogCost"]
+	require.False(t, hasEventLogCost)
+	_, hasMemoryGrowCost := gasMap["BaseOperationCost"]["MemoryGrowPerPage"]
+	require.False(t, hasMemoryGrowCost)
+
+	gasCost, err := CreateGasConfig(gasMap)
+	require.Nil(t, err)
+	require.Equal(t, DefaultEventLogCost(), gasCost.EventLogCost)
+	require.Equal(t, uint64(DefaultMemoryGrowPerPage), gasCost.BaseOperationCost.MemoryGrowPerPage)
+	require.Equal(t, uint64(1000000), gasCost.BaseOperationCost.GetCode)
+}
@@ -0,0 +1,47 @@
+package difftest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
+)
+
+// Configuration describes one of the two VM configurations compared by the harness
+type Configuration struct {
+	// Name identifies the configuration in the report
+	Name string
+
+	// EnabledFlags are the enable-epoch flags active in the VM
+	EnabledFlags []core.EnableEpochFlag
+
+	// GasSchedule, when set, replaces the gas schedules declared by the scenarios
+	GasSchedule config.GasScheduleMap
+}
+
+// ParseFlags parses a comma-separated list of enable-epoch flag names, checking that the VM uses them
+func ParseFlags(flagList string) ([]core.EnableEpochFlag, error) {
+	knownFlags := make(map[core.EnableEpochFlag]struct{})
+	for _, flag := range hostCore.AllFlags() {
+		knownFlags[flag] = struct{}{}
+	}
+
+	flags := make([]core.EnableEpochFlag, 0)
+	for _, name := range strings.Split(flagList, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+
+		flag := core.EnableEpochFlag(name)
+		_, isKnown := knownFlags[flag]
+		if !isKnown {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,9 @@
+package difftest
+
+import "errors"
+
+// ErrNilConfiguration signals that a nil VM configuration has been provided
+var ErrNilConfiguration = errors.New("nil VM configuration")
+
+// ErrUnknownFlag signals that an enable-epoch flag is not used by this VM
+var ErrUnknownFlag = errors.New("unknown enable-epoch flag")
@@ -0,0 +1,223 @@
+package difftest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	am "github.com/multiversx/mx-chain-vm-v1_2-go/scenarioexec"
+	mc "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/controller"
+	er "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/expression/reconstructor"
+	fr "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/vmoutput"
+)
+
+var log = logger.GetOrCreate("vm/difftest")
+
+// ScenarioSuffix is the suffix of the scenario files replayed from a directory
+const ScenarioSuffix = ".scen.json"
+
+// Harness replays scenario transactions against two VM configurations and compares their outputs.
+// The scenario state is built in the baseline world; before each transaction, the candidate world
+// is loaded with a clone of the baseline state, so that a divergence does not cascade into the
+// transactions that follow it.
+type Harness struct {
+	baseline          *am.VMTestExecutor
+	candidate         *am.VMTestExecutor
+	fileResolver      fr.FileResolver
+	exprReconstructor er.ExprReconstructor
+	currentScenario   string
+	builder           *reportBuilder
+}
+
+var _ mc.ScenarioExecutor = (*Harness)(nil)
+
+// NewHarness creates a new Harness, with one VM for each of the given configurations
+func NewHarness(scenarioexecPath string, baseline *Configuration, candidate *Configuration) (*Harness, error) {
+	if baseline == nil || candidate == nil {
+		return nil, ErrNilConfiguration
+	}
+
+	baselineExecutor, err := newExecutor(scenarioexecPath, baseline)
+	if err != nil {
+		return nil, err
+	}
+
+	candidateExecutor, err := newExecutor(scenarioexecPath, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Harness{
+		baseline:          baselineExecutor,
+		candidate:         candidateExecutor,
+		fileResolver:      nil,
+		exprReconstructor: er.ExprReconstructor{},
+		builder:           newReportBuilder(baseline.Name, candidate.Name),
+	}, nil
+}
+
+func newExecutor(scenarioexecPath string, configuration *Configuration) (*am.VMTestExecutor, error) {
+	return am.NewVMTestExecutorWithArgs(scenarioexecPath, am.VMTestExecutorArgs{
+		EnableEpochsHandler: am.NewEnableEpochsHandlerForFlags(configuration.EnabledFlags),
+		GasSchedule:         configuration.GasSchedule,
+	})
+}
+
+// RunScenarios replays the given scenario files, as well as the scenario files found in the given directories
+func (h *Harness) RunScenarios(paths []string) error {
+	for _, path := range paths {
+		err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(filePath, ScenarioSuffix) {
+				return nil
+			}
+
+			return h.RunScenarioFile(filePath)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunScenarioFile replays a single scenario file, on a fresh state
+func (h *Harness) RunScenarioFile(path string) error {
+	log.Debug("replaying scenario", "path", path)
+
+	h.Reset()
+	h.currentScenario = path
+	h.builder.addScenario()
+
+	runner := mc.NewScenarioRunner(h, mc.NewDefaultFileResolver())
+	return runner.RunSingleJSONScenario(path)
+}
+
+// Report returns the divergences found so far, grouped by contract and function
+func (h *Harness) Report() *Report {
+	return h.builder.build()
+}
+
+// Reset clears the state of both worlds.
+func (h *Harness) Reset() {
+	h.baseline.Reset()
+	h.candidate.Reset()
+}
+
+// ExecuteScenario replays the steps of a scenario on both configurations.
+func (h *Harness) ExecuteScenario(scenario *mj.Scenario, fileResolver fr.FileResolver) error {
+	h.fileResolver = fileResolver
+
+	err := h.baseline.SetScenariosGasSchedule(scenario.GasSchedule)
+	if err != nil {
+		return err
+	}
+	err = h.candidate.SetScenariosGasSchedule(scenario.GasSchedule)
+	if err != nil {
+		return err
+	}
+
+	for _, generalStep := range scenario.Steps {
+		err = h.executeStep(generalStep)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// executeStep only replays the steps that change the state; the checks are skipped,
+// since the harness compares the two configurations rather than the expected results
+func (h *Harness) executeStep(generalStep mj.Step) error {
+	switch step := generalStep.(type) {
+	case *mj.ExternalStepsStep:
+		return h.executeExternalStep(step)
+	case *mj.SetStateStep:
+		return h.baseline.ExecuteSetStateStep(step)
+	case *mj.TxStep:
+		h.compareTxStep(step)
+	}
+
+	return nil
+}
+
+func (h *Harness) executeExternalStep(step *mj.ExternalStepsStep) error {
+	fileResolverBackup := h.fileResolver
+	externalStepsRunner := mc.NewScenarioRunner(h, h.fileResolver.Clone())
+
+	err := externalStepsRunner.RunSingleJSONScenario(h.fileResolver.ResolveAbsolutePath(step.Path))
+	if err != nil {
+		return err
+	}
+
+	h.fileResolver = fileResolverBackup
+
+	return nil
+}
+
+func (h *Harness) compareTxStep(step *mj.TxStep) {
+	h.builder.addTx()
+	h.candidate.World.LoadStateFrom(h.baseline.World)
+	h.baseline.World.LastCreatedContractAddress = nil
+
+	contract, function := h.txTarget(step.Tx)
+
+	baselineOutput, baselineErr := h.baseline.RunTxStep(step)
+	candidateOutput, candidateErr := h.candidate.RunTxStep(step)
+
+	if step.Tx.Type == mj.ScDeploy && len(h.baseline.World.LastCreatedContractAddress) > 0 {
+		contract = h.exprReconstructor.Reconstruct(h.baseline.World.LastCreatedContractAddress, er.AddressHint)
+	}
+
+	divergence := &Divergence{
+		Scenario:       h.currentScenario,
+		TxID:           step.TxIdent,
+		BaselineError:  errorMessage(baselineErr),
+		CandidateError: errorMessage(candidateErr),
+		Fields:         compareOutputsIfPresent(baselineOutput, candidateOutput),
+	}
+	if divergence.BaselineError == divergence.CandidateError && len(divergence.Fields) == 0 {
+		return
+	}
+
+	log.Debug("divergence found", "scenario", h.currentScenario, "tx", step.TxIdent, "fields", len(divergence.Fields))
+	h.builder.addDivergence(contract, function, divergence)
+}
+
+// txTarget yields the contract and the function a transaction is grouped by in the report;
+// deployments are grouped by their sender until the address of the new contract is known
+func (h *Harness) txTarget(tx *mj.Transaction) (string, string) {
+	switch tx.Type {
+	case mj.ScDeploy:
+		return h.exprReconstructor.Reconstruct(tx.From.Value, er.AddressHint), "(deploy)"
+	case mj.Transfer:
+		return h.exprReconstructor.Reconstruct(tx.To.Value, er.AddressHint), "(transfer)"
+	case mj.ValidatorReward:
+		return h.exprReconstructor.Reconstruct(tx.To.Value, er.AddressHint), "(validatorReward)"
+	default:
+		return h.exprReconstructor.Reconstruct(tx.To.Value, er.AddressHint), tx.Function
+	}
+}
+
+func compareOutputsIfPresent(baseline *vmcommon.VMOutput, candidate *vmcommon.VMOutput) []*vmoutput.FieldDiff {
+	if baseline == nil && candidate == nil {
+		return nil
+	}
+
+	return vmoutput.Diff(baseline, candidate)
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,155 @@
+package difftest
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/vmoutput"
+)
+
+// Divergence describes a transaction whose VMOutput differs between the two configurations;
+// in the field differences, the first value is the baseline one and the second is the candidate one
+type Divergence struct {
+	Scenario       string                `json:"scenario"`
+	TxID           string                `json:"txId"`
+	BaselineError  string                `json:"baselineError,omitempty"`
+	CandidateError string                `json:"candidateError,omitempty"`
+	Fields         []*vmoutput.FieldDiff `json:"fields,omitempty"`
+}
+
+// FunctionDivergences groups the divergences of the calls to a contract function
+type FunctionDivergences struct {
+	Function    string        `json:"function"`
+	Divergences []*Divergence `json:"divergences"`
+}
+
+// ContractDivergences groups the divergences of the transactions targeting a contract
+type ContractDivergences struct {
+	Contract  string                 `json:"contract"`
+	Functions []*FunctionDivergences `json:"functions"`
+}
+
+// Report is the result of replaying a corpus of scenarios against two VM configurations
+type Report struct {
+	Baseline       string                 `json:"baseline"`
+	Candidate      string                 `json:"candidate"`
+	NumScenarios   int                    `json:"numScenarios"`
+	NumTxs         int                    `json:"numTxs"`
+	NumDivergences int                    `json:"numDivergences"`
+	Contracts      []*ContractDivergences `json:"contracts"`
+}
+
+// HasDivergences returns true if at least one transaction behaved differently
+func (report *Report) HasDivergences() bool {
+	return report.NumDivergences > 0
+}
+
+// String renders the report in a human-readable form
+func (report *Report) String() string {
+	buffer := &bytes.Buffer{}
+
+	_, _ = fmt.Fprintf(buffer, "baseline:  %s\ncandidate: %s\n", report.Baseline, report.Candidate)
+	_, _ = fmt.Fprintf(buffer, "scenarios: %d, transactions: %d, divergences: %d\n",
+		report.NumScenarios, report.NumTxs, report.NumDivergences)
+
+	for _, contract := range report.Contracts {
+		_, _ = fmt.Fprintf(buffer, "\ncontract %s\n", contract.Contract)
+		for _, function := range contract.Functions {
+			_, _ = fmt.Fprintf(buffer, "  function %s (%d)\n", function.Function, len(function.Divergences))
+			for _, divergence := range function.Divergences {
+				_, _ = fmt.Fprintf(buffer, "    %s, tx %s\n", divergence.Scenario, divergence.TxID)
+				if len(divergence.BaselineError) > 0 || len(divergence.CandidateError) > 0 {
+					_, _ = fmt.Fprintf(buffer, "      error: %q != %q\n", divergence.BaselineError, divergence.CandidateError)
+				}
+				for _, field := range divergence.Fields {
+					_, _ = fmt.Fprintf(buffer, "      %s: %s != %s\n", field.Field, field.First, field.Second)
+				}
+			}
+		}
+	}
+
+	if report.HasDivergences() {
+		buffer.WriteString("\nresult: DIVERGENT\n")
+	} else {
+		buffer.WriteString("\nresult: IDENTICAL\n")
+	}
+
+	return buffer.String()
+}
+
+type reportBuilder struct {
+	report      *Report
+	divergences map[string]map[string][]*Divergence
+}
+
+func newReportBuilder(baseline string, candidate string) *reportBuilder {
+	return &reportBuilder{
+		report: &Report{
+			Baseline:  baseline,
+			Candidate: candidate,
+			Contracts: make([]*ContractDivergences, 0),
+		},
+		divergences: make(map[string]map[string][]*Divergence),
+	}
+}
+
+func (builder *reportBuilder) addScenario() {
+	builder.report.NumScenarios++
+}
+
+func (builder *reportBuilder) addTx() {
+	builder.report.NumTxs++
+}
+
+func (builder *reportBuilder) addDivergence(contract string, function string, divergence *Divergence) {
+	functions, exists := builder.divergences[contract]
+	if !exists {
+		functions = make(map[string][]*Divergence)
+		builder.divergences[contract] = functions
+	}
+
+	functions[function] = append(functions[function], divergence)
+	builder.report.NumDivergences++
+}
+
+// build sorts the divergences by contract and function, keeping the transactions in execution order
+func (builder *reportBuilder) build() *Report {
+	report := *builder.report
+	report.Contracts = make([]*ContractDivergences, 0, len(builder.divergences))
+
+	for _, contract := range sortedMapKeys(builder.divergences) {
+		functions := builder.divergences[contract]
+		contractDivergences := &ContractDivergences{
+			Contract:  contract,
+			Functions: make([]*FunctionDivergences, 0, len(functions)),
+		}
+
+		functionNames := make([]string, 0, len(functions))
+		for function := range functions {
+			functionNames = append(functionNames, function)
+		}
+		sort.Strings(functionNames)
+
+		for _, function := range functionNames {
+			contractDivergences.Functions = append(contractDivergences.Functions, &FunctionDivergences{
+				Function:    function,
+				Divergences: functions[function],
+			})
+		}
+
+		report.Contracts = append(report.Contracts, contractDivergences)
+	}
+
+	return &report
+}
+
+func sortedMapKeys(divergences map[string]map[string][]*Divergence) []string {
+	keys := make([]string, 0, len(divergences))
+	for key := range divergences {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
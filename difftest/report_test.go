@@ -0,0 +1,54 @@
+package difftest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/vmoutput"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportBuilder_GroupsByContractAndFunction(t *testing.T) {
+	builder := newReportBuilder("base", "cand")
+	builder.addScenario()
+	builder.addTx()
+	builder.addTx()
+	builder.addTx()
+
+	builder.addDivergence("sc:b", "transfer", &Divergence{TxID: "1"})
+	builder.addDivergence("sc:a", "stake", &Divergence{TxID: "2"})
+	builder.addDivergence("sc:b", "approve", &Divergence{TxID: "3"})
+	builder.addDivergence("sc:b", "transfer", &Divergence{TxID: "4"})
+
+	report := builder.build()
+	require.True(t, report.HasDivergences())
+	require.Equal(t, 1, report.NumScenarios)
+	require.Equal(t, 3, report.NumTxs)
+	require.Equal(t, 4, report.NumDivergences)
+
+	require.Len(t, report.Contracts, 2)
+	require.Equal(t, "sc:a", report.Contracts[0].Contract)
+	require.Equal(t, "sc:b", report.Contracts[1].Contract)
+
+	functions := report.Contracts[1].Functions
+	require.Len(t, functions, 2)
+	require.Equal(t, "approve", functions[0].Function)
+	require.Equal(t, "transfer", functions[1].Function)
+	require.Equal(t, "1", functions[1].Divergences[0].TxID)
+	require.Equal(t, "4", functions[1].Divergences[1].TxID)
+}
+
+func TestReport_String(t *testing.T) {
+	builder := newReportBuilder("base", "cand")
+	require.Contains(t, builder.build().String(), "result: IDENTICAL")
+
+	builder.addDivergence("sc:a", "stake", &Divergence{
+		Scenario: "a.scen.json",
+		TxID:     "2",
+		Fields:   []*vmoutput.FieldDiff{{Field: "gasRemaining", First: "10", Second: "20"}},
+	})
+	text := builder.build().String()
+	require.Contains(t, text, "contract sc:a")
+	require.Contains(t, text, "function stake (1)")
+	require.Contains(t, text, "gasRemaining: 10 != 20")
+	require.Contains(t, text, "result: DIVERGENT")
+}
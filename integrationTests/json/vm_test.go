@@ -9,6 +9,7 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 	am "github.com/multiversx/mx-chain-vm-v1_2-go/scenarioexec"
 	mc "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/controller"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/coverage"
 	"github.com/stretchr/testify/require"
 )
 
@@ -175,6 +176,52 @@ func TestPingPongEgld(t *testing.T) {
 	runAllTestsInFolder(t, "ping-pong-egld")
 }
 
+// Runs a folder on several VMs at once, whose scenarios may declare different gas schedules.
+func TestParallelRunner(t *testing.T) {
+	if testing.Short() {
+		t.Skip("not a short test")
+	}
+
+	runner, err := mc.NewParallelScenarioRunner(
+		func() (mc.ScenarioExecutor, error) {
+			return am.NewVMTestExecutor("../../scenarioexec")
+		},
+		mc.NewDefaultFileResolver(),
+		mc.ParallelRunOptions{
+			NumWorkers: 4,
+		})
+	require.Nil(t, err)
+
+	report, err := runner.RunAllScenariosInDirectory(getTestRoot(), "features/basic-features/scenarios")
+	require.Nil(t, err)
+	require.NotEqual(t, 0, report.NumPassed)
+	require.Nil(t, report.Err())
+}
+
+// Collects the coverage of a contract while running its scenarios.
+func TestCoverage(t *testing.T) {
+	collector := coverage.NewCollector()
+	executor, err := am.NewVMTestExecutorWithArgs("../../scenarioexec", am.VMTestExecutorArgs{
+		CoverageCollector: collector,
+	})
+	require.Nil(t, err)
+	runner := mc.NewScenarioRunner(
+		executor,
+		mc.NewDefaultFileResolver(),
+	)
+
+	err = runner.RunAllScenariosInDirectory(getTestRoot(), "adder/scenarios", []string{})
+	require.Nil(t, err)
+
+	report := collector.Report()
+	require.Len(t, report.Contracts, 1)
+	adder := report.Contracts[0]
+	require.Equal(t, "adder.wasm", adder.Name)
+	require.Equal(t, 3, adder.Exports.Covered) // all but callBack
+	require.NotEqual(t, 0, adder.Functions.Covered)
+	require.NotEqual(t, 0, adder.Hooks.Covered)
+}
+
 func runAllTestsInFolder(t *testing.T, folder string) {
 	runTestsInFolder(t, folder, []string{})
 }
@@ -187,10 +234,9 @@ func runTestsInFolder(t *testing.T, folder string, exclusions []string) {
 		mc.NewDefaultFileResolver(),
 	)
 
-	err = runner.RunAllJSONScenariosInDirectory(
+	err = runner.RunAllScenariosInDirectory(
 		getTestRoot(),
 		folder,
-		".scen.json",
 		exclusions)
 
 	if err != nil {
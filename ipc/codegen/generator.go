@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const blockchainHookPackage = "github.com/multiversx/mx-chain-vm-common-go"
+const blockchainHookInterface = "BlockchainHook"
+
+// importNames are the names under which the generated code imports the packages whose name differs from the last element of their path
+var importNames = map[string]string{
+	blockchainHookPackage: "vmcommon",
+}
+
+// hookValue is a parameter or a result of a hook
+type hookValue struct {
+	// name is the name of the parameter, within the signature of the hook
+	name string
+	// field is the field of the message which carries the value, empty if the value is not carried
+	field string
+	typ   types.Type
+}
+
+type hook struct {
+	name     string
+	message  string
+	params   []*hookValue
+	results  []*hookValue
+	hasError bool
+}
+
+func (h *hook) requestKind() string {
+	return "Blockchain" + h.message + "Request"
+}
+
+func (h *hook) responseKind() string {
+	return "Blockchain" + h.message + "Response"
+}
+
+func (h *hook) hasCarriedParams() bool {
+	for _, param := range h.params {
+		if param.field != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generate returns the content of the generated files, by their paths
+func generate(root string) (map[string][]byte, error) {
+	hooks, err := loadHooks()
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkMessageKinds(filepath.Join(root, "common", "messages.go"), hooks)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, output := range outputs {
+		content, err := output.generate(hooks)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", output.path, err)
+		}
+
+		files[filepath.Join(root, filepath.FromSlash(output.path))] = content
+	}
+
+	return files, nil
+}
+
+// loadHooks type-checks the package of the hook (from source), and returns the hooks forwarded to the Node, in the order of their declaration
+func loadHooks() ([]*hook, error) {
+	fileSet := token.NewFileSet()
+	pkg, err := importer.ForCompiler(fileSet, "source", nil).Import(blockchainHookPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	object := pkg.Scope().Lookup(blockchainHookInterface)
+	if object == nil {
+		return nil, fmt.Errorf("%s not found in %s", blockchainHookInterface, blockchainHookPackage)
+	}
+
+	iface, ok := object.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", blockchainHookInterface)
+	}
+
+	methods := make([]*types.Func, 0, iface.NumMethods())
+	for i := 0; i < iface.NumMethods(); i++ {
+		methods = append(methods, iface.Method(i))
+	}
+
+	sort.SliceStable(methods, func(i, j int) bool {
+		return methods[i].Pos() < methods[j].Pos()
+	})
+
+	hooks := make([]*hook, 0, len(methods))
+	for _, method := range methods {
+		if localHooks[method.Name()] {
+			continue
+		}
+
+		h, err := newHook(method, hookConfigs[method.Name()])
+		if err != nil {
+			return nil, err
+		}
+
+		hooks = append(hooks, h)
+	}
+
+	return hooks, nil
+}
+
+func newHook(method *types.Func, config hookConfig) (*hook, error) {
+	signature := method.Type().(*types.Signature)
+	if signature.Variadic() {
+		return nil, fmt.Errorf("%s: variadic hooks are not supported", method.Name())
+	}
+
+	h := &hook{
+		name:    method.Name(),
+		message: config.message,
+	}
+	if h.message == "" {
+		h.message = h.name
+	}
+
+	params := signature.Params()
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		field, ok := config.params[param.Name()]
+		if !ok {
+			if param.Name() == "" {
+				return nil, fmt.Errorf("%s: parameter %d has no name, set its field in hookConfigs", h.name, i)
+			}
+
+			field = upperFirst(param.Name())
+		}
+		if field == dropped {
+			field = ""
+		}
+
+		name := param.Name()
+		if name == "" && field != "" {
+			name = variableName(field)
+		}
+
+		h.params = append(h.params, &hookValue{name: name, field: field, typ: param.Type()})
+	}
+
+	results := signature.Results()
+	numResults := results.Len()
+	if numResults > 0 && isError(results.At(numResults-1).Type()) {
+		h.hasError = true
+		numResults--
+	}
+
+	if len(config.results) == 0 && numResults == 1 {
+		config.results = []string{"Result"}
+	}
+	if len(config.results) != numResults {
+		return nil, fmt.Errorf("%s: set the fields of the %d results in hookConfigs", h.name, numResults)
+	}
+
+	for i := 0; i < numResults; i++ {
+		result := results.At(i)
+		if isError(result.Type()) {
+			return nil, fmt.Errorf("%s: only the last result can be an error", h.name)
+		}
+
+		field := config.results[i]
+		if field == dropped {
+			field = ""
+		}
+
+		h.results = append(h.results, &hookValue{field: field, typ: result.Type()})
+	}
+
+	return h, nil
+}
+
+func isError(typ types.Type) bool {
+	return types.Identical(typ, types.Universe.Lookup("error").Type())
+}
+
+// checkMessageKinds makes sure the kinds of the messages are declared; since their values are frozen, they are not generated
+func checkMessageKinds(messagesFile string, hooks []*hook) error {
+	declared, err := loadDeclaredConstants(messagesFile)
+	if err != nil {
+		return err
+	}
+
+	missing := make([]string, 0)
+	for _, h := range hooks {
+		for _, kind := range []string{h.requestKind(), h.responseKind()} {
+			if !declared[kind] {
+				missing = append(missing, kind)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("message kinds %s are not declared; append them to %s, with new values", strings.Join(missing, ", "), messagesFile)
+	}
+
+	return nil
+}
+
+func loadDeclaredConstants(goFile string) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), goFile, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]bool)
+	for _, declaration := range file.Decls {
+		genDecl, ok := declaration.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				declared[name.Name] = true
+			}
+		}
+	}
+
+	return declared, nil
+}
+
+// goFile accumulates the body of a generated file, and the imports it needs
+type goFile struct {
+	pkg     string
+	imports map[string]string
+	body    bytes.Buffer
+}
+
+func newGoFile(pkg string) *goFile {
+	return &goFile{
+		pkg:     pkg,
+		imports: make(map[string]string),
+	}
+}
+
+func (file *goFile) addImport(importPath string, name string) {
+	file.imports[importPath] = name
+}
+
+func (file *goFile) printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(&file.body, format, args...)
+}
+
+func (file *goFile) typeString(typ types.Type) string {
+	return types.TypeString(typ, func(pkg *types.Package) string {
+		name, ok := importNames[pkg.Path()]
+		if !ok {
+			name = pkg.Name()
+		}
+
+		file.addImport(pkg.Path(), name)
+		return name
+	})
+}
+
+// messageType is the type of the field which carries the value
+func (file *goFile) messageType(value *hookValue) string {
+	converter, ok := conversions[types.TypeString(value.typ, nil)]
+	if ok {
+		return converter.messageType
+	}
+
+	return file.typeString(value.typ)
+}
+
+func toMessage(value *hookValue, expression string) string {
+	converter, ok := conversions[types.TypeString(value.typ, nil)]
+	if ok {
+		return fmt.Sprintf(converter.toMessage, expression)
+	}
+
+	return expression
+}
+
+func fromMessage(value *hookValue, expression string) string {
+	converter, ok := conversions[types.TypeString(value.typ, nil)]
+	if ok {
+		return fmt.Sprintf(converter.fromMessage, expression)
+	}
+
+	return expression
+}
+
+func (file *goFile) zeroValue(typ types.Type) string {
+	switch underlying := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case underlying.Info()&types.IsBoolean != 0:
+			return "false"
+		case underlying.Info()&types.IsString != 0:
+			return `""`
+		case underlying.Info()&types.IsNumeric != 0:
+			return "0"
+		}
+	case *types.Struct, *types.Array:
+		return file.typeString(typ) + "{}"
+	}
+
+	return "nil"
+}
+
+func (file *goFile) bytes() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by ipc/codegen from vmcommon.BlockchainHook; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buffer, "package %s\n\n", file.pkg)
+
+	importPaths := make([]string, 0, len(file.imports))
+	for importPath := range file.imports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+
+	if len(importPaths) > 0 {
+		buffer.WriteString("import (\n")
+		for _, standard := range []bool{true, false} {
+			for _, importPath := range importPaths {
+				if isStandardPackage(importPath) != standard {
+					continue
+				}
+
+				name := file.imports[importPath]
+				if name == path.Base(importPath) {
+					fmt.Fprintf(&buffer, "\t%q\n", importPath)
+				} else {
+					fmt.Fprintf(&buffer, "\t%s %q\n", name, importPath)
+				}
+			}
+
+			// the standard packages are grouped apart
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString(")\n\n")
+	}
+
+	buffer.Write(file.body.Bytes())
+	return format.Source(buffer.Bytes())
+}
+
+func isStandardPackage(importPath string) bool {
+	firstElement := strings.Split(importPath, "/")[0]
+	return !strings.Contains(firstElement, ".")
+}
+
+func upperFirst(name string) string {
+	if name == "" {
+		return name
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// variableName derives the name of a variable from the name of a field, e.g. "ESDTData" gives "esdtData",
+// and "SerializableVMOutput" gives "vmOutput"
+func variableName(field string) string {
+	field = strings.TrimPrefix(field, "Serializable")
+
+	runes := []rune(field)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+
+	name := strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+	if token.IsKeyword(name) {
+		name += "Value"
+	}
+
+	return name
+}
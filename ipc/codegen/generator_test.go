@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_OutputIsUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type-checks vmcommon from source")
+	}
+
+	files, err := generate("..")
+	require.Nil(t, err)
+	require.Len(t, files, len(outputs))
+
+	for path, expected := range files {
+		actual, err := os.ReadFile(path)
+		require.Nil(t, err)
+		require.True(t, string(expected) == string(actual), "%s is stale, run \"go generate ./ipc/...\"", path)
+	}
+}
+
+func TestVariableName(t *testing.T) {
+	require.Equal(t, "result", variableName("Result"))
+	require.Equal(t, "address", variableName("Address"))
+	require.Equal(t, "vmType", variableName("VMType"))
+	require.Equal(t, "esdtData", variableName("ESDTData"))
+	require.Equal(t, "tokenID", variableName("TokenID"))
+	require.Equal(t, "vmOutput", variableName("SerializableVMOutput"))
+	require.Equal(t, "allState", variableName("SerializableAllState"))
+	require.Equal(t, "typeValue", variableName("Type"))
+}
+
+func TestCheckMessageKinds(t *testing.T) {
+	messagesFile := t.TempDir() + "/messages.go"
+	err := os.WriteFile(messagesFile, []byte("package common\n\nconst (\n\tBlockchainFooRequest MessageKind = 1\n)\n"), 0644)
+	require.Nil(t, err)
+
+	hooks := []*hook{{name: "Foo", message: "Foo"}}
+	err = checkMessageKinds(messagesFile, hooks)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "BlockchainFooResponse")
+	require.NotContains(t, err.Error(), "BlockchainFooRequest,")
+}
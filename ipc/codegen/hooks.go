@@ -0,0 +1,98 @@
+package main
+
+// dropped marks a parameter or a result of a hook which is not carried by the messages
+const dropped = "-"
+
+// hookConfig describes how the parameters and the results of a hook are carried by its messages.
+// By default, a parameter is carried by a field named after it, and a single result by the field "Result".
+type hookConfig struct {
+	// message is the name of the messages, if it differs from the name of the hook
+	message string
+	// params are the fields of the request, by the names of the parameters ("" for an unnamed parameter)
+	params map[string]string
+	// results are the fields of the response, in the order of the results (the error excluded)
+	results []string
+}
+
+// hookConfigs hold the exceptions to the defaults; changing them changes the messages, thus the protocol between the Node and VM
+var hookConfigs = map[string]hookConfig{
+	"NewAddress": {
+		params: map[string]string{"vmType": "VMType"},
+	},
+	"GetStorageData": {
+		params:  map[string]string{"accountAddress": "Address"},
+		results: []string{"Data", dropped},
+	},
+	"ProcessBuiltInFunction": {
+		message: "ProcessBuiltinFunction",
+		params:  map[string]string{"input": "CallInput"},
+		results: []string{"SerializableVMOutput"},
+	},
+	"GetESDTToken": {
+		results: []string{"ESDTData"},
+	},
+	"GetBuiltinFunctionNames": {
+		results: []string{"FunctionNames"},
+	},
+	"GetAllState": {
+		results: []string{"SerializableAllState"},
+	},
+	"GetUserAccount": {
+		results: []string{"Account"},
+	},
+	"GetCode": {
+		params:  map[string]string{"": "Account"},
+		results: []string{"Code"},
+	},
+	"GetShardOfAddress": {
+		results: []string{"Shard"},
+	},
+	"IsPayable": {
+		params: map[string]string{"sndAddress": dropped, "recvAddress": "Address"},
+	},
+	"GetCompiledCode": {
+		results: []string{"Found", "Code"},
+	},
+}
+
+// localHooks are answered by VM itself, thus they have no messages; the gateway implements them by hand
+var localHooks = map[string]bool{
+	"ClearCompiledCodes":                true,
+	"GetSnapshot":                       true,
+	"RevertToSnapshot":                  true,
+	"IsPaused":                          true,
+	"IsLimitedTransfer":                 true,
+	"ExecuteSmartContractCallOnOtherVM": true,
+	"IsInterfaceNil":                    true,
+}
+
+// conversion tells how a value of the hook is carried by a message, when it cannot be carried as it is
+type conversion struct {
+	messageType string
+	toMessage   string
+	fromMessage string
+}
+
+// conversions are keyed by the types of the hook, as printed by types.TypeString() without qualifier
+var conversions = map[string]conversion{
+	"github.com/multiversx/mx-chain-vm-common-go.UserAccountHandler": {
+		messageType: "*Account",
+		toMessage:   "NewSerializableAccount(%s)",
+		fromMessage: "%s",
+	},
+	"*github.com/multiversx/mx-chain-vm-common-go.ContractCallInput": {
+		messageType: "vmcommon.ContractCallInput",
+		toMessage:   "*%s",
+		fromMessage: "&%s",
+	},
+	"*github.com/multiversx/mx-chain-vm-common-go.VMOutput": {
+		messageType: "*SerializableVMOutput",
+		toMessage:   "NewSerializableVMOutput(%s)",
+		fromMessage: "%s.ConvertToVMOutput()",
+	},
+	"map[string][]byte": {
+		messageType: "*SerializableMapStringBytes",
+		toMessage:   "NewSerializableMapStringBytes(%s)",
+		fromMessage: "%s.ConvertToMap()",
+	},
+}
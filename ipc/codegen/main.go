@@ -0,0 +1,40 @@
+// Command codegen generates, out of the vmcommon.BlockchainHook interface, the code which forwards the hook calls from VM to the Node:
+// the messages of the hook calls, the repliers of the Node, and the methods of the gateway of VM.
+//
+// When a hook is added to the interface, append the kinds of its messages to ipc/common/messages.go,
+// set how its values are carried in hookConfigs (if the defaults do not fit), then run "go generate ./ipc/...".
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+func main() {
+	root := flag.String("root", "..", "the ipc directory, which holds the common, nodepart and vmpart packages")
+	flag.Parse()
+
+	files, err := generate(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "codegen:", err)
+		os.Exit(1)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		err = os.WriteFile(path, files[path], 0644)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "codegen:", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("codegen: generated", path)
+	}
+}
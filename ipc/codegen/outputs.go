@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const commonPackage = "github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+
+var outputs = []struct {
+	path     string
+	generate func(hooks []*hook) ([]byte, error)
+}{
+	{path: "common/messagesBlockchain.go", generate: generateMessages},
+	{path: "nodepart/blockchainRepliers.go", generate: generateRepliers},
+	{path: "vmpart/blockchainGatewayHooks.go", generate: generateGateway},
+}
+
+// generateMessages generates the request and response messages of the hooks, and registers their kinds
+func generateMessages(hooks []*hook) ([]byte, error) {
+	file := newGoFile("common")
+
+	for _, h := range hooks {
+		file.printMessage(h.requestKind(), "request", h.params, false)
+		file.printMessage(h.responseKind(), "response", h.results, h.hasError)
+	}
+
+	file.printf("func init() {\n")
+	for _, h := range hooks {
+		for _, kind := range []string{h.requestKind(), h.responseKind()} {
+			file.printf("messageKindNameByID[%s] = %q\n", kind, kind)
+		}
+	}
+	file.printf("\n")
+	for _, h := range hooks {
+		for _, kind := range []string{h.requestKind(), h.responseKind()} {
+			file.printf("messageCreators[%s] = createMessage%s\n", kind, kind)
+		}
+	}
+	file.printf("}\n\n")
+
+	for _, h := range hooks {
+		for _, kind := range []string{h.requestKind(), h.responseKind()} {
+			file.printf("func createMessage%s() MessageHandler {\n", kind)
+			file.printf("return &Message%s{}\n", kind)
+			file.printf("}\n\n")
+		}
+	}
+
+	file.printf("// isBlockchainHookKind returns whether the kind is the request or the response of a single hook call\n")
+	file.printf("func isBlockchainHookKind(kind MessageKind) bool {\n")
+	file.printf("switch kind {\n")
+	file.printf("case ")
+	for i, h := range hooks {
+		separator := ",\n"
+		if i == len(hooks)-1 {
+			separator = ":\n"
+		}
+		file.printf("%s, %s%s", h.requestKind(), h.responseKind(), separator)
+	}
+	file.printf("return true\n")
+	file.printf("}\n\n")
+	file.printf("return false\n")
+	file.printf("}\n")
+
+	return file.bytes()
+}
+
+func (file *goFile) printMessage(kind string, role string, values []*hookValue, hasError bool) {
+	file.printf("// Message%s represents a %s message\n", kind, role)
+	file.printf("type Message%s struct {\n", kind)
+	file.printf("Message\n")
+	for _, value := range values {
+		if value.field != "" {
+			file.printf("%s %s\n", value.field, file.messageType(value))
+		}
+	}
+	file.printf("}\n\n")
+
+	params := make([]string, 0, len(values)+1)
+	for _, value := range values {
+		if value.field != "" {
+			params = append(params, fmt.Sprintf("%s %s", variableName(value.field), file.typeString(value.typ)))
+		}
+	}
+	if hasError {
+		params = append(params, "err error")
+	}
+
+	file.printf("// NewMessage%s creates a %s message\n", kind, role)
+	file.printf("func NewMessage%s(%s) *Message%s {\n", kind, strings.Join(params, ", "), kind)
+	file.printf("message := &Message%s{}\n", kind)
+	file.printf("message.Kind = %s\n", kind)
+	for _, value := range values {
+		if value.field != "" {
+			file.printf("message.%s = %s\n", value.field, toMessage(value, variableName(value.field)))
+		}
+	}
+	if hasError {
+		file.printf("message.SetError(err)\n")
+	}
+	file.printf("return message\n")
+	file.printf("}\n\n")
+}
+
+// generateRepliers generates the repliers of the Node, which make the hook calls requested by VM
+func generateRepliers(hooks []*hook) ([]byte, error) {
+	file := newGoFile("nodepart")
+	file.addImport(commonPackage, "common")
+
+	file.printf("// addBlockchainRepliers sets the repliers of the hook calls\n")
+	file.printf("func (part *NodePart) addBlockchainRepliers() {\n")
+	for _, h := range hooks {
+		file.printf("part.Repliers[common.%s] = part.replyTo%s\n", h.requestKind(), "Blockchain"+h.message)
+	}
+	file.printf("}\n")
+
+	for _, h := range hooks {
+		err := file.printReplier(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return file.bytes()
+}
+
+func (file *goFile) printReplier(h *hook) error {
+	requestName := "request"
+	if !h.hasCarriedParams() {
+		requestName = "_"
+	}
+
+	file.printf("\nfunc (part *NodePart) replyToBlockchain%s(%s common.MessageHandler) common.MessageHandler {\n", h.message, requestName)
+	if h.hasCarriedParams() {
+		file.printf("typedRequest := request.(*common.Message%s)\n", h.requestKind())
+	}
+
+	args := make([]string, 0, len(h.params))
+	for _, param := range h.params {
+		if param.field == "" {
+			args = append(args, file.zeroValue(param.typ))
+			continue
+		}
+
+		args = append(args, fromMessage(param, "typedRequest."+param.field))
+	}
+
+	variables := make([]string, 0, len(h.results)+1)
+	responseArgs := make([]string, 0, len(h.results)+1)
+	for _, result := range h.results {
+		if result.field == "" {
+			variables = append(variables, "_")
+			continue
+		}
+
+		name := variableName(result.field)
+		if name == "part" || name == "request" || name == "typedRequest" {
+			return fmt.Errorf("%s: the result %s hides a variable of the replier", h.name, result.field)
+		}
+
+		variables = append(variables, name)
+		responseArgs = append(responseArgs, name)
+	}
+	if h.hasError {
+		variables = append(variables, "err")
+		responseArgs = append(responseArgs, "err")
+	}
+
+	call := fmt.Sprintf("part.blockchain.%s(%s)", h.name, strings.Join(args, ", "))
+	switch {
+	case len(variables) == 0:
+		file.printf("%s\n", call)
+	case len(responseArgs) == 0:
+		file.printf("%s = %s\n", strings.Join(variables, ", "), call)
+	default:
+		file.printf("%s := %s\n", strings.Join(variables, ", "), call)
+	}
+
+	file.printf("return common.NewMessage%s(%s)\n", h.responseKind(), strings.Join(responseArgs, ", "))
+	file.printf("}\n")
+	return nil
+}
+
+var gatewayVariables = map[string]bool{
+	"blockchain":  true,
+	"request":     true,
+	"rawResponse": true,
+	"response":    true,
+	"err":         true,
+}
+
+// generateGateway generates the methods of the gateway of VM, which forward the hook calls to the Node
+func generateGateway(hooks []*hook) ([]byte, error) {
+	file := newGoFile("vmpart")
+	file.addImport(commonPackage, "common")
+
+	for i, h := range hooks {
+		if i > 0 {
+			file.printf("\n")
+		}
+
+		err := file.printGatewayMethod(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return file.bytes()
+}
+
+func (file *goFile) printGatewayMethod(h *hook) error {
+	params := make([]string, 0, len(h.params))
+	args := make([]string, 0, len(h.params))
+	for _, param := range h.params {
+		if gatewayVariables[param.name] {
+			return fmt.Errorf("%s: the parameter %s hides a variable of the gateway", h.name, param.name)
+		}
+
+		if param.field == "" {
+			params = append(params, "_ "+file.typeString(param.typ))
+			continue
+		}
+
+		params = append(params, param.name+" "+file.typeString(param.typ))
+		args = append(args, param.name)
+	}
+
+	results := make([]string, 0, len(h.results)+1)
+	zeroResults := make([]string, 0, len(h.results)+1)
+	responseResults := make([]string, 0, len(h.results)+1)
+	for _, result := range h.results {
+		results = append(results, file.typeString(result.typ))
+		zeroResults = append(zeroResults, file.zeroValue(result.typ))
+		if result.field == "" {
+			responseResults = append(responseResults, file.zeroValue(result.typ))
+			continue
+		}
+
+		responseResults = append(responseResults, fromMessage(result, "response."+result.field))
+	}
+	if h.hasError {
+		results = append(results, "error")
+		responseResults = append(responseResults, "response.GetError()")
+	}
+
+	resultsSignature := strings.Join(results, ", ")
+	if len(results) > 1 {
+		resultsSignature = "(" + resultsSignature + ")"
+	}
+
+	badReturn := func(err string) string {
+		values := zeroResults
+		if h.hasError {
+			values = append(values[:len(values):len(values)], err)
+		}
+
+		return strings.TrimSpace("return " + strings.Join(values, ", "))
+	}
+
+	file.printf("// %s forwards a message to the actual hook\n", h.name)
+	file.printf("func (blockchain *BlockchainHookGateway) %s(%s) %s {\n", h.name, strings.Join(params, ", "), resultsSignature)
+	file.printf("request := common.NewMessage%s(%s)\n", h.requestKind(), strings.Join(args, ", "))
+	file.printf("rawResponse, err := blockchain.sendHookCall(request)\n")
+	file.printf("if err != nil {\n")
+	file.printf("%s\n", badReturn("err"))
+	file.printf("}\n\n")
+	file.printf("if rawResponse.GetKind() != common.%s {\n", h.responseKind())
+	file.printf("log.Error(%q, \"err\", common.ErrBadHookResponseFromNode)\n", h.name)
+	if len(responseResults) > 0 {
+		file.printf("%s\n", badReturn("common.ErrBadHookResponseFromNode"))
+	}
+	file.printf("}\n")
+
+	if len(responseResults) > 0 {
+		file.printf("\nresponse := rawResponse.(*common.Message%s)\n", h.responseKind())
+		file.printf("return %s\n", strings.Join(responseResults, ", "))
+	}
+
+	file.printf("}\n")
+	return nil
+}
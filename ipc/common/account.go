@@ -3,6 +3,7 @@ package common
 import (
 	"math/big"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
@@ -19,6 +20,25 @@ type Account struct {
 	CodeMetadata    []byte
 }
 
+// NewSerializableAccount copies the account data which is carried by the messages
+func NewSerializableAccount(account vmcommon.UserAccountHandler) *Account {
+	if check.IfNil(account) {
+		return nil
+	}
+
+	return &Account{
+		Nonce:           account.GetNonce(),
+		Balance:         account.GetBalance(),
+		CodeHash:        account.GetCodeHash(),
+		RootHash:        account.GetRootHash(),
+		Address:         account.AddressBytes(),
+		DeveloperReward: account.GetDeveloperReward(),
+		OwnerAddress:    account.GetOwnerAddress(),
+		UserName:        account.GetUserName(),
+		CodeMetadata:    account.GetCodeMetadata(),
+	}
+}
+
 // AddressBytes gets the address
 func (a *Account) AddressBytes() []byte {
 	return a.Address
@@ -1,8 +1,7 @@
 package common
 
 import (
-	"os"
-
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 )
@@ -12,25 +11,37 @@ type VMArguments struct {
 	vmhost.VMHostParameters
 	LogsMarshalizer     marshaling.MarshalizerKind
 	MessagesMarshalizer marshaling.MarshalizerKind
+	// EnableEpochs are the activation epochs from which the VM process creates its EnableEpochsHandler;
+	// the epochs missing from the message are those of config.MakeDefaultEnableEpochs
+	EnableEpochs config.EnableEpochs
+	// HeartbeatPeriod is the period, in milliseconds, of the heartbeats VM sends while executing a request (if agreed upon in the handshake)
+	HeartbeatPeriod int
+	// HookReplyTimeout is the time, in milliseconds, VM waits for the reply to a hook call; 0 means no timeout
+	HookReplyTimeout int
 }
 
-// SendVMArguments sends initialization arguments through a pipe
-func SendVMArguments(pipe *os.File, pipeArguments VMArguments) error {
+// SendVMArguments sends initialization arguments through a pipe or a socket
+func SendVMArguments(pipe Transport, pipeArguments VMArguments) error {
 	sender := NewSender(pipe, createArgumentsMarshalizer())
 	message := NewMessageInitialize(pipeArguments)
 	_, err := sender.Send(message)
 	return err
 }
 
-// GetVMArguments reads initialization arguments from the pipe
-func GetVMArguments(pipe *os.File) (*VMArguments, error) {
+// GetVMArguments reads initialization arguments from the pipe or the socket;
+// ErrBadVMArguments is returned if another message comes first
+func GetVMArguments(pipe Transport) (*VMArguments, error) {
 	receiver := NewReceiver(pipe, createArgumentsMarshalizer())
 	message, _, err := receiver.Receive(0)
 	if err != nil {
 		return nil, err
 	}
 
-	typedMessage := message.(*MessageInitialize)
+	typedMessage, ok := message.(*MessageInitialize)
+	if !ok {
+		return nil, ErrBadVMArguments
+	}
+
 	return &typedMessage.Arguments, nil
 }
 
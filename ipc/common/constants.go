@@ -2,3 +2,9 @@ package common
 
 // EnvVarVMPath is an environment variable
 const EnvVarVMPath = "VM_PATH"
+
+// compressedMessageFlag is set on the kind of a message sent compressed
+const compressedMessageFlag MessageKind = 1 << 31
+
+// compressionThreshold is the size above which the messages are compressed, if compression is enabled
+const compressionThreshold = 4096
@@ -0,0 +1,135 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// The parts of a dialogue, as the senders of the recorded messages
+const (
+	PartNode = "node"
+	PartVM   = "vm"
+)
+
+// ReplaySession starts a session (the messages exchanged with a single VM, from its start) within a replay file
+type ReplaySession struct {
+	VMArguments  VMArguments
+	Capabilities []string
+}
+
+// ReplayRecord is a line of a replay file: either the start of a session, or a message of the current session.
+// The messages are marshalized as JSON, whatever the marshalizer of the dialogue, so that the replay files are readable.
+type ReplayRecord struct {
+	Session *ReplaySession  `json:",omitempty"`
+	Sender  string          `json:",omitempty"`
+	Kind    MessageKind     `json:",omitempty"`
+	Message json.RawMessage `json:",omitempty"`
+}
+
+// DialogueRecorder writes the messages exchanged between the Node and VM to a replay file, see ReadReplayFile()
+type DialogueRecorder struct {
+	mutex     sync.Mutex
+	localPart string
+	encoder   *json.Encoder
+}
+
+// NewDialogueRecorder creates a recorder for the messenger of the given part (PartNode or PartVM)
+func NewDialogueRecorder(output io.Writer, localPart string) *DialogueRecorder {
+	return &DialogueRecorder{
+		localPart: localPart,
+		encoder:   json.NewEncoder(output),
+	}
+}
+
+// BeginSession has to be called each time VM is (re)started, before recording its messages
+func (recorder *DialogueRecorder) BeginSession(session ReplaySession) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return recorder.encoder.Encode(&ReplayRecord{Session: &session})
+}
+
+// RecordSent records a message sent by the local part
+func (recorder *DialogueRecorder) RecordSent(message MessageHandler) {
+	recorder.record(recorder.localPart, message)
+}
+
+// RecordReceived records a message received from the other part
+func (recorder *DialogueRecorder) RecordReceived(message MessageHandler) {
+	if recorder.localPart == PartNode {
+		recorder.record(PartVM, message)
+	} else {
+		recorder.record(PartNode, message)
+	}
+}
+
+// record does not fail the dialogue, the errors are only logged
+func (recorder *DialogueRecorder) record(sender string, message MessageHandler) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Error("DialogueRecorder: cannot marshalize message", "kind", message.GetKindName(), "err", err)
+		return
+	}
+
+	err = recorder.encoder.Encode(&ReplayRecord{Sender: sender, Kind: message.GetKind(), Message: data})
+	if err != nil {
+		log.Error("DialogueRecorder: cannot write message", "kind", message.GetKindName(), "err", err)
+	}
+}
+
+// RecordedMessage is a message read from a replay file
+type RecordedMessage struct {
+	Sender  string
+	Message MessageHandler
+}
+
+// RecordedSession is a session read from a replay file
+type RecordedSession struct {
+	ReplaySession
+	Messages []*RecordedMessage
+}
+
+// ReadReplayFile reads the sessions recorded by a DialogueRecorder
+func ReadReplayFile(input io.Reader) ([]*RecordedSession, error) {
+	decoder := json.NewDecoder(input)
+	sessions := make([]*RecordedSession, 0)
+
+	for index := 0; decoder.More(); index++ {
+		record := &ReplayRecord{}
+		err := decoder.Decode(record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", ErrBadReplayFile, index, err)
+		}
+
+		if record.Session != nil {
+			sessions = append(sessions, &RecordedSession{ReplaySession: *record.Session})
+			continue
+		}
+
+		if len(sessions) == 0 {
+			return nil, fmt.Errorf("%w: record %d precedes the first session", ErrBadReplayFile, index)
+		}
+		if record.Sender != PartNode && record.Sender != PartVM {
+			return nil, fmt.Errorf("%w: record %d has unknown sender %q", ErrBadReplayFile, index, record.Sender)
+		}
+		if record.Kind >= LastKind {
+			return nil, fmt.Errorf("%w: record %d has unknown kind %d", ErrBadReplayFile, index, record.Kind)
+		}
+
+		message := CreateMessage(record.Kind)
+		err = json.Unmarshal(record.Message, message)
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", ErrBadReplayFile, index, err)
+		}
+
+		session := sessions[len(sessions)-1]
+		session.Messages = append(session.Messages, &RecordedMessage{Sender: record.Sender, Message: message})
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialogueRecorder_RecordsBothParts(t *testing.T) {
+	nodeToVMRead, nodeToVMWrite, err := os.Pipe()
+	require.Nil(t, err)
+	vmToNodeRead, vmToNodeWrite, err := os.Pipe()
+	require.Nil(t, err)
+
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	node := NewMessengerPipes("NODE", vmToNodeRead, nodeToVMWrite, marshalizer)
+	vm := NewMessengerPipes("VM", nodeToVMRead, vmToNodeWrite, marshalizer)
+	defer node.Shutdown()
+	defer vm.Shutdown()
+
+	output := &bytes.Buffer{}
+	recorder := NewDialogueRecorder(output, PartNode)
+	err = recorder.BeginSession(ReplaySession{Capabilities: []string{CapabilityCompression}})
+	require.Nil(t, err)
+	node.SetRecorder(recorder)
+
+	go func() {
+		_, _ = vm.Receive(0)
+		_ = vm.Send(NewMessageBlockchainGetBlockhashRequest(42))
+		_, _ = vm.Receive(0)
+		_ = vm.Send(NewMessageVersionResponse("v1.2"))
+	}()
+
+	err = node.Send(NewMessageVersionRequest())
+	require.Nil(t, err)
+	_, err = node.Receive(1000)
+	require.Nil(t, err)
+	err = node.Send(NewMessageBlockchainGetBlockhashResponse([]byte("hash"), nil))
+	require.Nil(t, err)
+	_, err = node.Receive(1000)
+	require.Nil(t, err)
+
+	sessions, err := ReadReplayFile(output)
+	require.Nil(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, []string{CapabilityCompression}, sessions[0].Capabilities)
+
+	messages := sessions[0].Messages
+	require.Len(t, messages, 4)
+	require.Equal(t, PartNode, messages[0].Sender)
+	require.Equal(t, VersionRequest, messages[0].Message.GetKind())
+	require.Equal(t, PartVM, messages[1].Sender)
+	require.Equal(t, uint64(42), messages[1].Message.(*MessageBlockchainGetBlockhashRequest).Nonce)
+	require.Equal(t, PartNode, messages[2].Sender)
+	require.Equal(t, []byte("hash"), messages[2].Message.(*MessageBlockchainGetBlockhashResponse).Result)
+	require.Equal(t, PartVM, messages[3].Sender)
+	require.Equal(t, "v1.2", messages[3].Message.(*MessageVersionResponse).Version)
+}
+
+func TestReadReplayFile_BadFiles(t *testing.T) {
+	_, err := ReadReplayFile(strings.NewReader(`{"Sender":"node","Kind":1,"Message":{}}`))
+	require.ErrorIs(t, err, ErrBadReplayFile)
+	require.Contains(t, err.Error(), "precedes the first session")
+
+	_, err = ReadReplayFile(strings.NewReader("{\"Session\":{}}\n{\"Sender\":\"other\",\"Kind\":1,\"Message\":{}}"))
+	require.ErrorIs(t, err, ErrBadReplayFile)
+	require.Contains(t, err.Error(), "unknown sender")
+
+	_, err = ReadReplayFile(strings.NewReader("{\"Session\":{}}\n{\"Sender\":\"vm\",\"Kind\":100000,\"Message\":{}}"))
+	require.ErrorIs(t, err, ErrBadReplayFile)
+	require.Contains(t, err.Error(), "unknown kind")
+
+	_, err = ReadReplayFile(strings.NewReader("{\"Session\":{}}\nnot json"))
+	require.ErrorIs(t, err, ErrBadReplayFile)
+
+	sessions, err := ReadReplayFile(strings.NewReader(""))
+	require.Nil(t, err)
+	require.Empty(t, sessions)
+}
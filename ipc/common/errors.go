@@ -36,11 +36,17 @@ var ErrBadVMArguments = &CriticalError{InnerErr: fmt.Errorf("bad arguments passe
 var ErrVMClosed = &CriticalError{InnerErr: fmt.Errorf("vm closed")}
 
 // ErrVMTimeExpired signals a critical error
-var ErrVMTimeExpired = &CriticalError{InnerErr: fmt.Errorf("vm time expired")}
+var ErrVMTimeExpired = &CriticalError{InnerErr: fmt.Errorf("vm time expired: timeout of the dialogue exceeded")}
+
+// ErrVMNotResponding signals a critical error: VM sent no message, not even a heartbeat, before the heartbeat timeout
+var ErrVMNotResponding = &CriticalError{InnerErr: fmt.Errorf("vm not responding: no heartbeat before timeout")}
 
 // ErrVMNotFound signals a critical error
 var ErrVMNotFound = &CriticalError{InnerErr: fmt.Errorf("vm binary not found")}
 
+// ErrInvalidTransportAddress signals a critical error
+var ErrInvalidTransportAddress = &CriticalError{InnerErr: fmt.Errorf("invalid transport address, expected unix://<path> or tcp://<host>:<port>")}
+
 // ErrInvalidMessageNonce signals a critical error
 var ErrInvalidMessageNonce = &CriticalError{InnerErr: fmt.Errorf("invalid dialogue nonce")}
 
@@ -68,6 +74,18 @@ var ErrCannotReceiveHookCallResponse = &CriticalError{InnerErr: fmt.Errorf("cann
 // ErrBadHookResponseFromNode signals a critical error
 var ErrBadHookResponseFromNode = &CriticalError{InnerErr: fmt.Errorf("bad hook response from node")}
 
+// ErrBadBatchOfHookCalls signals a critical error
+var ErrBadBatchOfHookCalls = &CriticalError{InnerErr: fmt.Errorf("bad batch of hook calls")}
+
+// ErrBatchOfHookCallsNotSupported signals that the Node does not know the batch message, thus the hook calls have to be made one at a time
+var ErrBatchOfHookCallsNotSupported = fmt.Errorf("batch of hook calls not supported by node")
+
+// ErrBadReplayFile signals that a replay file cannot be read
+var ErrBadReplayFile = fmt.Errorf("bad replay file")
+
+// ErrReplayDivergence signals that VM did not send the recorded message, when replaying a dialogue
+var ErrReplayDivergence = fmt.Errorf("vm diverged from the recorded dialogue")
+
 const (
 	// ErrCodeSuccess signals success
 	ErrCodeSuccess = iota
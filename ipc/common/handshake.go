@@ -0,0 +1,210 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+)
+
+// ProtocolVersion is the version of the protocol between the Node and VM (the messages, their kinds and their content);
+// it must be increased on any change which breaks the compatibility, and the Node and VM must have the same version
+const ProtocolVersion uint32 = 1
+
+const (
+	// CapabilityBatchOfHookCalls signals the support of BlockchainBatchRequest
+	CapabilityBatchOfHookCalls = "batchOfHookCalls"
+	// CapabilityPrefetchHints signals the support of the prefetch hints of the contract calls
+	CapabilityPrefetchHints = "prefetchHints"
+	// CapabilityCompression signals the support of compressed messages
+	CapabilityCompression = "compression"
+	// CapabilityHeartbeats signals the support of the heartbeats sent by VM while it executes a request
+	CapabilityHeartbeats = "heartbeats"
+	// CapabilityDiagnoseStats signals the support of DiagnoseStatsRequest
+	CapabilityDiagnoseStats = "diagnoseStats"
+)
+
+// handshakeTimeout is the time, in milliseconds, VM has to answer the handshake
+const handshakeTimeout = 10000
+
+// ErrProtocolMismatch signals that the Node and VM cannot talk to each other, see ProtocolMismatchError
+var ErrProtocolMismatch = errors.New("node and vm protocol mismatch")
+
+// ProtocolMismatchError tells why the Node and VM cannot talk to each other
+type ProtocolMismatchError struct {
+	Reason string
+}
+
+func newProtocolMismatchError(format string, args ...interface{}) error {
+	return WrapCriticalError(&ProtocolMismatchError{Reason: fmt.Sprintf(format, args...)})
+}
+
+func (err *ProtocolMismatchError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrProtocolMismatch, err.Reason)
+}
+
+// Is makes errors.Is(err, ErrProtocolMismatch) hold
+func (err *ProtocolMismatchError) Is(target error) bool {
+	return target == ErrProtocolMismatch
+}
+
+func getMismatchReason(err error) string {
+	var mismatchErr *ProtocolMismatchError
+	if errors.As(err, &mismatchErr) {
+		return mismatchErr.Reason
+	}
+
+	return err.Error()
+}
+
+// Handshake is what the Node or VM declares about itself
+type Handshake struct {
+	ProtocolVersion uint32
+	Marshalizers    []marshaling.MarshalizerKind
+	Capabilities    []string
+}
+
+// NewHandshake creates the handshake of the current build
+func NewHandshake() Handshake {
+	return Handshake{
+		ProtocolVersion: ProtocolVersion,
+		Marshalizers:    []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob, marshaling.Binary},
+		Capabilities:    []string{CapabilityBatchOfHookCalls, CapabilityPrefetchHints, CapabilityCompression, CapabilityHeartbeats, CapabilityDiagnoseStats},
+	}
+}
+
+// Negotiate checks whether the peer ("node" or "vm") can exchange messages using the given marshalizer,
+// and returns the capabilities supported by both
+func (handshake Handshake) Negotiate(peerName string, peer Handshake, messagesMarshalizer marshaling.MarshalizerKind) ([]string, error) {
+	if handshake.ProtocolVersion != peer.ProtocolVersion {
+		return nil, newProtocolMismatchError("protocol version %d, while the %s has protocol version %d",
+			handshake.ProtocolVersion, peerName, peer.ProtocolVersion)
+	}
+
+	if !supportsMarshalizer(handshake.Marshalizers, messagesMarshalizer) {
+		return nil, newProtocolMismatchError("marshalizer %d is not supported, while the %s requires it", messagesMarshalizer, peerName)
+	}
+	if !supportsMarshalizer(peer.Marshalizers, messagesMarshalizer) {
+		return nil, newProtocolMismatchError("marshalizer %d is not supported by the %s", messagesMarshalizer, peerName)
+	}
+
+	capabilities := make([]string, 0, len(handshake.Capabilities))
+	for _, capability := range handshake.Capabilities {
+		if HasCapability(peer.Capabilities, capability) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+
+	return capabilities, nil
+}
+
+func supportsMarshalizer(marshalizers []marshaling.MarshalizerKind, marshalizer marshaling.MarshalizerKind) bool {
+	for _, supported := range marshalizers {
+		if supported == marshalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasCapability returns whether the capability is among the given ones
+func HasCapability(capabilities []string, capability string) bool {
+	for _, item := range capabilities {
+		if item == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MessageHandshakeRequest is the handshake of the Node, sent right after the VMArguments
+type MessageHandshakeRequest struct {
+	Message
+	Handshake           Handshake
+	MessagesMarshalizer marshaling.MarshalizerKind
+}
+
+// NewMessageHandshakeRequest creates a request message
+func NewMessageHandshakeRequest(handshake Handshake, messagesMarshalizer marshaling.MarshalizerKind) *MessageHandshakeRequest {
+	message := &MessageHandshakeRequest{}
+	message.Kind = HandshakeRequest
+	message.Handshake = handshake
+	message.MessagesMarshalizer = messagesMarshalizer
+	return message
+}
+
+// MessageHandshakeResponse is the handshake of VM; the error is set if VM refuses the Node
+type MessageHandshakeResponse struct {
+	Message
+	Handshake Handshake
+	Version   string
+}
+
+// NewMessageHandshakeResponse creates a response message
+func NewMessageHandshakeResponse(handshake Handshake, version string, err error) *MessageHandshakeResponse {
+	message := &MessageHandshakeResponse{}
+	message.Kind = HandshakeResponse
+	message.Handshake = handshake
+	message.Version = version
+	message.SetError(err)
+	return message
+}
+
+// PerformHandshake is done by the Node, right after sending the VMArguments. It returns the capabilities supported by both parts.
+// Like the VMArguments, the handshake is always marshalized as JSON, so that a mismatch of the messages marshalizer is reported as such.
+func PerformHandshake(input Transport, output Transport, handshake Handshake, messagesMarshalizer marshaling.MarshalizerKind) ([]string, error) {
+	sender := NewSender(output, createArgumentsMarshalizer())
+	_, err := sender.Send(NewMessageHandshakeRequest(handshake, messagesMarshalizer))
+	if err != nil {
+		return nil, err
+	}
+
+	receiver := NewReceiver(input, createArgumentsMarshalizer())
+	message, _, err := receiver.Receive(handshakeTimeout)
+	if err != nil {
+		return nil, newProtocolMismatchError("no handshake from vm (%v)", err)
+	}
+
+	response, ok := message.(*MessageHandshakeResponse)
+	if !ok {
+		return nil, newProtocolMismatchError("received %s instead of the handshake of vm", message.GetKindName())
+	}
+
+	if len(response.ErrorMessage) > 0 {
+		return nil, newProtocolMismatchError("vm %s refused the handshake: %s", response.Version, response.ErrorMessage)
+	}
+
+	return handshake.Negotiate("vm", response.Handshake, messagesMarshalizer)
+}
+
+// AcceptHandshake is done by VM, right after receiving the VMArguments. It returns the capabilities supported by both parts.
+func AcceptHandshake(input Transport, output Transport, handshake Handshake, version string) ([]string, error) {
+	receiver := NewReceiver(input, createArgumentsMarshalizer())
+	message, _, err := receiver.Receive(0)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := NewSender(output, createArgumentsMarshalizer())
+	request, ok := message.(*MessageHandshakeRequest)
+	if !ok {
+		err = newProtocolMismatchError("received %s instead of the handshake of the node", message.GetKindName())
+		_, _ = sender.Send(NewMessageHandshakeResponse(handshake, version, errors.New(getMismatchReason(err))))
+		return nil, err
+	}
+
+	capabilities, err := handshake.Negotiate("node", request.Handshake, request.MessagesMarshalizer)
+	if err != nil {
+		_, _ = sender.Send(NewMessageHandshakeResponse(handshake, version, errors.New(getMismatchReason(err))))
+		return nil, err
+	}
+
+	_, err = sender.Send(NewMessageHandshakeResponse(handshake, version, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return capabilities, nil
+}
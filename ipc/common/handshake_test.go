@@ -0,0 +1,313 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+// The values are part of the protocol, see MessageKind: this table must only grow
+func TestMessageKind_ValuesAreFrozen(t *testing.T) {
+	frozenValues := map[MessageKind]uint32{
+		FirstKind:                                 0,
+		Initialize:                                1,
+		Stop:                                      2,
+		ContractDeployRequest:                     3,
+		ContractCallRequest:                       4,
+		ContractResponse:                          5,
+		GasScheduleChangeRequest:                  6,
+		GasScheduleChangeResponse:                 7,
+		BlockchainNewAddressRequest:               8,
+		BlockchainNewAddressResponse:              9,
+		BlockchainGetStorageDataRequest:           10,
+		BlockchainGetStorageDataResponse:          11,
+		BlockchainGetBlockhashRequest:             12,
+		BlockchainGetBlockhashResponse:            13,
+		BlockchainLastNonceRequest:                14,
+		BlockchainLastNonceResponse:               15,
+		BlockchainLastRoundRequest:                16,
+		BlockchainLastRoundResponse:               17,
+		BlockchainLastTimeStampRequest:            18,
+		BlockchainLastTimeStampResponse:           19,
+		BlockchainLastRandomSeedRequest:           20,
+		BlockchainLastRandomSeedResponse:          21,
+		BlockchainLastEpochRequest:                22,
+		BlockchainLastEpochResponse:               23,
+		BlockchainGetStateRootHashRequest:         24,
+		BlockchainGetStateRootHashResponse:        25,
+		BlockchainCurrentNonceRequest:             26,
+		BlockchainCurrentNonceResponse:            27,
+		BlockchainCurrentRoundRequest:             28,
+		BlockchainCurrentRoundResponse:            29,
+		BlockchainCurrentTimeStampRequest:         30,
+		BlockchainCurrentTimeStampResponse:        31,
+		BlockchainCurrentRandomSeedRequest:        32,
+		BlockchainCurrentRandomSeedResponse:       33,
+		BlockchainCurrentEpochRequest:             34,
+		BlockchainCurrentEpochResponse:            35,
+		BlockchainProcessBuiltinFunctionRequest:   36,
+		BlockchainProcessBuiltinFunctionResponse:  37,
+		BlockchainGetESDTTokenRequest:             38,
+		BlockchainGetESDTTokenResponse:            39,
+		BlockchainGetBuiltinFunctionNamesRequest:  40,
+		BlockchainGetBuiltinFunctionNamesResponse: 41,
+		BlockchainGetAllStateRequest:              42,
+		BlockchainGetAllStateResponse:             43,
+		BlockchainGetUserAccountRequest:           44,
+		BlockchainGetUserAccountResponse:          45,
+		BlockchainGetCodeRequest:                  46,
+		BlockchainGetCodeResponse:                 47,
+		BlockchainGetShardOfAddressRequest:        48,
+		BlockchainGetShardOfAddressResponse:       49,
+		BlockchainIsPayableRequest:                50,
+		BlockchainIsPayableResponse:               51,
+		BlockchainIsSmartContractRequest:          52,
+		BlockchainIsSmartContractResponse:         53,
+		BlockchainSaveCompiledCodeRequest:         54,
+		BlockchainSaveCompiledCodeResponse:        55,
+		BlockchainGetCompiledCodeRequest:          56,
+		BlockchainGetCompiledCodeResponse:         57,
+		DiagnoseWaitRequest:                       58,
+		DiagnoseWaitResponse:                      59,
+		VersionRequest:                            60,
+		VersionResponse:                           61,
+		UndefinedRequestOrResponse:                62,
+		BlockchainBatchRequest:                    63,
+		BlockchainBatchResponse:                   64,
+		HandshakeRequest:                          65,
+		HandshakeResponse:                         66,
+		Heartbeat:                                 67,
+		DiagnoseStatsRequest:                      68,
+		DiagnoseStatsResponse:                     69,
+	}
+
+	for kind, value := range frozenValues {
+		require.Equal(t, value, uint32(kind), messageKindNameByID[kind])
+	}
+
+	for kind := FirstKind; kind < LastKind; kind++ {
+		_, ok := frozenValues[kind]
+		require.True(t, ok, "kind %d must be added to the frozen values", kind)
+		require.NotEmpty(t, messageKindNameByID[kind], "kind %d has no name", kind)
+		require.Zero(t, kind&compressedMessageFlag)
+	}
+}
+
+// The kinds known before the values were frozen, in the order which gave them their values:
+// they must keep these values, so that the Node and VM of the older releases still understand each other
+func TestMessageKind_BaselineKindsKeepTheirValues(t *testing.T) {
+	baselineKinds := []MessageKind{
+		FirstKind,
+		Initialize,
+		Stop,
+		ContractDeployRequest,
+		ContractCallRequest,
+		ContractResponse,
+		GasScheduleChangeRequest,
+		GasScheduleChangeResponse,
+		BlockchainNewAddressRequest,
+		BlockchainNewAddressResponse,
+		BlockchainGetStorageDataRequest,
+		BlockchainGetStorageDataResponse,
+		BlockchainGetBlockhashRequest,
+		BlockchainGetBlockhashResponse,
+		BlockchainLastNonceRequest,
+		BlockchainLastNonceResponse,
+		BlockchainLastRoundRequest,
+		BlockchainLastRoundResponse,
+		BlockchainLastTimeStampRequest,
+		BlockchainLastTimeStampResponse,
+		BlockchainLastRandomSeedRequest,
+		BlockchainLastRandomSeedResponse,
+		BlockchainLastEpochRequest,
+		BlockchainLastEpochResponse,
+		BlockchainGetStateRootHashRequest,
+		BlockchainGetStateRootHashResponse,
+		BlockchainCurrentNonceRequest,
+		BlockchainCurrentNonceResponse,
+		BlockchainCurrentRoundRequest,
+		BlockchainCurrentRoundResponse,
+		BlockchainCurrentTimeStampRequest,
+		BlockchainCurrentTimeStampResponse,
+		BlockchainCurrentRandomSeedRequest,
+		BlockchainCurrentRandomSeedResponse,
+		BlockchainCurrentEpochRequest,
+		BlockchainCurrentEpochResponse,
+		BlockchainProcessBuiltinFunctionRequest,
+		BlockchainProcessBuiltinFunctionResponse,
+		BlockchainGetESDTTokenRequest,
+		BlockchainGetESDTTokenResponse,
+		BlockchainGetBuiltinFunctionNamesRequest,
+		BlockchainGetBuiltinFunctionNamesResponse,
+		BlockchainGetAllStateRequest,
+		BlockchainGetAllStateResponse,
+		BlockchainGetUserAccountRequest,
+		BlockchainGetUserAccountResponse,
+		BlockchainGetCodeRequest,
+		BlockchainGetCodeResponse,
+		BlockchainGetShardOfAddressRequest,
+		BlockchainGetShardOfAddressResponse,
+		BlockchainIsPayableRequest,
+		BlockchainIsPayableResponse,
+		BlockchainIsSmartContractRequest,
+		BlockchainIsSmartContractResponse,
+		BlockchainSaveCompiledCodeRequest,
+		BlockchainSaveCompiledCodeResponse,
+		BlockchainGetCompiledCodeRequest,
+		BlockchainGetCompiledCodeResponse,
+		DiagnoseWaitRequest,
+		DiagnoseWaitResponse,
+		VersionRequest,
+		VersionResponse,
+		UndefinedRequestOrResponse,
+	}
+
+	for value, kind := range baselineKinds {
+		require.Equal(t, uint32(value), uint32(kind), messageKindNameByID[kind])
+	}
+	require.Equal(t, uint32(62), uint32(UndefinedRequestOrResponse))
+}
+
+type handshakeParty struct {
+	name      string
+	handshake Handshake
+}
+
+func TestHandshake_CompatibilityMatrix(t *testing.T) {
+	current := NewHandshake()
+	parties := []handshakeParty{
+		{name: "current", handshake: current},
+		{name: "older", handshake: Handshake{
+			ProtocolVersion: ProtocolVersion - 1,
+			Marshalizers:    []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob},
+		}},
+		{name: "newer", handshake: Handshake{
+			ProtocolVersion: ProtocolVersion + 1,
+			Marshalizers:    current.Marshalizers,
+			Capabilities:    append([]string{"futureCapability"}, current.Capabilities...),
+		}},
+		{name: "jsonOnly", handshake: Handshake{
+			ProtocolVersion: ProtocolVersion,
+			Marshalizers:    []marshaling.MarshalizerKind{marshaling.JSON},
+			Capabilities:    []string{CapabilityCompression, "futureCapability"},
+		}},
+		{name: "noCapabilities", handshake: Handshake{
+			ProtocolVersion: ProtocolVersion,
+			Marshalizers:    current.Marshalizers,
+		}},
+	}
+	marshalizers := []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob, marshaling.Binary}
+
+	for _, node := range parties {
+		for _, vm := range parties {
+			for _, marshalizer := range marshalizers {
+				name := fmt.Sprintf("node=%s/vm=%s/marshalizer=%d", node.name, vm.name, marshalizer)
+				t.Run(name, func(t *testing.T) {
+					nodeCapabilities, vmCapabilities, nodeErr, vmErr := runHandshake(t, node.handshake, vm.handshake, marshalizer)
+
+					compatible := node.handshake.ProtocolVersion == vm.handshake.ProtocolVersion &&
+						supportsMarshalizer(node.handshake.Marshalizers, marshalizer) &&
+						supportsMarshalizer(vm.handshake.Marshalizers, marshalizer)
+					if !compatible {
+						require.True(t, errors.Is(nodeErr, ErrProtocolMismatch), nodeErr)
+						require.True(t, IsCriticalError(nodeErr))
+						require.True(t, errors.Is(vmErr, ErrProtocolMismatch), vmErr)
+						return
+					}
+
+					require.Nil(t, nodeErr)
+					require.Nil(t, vmErr)
+					require.ElementsMatch(t, nodeCapabilities, vmCapabilities)
+					for _, capability := range nodeCapabilities {
+						require.True(t, HasCapability(node.handshake.Capabilities, capability))
+						require.True(t, HasCapability(vm.handshake.Capabilities, capability))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestHandshake_NodeReportsTheReasonOfVM(t *testing.T) {
+	newerVM := NewHandshake()
+	newerVM.ProtocolVersion++
+
+	_, _, nodeErr, _ := runHandshake(t, NewHandshake(), newerVM, marshaling.JSON)
+	require.Equal(t, fmt.Sprintf("critical error: node and vm protocol mismatch: vm v1.2.test refused the handshake: "+
+		"protocol version %d, while the node has protocol version %d", newerVM.ProtocolVersion, ProtocolVersion), nodeErr.Error())
+}
+
+func TestHandshake_WithVMNotSupportingHandshake(t *testing.T) {
+	files := createHandshakePipes(t)
+
+	// As an older VM, which stops on an unknown message
+	go func() {
+		_, _, _ = NewReceiver(files.inputOfVM, createArgumentsMarshalizer()).Receive(0)
+		_ = files.outputOfVM.Close()
+	}()
+
+	_, err := PerformHandshake(files.inputOfNode, files.outputOfNode, NewHandshake(), marshaling.JSON)
+	require.True(t, errors.Is(err, ErrProtocolMismatch))
+	require.Contains(t, err.Error(), "no handshake from vm")
+}
+
+func TestHandshake_WithNodeNotSupportingHandshake(t *testing.T) {
+	files := createHandshakePipes(t)
+
+	// As an older Node, which sends the first request right away
+	go func() {
+		_, _ = NewSender(files.outputOfNode, createArgumentsMarshalizer()).Send(NewMessageVersionRequest())
+	}()
+
+	_, err := AcceptHandshake(files.inputOfVM, files.outputOfVM, NewHandshake(), "v1.2.test")
+	require.True(t, errors.Is(err, ErrProtocolMismatch))
+	require.Contains(t, err.Error(), "received VersionRequest instead of the handshake of the node")
+}
+
+func runHandshake(t *testing.T, node Handshake, vm Handshake, marshalizer marshaling.MarshalizerKind) ([]string, []string, error, error) {
+	files := createHandshakePipes(t)
+
+	type vmResult struct {
+		capabilities []string
+		err          error
+	}
+	vmDone := make(chan vmResult, 1)
+	go func() {
+		capabilities, err := AcceptHandshake(files.inputOfVM, files.outputOfVM, vm, "v1.2.test")
+		vmDone <- vmResult{capabilities: capabilities, err: err}
+	}()
+
+	nodeCapabilities, nodeErr := PerformHandshake(files.inputOfNode, files.outputOfNode, node, marshalizer)
+	result := <-vmDone
+	return nodeCapabilities, result.capabilities, nodeErr, result.err
+}
+
+type handshakePipes struct {
+	inputOfVM    *os.File
+	outputOfNode *os.File
+	inputOfNode  *os.File
+	outputOfVM   *os.File
+}
+
+func createHandshakePipes(t *testing.T) handshakePipes {
+	files := handshakePipes{}
+
+	var err error
+	files.inputOfVM, files.outputOfNode, err = os.Pipe()
+	require.Nil(t, err)
+	files.inputOfNode, files.outputOfVM, err = os.Pipe()
+	require.Nil(t, err)
+
+	t.Cleanup(func() {
+		_ = files.inputOfVM.Close()
+		_ = files.outputOfNode.Close()
+		_ = files.inputOfNode.Close()
+		_ = files.outputOfVM.Close()
+	})
+
+	return files
+}
@@ -5,74 +5,87 @@ import (
 	"math"
 )
 
+//go:generate go run ../codegen
+
 // MessageKind is the kind of a message (that is passed between the Node and VM)
 type MessageKind uint32
 
+// The values of the kinds are part of the protocol between the Node and VM, thus they are frozen:
+// a value must never change or be reused, and new kinds are appended (before LastKind, which is never sent).
+// The highest bit of a kind is reserved, see compressedMessageFlag.
+// The messages of the hook calls are generated out of vmcommon.BlockchainHook, though their kinds are declared here, as well.
 const (
-	FirstKind MessageKind = iota
-	Initialize
-	Stop
-	ContractDeployRequest
-	ContractCallRequest
-	ContractResponse
-	GasScheduleChangeRequest
-	GasScheduleChangeResponse
-	BlockchainNewAddressRequest
-	BlockchainNewAddressResponse
-	BlockchainGetStorageDataRequest
-	BlockchainGetStorageDataResponse
-	BlockchainGetBlockhashRequest
-	BlockchainGetBlockhashResponse
-	BlockchainLastNonceRequest
-	BlockchainLastNonceResponse
-	BlockchainLastRoundRequest
-	BlockchainLastRoundResponse
-	BlockchainLastTimeStampRequest
-	BlockchainLastTimeStampResponse
-	BlockchainLastRandomSeedRequest
-	BlockchainLastRandomSeedResponse
-	BlockchainLastEpochRequest
-	BlockchainLastEpochResponse
-	BlockchainGetStateRootHashRequest
-	BlockchainGetStateRootHashResponse
-	BlockchainCurrentNonceRequest
-	BlockchainCurrentNonceResponse
-	BlockchainCurrentRoundRequest
-	BlockchainCurrentRoundResponse
-	BlockchainCurrentTimeStampRequest
-	BlockchainCurrentTimeStampResponse
-	BlockchainCurrentRandomSeedRequest
-	BlockchainCurrentRandomSeedResponse
-	BlockchainCurrentEpochRequest
-	BlockchainCurrentEpochResponse
-	BlockchainProcessBuiltinFunctionRequest
-	BlockchainProcessBuiltinFunctionResponse
-	BlockchainGetESDTTokenRequest
-	BlockchainGetESDTTokenResponse
-	BlockchainGetBuiltinFunctionNamesRequest
-	BlockchainGetBuiltinFunctionNamesResponse
-	BlockchainGetAllStateRequest
-	BlockchainGetAllStateResponse
-	BlockchainGetUserAccountRequest
-	BlockchainGetUserAccountResponse
-	BlockchainGetCodeRequest
-	BlockchainGetCodeResponse
-	BlockchainGetShardOfAddressRequest
-	BlockchainGetShardOfAddressResponse
-	BlockchainIsPayableRequest
-	BlockchainIsPayableResponse
-	BlockchainIsSmartContractRequest
-	BlockchainIsSmartContractResponse
-	BlockchainSaveCompiledCodeRequest
-	BlockchainSaveCompiledCodeResponse
-	BlockchainGetCompiledCodeRequest
-	BlockchainGetCompiledCodeResponse
-	DiagnoseWaitRequest
-	DiagnoseWaitResponse
-	VersionRequest
-	VersionResponse
-	UndefinedRequestOrResponse
-	LastKind
+	FirstKind                                 MessageKind = 0
+	Initialize                                MessageKind = 1
+	Stop                                      MessageKind = 2
+	ContractDeployRequest                     MessageKind = 3
+	ContractCallRequest                       MessageKind = 4
+	ContractResponse                          MessageKind = 5
+	GasScheduleChangeRequest                  MessageKind = 6
+	GasScheduleChangeResponse                 MessageKind = 7
+	BlockchainNewAddressRequest               MessageKind = 8
+	BlockchainNewAddressResponse              MessageKind = 9
+	BlockchainGetStorageDataRequest           MessageKind = 10
+	BlockchainGetStorageDataResponse          MessageKind = 11
+	BlockchainGetBlockhashRequest             MessageKind = 12
+	BlockchainGetBlockhashResponse            MessageKind = 13
+	BlockchainLastNonceRequest                MessageKind = 14
+	BlockchainLastNonceResponse               MessageKind = 15
+	BlockchainLastRoundRequest                MessageKind = 16
+	BlockchainLastRoundResponse               MessageKind = 17
+	BlockchainLastTimeStampRequest            MessageKind = 18
+	BlockchainLastTimeStampResponse           MessageKind = 19
+	BlockchainLastRandomSeedRequest           MessageKind = 20
+	BlockchainLastRandomSeedResponse          MessageKind = 21
+	BlockchainLastEpochRequest                MessageKind = 22
+	BlockchainLastEpochResponse               MessageKind = 23
+	BlockchainGetStateRootHashRequest         MessageKind = 24
+	BlockchainGetStateRootHashResponse        MessageKind = 25
+	BlockchainCurrentNonceRequest             MessageKind = 26
+	BlockchainCurrentNonceResponse            MessageKind = 27
+	BlockchainCurrentRoundRequest             MessageKind = 28
+	BlockchainCurrentRoundResponse            MessageKind = 29
+	BlockchainCurrentTimeStampRequest         MessageKind = 30
+	BlockchainCurrentTimeStampResponse        MessageKind = 31
+	BlockchainCurrentRandomSeedRequest        MessageKind = 32
+	BlockchainCurrentRandomSeedResponse       MessageKind = 33
+	BlockchainCurrentEpochRequest             MessageKind = 34
+	BlockchainCurrentEpochResponse            MessageKind = 35
+	BlockchainProcessBuiltinFunctionRequest   MessageKind = 36
+	BlockchainProcessBuiltinFunctionResponse  MessageKind = 37
+	BlockchainGetESDTTokenRequest             MessageKind = 38
+	BlockchainGetESDTTokenResponse            MessageKind = 39
+	BlockchainGetBuiltinFunctionNamesRequest  MessageKind = 40
+	BlockchainGetBuiltinFunctionNamesResponse MessageKind = 41
+	BlockchainGetAllStateRequest              MessageKind = 42
+	BlockchainGetAllStateResponse             MessageKind = 43
+	BlockchainGetUserAccountRequest           MessageKind = 44
+	BlockchainGetUserAccountResponse          MessageKind = 45
+	BlockchainGetCodeRequest                  MessageKind = 46
+	BlockchainGetCodeResponse                 MessageKind = 47
+	BlockchainGetShardOfAddressRequest        MessageKind = 48
+	BlockchainGetShardOfAddressResponse       MessageKind = 49
+	BlockchainIsPayableRequest                MessageKind = 50
+	BlockchainIsPayableResponse               MessageKind = 51
+	BlockchainIsSmartContractRequest          MessageKind = 52
+	BlockchainIsSmartContractResponse         MessageKind = 53
+	BlockchainSaveCompiledCodeRequest         MessageKind = 54
+	BlockchainSaveCompiledCodeResponse        MessageKind = 55
+	BlockchainGetCompiledCodeRequest          MessageKind = 56
+	BlockchainGetCompiledCodeResponse         MessageKind = 57
+	DiagnoseWaitRequest                       MessageKind = 58
+	DiagnoseWaitResponse                      MessageKind = 59
+	VersionRequest                            MessageKind = 60
+	VersionResponse                           MessageKind = 61
+	UndefinedRequestOrResponse                MessageKind = 62
+	BlockchainBatchRequest                    MessageKind = 63
+	BlockchainBatchResponse                   MessageKind = 64
+	HandshakeRequest                          MessageKind = 65
+	HandshakeResponse                         MessageKind = 66
+	Heartbeat                                 MessageKind = 67
+	DiagnoseStatsRequest                      MessageKind = 68
+	DiagnoseStatsResponse                     MessageKind = 69
+	LastKind                                  MessageKind = 70
 )
 
 var messageKindNameByID = map[MessageKind]string{}
@@ -86,61 +99,18 @@ func init() {
 	messageKindNameByID[ContractResponse] = "ContractResponse"
 	messageKindNameByID[GasScheduleChangeRequest] = "GasScheduleChangeRequest"
 	messageKindNameByID[GasScheduleChangeResponse] = "GasScheduleChangeResponse"
-	messageKindNameByID[BlockchainNewAddressRequest] = "BlockchainNewAddressRequest"
-	messageKindNameByID[BlockchainNewAddressResponse] = "BlockchainNewAddressResponse"
-	messageKindNameByID[BlockchainGetStorageDataRequest] = "BlockchainGetStorageDataRequest"
-	messageKindNameByID[BlockchainGetStorageDataResponse] = "BlockchainGetStorageDataResponse"
-	messageKindNameByID[BlockchainGetBlockhashRequest] = "BlockchainGetBlockhashRequest"
-	messageKindNameByID[BlockchainGetBlockhashResponse] = "BlockchainGetBlockhashResponse"
-	messageKindNameByID[BlockchainLastNonceRequest] = "BlockchainLastNonceRequest"
-	messageKindNameByID[BlockchainLastNonceResponse] = "BlockchainLastNonceResponse"
-	messageKindNameByID[BlockchainLastRoundRequest] = "BlockchainLastRoundRequest"
-	messageKindNameByID[BlockchainLastRoundResponse] = "BlockchainLastRoundResponse"
-	messageKindNameByID[BlockchainLastTimeStampRequest] = "BlockchainLastTimeStampRequest"
-	messageKindNameByID[BlockchainLastTimeStampResponse] = "BlockchainLastTimeStampResponse"
-	messageKindNameByID[BlockchainLastRandomSeedRequest] = "BlockchainLastRandomSeedRequest"
-	messageKindNameByID[BlockchainLastRandomSeedResponse] = "BlockchainLastRandomSeedResponse"
-	messageKindNameByID[BlockchainLastEpochRequest] = "BlockchainLastEpochRequest"
-	messageKindNameByID[BlockchainLastEpochResponse] = "BlockchainLastEpochResponse"
-	messageKindNameByID[BlockchainGetStateRootHashRequest] = "BlockchainGetStateRootHashRequest"
-	messageKindNameByID[BlockchainGetStateRootHashResponse] = "BlockchainGetStateRootHashResponse"
-	messageKindNameByID[BlockchainCurrentNonceRequest] = "BlockchainCurrentNonceRequest"
-	messageKindNameByID[BlockchainCurrentNonceResponse] = "BlockchainCurrentNonceResponse"
-	messageKindNameByID[BlockchainCurrentRoundRequest] = "BlockchainCurrentRoundRequest"
-	messageKindNameByID[BlockchainCurrentRoundResponse] = "BlockchainCurrentRoundResponse"
-	messageKindNameByID[BlockchainCurrentTimeStampRequest] = "BlockchainCurrentTimeStampRequest"
-	messageKindNameByID[BlockchainCurrentTimeStampResponse] = "BlockchainCurrentTimeStampResponse"
-	messageKindNameByID[BlockchainCurrentRandomSeedRequest] = "BlockchainCurrentRandomSeedRequest"
-	messageKindNameByID[BlockchainCurrentRandomSeedResponse] = "BlockchainCurrentRandomSeedResponse"
-	messageKindNameByID[BlockchainCurrentEpochRequest] = "BlockchainCurrentEpochRequest"
-	messageKindNameByID[BlockchainCurrentEpochResponse] = "BlockchainCurrentEpochResponse"
-	messageKindNameByID[BlockchainProcessBuiltinFunctionRequest] = "BlockchainProcessBuiltinFunctionRequest"
-	messageKindNameByID[BlockchainProcessBuiltinFunctionResponse] = "BlockchainProcessBuiltinFunctionResponse"
-	messageKindNameByID[BlockchainGetESDTTokenRequest] = "BlockchainGetESDTTokenRequest"
-	messageKindNameByID[BlockchainGetESDTTokenResponse] = "BlockchainGetESDTTokenResponse"
-	messageKindNameByID[BlockchainGetBuiltinFunctionNamesRequest] = "BlockchainGetBuiltinFunctionNamesRequest"
-	messageKindNameByID[BlockchainGetBuiltinFunctionNamesResponse] = "BlockchainGetBuiltinFunctionNamesResponse"
-	messageKindNameByID[BlockchainGetAllStateRequest] = "BlockchainGetAllStateRequest"
-	messageKindNameByID[BlockchainGetAllStateResponse] = "BlockchainGetAllStateResponse"
-	messageKindNameByID[BlockchainGetUserAccountRequest] = "BlockchainGetUserAccountRequest"
-	messageKindNameByID[BlockchainGetUserAccountResponse] = "BlockchainGetUserAccountResponse"
-	messageKindNameByID[BlockchainGetCodeRequest] = "BlockchainGetCodeRequest"
-	messageKindNameByID[BlockchainGetCodeResponse] = "BlockchainGetCodeResponse"
-	messageKindNameByID[BlockchainGetShardOfAddressRequest] = "BlockchainGetShardOfAddressRequest"
-	messageKindNameByID[BlockchainGetShardOfAddressResponse] = "BlockchainGetShardOfAddressResponse"
-	messageKindNameByID[BlockchainIsSmartContractRequest] = "BlockchainIsSmartContractRequest"
-	messageKindNameByID[BlockchainIsSmartContractResponse] = "BlockchainIsSmartContractResponse"
-	messageKindNameByID[BlockchainIsPayableRequest] = "BlockchainIsPayableRequest"
-	messageKindNameByID[BlockchainIsPayableResponse] = "BlockchainIsPayableResponse"
-	messageKindNameByID[BlockchainGetCompiledCodeResponse] = "BlockchainGetCompiledCodeResponse"
-	messageKindNameByID[BlockchainGetCompiledCodeRequest] = "BlockchainGetCompiledCodeRequest"
-	messageKindNameByID[BlockchainSaveCompiledCodeRequest] = "BlockchainSaveCompiledCodeRequest"
-	messageKindNameByID[BlockchainSaveCompiledCodeResponse] = "BlockchainSaveCompiledCodeResponse"
 	messageKindNameByID[DiagnoseWaitRequest] = "DiagnoseWaitRequest"
 	messageKindNameByID[DiagnoseWaitResponse] = "DiagnoseWaitResponse"
 	messageKindNameByID[VersionRequest] = "VersionRequest"
 	messageKindNameByID[VersionResponse] = "VersionResponse"
 	messageKindNameByID[UndefinedRequestOrResponse] = "UndefinedRequestOrResponse"
+	messageKindNameByID[BlockchainBatchRequest] = "BlockchainBatchRequest"
+	messageKindNameByID[BlockchainBatchResponse] = "BlockchainBatchResponse"
+	messageKindNameByID[HandshakeRequest] = "HandshakeRequest"
+	messageKindNameByID[HandshakeResponse] = "HandshakeResponse"
+	messageKindNameByID[Heartbeat] = "Heartbeat"
+	messageKindNameByID[DiagnoseStatsRequest] = "DiagnoseStatsRequest"
+	messageKindNameByID[DiagnoseStatsResponse] = "DiagnoseStatsResponse"
 	messageKindNameByID[LastKind] = "LastKind"
 }
 
@@ -266,7 +236,9 @@ func CreateReplySlots(noopReplier MessageReplier) []MessageReplier {
 // IsHookCall returns whether a message is a hook call
 func IsHookCall(message MessageHandler) bool {
 	kind := message.GetKind()
-	return kind >= BlockchainNewAddressRequest && kind <= BlockchainGetCompiledCodeResponse
+	isSingleHookCall := isBlockchainHookKind(kind)
+	isBatchOfHookCalls := kind == BlockchainBatchRequest || kind == BlockchainBatchResponse
+	return isSingleHookCall || isBatchOfHookCalls
 }
 
 // IsStopRequest returns whether a message is a stop request
@@ -292,5 +264,12 @@ func IsGasScheduleChangeResponse(message MessageHandler) bool {
 // IsDiagnose returns whether a message is a diagnose request
 func IsDiagnose(message MessageHandler) bool {
 	kind := message.GetKind()
-	return kind >= DiagnoseWaitRequest && kind <= DiagnoseWaitResponse
+	isDiagnoseWait := kind >= DiagnoseWaitRequest && kind <= DiagnoseWaitResponse
+	isDiagnoseStats := kind == DiagnoseStatsRequest || kind == DiagnoseStatsResponse
+	return isDiagnoseWait || isDiagnoseStats
+}
+
+// IsHeartbeat returns whether a message is a heartbeat, which is not part of the dialogue
+func IsHeartbeat(message MessageHandler) bool {
+	return message.GetKind() == Heartbeat
 }
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+)
+
+// RawMessage is a message marshalized on its own, so that messages of different kinds can be carried within a batch
+type RawMessage struct {
+	Kind MessageKind
+	Data []byte
+}
+
+// MessageBlockchainBatchRequest carries several hook calls, answered by the Node in a single round trip (from VM)
+type MessageBlockchainBatchRequest struct {
+	Message
+	Requests []*RawMessage
+}
+
+// NewMessageBlockchainBatchRequest creates a request message
+func NewMessageBlockchainBatchRequest(requests []*RawMessage) *MessageBlockchainBatchRequest {
+	message := &MessageBlockchainBatchRequest{}
+	message.Kind = BlockchainBatchRequest
+	message.Requests = requests
+	return message
+}
+
+// MessageBlockchainBatchResponse carries the responses to a batch of hook calls, in the order of the requests (from the Node)
+type MessageBlockchainBatchResponse struct {
+	Message
+	Responses []*RawMessage
+}
+
+// NewMessageBlockchainBatchResponse creates a response message
+func NewMessageBlockchainBatchResponse(responses []*RawMessage, err error) *MessageBlockchainBatchResponse {
+	message := &MessageBlockchainBatchResponse{}
+	message.Kind = BlockchainBatchResponse
+	message.Responses = responses
+	message.SetError(err)
+	return message
+}
+
+// PackMessages marshalizes each message on its own
+func PackMessages(messages []MessageHandler, marshalizer marshaling.Marshalizer) ([]*RawMessage, error) {
+	rawMessages := make([]*RawMessage, 0, len(messages))
+	for _, message := range messages {
+		data, err := marshalizer.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+
+		rawMessages = append(rawMessages, &RawMessage{Kind: message.GetKind(), Data: data})
+	}
+
+	return rawMessages, nil
+}
+
+// UnpackMessages unmarshalizes messages packed by PackMessages
+func UnpackMessages(rawMessages []*RawMessage, marshalizer marshaling.Marshalizer) ([]MessageHandler, error) {
+	messages := make([]MessageHandler, 0, len(rawMessages))
+	for _, rawMessage := range rawMessages {
+		if rawMessage == nil || rawMessage.Kind >= UndefinedRequestOrResponse {
+			return nil, ErrBadBatchOfHookCalls
+		}
+
+		message := CreateMessage(rawMessage.Kind)
+		err := marshalizer.Unmarshal(message, rawMessage.Data)
+		if err != nil {
+			return nil, err
+		}
+		if message.GetKind() != rawMessage.Kind {
+			return nil, ErrBadBatchOfHookCalls
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
@@ -1,8 +1,10 @@
+// Code generated by ipc/codegen from vmcommon.BlockchainHook; DO NOT EDIT.
+
 package common
 
 import (
 	"github.com/multiversx/mx-chain-core-go/data/esdt"
-	"github.com/multiversx/mx-chain-vm-common-go"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
 // MessageBlockchainNewAddressRequest represents a request message
@@ -107,7 +109,6 @@ type MessageBlockchainLastNonceRequest struct {
 func NewMessageBlockchainLastNonceRequest() *MessageBlockchainLastNonceRequest {
 	message := &MessageBlockchainLastNonceRequest{}
 	message.Kind = BlockchainLastNonceRequest
-
 	return message
 }
 
@@ -134,7 +135,6 @@ type MessageBlockchainLastRoundRequest struct {
 func NewMessageBlockchainLastRoundRequest() *MessageBlockchainLastRoundRequest {
 	message := &MessageBlockchainLastRoundRequest{}
 	message.Kind = BlockchainLastRoundRequest
-
 	return message
 }
 
@@ -161,7 +161,6 @@ type MessageBlockchainLastTimeStampRequest struct {
 func NewMessageBlockchainLastTimeStampRequest() *MessageBlockchainLastTimeStampRequest {
 	message := &MessageBlockchainLastTimeStampRequest{}
 	message.Kind = BlockchainLastTimeStampRequest
-
 	return message
 }
 
@@ -188,7 +187,6 @@ type MessageBlockchainLastRandomSeedRequest struct {
 func NewMessageBlockchainLastRandomSeedRequest() *MessageBlockchainLastRandomSeedRequest {
 	message := &MessageBlockchainLastRandomSeedRequest{}
 	message.Kind = BlockchainLastRandomSeedRequest
-
 	return message
 }
 
@@ -215,7 +213,6 @@ type MessageBlockchainLastEpochRequest struct {
 func NewMessageBlockchainLastEpochRequest() *MessageBlockchainLastEpochRequest {
 	message := &MessageBlockchainLastEpochRequest{}
 	message.Kind = BlockchainLastEpochRequest
-
 	return message
 }
 
@@ -242,7 +239,6 @@ type MessageBlockchainGetStateRootHashRequest struct {
 func NewMessageBlockchainGetStateRootHashRequest() *MessageBlockchainGetStateRootHashRequest {
 	message := &MessageBlockchainGetStateRootHashRequest{}
 	message.Kind = BlockchainGetStateRootHashRequest
-
 	return message
 }
 
@@ -269,7 +265,6 @@ type MessageBlockchainCurrentNonceRequest struct {
 func NewMessageBlockchainCurrentNonceRequest() *MessageBlockchainCurrentNonceRequest {
 	message := &MessageBlockchainCurrentNonceRequest{}
 	message.Kind = BlockchainCurrentNonceRequest
-
 	return message
 }
 
@@ -296,7 +291,6 @@ type MessageBlockchainCurrentRoundRequest struct {
 func NewMessageBlockchainCurrentRoundRequest() *MessageBlockchainCurrentRoundRequest {
 	message := &MessageBlockchainCurrentRoundRequest{}
 	message.Kind = BlockchainCurrentRoundRequest
-
 	return message
 }
 
@@ -323,7 +317,6 @@ type MessageBlockchainCurrentTimeStampRequest struct {
 func NewMessageBlockchainCurrentTimeStampRequest() *MessageBlockchainCurrentTimeStampRequest {
 	message := &MessageBlockchainCurrentTimeStampRequest{}
 	message.Kind = BlockchainCurrentTimeStampRequest
-
 	return message
 }
 
@@ -350,7 +343,6 @@ type MessageBlockchainCurrentRandomSeedRequest struct {
 func NewMessageBlockchainCurrentRandomSeedRequest() *MessageBlockchainCurrentRandomSeedRequest {
 	message := &MessageBlockchainCurrentRandomSeedRequest{}
 	message.Kind = BlockchainCurrentRandomSeedRequest
-
 	return message
 }
 
@@ -377,7 +369,6 @@ type MessageBlockchainCurrentEpochRequest struct {
 func NewMessageBlockchainCurrentEpochRequest() *MessageBlockchainCurrentEpochRequest {
 	message := &MessageBlockchainCurrentEpochRequest{}
 	message.Kind = BlockchainCurrentEpochRequest
-
 	return message
 }
 
@@ -402,11 +393,10 @@ type MessageBlockchainProcessBuiltinFunctionRequest struct {
 }
 
 // NewMessageBlockchainProcessBuiltinFunctionRequest creates a request message
-func NewMessageBlockchainProcessBuiltinFunctionRequest(callInput vmcommon.ContractCallInput) *MessageBlockchainProcessBuiltinFunctionRequest {
+func NewMessageBlockchainProcessBuiltinFunctionRequest(callInput *vmcommon.ContractCallInput) *MessageBlockchainProcessBuiltinFunctionRequest {
 	message := &MessageBlockchainProcessBuiltinFunctionRequest{}
 	message.Kind = BlockchainProcessBuiltinFunctionRequest
-	message.CallInput = callInput
-
+	message.CallInput = *callInput
 	return message
 }
 
@@ -425,40 +415,6 @@ func NewMessageBlockchainProcessBuiltinFunctionResponse(vmOutput *vmcommon.VMOut
 	return message
 }
 
-// MessageBlockchainGetESDTTokenRequest represents a request message
-type MessageBlockchainGetESDTTokenRequest struct {
-	Message
-	Address []byte
-	TokenID []byte
-	Nonce   uint64
-}
-
-// NewMessageBlockchainGetESDTTokenRequest creates a request message
-func NewMessageBlockchainGetESDTTokenRequest(address []byte, tokenID []byte, nonce uint64) *MessageBlockchainGetESDTTokenRequest {
-	message := &MessageBlockchainGetESDTTokenRequest{}
-	message.Kind = BlockchainGetESDTTokenRequest
-	message.Address = address
-	message.TokenID = tokenID
-	message.Nonce = nonce
-
-	return message
-}
-
-// MessageBlockchainGetESDTTokenResponse represents a response message
-type MessageBlockchainGetESDTTokenResponse struct {
-	Message
-	ESDTData *esdt.ESDigitalToken
-}
-
-// NewMessageBlockchainGetESDTTokenResponse creates a response message
-func NewMessageBlockchainGetESDTTokenResponse(esdtData *esdt.ESDigitalToken, err error) *MessageBlockchainGetESDTTokenResponse {
-	message := &MessageBlockchainGetESDTTokenResponse{}
-	message.Kind = BlockchainGetESDTTokenResponse
-	message.ESDTData = esdtData
-	message.SetError(err)
-	return message
-}
-
 // MessageBlockchainGetBuiltinFunctionNamesRequest represents a request message
 type MessageBlockchainGetBuiltinFunctionNamesRequest struct {
 	Message
@@ -468,7 +424,6 @@ type MessageBlockchainGetBuiltinFunctionNamesRequest struct {
 func NewMessageBlockchainGetBuiltinFunctionNamesRequest() *MessageBlockchainGetBuiltinFunctionNamesRequest {
 	message := &MessageBlockchainGetBuiltinFunctionNamesRequest{}
 	message.Kind = BlockchainGetBuiltinFunctionNamesRequest
-
 	return message
 }
 
@@ -483,7 +438,6 @@ func NewMessageBlockchainGetBuiltinFunctionNamesResponse(functionNames vmcommon.
 	message := &MessageBlockchainGetBuiltinFunctionNamesResponse{}
 	message.Kind = BlockchainGetBuiltinFunctionNamesResponse
 	message.FunctionNames = functionNames
-
 	return message
 }
 
@@ -508,10 +462,10 @@ type MessageBlockchainGetAllStateResponse struct {
 }
 
 // NewMessageBlockchainGetAllStateResponse creates a response message
-func NewMessageBlockchainGetAllStateResponse(state map[string][]byte, err error) *MessageBlockchainGetAllStateResponse {
+func NewMessageBlockchainGetAllStateResponse(allState map[string][]byte, err error) *MessageBlockchainGetAllStateResponse {
 	message := &MessageBlockchainGetAllStateResponse{}
 	message.Kind = BlockchainGetAllStateResponse
-	message.SerializableAllState = NewSerializableMapStringBytes(state)
+	message.SerializableAllState = NewSerializableMapStringBytes(allState)
 	message.SetError(err)
 	return message
 }
@@ -537,25 +491,25 @@ type MessageBlockchainGetUserAccountResponse struct {
 }
 
 // NewMessageBlockchainGetUserAccountResponse creates a response message
-func NewMessageBlockchainGetUserAccountResponse(account *Account, err error) *MessageBlockchainGetUserAccountResponse {
+func NewMessageBlockchainGetUserAccountResponse(account vmcommon.UserAccountHandler, err error) *MessageBlockchainGetUserAccountResponse {
 	message := &MessageBlockchainGetUserAccountResponse{}
 	message.Kind = BlockchainGetUserAccountResponse
-	message.Account = account
+	message.Account = NewSerializableAccount(account)
 	message.SetError(err)
 	return message
 }
 
-// NewMessageBlockchainGetCodeRequest represents a request message
+// MessageBlockchainGetCodeRequest represents a request message
 type MessageBlockchainGetCodeRequest struct {
 	Message
 	Account *Account
 }
 
 // NewMessageBlockchainGetCodeRequest creates a request message
-func NewMessageBlockchainGetCodeRequest(account *Account) *MessageBlockchainGetCodeRequest {
+func NewMessageBlockchainGetCodeRequest(account vmcommon.UserAccountHandler) *MessageBlockchainGetCodeRequest {
 	message := &MessageBlockchainGetCodeRequest{}
 	message.Kind = BlockchainGetCodeRequest
-	message.Account = account
+	message.Account = NewSerializableAccount(account)
 	return message
 }
 
@@ -708,10 +662,381 @@ type MessageBlockchainGetCompiledCodeResponse struct {
 }
 
 // NewMessageBlockchainGetCompiledCodeResponse creates a response message
-func NewMessageBlockchainGetCompiledCodeResponse(result bool, code []byte) *MessageBlockchainGetCompiledCodeResponse {
+func NewMessageBlockchainGetCompiledCodeResponse(found bool, code []byte) *MessageBlockchainGetCompiledCodeResponse {
 	message := &MessageBlockchainGetCompiledCodeResponse{}
 	message.Kind = BlockchainGetCompiledCodeResponse
-	message.Found = result
+	message.Found = found
 	message.Code = code
 	return message
 }
+
+// MessageBlockchainGetESDTTokenRequest represents a request message
+type MessageBlockchainGetESDTTokenRequest struct {
+	Message
+	Address []byte
+	TokenID []byte
+	Nonce   uint64
+}
+
+// NewMessageBlockchainGetESDTTokenRequest creates a request message
+func NewMessageBlockchainGetESDTTokenRequest(address []byte, tokenID []byte, nonce uint64) *MessageBlockchainGetESDTTokenRequest {
+	message := &MessageBlockchainGetESDTTokenRequest{}
+	message.Kind = BlockchainGetESDTTokenRequest
+	message.Address = address
+	message.TokenID = tokenID
+	message.Nonce = nonce
+	return message
+}
+
+// MessageBlockchainGetESDTTokenResponse represents a response message
+type MessageBlockchainGetESDTTokenResponse struct {
+	Message
+	ESDTData *esdt.ESDigitalToken
+}
+
+// NewMessageBlockchainGetESDTTokenResponse creates a response message
+func NewMessageBlockchainGetESDTTokenResponse(esdtData *esdt.ESDigitalToken, err error) *MessageBlockchainGetESDTTokenResponse {
+	message := &MessageBlockchainGetESDTTokenResponse{}
+	message.Kind = BlockchainGetESDTTokenResponse
+	message.ESDTData = esdtData
+	message.SetError(err)
+	return message
+}
+
+func init() {
+	messageKindNameByID[BlockchainNewAddressRequest] = "BlockchainNewAddressRequest"
+	messageKindNameByID[BlockchainNewAddressResponse] = "BlockchainNewAddressResponse"
+	messageKindNameByID[BlockchainGetStorageDataRequest] = "BlockchainGetStorageDataRequest"
+	messageKindNameByID[BlockchainGetStorageDataResponse] = "BlockchainGetStorageDataResponse"
+	messageKindNameByID[BlockchainGetBlockhashRequest] = "BlockchainGetBlockhashRequest"
+	messageKindNameByID[BlockchainGetBlockhashResponse] = "BlockchainGetBlockhashResponse"
+	messageKindNameByID[BlockchainLastNonceRequest] = "BlockchainLastNonceRequest"
+	messageKindNameByID[BlockchainLastNonceResponse] = "BlockchainLastNonceResponse"
+	messageKindNameByID[BlockchainLastRoundRequest] = "BlockchainLastRoundRequest"
+	messageKindNameByID[BlockchainLastRoundResponse] = "BlockchainLastRoundResponse"
+	messageKindNameByID[BlockchainLastTimeStampRequest] = "BlockchainLastTimeStampRequest"
+	messageKindNameByID[BlockchainLastTimeStampResponse] = "BlockchainLastTimeStampResponse"
+	messageKindNameByID[BlockchainLastRandomSeedRequest] = "BlockchainLastRandomSeedRequest"
+	messageKindNameByID[BlockchainLastRandomSeedResponse] = "BlockchainLastRandomSeedResponse"
+	messageKindNameByID[BlockchainLastEpochRequest] = "BlockchainLastEpochRequest"
+	messageKindNameByID[BlockchainLastEpochResponse] = "BlockchainLastEpochResponse"
+	messageKindNameByID[BlockchainGetStateRootHashRequest] = "BlockchainGetStateRootHashRequest"
+	messageKindNameByID[BlockchainGetStateRootHashResponse] = "BlockchainGetStateRootHashResponse"
+	messageKindNameByID[BlockchainCurrentNonceRequest] = "BlockchainCurrentNonceRequest"
+	messageKindNameByID[BlockchainCurrentNonceResponse] = "BlockchainCurrentNonceResponse"
+	messageKindNameByID[BlockchainCurrentRoundRequest] = "BlockchainCurrentRoundRequest"
+	messageKindNameByID[BlockchainCurrentRoundResponse] = "BlockchainCurrentRoundResponse"
+	messageKindNameByID[BlockchainCurrentTimeStampRequest] = "BlockchainCurrentTimeStampRequest"
+	messageKindNameByID[BlockchainCurrentTimeStampResponse] = "BlockchainCurrentTimeStampResponse"
+	messageKindNameByID[BlockchainCurrentRandomSeedRequest] = "BlockchainCurrentRandomSeedRequest"
+	messageKindNameByID[BlockchainCurrentRandomSeedResponse] = "BlockchainCurrentRandomSeedResponse"
+	messageKindNameByID[BlockchainCurrentEpochRequest] = "BlockchainCurrentEpochRequest"
+	messageKindNameByID[BlockchainCurrentEpochResponse] = "BlockchainCurrentEpochResponse"
+	messageKindNameByID[BlockchainProcessBuiltinFunctionRequest] = "BlockchainProcessBuiltinFunctionRequest"
+	messageKindNameByID[BlockchainProcessBuiltinFunctionResponse] = "BlockchainProcessBuiltinFunctionResponse"
+	messageKindNameByID[BlockchainGetBuiltinFunctionNamesRequest] = "BlockchainGetBuiltinFunctionNamesRequest"
+	messageKindNameByID[BlockchainGetBuiltinFunctionNamesResponse] = "BlockchainGetBuiltinFunctionNamesResponse"
+	messageKindNameByID[BlockchainGetAllStateRequest] = "BlockchainGetAllStateRequest"
+	messageKindNameByID[BlockchainGetAllStateResponse] = "BlockchainGetAllStateResponse"
+	messageKindNameByID[BlockchainGetUserAccountRequest] = "BlockchainGetUserAccountRequest"
+	messageKindNameByID[BlockchainGetUserAccountResponse] = "BlockchainGetUserAccountResponse"
+	messageKindNameByID[BlockchainGetCodeRequest] = "BlockchainGetCodeRequest"
+	messageKindNameByID[BlockchainGetCodeResponse] = "BlockchainGetCodeResponse"
+	messageKindNameByID[BlockchainGetShardOfAddressRequest] = "BlockchainGetShardOfAddressRequest"
+	messageKindNameByID[BlockchainGetShardOfAddressResponse] = "BlockchainGetShardOfAddressResponse"
+	messageKindNameByID[BlockchainIsSmartContractRequest] = "BlockchainIsSmartContractRequest"
+	messageKindNameByID[BlockchainIsSmartContractResponse] = "BlockchainIsSmartContractResponse"
+	messageKindNameByID[BlockchainIsPayableRequest] = "BlockchainIsPayableRequest"
+	messageKindNameByID[BlockchainIsPayableResponse] = "BlockchainIsPayableResponse"
+	messageKindNameByID[BlockchainSaveCompiledCodeRequest] = "BlockchainSaveCompiledCodeRequest"
+	messageKindNameByID[BlockchainSaveCompiledCodeResponse] = "BlockchainSaveCompiledCodeResponse"
+	messageKindNameByID[BlockchainGetCompiledCodeRequest] = "BlockchainGetCompiledCodeRequest"
+	messageKindNameByID[BlockchainGetCompiledCodeResponse] = "BlockchainGetCompiledCodeResponse"
+	messageKindNameByID[BlockchainGetESDTTokenRequest] = "BlockchainGetESDTTokenRequest"
+	messageKindNameByID[BlockchainGetESDTTokenResponse] = "BlockchainGetESDTTokenResponse"
+
+	messageCreators[BlockchainNewAddressRequest] = createMessageBlockchainNewAddressRequest
+	messageCreators[BlockchainNewAddressResponse] = createMessageBlockchainNewAddressResponse
+	messageCreators[BlockchainGetStorageDataRequest] = createMessageBlockchainGetStorageDataRequest
+	messageCreators[BlockchainGetStorageDataResponse] = createMessageBlockchainGetStorageDataResponse
+	messageCreators[BlockchainGetBlockhashRequest] = createMessageBlockchainGetBlockhashRequest
+	messageCreators[BlockchainGetBlockhashResponse] = createMessageBlockchainGetBlockhashResponse
+	messageCreators[BlockchainLastNonceRequest] = createMessageBlockchainLastNonceRequest
+	messageCreators[BlockchainLastNonceResponse] = createMessageBlockchainLastNonceResponse
+	messageCreators[BlockchainLastRoundRequest] = createMessageBlockchainLastRoundRequest
+	messageCreators[BlockchainLastRoundResponse] = createMessageBlockchainLastRoundResponse
+	messageCreators[BlockchainLastTimeStampRequest] = createMessageBlockchainLastTimeStampRequest
+	messageCreators[BlockchainLastTimeStampResponse] = createMessageBlockchainLastTimeStampResponse
+	messageCreators[BlockchainLastRandomSeedRequest] = createMessageBlockchainLastRandomSeedRequest
+	messageCreators[BlockchainLastRandomSeedResponse] = createMessageBlockchainLastRandomSeedResponse
+	messageCreators[BlockchainLastEpochRequest] = createMessageBlockchainLastEpochRequest
+	messageCreators[BlockchainLastEpochResponse] = createMessageBlockchainLastEpochResponse
+	messageCreators[BlockchainGetStateRootHashRequest] = createMessageBlockchainGetStateRootHashRequest
+	messageCreators[BlockchainGetStateRootHashResponse] = createMessageBlockchainGetStateRootHashResponse
+	messageCreators[BlockchainCurrentNonceRequest] = createMessageBlockchainCurrentNonceRequest
+	messageCreators[BlockchainCurrentNonceResponse] = createMessageBlockchainCurrentNonceResponse
+	messageCreators[BlockchainCurrentRoundRequest] = createMessageBlockchainCurrentRoundRequest
+	messageCreators[BlockchainCurrentRoundResponse] = createMessageBlockchainCurrentRoundResponse
+	messageCreators[BlockchainCurrentTimeStampRequest] = createMessageBlockchainCurrentTimeStampRequest
+	messageCreators[BlockchainCurrentTimeStampResponse] = createMessageBlockchainCurrentTimeStampResponse
+	messageCreators[BlockchainCurrentRandomSeedRequest] = createMessageBlockchainCurrentRandomSeedRequest
+	messageCreators[BlockchainCurrentRandomSeedResponse] = createMessageBlockchainCurrentRandomSeedResponse
+	messageCreators[BlockchainCurrentEpochRequest] = createMessageBlockchainCurrentEpochRequest
+	messageCreators[BlockchainCurrentEpochResponse] = createMessageBlockchainCurrentEpochResponse
+	messageCreators[BlockchainProcessBuiltinFunctionRequest] = createMessageBlockchainProcessBuiltinFunctionRequest
+	messageCreators[BlockchainProcessBuiltinFunctionResponse] = createMessageBlockchainProcessBuiltinFunctionResponse
+	messageCreators[BlockchainGetBuiltinFunctionNamesRequest] = createMessageBlockchainGetBuiltinFunctionNamesRequest
+	messageCreators[BlockchainGetBuiltinFunctionNamesResponse] = createMessageBlockchainGetBuiltinFunctionNamesResponse
+	messageCreators[BlockchainGetAllStateRequest] = createMessageBlockchainGetAllStateRequest
+	messageCreators[BlockchainGetAllStateResponse] = createMessageBlockchainGetAllStateResponse
+	messageCreators[BlockchainGetUserAccountRequest] = createMessageBlockchainGetUserAccountRequest
+	messageCreators[BlockchainGetUserAccountResponse] = createMessageBlockchainGetUserAccountResponse
+	messageCreators[BlockchainGetCodeRequest] = createMessageBlockchainGetCodeRequest
+	messageCreators[BlockchainGetCodeResponse] = createMessageBlockchainGetCodeResponse
+	messageCreators[BlockchainGetShardOfAddressRequest] = createMessageBlockchainGetShardOfAddressRequest
+	messageCreators[BlockchainGetShardOfAddressResponse] = createMessageBlockchainGetShardOfAddressResponse
+	messageCreators[BlockchainIsSmartContractRequest] = createMessageBlockchainIsSmartContractRequest
+	messageCreators[BlockchainIsSmartContractResponse] = createMessageBlockchainIsSmartContractResponse
+	messageCreators[BlockchainIsPayableRequest] = createMessageBlockchainIsPayableRequest
+	messageCreators[BlockchainIsPayableResponse] = createMessageBlockchainIsPayableResponse
+	messageCreators[BlockchainSaveCompiledCodeRequest] = createMessageBlockchainSaveCompiledCodeRequest
+	messageCreators[BlockchainSaveCompiledCodeResponse] = createMessageBlockchainSaveCompiledCodeResponse
+	messageCreators[BlockchainGetCompiledCodeRequest] = createMessageBlockchainGetCompiledCodeRequest
+	messageCreators[BlockchainGetCompiledCodeResponse] = createMessageBlockchainGetCompiledCodeResponse
+	messageCreators[BlockchainGetESDTTokenRequest] = createMessageBlockchainGetESDTTokenRequest
+	messageCreators[BlockchainGetESDTTokenResponse] = createMessageBlockchainGetESDTTokenResponse
+}
+
+func createMessageBlockchainNewAddressRequest() MessageHandler {
+	return &MessageBlockchainNewAddressRequest{}
+}
+
+func createMessageBlockchainNewAddressResponse() MessageHandler {
+	return &MessageBlockchainNewAddressResponse{}
+}
+
+func createMessageBlockchainGetStorageDataRequest() MessageHandler {
+	return &MessageBlockchainGetStorageDataRequest{}
+}
+
+func createMessageBlockchainGetStorageDataResponse() MessageHandler {
+	return &MessageBlockchainGetStorageDataResponse{}
+}
+
+func createMessageBlockchainGetBlockhashRequest() MessageHandler {
+	return &MessageBlockchainGetBlockhashRequest{}
+}
+
+func createMessageBlockchainGetBlockhashResponse() MessageHandler {
+	return &MessageBlockchainGetBlockhashResponse{}
+}
+
+func createMessageBlockchainLastNonceRequest() MessageHandler {
+	return &MessageBlockchainLastNonceRequest{}
+}
+
+func createMessageBlockchainLastNonceResponse() MessageHandler {
+	return &MessageBlockchainLastNonceResponse{}
+}
+
+func createMessageBlockchainLastRoundRequest() MessageHandler {
+	return &MessageBlockchainLastRoundRequest{}
+}
+
+func createMessageBlockchainLastRoundResponse() MessageHandler {
+	return &MessageBlockchainLastRoundResponse{}
+}
+
+func createMessageBlockchainLastTimeStampRequest() MessageHandler {
+	return &MessageBlockchainLastTimeStampRequest{}
+}
+
+func createMessageBlockchainLastTimeStampResponse() MessageHandler {
+	return &MessageBlockchainLastTimeStampResponse{}
+}
+
+func createMessageBlockchainLastRandomSeedRequest() MessageHandler {
+	return &MessageBlockchainLastRandomSeedRequest{}
+}
+
+func createMessageBlockchainLastRandomSeedResponse() MessageHandler {
+	return &MessageBlockchainLastRandomSeedResponse{}
+}
+
+func createMessageBlockchainLastEpochRequest() MessageHandler {
+	return &MessageBlockchainLastEpochRequest{}
+}
+
+func createMessageBlockchainLastEpochResponse() MessageHandler {
+	return &MessageBlockchainLastEpochResponse{}
+}
+
+func createMessageBlockchainGetStateRootHashRequest() MessageHandler {
+	return &MessageBlockchainGetStateRootHashRequest{}
+}
+
+func createMessageBlockchainGetStateRootHashResponse() MessageHandler {
+	return &MessageBlockchainGetStateRootHashResponse{}
+}
+
+func createMessageBlockchainCurrentNonceRequest() MessageHandler {
+	return &MessageBlockchainCurrentNonceRequest{}
+}
+
+func createMessageBlockchainCurrentNonceResponse() MessageHandler {
+	return &MessageBlockchainCurrentNonceResponse{}
+}
+
+func createMessageBlockchainCurrentRoundRequest() MessageHandler {
+	return &MessageBlockchainCurrentRoundRequest{}
+}
+
+func createMessageBlockchainCurrentRoundResponse() MessageHandler {
+	return &MessageBlockchainCurrentRoundResponse{}
+}
+
+func createMessageBlockchainCurrentTimeStampRequest() MessageHandler {
+	return &MessageBlockchainCurrentTimeStampRequest{}
+}
+
+func createMessageBlockchainCurrentTimeStampResponse() MessageHandler {
+	return &MessageBlockchainCurrentTimeStampResponse{}
+}
+
+func createMessageBlockchainCurrentRandomSeedRequest() MessageHandler {
+	return &MessageBlockchainCurrentRandomSeedRequest{}
+}
+
+func createMessageBlockchainCurrentRandomSeedResponse() MessageHandler {
+	return &MessageBlockchainCurrentRandomSeedResponse{}
+}
+
+func createMessageBlockchainCurrentEpochRequest() MessageHandler {
+	return &MessageBlockchainCurrentEpochRequest{}
+}
+
+func createMessageBlockchainCurrentEpochResponse() MessageHandler {
+	return &MessageBlockchainCurrentEpochResponse{}
+}
+
+func createMessageBlockchainProcessBuiltinFunctionRequest() MessageHandler {
+	return &MessageBlockchainProcessBuiltinFunctionRequest{}
+}
+
+func createMessageBlockchainProcessBuiltinFunctionResponse() MessageHandler {
+	return &MessageBlockchainProcessBuiltinFunctionResponse{}
+}
+
+func createMessageBlockchainGetBuiltinFunctionNamesRequest() MessageHandler {
+	return &MessageBlockchainGetBuiltinFunctionNamesRequest{}
+}
+
+func createMessageBlockchainGetBuiltinFunctionNamesResponse() MessageHandler {
+	return &MessageBlockchainGetBuiltinFunctionNamesResponse{}
+}
+
+func createMessageBlockchainGetAllStateRequest() MessageHandler {
+	return &MessageBlockchainGetAllStateRequest{}
+}
+
+func createMessageBlockchainGetAllStateResponse() MessageHandler {
+	return &MessageBlockchainGetAllStateResponse{}
+}
+
+func createMessageBlockchainGetUserAccountRequest() MessageHandler {
+	return &MessageBlockchainGetUserAccountRequest{}
+}
+
+func createMessageBlockchainGetUserAccountResponse() MessageHandler {
+	return &MessageBlockchainGetUserAccountResponse{}
+}
+
+func createMessageBlockchainGetCodeRequest() MessageHandler {
+	return &MessageBlockchainGetCodeRequest{}
+}
+
+func createMessageBlockchainGetCodeResponse() MessageHandler {
+	return &MessageBlockchainGetCodeResponse{}
+}
+
+func createMessageBlockchainGetShardOfAddressRequest() MessageHandler {
+	return &MessageBlockchainGetShardOfAddressRequest{}
+}
+
+func createMessageBlockchainGetShardOfAddressResponse() MessageHandler {
+	return &MessageBlockchainGetShardOfAddressResponse{}
+}
+
+func createMessageBlockchainIsSmartContractRequest() MessageHandler {
+	return &MessageBlockchainIsSmartContractRequest{}
+}
+
+func createMessageBlockchainIsSmartContractResponse() MessageHandler {
+	return &MessageBlockchainIsSmartContractResponse{}
+}
+
+func createMessageBlockchainIsPayableRequest() MessageHandler {
+	return &MessageBlockchainIsPayableRequest{}
+}
+
+func createMessageBlockchainIsPayableResponse() MessageHandler {
+	return &MessageBlockchainIsPayableResponse{}
+}
+
+func createMessageBlockchainSaveCompiledCodeRequest() MessageHandler {
+	return &MessageBlockchainSaveCompiledCodeRequest{}
+}
+
+func createMessageBlockchainSaveCompiledCodeResponse() MessageHandler {
+	return &MessageBlockchainSaveCompiledCodeResponse{}
+}
+
+func createMessageBlockchainGetCompiledCodeRequest() MessageHandler {
+	return &MessageBlockchainGetCompiledCodeRequest{}
+}
+
+func createMessageBlockchainGetCompiledCodeResponse() MessageHandler {
+	return &MessageBlockchainGetCompiledCodeResponse{}
+}
+
+func createMessageBlockchainGetESDTTokenRequest() MessageHandler {
+	return &MessageBlockchainGetESDTTokenRequest{}
+}
+
+func createMessageBlockchainGetESDTTokenResponse() MessageHandler {
+	return &MessageBlockchainGetESDTTokenResponse{}
+}
+
+// isBlockchainHookKind returns whether the kind is the request or the response of a single hook call
+func isBlockchainHookKind(kind MessageKind) bool {
+	switch kind {
+	case BlockchainNewAddressRequest, BlockchainNewAddressResponse,
+		BlockchainGetStorageDataRequest, BlockchainGetStorageDataResponse,
+		BlockchainGetBlockhashRequest, BlockchainGetBlockhashResponse,
+		BlockchainLastNonceRequest, BlockchainLastNonceResponse,
+		BlockchainLastRoundRequest, BlockchainLastRoundResponse,
+		BlockchainLastTimeStampRequest, BlockchainLastTimeStampResponse,
+		BlockchainLastRandomSeedRequest, BlockchainLastRandomSeedResponse,
+		BlockchainLastEpochRequest, BlockchainLastEpochResponse,
+		BlockchainGetStateRootHashRequest, BlockchainGetStateRootHashResponse,
+		BlockchainCurrentNonceRequest, BlockchainCurrentNonceResponse,
+		BlockchainCurrentRoundRequest, BlockchainCurrentRoundResponse,
+		BlockchainCurrentTimeStampRequest, BlockchainCurrentTimeStampResponse,
+		BlockchainCurrentRandomSeedRequest, BlockchainCurrentRandomSeedResponse,
+		BlockchainCurrentEpochRequest, BlockchainCurrentEpochResponse,
+		BlockchainProcessBuiltinFunctionRequest, BlockchainProcessBuiltinFunctionResponse,
+		BlockchainGetBuiltinFunctionNamesRequest, BlockchainGetBuiltinFunctionNamesResponse,
+		BlockchainGetAllStateRequest, BlockchainGetAllStateResponse,
+		BlockchainGetUserAccountRequest, BlockchainGetUserAccountResponse,
+		BlockchainGetCodeRequest, BlockchainGetCodeResponse,
+		BlockchainGetShardOfAddressRequest, BlockchainGetShardOfAddressResponse,
+		BlockchainIsSmartContractRequest, BlockchainIsSmartContractResponse,
+		BlockchainIsPayableRequest, BlockchainIsPayableResponse,
+		BlockchainSaveCompiledCodeRequest, BlockchainSaveCompiledCodeResponse,
+		BlockchainGetCompiledCodeRequest, BlockchainGetCompiledCodeResponse,
+		BlockchainGetESDTTokenRequest, BlockchainGetESDTTokenResponse:
+		return true
+	}
+
+	return false
+}
@@ -22,6 +22,16 @@ func NewMessageContractDeployRequest(input *vmcommon.ContractCreateInput) *Messa
 type MessageContractCallRequest struct {
 	Message
 	CallInput *vmcommon.ContractCallInput
+	// Prefetch is optional, nil unless the Node is configured to ship prefetch hints
+	Prefetch *PrefetchHint
+}
+
+// PrefetchHint is the data shipped by the Node along with a contract call, so that VM does not have to fetch it through hook calls:
+// the account of the contract (including the owner), its code, and the storage entries it reads most often
+type PrefetchHint struct {
+	Account *Account
+	Code    []byte
+	Storage *SerializableMapStringBytes
 }
 
 // NewMessageContractCallRequest creates a MessageContractCallRequest
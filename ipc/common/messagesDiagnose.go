@@ -25,3 +25,42 @@ func NewMessageDiagnoseWaitResponse() *MessageDiagnoseWaitResponse {
 	message.Kind = DiagnoseWaitResponse
 	return message
 }
+
+// MessageDiagnoseStatsRequest asks VM for its counters (from Node)
+type MessageDiagnoseStatsRequest struct {
+	Message
+}
+
+// NewMessageDiagnoseStatsRequest creates a message
+func NewMessageDiagnoseStatsRequest() *MessageDiagnoseStatsRequest {
+	message := &MessageDiagnoseStatsRequest{}
+	message.Kind = DiagnoseStatsRequest
+	return message
+}
+
+// MessageDiagnoseStatsResponse holds the counters of VM (from VM)
+type MessageDiagnoseStatsResponse struct {
+	Message
+	Stats *Stats
+}
+
+// NewMessageDiagnoseStatsResponse creates a message
+func NewMessageDiagnoseStatsResponse(stats *Stats) *MessageDiagnoseStatsResponse {
+	message := &MessageDiagnoseStatsResponse{}
+	message.Kind = DiagnoseStatsResponse
+	message.Stats = stats
+	return message
+}
+
+// MessageHeartbeat is sent periodically by VM while it executes a request, to tell the Node it is alive (from VM).
+// The heartbeats are not part of the dialogue: they carry no dialogue nonce and they are not answered.
+type MessageHeartbeat struct {
+	Message
+}
+
+// NewMessageHeartbeat creates a message
+func NewMessageHeartbeat() *MessageHeartbeat {
+	message := &MessageHeartbeat{}
+	message.Kind = Heartbeat
+	return message
+}
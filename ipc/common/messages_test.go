@@ -1,10 +1,15 @@
 package common
 
 import (
+	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
 	"github.com/stretchr/testify/require"
 )
@@ -42,6 +47,27 @@ func TestMessageContractResponse_IsConsistentlySerializable(t *testing.T) {
 	requireSerializationConsistency(t, message, &MessageContractResponse{})
 }
 
+func TestMessageContractResponse_SerializationIsStable(t *testing.T) {
+	vmOutput := &vmcommon.VMOutput{OutputAccounts: make(map[string]*vmcommon.OutputAccount)}
+	for _, address := range []string{"alice", "bob", "carol", "dave"} {
+		account := &vmcommon.OutputAccount{Address: []byte(address), StorageUpdates: make(map[string]*vmcommon.StorageUpdate)}
+		for _, key := range []string{"a", "b", "c", "d"} {
+			account.StorageUpdates[key] = &vmcommon.StorageUpdate{Offset: []byte(key), Data: []byte(address)}
+		}
+		vmOutput.OutputAccounts[address] = account
+	}
+
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	expected, err := marshalizer.Marshal(NewMessageContractResponse(vmOutput, nil))
+	require.Nil(t, err)
+
+	for i := 0; i < 10; i++ {
+		serialized, err := marshalizer.Marshal(NewMessageContractResponse(vmOutput, nil))
+		require.Nil(t, err)
+		require.Equal(t, expected, serialized)
+	}
+}
+
 func TestMessageContractResponse_CanWrapNilVMOutput(t *testing.T) {
 	message := NewMessageContractResponse(nil, nil)
 	expectedEmptyVMOutput := vmcommon.VMOutput{OutputAccounts: make(map[string]*vmcommon.OutputAccount)}
@@ -70,6 +96,128 @@ func TestMessageBlockchainGetAllStateResponse_IsConsistentlySerializable(t *test
 	requireSerializationConsistency(t, message, &MessageBlockchainGetAllStateResponse{})
 }
 
+func TestMessages_AllKindsAreSerializableWithBinaryMarshalizer(t *testing.T) {
+	marshalizer := marshaling.CreateMarshalizer(marshaling.Binary)
+
+	for kind := FirstKind; kind < LastKind; kind++ {
+		message := CreateMessage(kind)
+		message.SetNonce(uint32(kind))
+		message.SetError(fmt.Errorf("error of %s", message.GetKindName()))
+
+		serialized, err := marshalizer.Marshal(message)
+		require.Nil(t, err, message.GetKindName())
+
+		intoMessage := CreateMessage(kind)
+		err = marshalizer.Unmarshal(intoMessage, serialized)
+		require.Nil(t, err, message.GetKindName())
+		require.Equal(t, message, intoMessage)
+	}
+}
+
+func TestMessages_AreConsistentlySerializableWithBinaryMarshalizer(t *testing.T) {
+	callInput := vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("caller"),
+			Arguments:   [][]byte{{1}, {}},
+			CallValue:   big.NewInt(100),
+			GasProvided: 1000,
+			ESDTTransfers: []*vmcommon.ESDTTransfer{
+				{ESDTValue: big.NewInt(5), ESDTTokenName: []byte("TOKEN-abcdef")},
+			},
+		},
+		RecipientAddr: []byte("recipient"),
+		Function:      "increment",
+	}
+	vmOutput := &vmcommon.VMOutput{
+		ReturnData: [][]byte{{42}},
+		GasRefund:  big.NewInt(0),
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"alice": {
+				Address:        []byte("alice"),
+				Balance:        big.NewInt(-3),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{"key": {Offset: []byte("key"), Data: []byte{0}}},
+				OutputTransfers: []vmcommon.OutputTransfer{
+					{Value: big.NewInt(7), Data: []byte("data"), CallType: vm.AsynchronousCallBack},
+				},
+			},
+		},
+		Logs: []*vmcommon.LogEntry{{Identifier: []byte("event"), Topics: [][]byte{{1}}}},
+	}
+
+	requireBinarySerializationConsistency(t, NewMessageContractDeployRequest(&vmcommon.ContractCreateInput{
+		VMInput:      callInput.VMInput,
+		ContractCode: []byte{0, 'a', 's', 'm'},
+	}), &MessageContractDeployRequest{})
+	requireBinarySerializationConsistency(t, NewMessageContractCallRequest(&callInput), &MessageContractCallRequest{})
+	requireBinarySerializationConsistency(t, NewMessageContractResponse(vmOutput, nil), &MessageContractResponse{})
+	requireBinarySerializationConsistency(t, NewMessageBlockchainProcessBuiltinFunctionRequest(&callInput), &MessageBlockchainProcessBuiltinFunctionRequest{})
+	requireBinarySerializationConsistency(t, NewMessageBlockchainGetESDTTokenResponse(&esdt.ESDigitalToken{
+		Value:         big.NewInt(20),
+		TokenMetaData: &esdt.MetaData{Nonce: 1, Name: []byte("NFT"), URIs: [][]byte{[]byte("uri")}},
+	}, nil), &MessageBlockchainGetESDTTokenResponse{})
+	requireBinarySerializationConsistency(t, NewMessageBlockchainGetBuiltinFunctionNamesResponse(vmcommon.FunctionNames{
+		"ESDTTransfer": {},
+	}), &MessageBlockchainGetBuiltinFunctionNamesResponse{})
+	requireBinarySerializationConsistency(t, NewMessageBlockchainGetUserAccountResponse(&Account{
+		Nonce:   3,
+		Balance: big.NewInt(1000),
+		Address: []byte("alice"),
+	}, nil), &MessageBlockchainGetUserAccountResponse{})
+	requireBinarySerializationConsistency(t, NewMessageGasScheduleChangeRequest(map[string]map[string]uint64{
+		"BaseOperationCost": {"StorePerByte": 50},
+	}), &MessageGasScheduleChangeRequest{})
+}
+
+func TestMessageContractCallRequest_WithPrefetchHintIsConsistentlySerializable(t *testing.T) {
+	message := NewMessageContractCallRequest(&vmcommon.ContractCallInput{Function: "increment"})
+	message.Prefetch = &PrefetchHint{
+		Account: &Account{Address: []byte("contract"), OwnerAddress: []byte("alice"), CodeHash: []byte("hash")},
+		Code:    []byte("code"),
+		Storage: NewSerializableMapStringBytes(map[string][]byte{"counter": {42}}),
+	}
+	requireBinarySerializationConsistency(t, message, &MessageContractCallRequest{})
+}
+
+func TestMessages_PackAndUnpackBatchOfHookCalls(t *testing.T) {
+	hookCalls := []MessageHandler{
+		NewMessageBlockchainGetStorageDataRequest([]byte("alice"), []byte("foo")),
+		NewMessageBlockchainCurrentEpochRequest(),
+		NewMessageBlockchainGetUserAccountRequest([]byte("bob")),
+	}
+
+	for _, kind := range []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob, marshaling.Binary} {
+		marshalizer := marshaling.CreateMarshalizer(kind)
+
+		rawMessages, err := PackMessages(hookCalls, marshalizer)
+		require.Nil(t, err)
+		batch := NewMessageBlockchainBatchRequest(rawMessages)
+		require.True(t, IsHookCall(batch))
+
+		serialized, err := marshalizer.Marshal(batch)
+		require.Nil(t, err)
+		intoBatch := &MessageBlockchainBatchRequest{}
+		err = marshalizer.Unmarshal(intoBatch, serialized)
+		require.Nil(t, err)
+
+		unpacked, err := UnpackMessages(intoBatch.Requests, marshalizer)
+		require.Nil(t, err)
+		require.Equal(t, hookCalls, unpacked)
+	}
+}
+
+func TestMessages_UnpackRejectsInvalidKinds(t *testing.T) {
+	marshalizer := marshaling.CreateMarshalizer(marshaling.Binary)
+
+	_, err := UnpackMessages([]*RawMessage{{Kind: LastKind}}, marshalizer)
+	require.Equal(t, ErrBadBatchOfHookCalls, err)
+
+	rawMessages, err := PackMessages([]MessageHandler{NewMessageBlockchainCurrentEpochRequest()}, marshalizer)
+	require.Nil(t, err)
+	rawMessages[0].Kind = BlockchainLastEpochRequest
+	_, err = UnpackMessages(rawMessages, marshalizer)
+	require.Equal(t, ErrBadBatchOfHookCalls, err)
+}
+
 func requireSerializationConsistency(t *testing.T, message interface{}, intoMessage interface{}) {
 	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
 
@@ -82,4 +230,33 @@ func requireSerializationConsistency(t *testing.T, message interface{}, intoMess
 	if !areEqual {
 		require.FailNow(t, "Serialization is not consistent.")
 	}
+
+	intoMessage = reflect.New(reflect.TypeOf(intoMessage).Elem()).Interface()
+	requireBinarySerializationConsistency(t, message, intoMessage)
+}
+
+func requireBinarySerializationConsistency(t *testing.T, message interface{}, intoMessage interface{}) {
+	marshalizer := marshaling.CreateMarshalizer(marshaling.Binary)
+
+	serialized, err := marshalizer.Marshal(message)
+	require.Nil(t, err)
+	err = marshalizer.Unmarshal(intoMessage, serialized)
+	require.Nil(t, err)
+	require.Equal(t, message, intoMessage)
+}
+
+func TestMessageInitialize_MissingEnableEpochsAreTheDefaultOnes(t *testing.T) {
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+
+	message := CreateMessage(Initialize).(*MessageInitialize)
+	err := marshalizer.Unmarshal(message, []byte(`{"Kind":1,"Arguments":{}}`))
+	require.Nil(t, err)
+	require.Equal(t, config.MakeDefaultEnableEpochs(), message.Arguments.EnableEpochs)
+
+	message = CreateMessage(Initialize).(*MessageInitialize)
+	err = marshalizer.Unmarshal(message, []byte(`{"Kind":1,"Arguments":{"EnableEpochs":{"SCDeployEnableEpoch":3}}}`))
+	require.Nil(t, err)
+	expected := config.MakeDefaultEnableEpochs()
+	expected.SCDeployEnableEpoch = 3
+	require.Equal(t, expected, message.Arguments.EnableEpochs)
 }
@@ -2,7 +2,6 @@ package common
 
 import (
 	"fmt"
-	"os"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
@@ -16,14 +15,17 @@ type Messenger struct {
 	Nonce    uint32
 	receiver *Receiver
 	sender   *Sender
+	recorder *DialogueRecorder
+	stats    *StatsCollector
 }
 
-// NewMessengerPipes creates a new messenger from pipes
-func NewMessengerPipes(name string, reader *os.File, writer *os.File, marshalizer marshaling.Marshalizer) *Messenger {
+// NewMessengerPipes creates a new messenger from pipes, or from a socket used both as reader and writer
+func NewMessengerPipes(name string, reader Transport, writer Transport, marshalizer marshaling.Marshalizer) *Messenger {
 	return &Messenger{
 		Name:     name,
 		receiver: NewReceiver(reader, marshalizer),
 		sender:   NewSender(writer, marshalizer),
+		stats:    NewStatsCollector(),
 	}
 }
 
@@ -33,6 +35,7 @@ func NewMessenger(name string, receiver *Receiver, sender *Sender) *Messenger {
 		Name:     name,
 		receiver: receiver,
 		sender:   sender,
+		stats:    NewStatsCollector(),
 	}
 }
 
@@ -42,7 +45,28 @@ func (messenger *Messenger) Send(message MessageHandler) error {
 	message.SetNonce(messenger.Nonce)
 	length, err := messenger.sender.Send(message)
 	log.Trace(fmt.Sprintf("[%s][#%d]: SENT message", messenger.Name, message.GetNonce()), "size", length, "msg", message.DebugString())
-	return err
+	if err != nil {
+		return err
+	}
+
+	messenger.stats.countSent(message, length)
+	if messenger.recorder != nil {
+		messenger.recorder.RecordSent(message)
+	}
+
+	return nil
+}
+
+// SendHeartbeat sends a heartbeat, outside of the dialogue; it can be called concurrently with Send
+func (messenger *Messenger) SendHeartbeat() error {
+	message := NewMessageHeartbeat()
+	length, err := messenger.sender.Send(message)
+	if err != nil {
+		return err
+	}
+
+	messenger.stats.countSent(message, length)
+	return nil
 }
 
 // Receive receives a message, reads it from the pipe
@@ -54,6 +78,15 @@ func (messenger *Messenger) Receive(timeout int) (MessageHandler, error) {
 	}
 
 	log.Trace(fmt.Sprintf("[%s][#%d]: RECEIVED message", messenger.Name, message.GetNonce()), "size", length, "msg", message.DebugString())
+	messenger.stats.countReceived(message, length)
+	if IsHeartbeat(message) {
+		return message, nil
+	}
+
+	if messenger.recorder != nil {
+		messenger.recorder.RecordReceived(message)
+	}
+
 	messageNonce := message.GetNonce()
 	if messageNonce != messenger.Nonce+1 {
 		return nil, ErrInvalidMessageNonce
@@ -63,6 +96,36 @@ func (messenger *Messenger) Receive(timeout int) (MessageHandler, error) {
 	return message, nil
 }
 
+// PackMessages marshalizes messages to be carried within a batch, using the marshalizer of the sender
+func (messenger *Messenger) PackMessages(messages []MessageHandler) ([]*RawMessage, error) {
+	return PackMessages(messages, messenger.sender.marshalizer)
+}
+
+// UnpackMessages unmarshalizes the messages carried within a batch, using the marshalizer of the receiver
+func (messenger *Messenger) UnpackMessages(rawMessages []*RawMessage) ([]MessageHandler, error) {
+	return UnpackMessages(rawMessages, messenger.receiver.marshalizer)
+}
+
+// EnableCompression compresses the large messages sent from now on; the receiver accepts compressed messages anyway
+func (messenger *Messenger) EnableCompression() {
+	messenger.sender.SetCompression(true)
+}
+
+// SetRecorder records the messages sent and received from now on; nil stops the recording
+func (messenger *Messenger) SetRecorder(recorder *DialogueRecorder) {
+	messenger.recorder = recorder
+}
+
+// SetStatsCollector replaces the counters of the messenger, e.g. by ones which outlive it
+func (messenger *Messenger) SetStatsCollector(stats *StatsCollector) {
+	messenger.stats = stats
+}
+
+// GetStatsCollector returns the counters of the messenger
+func (messenger *Messenger) GetStatsCollector() *StatsCollector {
+	return messenger.stats
+}
+
 // Reset resets the messenger
 func (messenger *Messenger) Reset() {
 	messenger.ResetDialogue()
@@ -82,6 +145,10 @@ func (messenger *Messenger) Shutdown() {
 		log.Error("Cannot close receiver", "err", err)
 	}
 
+	if messenger.sender.writer == messenger.receiver.reader {
+		return
+	}
+
 	err = messenger.sender.Shutdown()
 	if err != nil {
 		log.Error("Cannot close sender", "err", err)
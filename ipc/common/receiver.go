@@ -1,29 +1,30 @@
 package common
 
 import (
+	"bytes"
+	"compress/flate"
 	"encoding/binary"
 	"io"
-	"os"
 	"time"
 
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
 )
 
-// Receiver intermediates communication (message receiving) via pipes
+// Receiver intermediates communication (message receiving) via pipes or sockets
 type Receiver struct {
-	reader      *os.File
+	reader      Transport
 	marshalizer marshaling.Marshalizer
 }
 
 // NewReceiver creates a new receiver
-func NewReceiver(reader *os.File, marshalizer marshaling.Marshalizer) *Receiver {
+func NewReceiver(reader Transport, marshalizer marshaling.Marshalizer) *Receiver {
 	return &Receiver{
 		reader:      reader,
 		marshalizer: marshalizer,
 	}
 }
 
-// Receive receives a message, reads it from the pipe
+// Receive receives a message, reads it from the transport
 func (receiver *Receiver) Receive(timeout int) (MessageHandler, int, error) {
 	if timeout > 0 {
 		err := receiver.setReceiveDeadline(timeout)
@@ -50,11 +51,11 @@ func (receiver *Receiver) Receive(timeout int) (MessageHandler, int, error) {
 func (receiver *Receiver) setReceiveDeadline(timeout int) error {
 	duration := time.Duration(timeout) * time.Millisecond
 	future := time.Now().Add(duration)
-	return receiver.reader.SetDeadline(future)
+	return receiver.reader.SetReadDeadline(future)
 }
 
 func (receiver *Receiver) resetReceiveDeadlineQuietly() {
-	_ = receiver.reader.SetDeadline(time.Time{})
+	_ = receiver.reader.SetReadDeadline(time.Time{})
 }
 
 func (receiver *Receiver) receiveMessageLengthAndKind() (int, MessageKind, error) {
@@ -76,6 +77,14 @@ func (receiver *Receiver) readMessage(kind MessageKind, length int) (MessageHand
 		return nil, err
 	}
 
+	if kind&compressedMessageFlag != 0 {
+		kind &^= compressedMessageFlag
+		buffer, err = io.ReadAll(flate.NewReader(bytes.NewReader(buffer)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	message := CreateMessage(kind)
 	err = receiver.marshalizer.Unmarshal(message, buffer)
 	if err != nil {
@@ -85,7 +94,7 @@ func (receiver *Receiver) readMessage(kind MessageKind, length int) (MessageHand
 	return message, nil
 }
 
-// Shutdown closes the pipe
+// Shutdown closes the transport
 func (receiver *Receiver) Shutdown() error {
 	err := receiver.reader.Close()
 	return err
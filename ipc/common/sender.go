@@ -1,35 +1,48 @@
 package common
 
 import (
+	"bytes"
+	"compress/flate"
 	"encoding/binary"
-	"os"
+	"sync"
 
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
 )
 
-// Sender intermediates communication (message sending) via pipes
+// Sender intermediates communication (message sending) via pipes or sockets
 type Sender struct {
-	writer      *os.File
+	// mutex keeps the messages whole when they are sent concurrently, e.g. the heartbeats
+	mutex       sync.Mutex
+	writer      Transport
 	marshalizer marshaling.Marshalizer
+	compression bool
 }
 
 // NewSender creates a new sender
-func NewSender(writer *os.File, marshalizer marshaling.Marshalizer) *Sender {
+func NewSender(writer Transport, marshalizer marshaling.Marshalizer) *Sender {
 	return &Sender{
 		writer:      writer,
 		marshalizer: marshalizer,
 	}
 }
 
-// Send sends a message over the pipe
+// Send sends a message over the transport
 func (sender *Sender) Send(message MessageHandler) (int, error) {
 	dataBytes, err := sender.marshalizer.Marshal(message)
 	if err != nil {
 		return 0, err
 	}
 
+	kind := message.GetKind()
+	if sender.compression && len(dataBytes) > compressionThreshold {
+		dataBytes, kind = compressMessage(dataBytes, kind)
+	}
+
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+
 	length := len(dataBytes)
-	err = sender.sendMessageLengthAndKind(length, message.GetKind())
+	err = sender.sendMessageLengthAndKind(length, kind)
 	if err != nil {
 		return 0, err
 	}
@@ -50,7 +63,33 @@ func (sender *Sender) sendMessageLengthAndKind(length int, kind MessageKind) err
 	return err
 }
 
-// Shutdown closes the pipe
+// SetCompression enables or disables the compression of the large messages; it must be agreed upon in the handshake
+func (sender *Sender) SetCompression(enabled bool) {
+	sender.compression = enabled
+}
+
+// compressMessage returns the message unchanged if it does not compress well
+func compressMessage(dataBytes []byte, kind MessageKind) ([]byte, MessageKind) {
+	buffer := bytes.NewBuffer(make([]byte, 0, len(dataBytes)/2))
+	writer, err := flate.NewWriter(buffer, flate.BestSpeed)
+	if err != nil {
+		return dataBytes, kind
+	}
+
+	_, err = writer.Write(dataBytes)
+	if err != nil {
+		return dataBytes, kind
+	}
+
+	err = writer.Close()
+	if err != nil || buffer.Len() >= len(dataBytes) {
+		return dataBytes, kind
+	}
+
+	return buffer.Bytes(), kind | compressedMessageFlag
+}
+
+// Shutdown closes the transport
 func (sender *Sender) Shutdown() error {
 	err := sender.writer.Close()
 	return err
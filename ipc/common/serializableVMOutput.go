@@ -5,6 +5,7 @@ import (
 
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/vmoutput"
 )
 
 type SerializableVMOutput struct {
@@ -36,7 +37,7 @@ func NewSerializableVMOutput(vmOutput *vmcommon.VMOutput) *SerializableVMOutput
 		Logs:                    vmOutput.Logs,
 	}
 
-	for _, account := range vmOutput.OutputAccounts {
+	for _, account := range vmoutput.SortedOutputAccounts(vmOutput.OutputAccounts) {
 		o.CorrectedOutputAccounts = append(o.CorrectedOutputAccounts, NewSerializableOutputAccount(account))
 	}
 
@@ -111,9 +112,7 @@ func NewSerializableOutputAccount(account *vmcommon.OutputAccount) *Serializable
 		a.Transfers[i] = serializableTransfer
 	}
 
-	for _, storageUpdate := range account.StorageUpdates {
-		a.StorageUpdates = append(a.StorageUpdates, storageUpdate)
-	}
+	a.StorageUpdates = append(a.StorageUpdates, vmoutput.SortedStorageUpdates(account.StorageUpdates)...)
 
 	return a
 }
@@ -0,0 +1,171 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// LatencyBucketBounds are the upper bounds, in milliseconds, of the buckets of a LatencyHistogram;
+// a last bucket holds the longer durations
+var LatencyBucketBounds = []int64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000}
+
+// LatencyHistogram counts durations within the buckets of LatencyBucketBounds
+type LatencyHistogram struct {
+	Buckets         []uint64
+	Count           uint64
+	SumMilliseconds uint64
+	MaxMilliseconds uint64
+}
+
+func newLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{
+		Buckets: make([]uint64, len(LatencyBucketBounds)+1),
+	}
+}
+
+func (histogram *LatencyHistogram) add(duration time.Duration) {
+	milliseconds := duration.Milliseconds()
+	bucket := len(LatencyBucketBounds)
+	for i, bound := range LatencyBucketBounds {
+		if milliseconds < bound {
+			bucket = i
+			break
+		}
+	}
+
+	histogram.Buckets[bucket]++
+	histogram.Count++
+	histogram.SumMilliseconds += uint64(milliseconds)
+	if uint64(milliseconds) > histogram.MaxMilliseconds {
+		histogram.MaxMilliseconds = uint64(milliseconds)
+	}
+}
+
+func (histogram *LatencyHistogram) clone() *LatencyHistogram {
+	clone := *histogram
+	clone.Buckets = append([]uint64(nil), histogram.Buckets...)
+	return &clone
+}
+
+// Stats are the counters of a part (Node or VM), held by name of message kind
+type Stats struct {
+	SentMessages     map[string]uint64
+	ReceivedMessages map[string]uint64
+	SentBytes        uint64
+	ReceivedBytes    uint64
+	// Heartbeats are the heartbeats sent by VM, or received by the Node
+	Heartbeats uint64
+	// Latencies are, for the Node, the durations of the dialogues (by kind of request) and of the replies to the hook calls;
+	// for VM, the durations of the requests of the Node and of the hook calls, Node included
+	Latencies map[string]*LatencyHistogram
+	// Restarts of VM, only counted by the Node
+	Restarts uint64
+	// SlowVMTimeouts are the dialogues which exceeded their timeout while VM was alive (it sent heartbeats, or heartbeats are not supported)
+	SlowVMTimeouts uint64
+	// StuckVMTimeouts are the dialogues abandoned because VM did not send any message, not even a heartbeat
+	StuckVMTimeouts uint64
+}
+
+// NewStats creates empty counters
+func NewStats() *Stats {
+	return &Stats{
+		SentMessages:     make(map[string]uint64),
+		ReceivedMessages: make(map[string]uint64),
+		Latencies:        make(map[string]*LatencyHistogram),
+	}
+}
+
+// StatsCollector updates Stats from several goroutines; a collector can outlive the messengers it is shared with
+type StatsCollector struct {
+	mutex sync.Mutex
+	stats *Stats
+}
+
+// NewStatsCollector creates a collector
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{
+		stats: NewStats(),
+	}
+}
+
+func (collector *StatsCollector) countSent(message MessageHandler, length int) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	collector.stats.SentMessages[message.GetKindName()]++
+	collector.stats.SentBytes += uint64(length)
+	if IsHeartbeat(message) {
+		collector.stats.Heartbeats++
+	}
+}
+
+func (collector *StatsCollector) countReceived(message MessageHandler, length int) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	collector.stats.ReceivedMessages[message.GetKindName()]++
+	collector.stats.ReceivedBytes += uint64(length)
+	if IsHeartbeat(message) {
+		collector.stats.Heartbeats++
+	}
+}
+
+// AddLatency adds a duration to the histogram of the given name (usually the name of a message kind)
+func (collector *StatsCollector) AddLatency(name string, duration time.Duration) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	histogram, ok := collector.stats.Latencies[name]
+	if !ok {
+		histogram = newLatencyHistogram()
+		collector.stats.Latencies[name] = histogram
+	}
+
+	histogram.add(duration)
+}
+
+// CountRestart counts a restart of VM
+func (collector *StatsCollector) CountRestart() {
+	collector.mutex.Lock()
+	collector.stats.Restarts++
+	collector.mutex.Unlock()
+}
+
+// CountSlowVMTimeout counts a dialogue which exceeded its timeout while VM was alive
+func (collector *StatsCollector) CountSlowVMTimeout() {
+	collector.mutex.Lock()
+	collector.stats.SlowVMTimeouts++
+	collector.mutex.Unlock()
+}
+
+// CountStuckVMTimeout counts a dialogue abandoned because VM did not send any message
+func (collector *StatsCollector) CountStuckVMTimeout() {
+	collector.mutex.Lock()
+	collector.stats.StuckVMTimeouts++
+	collector.mutex.Unlock()
+}
+
+// GetStats returns a copy of the counters
+func (collector *StatsCollector) GetStats() *Stats {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	stats := *collector.stats
+	stats.SentMessages = copyCounters(collector.stats.SentMessages)
+	stats.ReceivedMessages = copyCounters(collector.stats.ReceivedMessages)
+	stats.Latencies = make(map[string]*LatencyHistogram, len(collector.stats.Latencies))
+	for name, histogram := range collector.stats.Latencies {
+		stats.Latencies[name] = histogram.clone()
+	}
+
+	return &stats
+}
+
+func copyCounters(counters map[string]uint64) map[string]uint64 {
+	result := make(map[string]uint64, len(counters))
+	for name, count := range counters {
+		result[name] = count
+	}
+
+	return result
+}
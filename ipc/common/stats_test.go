@@ -0,0 +1,118 @@
+package common
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyHistogram_Buckets(t *testing.T) {
+	histogram := newLatencyHistogram()
+	histogram.add(0)
+	histogram.add(3 * time.Millisecond)
+	histogram.add(5 * time.Millisecond)
+	histogram.add(time.Minute)
+
+	require.Equal(t, uint64(1), histogram.Buckets[0])
+	require.Equal(t, uint64(1), histogram.Buckets[2])
+	require.Equal(t, uint64(1), histogram.Buckets[3])
+	require.Equal(t, uint64(1), histogram.Buckets[len(LatencyBucketBounds)])
+	require.Equal(t, uint64(4), histogram.Count)
+	require.Equal(t, uint64(60008), histogram.SumMilliseconds)
+	require.Equal(t, uint64(60000), histogram.MaxMilliseconds)
+}
+
+func TestStatsCollector_GetStatsReturnsCopy(t *testing.T) {
+	collector := NewStatsCollector()
+	collector.countSent(NewMessageVersionRequest(), 10)
+	collector.AddLatency("VersionRequest", time.Millisecond)
+
+	stats := collector.GetStats()
+	collector.countSent(NewMessageVersionRequest(), 10)
+	collector.AddLatency("VersionRequest", time.Millisecond)
+	collector.CountRestart()
+
+	require.Equal(t, uint64(1), stats.SentMessages["VersionRequest"])
+	require.Equal(t, uint64(10), stats.SentBytes)
+	require.Equal(t, uint64(1), stats.Latencies["VersionRequest"].Count)
+	require.Zero(t, stats.Restarts)
+
+	stats = collector.GetStats()
+	require.Equal(t, uint64(2), stats.SentMessages["VersionRequest"])
+	require.Equal(t, uint64(2), stats.Latencies["VersionRequest"].Count)
+	require.Equal(t, uint64(1), stats.Restarts)
+}
+
+func TestStats_IsSerializable(t *testing.T) {
+	collector := NewStatsCollector()
+	collector.countReceived(NewMessageHeartbeat(), 8)
+	collector.AddLatency("ContractCallRequest", 42*time.Millisecond)
+	message := NewMessageDiagnoseStatsResponse(collector.GetStats())
+
+	for _, kind := range []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob, marshaling.Binary} {
+		marshalizer := marshaling.CreateMarshalizer(kind)
+		data, err := marshalizer.Marshal(message)
+		require.Nil(t, err)
+
+		unmarshalized := &MessageDiagnoseStatsResponse{}
+		err = marshalizer.Unmarshal(unmarshalized, data)
+		require.Nil(t, err)
+		require.Equal(t, message.Stats, unmarshalized.Stats)
+	}
+}
+
+func TestMessenger_HeartbeatsAreOutsideOfTheDialogue(t *testing.T) {
+	nodeToVMRead, nodeToVMWrite, err := os.Pipe()
+	require.Nil(t, err)
+	vmToNodeRead, vmToNodeWrite, err := os.Pipe()
+	require.Nil(t, err)
+
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	node := NewMessengerPipes("NODE", vmToNodeRead, nodeToVMWrite, marshalizer)
+	vm := NewMessengerPipes("VM", nodeToVMRead, vmToNodeWrite, marshalizer)
+	defer node.Shutdown()
+	defer vm.Shutdown()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			_ = vm.SendHeartbeat()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		_ = vm.Send(NewMessageVersionResponse("v1.2"))
+	}()
+
+	heartbeats := 0
+	for {
+		message, err := node.Receive(1000)
+		require.Nil(t, err)
+		if IsHeartbeat(message) {
+			heartbeats++
+			continue
+		}
+
+		require.Equal(t, VersionResponse, message.GetKind())
+		require.Equal(t, uint32(1), node.Nonce)
+		break
+	}
+
+	wg.Wait()
+	for heartbeats < 10 {
+		message, err := node.Receive(1000)
+		require.Nil(t, err)
+		require.True(t, IsHeartbeat(message))
+		heartbeats++
+	}
+
+	require.Equal(t, uint64(10), vm.GetStatsCollector().GetStats().Heartbeats)
+	require.Equal(t, uint64(10), node.GetStatsCollector().GetStats().Heartbeats)
+	require.Equal(t, uint64(1), node.GetStatsCollector().GetStats().ReceivedMessages["VersionResponse"])
+}
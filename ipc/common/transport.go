@@ -0,0 +1,108 @@
+package common
+
+import (
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	unixAddressPrefix = "unix://"
+	tcpAddressPrefix  = "tcp://"
+)
+
+// Transport is the stream through which messages are exchanged between the Node and VM:
+// the pipes inherited by a VM child process (*os.File), or a Unix domain socket or TCP connection (net.Conn)
+type Transport interface {
+	io.Reader
+	io.Writer
+	SetReadDeadline(deadline time.Time) error
+	Close() error
+}
+
+// ParseTransportAddress splits an address such as "unix:///var/run/vm.sock" or "tcp://127.0.0.1:9090"
+// into the network and the address expected by the net package
+func ParseTransportAddress(address string) (string, string, error) {
+	if strings.HasPrefix(address, unixAddressPrefix) {
+		path := strings.TrimPrefix(address, unixAddressPrefix)
+		if len(path) == 0 {
+			return "", "", ErrInvalidTransportAddress
+		}
+
+		return "unix", path, nil
+	}
+
+	if strings.HasPrefix(address, tcpAddressPrefix) {
+		hostAndPort := strings.TrimPrefix(address, tcpAddressPrefix)
+		_, _, err := net.SplitHostPort(hostAndPort)
+		if err != nil {
+			return "", "", ErrInvalidTransportAddress
+		}
+
+		return "tcp", hostAndPort, nil
+	}
+
+	return "", "", ErrInvalidTransportAddress
+}
+
+// IsLoopbackTransportAddress returns whether only the processes of the same machine may connect at the given address:
+// a Unix domain socket, or a TCP address whose host is "localhost" or a loopback IP
+func IsLoopbackTransportAddress(address string) bool {
+	network, netAddress, err := ParseTransportAddress(address)
+	if err != nil {
+		return false
+	}
+	if network == "unix" {
+		return true
+	}
+
+	host, _, _ := net.SplitHostPort(netAddress)
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// DialTransport connects to a VM server listening at the given address
+func DialTransport(address string, timeout time.Duration) (Transport, error) {
+	network, netAddress, err := ParseTransportAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return net.DialTimeout(network, netAddress, timeout)
+}
+
+// ListenTransport listens for the connections of the Nodes at the given address;
+// a Unix domain socket left behind by a previous VM process is removed first, unless a VM still listens on it
+func ListenTransport(address string) (net.Listener, error) {
+	network, netAddress, err := ParseTransportAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unix" {
+		removeStaleSocket(netAddress)
+	}
+
+	return net.Listen(network, netAddress)
+}
+
+func removeStaleSocket(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return
+	}
+
+	connection, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		_ = connection.Close()
+		return
+	}
+
+	_ = os.Remove(path)
+}
@@ -0,0 +1,170 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransportAddress(t *testing.T) {
+	network, address, err := ParseTransportAddress("unix:///var/run/vm.sock")
+	require.Nil(t, err)
+	require.Equal(t, "unix", network)
+	require.Equal(t, "/var/run/vm.sock", address)
+
+	network, address, err = ParseTransportAddress("tcp://127.0.0.1:9090")
+	require.Nil(t, err)
+	require.Equal(t, "tcp", network)
+	require.Equal(t, "127.0.0.1:9090", address)
+
+	for _, invalidAddress := range []string{"", "unix://", "tcp://127.0.0.1", "udp://127.0.0.1:9090", "/var/run/vm.sock"} {
+		_, _, err = ParseTransportAddress(invalidAddress)
+		require.Equal(t, ErrInvalidTransportAddress, err, invalidAddress)
+	}
+}
+
+func TestIsLoopbackTransportAddress(t *testing.T) {
+	for _, address := range []string{"unix:///var/run/vm.sock", "tcp://127.0.0.1:9090", "tcp://localhost:9090", "tcp://[::1]:9090"} {
+		require.True(t, IsLoopbackTransportAddress(address), address)
+	}
+
+	for _, address := range []string{"tcp://:9090", "tcp://0.0.0.0:9090", "tcp://10.0.0.1:9090", "tcp://example.com:9090", "tcp://127.0.0.1"} {
+		require.False(t, IsLoopbackTransportAddress(address), address)
+	}
+}
+
+func TestTransport_UnixSocket(t *testing.T) {
+	testMessagesOverSocket(t, "unix://"+filepath.Join(t.TempDir(), "vm.sock"))
+}
+
+func TestTransport_ListenRemovesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vm.sock")
+	listener, err := ListenTransport("unix://" + path)
+	require.Nil(t, err)
+
+	// As if the VM process had crashed
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = listener.Close()
+	_, err = os.Stat(path)
+	require.Nil(t, err)
+
+	listener, err = ListenTransport("unix://" + path)
+	require.Nil(t, err)
+	_ = listener.Close()
+}
+
+func TestTransport_TCP(t *testing.T) {
+	testMessagesOverSocket(t, "tcp://127.0.0.1:0")
+}
+
+func TestTransport_ListenDoesNotRemoveSocketInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vm.sock")
+	listener, err := ListenTransport("unix://" + path)
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	_, err = ListenTransport("unix://" + path)
+	require.NotNil(t, err)
+
+	_, err = os.Stat(path)
+	require.Nil(t, err)
+}
+
+func TestTransport_CompressedMessages(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	require.Nil(t, err)
+	defer func() {
+		_ = reader.Close()
+		_ = writer.Close()
+	}()
+
+	marshalizer := marshaling.CreateMarshalizer(marshaling.Binary)
+	sender := NewSender(writer, marshalizer)
+	receiver := NewReceiver(reader, marshalizer)
+
+	state := make(map[string][]byte)
+	for i := 0; i < 1000; i++ {
+		state[fmt.Sprintf("key-%04d", i)] = []byte("value")
+	}
+	message := NewMessageBlockchainGetAllStateResponse(state, nil)
+
+	go func() {
+		_, _ = sender.Send(message)
+		sender.SetCompression(true)
+		_, _ = sender.Send(message)
+		// Small messages are not compressed
+		_, _ = sender.Send(NewMessageVersionRequest())
+	}()
+
+	_, uncompressedLength, err := receiver.Receive(1000)
+	require.Nil(t, err)
+
+	received, compressedLength, err := receiver.Receive(1000)
+	require.Nil(t, err)
+	require.Less(t, compressedLength, uncompressedLength/2)
+	require.Equal(t, BlockchainGetAllStateResponse, received.GetKind())
+	require.Equal(t, state, received.(*MessageBlockchainGetAllStateResponse).SerializableAllState.ConvertToMap())
+
+	received, _, err = receiver.Receive(1000)
+	require.Nil(t, err)
+	require.Equal(t, VersionRequest, received.GetKind())
+}
+
+func testMessagesOverSocket(t *testing.T, address string) {
+	listener, err := ListenTransport(address)
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	if listener.Addr().Network() == "tcp" {
+		address = "tcp://" + listener.Addr().String()
+	}
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		connection, acceptErr := listener.Accept()
+		require.Nil(t, acceptErr)
+		accepted <- connection
+	}()
+
+	clientConnection, err := DialTransport(address, time.Second)
+	require.Nil(t, err)
+	serverConnection := <-accepted
+
+	marshalizer := marshaling.CreateMarshalizer(marshaling.Binary)
+	client := NewMessengerPipes("NODE", clientConnection, clientConnection, marshalizer)
+	server := NewMessengerPipes("VM", serverConnection, serverConnection, marshalizer)
+
+	err = client.Send(NewMessageVersionRequest())
+	require.Nil(t, err)
+	request, err := server.Receive(1000)
+	require.Nil(t, err)
+	require.Equal(t, VersionRequest, request.GetKind())
+
+	err = server.Send(NewMessageVersionResponse("v1.2"))
+	require.Nil(t, err)
+	response, err := client.Receive(1000)
+	require.Nil(t, err)
+	require.Equal(t, "v1.2", response.(*MessageVersionResponse).Version)
+
+	// The receive deadline applies to sockets as it does to pipes
+	start := time.Now()
+	_, err = client.Receive(100)
+	require.NotNil(t, err)
+	require.True(t, os.IsTimeout(err))
+	require.Less(t, time.Since(start), time.Second)
+
+	client.Shutdown()
+	_, err = server.Receive(1000)
+	require.NotNil(t, err)
+	server.Shutdown()
+}
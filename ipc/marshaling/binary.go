@@ -0,0 +1,685 @@
+package marshaling
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var _ Marshalizer = (*binaryMarshalizer)(nil)
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// binaryMarshalizer is a compact, schema-driven marshalizer: the schema of a message is the Go type itself.
+// The exported fields are written in declaration order, without names; integers are varints,
+// while strings, byte slices, slices and maps are length-prefixed. Slices, maps and pointers keep
+// the distinction between nil and empty values, and maps are written in the order of their encoded keys,
+// so that the output is deterministic. Fields tagged with `json:"-"` are skipped, as for JSON.
+type binaryMarshalizer struct {
+}
+
+func (marshalizer *binaryMarshalizer) Marshal(data interface{}) ([]byte, error) {
+	value := reflect.ValueOf(data)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, fmt.Errorf("%w: nil %v", ErrUnsupportedType, value.Type())
+		}
+		value = value.Elem()
+	}
+
+	codec, err := getBinaryCodec(value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := &binaryEncoder{buffer: make([]byte, 0, 64)}
+	codec.encode(encoder, value)
+	return encoder.buffer, nil
+}
+
+func (marshalizer *binaryMarshalizer) Unmarshal(data interface{}, dataBytes []byte) error {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return ErrInvalidUnmarshalTarget
+	}
+	value = value.Elem()
+
+	codec, err := getBinaryCodec(value.Type())
+	if err != nil {
+		return err
+	}
+
+	decoder := &binaryDecoder{data: dataBytes}
+	err = codec.decode(decoder, value)
+	if err != nil {
+		return err
+	}
+	if decoder.remaining() != 0 {
+		return ErrInvalidBinaryData
+	}
+
+	return nil
+}
+
+func (marshalizer *binaryMarshalizer) IsInterfaceNil() bool {
+	return marshalizer == nil
+}
+
+type binaryCodec struct {
+	encode func(encoder *binaryEncoder, value reflect.Value)
+	decode func(decoder *binaryDecoder, value reflect.Value) error
+}
+
+var (
+	binaryCodecsMutex  sync.Mutex
+	binaryCodecsByType = make(map[reflect.Type]*binaryCodec)
+)
+
+// getBinaryCodec returns the codec of a type, building it on first use
+func getBinaryCodec(valueType reflect.Type) (*binaryCodec, error) {
+	binaryCodecsMutex.Lock()
+	defer binaryCodecsMutex.Unlock()
+
+	building := make(map[reflect.Type]*binaryCodec)
+	codec, err := buildBinaryCodec(valueType, building)
+	if err != nil {
+		return nil, err
+	}
+
+	for builtType, builtCodec := range building {
+		binaryCodecsByType[builtType] = builtCodec
+	}
+
+	return codec, nil
+}
+
+// buildBinaryCodec registers the codec in the building map before creating the codecs of the inner types,
+// so that recursive types refer to the codec being built
+func buildBinaryCodec(valueType reflect.Type, building map[reflect.Type]*binaryCodec) (*binaryCodec, error) {
+	codec, ok := binaryCodecsByType[valueType]
+	if ok {
+		return codec, nil
+	}
+	codec, ok = building[valueType]
+	if ok {
+		return codec, nil
+	}
+
+	codec = &binaryCodec{}
+	building[valueType] = codec
+
+	var err error
+	switch valueType.Kind() {
+	case reflect.Bool:
+		codec.encode, codec.decode = encodeBool, decodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		codec.encode, codec.decode = encodeInt, decodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		codec.encode, codec.decode = encodeUint, decodeUint
+	case reflect.Float32, reflect.Float64:
+		codec.encode, codec.decode = encodeFloat, decodeFloat
+	case reflect.String:
+		codec.encode, codec.decode = encodeString, decodeString
+	case reflect.Slice:
+		err = buildSliceCodec(codec, valueType, building)
+	case reflect.Array:
+		err = buildArrayCodec(codec, valueType, building)
+	case reflect.Map:
+		err = buildMapCodec(codec, valueType, building)
+	case reflect.Ptr:
+		err = buildPointerCodec(codec, valueType, building)
+	case reflect.Struct:
+		err = buildStructCodec(codec, valueType, building)
+	default:
+		err = fmt.Errorf("%w: %v", ErrUnsupportedType, valueType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return codec, nil
+}
+
+func buildSliceCodec(codec *binaryCodec, valueType reflect.Type, building map[reflect.Type]*binaryCodec) error {
+	if valueType.Elem().Kind() == reflect.Uint8 {
+		codec.encode, codec.decode = encodeByteSlice, decodeByteSlice
+		return nil
+	}
+
+	elementCodec, err := buildBinaryCodec(valueType.Elem(), building)
+	if err != nil {
+		return err
+	}
+
+	codec.encode = func(encoder *binaryEncoder, value reflect.Value) {
+		if value.IsNil() {
+			encoder.writeUvarint(0)
+			return
+		}
+
+		length := value.Len()
+		encoder.writeUvarint(uint64(length) + 1)
+		for i := 0; i < length; i++ {
+			elementCodec.encode(encoder, value.Index(i))
+		}
+	}
+	codec.decode = func(decoder *binaryDecoder, value reflect.Value) error {
+		length, isNil, err := decoder.readLength()
+		if err != nil {
+			return err
+		}
+		if isNil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+
+		slice := reflect.MakeSlice(value.Type(), length, length)
+		for i := 0; i < length; i++ {
+			err = elementCodec.decode(decoder, slice.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+
+		value.Set(slice)
+		return nil
+	}
+
+	return nil
+}
+
+func buildArrayCodec(codec *binaryCodec, valueType reflect.Type, building map[reflect.Type]*binaryCodec) error {
+	if valueType.Elem().Kind() == reflect.Uint8 {
+		codec.encode, codec.decode = encodeByteArray, decodeByteArray
+		return nil
+	}
+
+	elementCodec, err := buildBinaryCodec(valueType.Elem(), building)
+	if err != nil {
+		return err
+	}
+
+	codec.encode = func(encoder *binaryEncoder, value reflect.Value) {
+		for i := 0; i < value.Len(); i++ {
+			elementCodec.encode(encoder, value.Index(i))
+		}
+	}
+	codec.decode = func(decoder *binaryDecoder, value reflect.Value) error {
+		for i := 0; i < value.Len(); i++ {
+			err := elementCodec.decode(decoder, value.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+type encodedMapEntry struct {
+	encodedKey []byte
+	element    reflect.Value
+}
+
+func buildMapCodec(codec *binaryCodec, valueType reflect.Type, building map[reflect.Type]*binaryCodec) error {
+	keyCodec, err := buildBinaryCodec(valueType.Key(), building)
+	if err != nil {
+		return err
+	}
+
+	elementCodec, err := buildBinaryCodec(valueType.Elem(), building)
+	if err != nil {
+		return err
+	}
+
+	codec.encode = func(encoder *binaryEncoder, value reflect.Value) {
+		if value.IsNil() {
+			encoder.writeUvarint(0)
+			return
+		}
+
+		entries := make([]encodedMapEntry, 0, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			keyEncoder := &binaryEncoder{}
+			keyCodec.encode(keyEncoder, iterator.Key())
+			entries = append(entries, encodedMapEntry{encodedKey: keyEncoder.buffer, element: iterator.Value()})
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i].encodedKey, entries[j].encodedKey) < 0
+		})
+
+		encoder.writeUvarint(uint64(len(entries)) + 1)
+		for _, entry := range entries {
+			encoder.buffer = append(encoder.buffer, entry.encodedKey...)
+			elementCodec.encode(encoder, entry.element)
+		}
+	}
+	codec.decode = func(decoder *binaryDecoder, value reflect.Value) error {
+		length, isNil, err := decoder.readLength()
+		if err != nil {
+			return err
+		}
+		if isNil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+
+		mapValue := reflect.MakeMapWithSize(value.Type(), length)
+		for i := 0; i < length; i++ {
+			key := reflect.New(valueType.Key()).Elem()
+			err = keyCodec.decode(decoder, key)
+			if err != nil {
+				return err
+			}
+
+			element := reflect.New(valueType.Elem()).Elem()
+			err = elementCodec.decode(decoder, element)
+			if err != nil {
+				return err
+			}
+
+			mapValue.SetMapIndex(key, element)
+		}
+
+		value.Set(mapValue)
+		return nil
+	}
+
+	return nil
+}
+
+func buildPointerCodec(codec *binaryCodec, valueType reflect.Type, building map[reflect.Type]*binaryCodec) error {
+	if valueType.Elem() == bigIntType {
+		codec.encode, codec.decode = encodeBigIntPointer, decodeBigIntPointer
+		return nil
+	}
+
+	elementCodec, err := buildBinaryCodec(valueType.Elem(), building)
+	if err != nil {
+		return err
+	}
+
+	codec.encode = func(encoder *binaryEncoder, value reflect.Value) {
+		if value.IsNil() {
+			encoder.writeByte(0)
+			return
+		}
+
+		encoder.writeByte(1)
+		elementCodec.encode(encoder, value.Elem())
+	}
+	codec.decode = func(decoder *binaryDecoder, value reflect.Value) error {
+		isPresent, err := decoder.readBool()
+		if err != nil {
+			return err
+		}
+		if !isPresent {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+
+		element := reflect.New(valueType.Elem())
+		err = elementCodec.decode(decoder, element.Elem())
+		if err != nil {
+			return err
+		}
+
+		value.Set(element)
+		return nil
+	}
+
+	return nil
+}
+
+func buildStructCodec(codec *binaryCodec, valueType reflect.Type, building map[reflect.Type]*binaryCodec) error {
+	if valueType == bigIntType {
+		codec.encode, codec.decode = encodeBigInt, decodeBigInt
+		return nil
+	}
+
+	fieldIndexes := make([]int, 0, valueType.NumField())
+	fieldCodecs := make([]*binaryCodec, 0, valueType.NumField())
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		fieldCodec, err := buildBinaryCodec(field.Type, building)
+		if err != nil {
+			return fmt.Errorf("%w (field %s of %v)", err, field.Name, valueType)
+		}
+
+		fieldIndexes = append(fieldIndexes, i)
+		fieldCodecs = append(fieldCodecs, fieldCodec)
+	}
+
+	codec.encode = func(encoder *binaryEncoder, value reflect.Value) {
+		for i, fieldIndex := range fieldIndexes {
+			fieldCodecs[i].encode(encoder, value.Field(fieldIndex))
+		}
+	}
+	codec.decode = func(decoder *binaryDecoder, value reflect.Value) error {
+		for i, fieldIndex := range fieldIndexes {
+			err := fieldCodecs[i].decode(decoder, value.Field(fieldIndex))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func encodeBool(encoder *binaryEncoder, value reflect.Value) {
+	if value.Bool() {
+		encoder.writeByte(1)
+		return
+	}
+
+	encoder.writeByte(0)
+}
+
+func decodeBool(decoder *binaryDecoder, value reflect.Value) error {
+	boolValue, err := decoder.readBool()
+	if err != nil {
+		return err
+	}
+
+	value.SetBool(boolValue)
+	return nil
+}
+
+func encodeInt(encoder *binaryEncoder, value reflect.Value) {
+	encoder.buffer = binary.AppendVarint(encoder.buffer, value.Int())
+}
+
+func decodeInt(decoder *binaryDecoder, value reflect.Value) error {
+	intValue, err := decoder.readVarint()
+	if err != nil {
+		return err
+	}
+	if value.OverflowInt(intValue) {
+		return ErrInvalidBinaryData
+	}
+
+	value.SetInt(intValue)
+	return nil
+}
+
+func encodeUint(encoder *binaryEncoder, value reflect.Value) {
+	encoder.writeUvarint(value.Uint())
+}
+
+func decodeUint(decoder *binaryDecoder, value reflect.Value) error {
+	uintValue, err := decoder.readUvarint()
+	if err != nil {
+		return err
+	}
+	if value.OverflowUint(uintValue) {
+		return ErrInvalidBinaryData
+	}
+
+	value.SetUint(uintValue)
+	return nil
+}
+
+func encodeFloat(encoder *binaryEncoder, value reflect.Value) {
+	encoder.buffer = binary.LittleEndian.AppendUint64(encoder.buffer, math.Float64bits(value.Float()))
+}
+
+func decodeFloat(decoder *binaryDecoder, value reflect.Value) error {
+	floatBytes, err := decoder.readBytes(8)
+	if err != nil {
+		return err
+	}
+
+	value.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(floatBytes)))
+	return nil
+}
+
+func encodeString(encoder *binaryEncoder, value reflect.Value) {
+	stringValue := value.String()
+	encoder.writeUvarint(uint64(len(stringValue)))
+	encoder.buffer = append(encoder.buffer, stringValue...)
+}
+
+func decodeString(decoder *binaryDecoder, value reflect.Value) error {
+	length, err := decoder.readUvarint()
+	if err != nil {
+		return err
+	}
+
+	stringBytes, err := decoder.readBytes(length)
+	if err != nil {
+		return err
+	}
+
+	value.SetString(string(stringBytes))
+	return nil
+}
+
+func encodeByteSlice(encoder *binaryEncoder, value reflect.Value) {
+	if value.IsNil() {
+		encoder.writeUvarint(0)
+		return
+	}
+
+	byteSlice := value.Bytes()
+	encoder.writeUvarint(uint64(len(byteSlice)) + 1)
+	encoder.buffer = append(encoder.buffer, byteSlice...)
+}
+
+func decodeByteSlice(decoder *binaryDecoder, value reflect.Value) error {
+	length, isNil, err := decoder.readLength()
+	if err != nil {
+		return err
+	}
+	if isNil {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+
+	byteSlice, err := decoder.readBytes(uint64(length))
+	if err != nil {
+		return err
+	}
+
+	value.SetBytes(append(make([]byte, 0, length), byteSlice...))
+	return nil
+}
+
+func encodeByteArray(encoder *binaryEncoder, value reflect.Value) {
+	for i := 0; i < value.Len(); i++ {
+		encoder.writeByte(byte(value.Index(i).Uint()))
+	}
+}
+
+func decodeByteArray(decoder *binaryDecoder, value reflect.Value) error {
+	byteArray, err := decoder.readBytes(uint64(value.Len()))
+	if err != nil {
+		return err
+	}
+
+	for i, byteValue := range byteArray {
+		value.Index(i).SetUint(uint64(byteValue))
+	}
+
+	return nil
+}
+
+// A big integer is written as a varint header, (length of magnitude << 1 | sign) + 1, followed by the magnitude;
+// the header of a nil pointer is 0
+func encodeBigIntPointer(encoder *binaryEncoder, value reflect.Value) {
+	if value.IsNil() {
+		encoder.writeUvarint(0)
+		return
+	}
+
+	encoder.writeBigInt(value.Interface().(*big.Int))
+}
+
+func decodeBigIntPointer(decoder *binaryDecoder, value reflect.Value) error {
+	bigInt, err := decoder.readBigInt()
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(bigInt))
+	return nil
+}
+
+func encodeBigInt(encoder *binaryEncoder, value reflect.Value) {
+	bigInt := value.Interface().(big.Int)
+	encoder.writeBigInt(&bigInt)
+}
+
+func decodeBigInt(decoder *binaryDecoder, value reflect.Value) error {
+	bigInt, err := decoder.readBigInt()
+	if err != nil {
+		return err
+	}
+	if bigInt == nil {
+		return ErrInvalidBinaryData
+	}
+
+	value.Set(reflect.ValueOf(bigInt).Elem())
+	return nil
+}
+
+type binaryEncoder struct {
+	buffer []byte
+}
+
+func (encoder *binaryEncoder) writeByte(value byte) {
+	encoder.buffer = append(encoder.buffer, value)
+}
+
+func (encoder *binaryEncoder) writeUvarint(value uint64) {
+	encoder.buffer = binary.AppendUvarint(encoder.buffer, value)
+}
+
+func (encoder *binaryEncoder) writeBigInt(value *big.Int) {
+	magnitude := value.Bytes()
+	header := uint64(len(magnitude)) << 1
+	if value.Sign() < 0 {
+		header |= 1
+	}
+
+	encoder.writeUvarint(header + 1)
+	encoder.buffer = append(encoder.buffer, magnitude...)
+}
+
+type binaryDecoder struct {
+	data   []byte
+	offset int
+}
+
+func (decoder *binaryDecoder) remaining() int {
+	return len(decoder.data) - decoder.offset
+}
+
+func (decoder *binaryDecoder) readBytes(length uint64) ([]byte, error) {
+	if length > uint64(decoder.remaining()) {
+		return nil, ErrInvalidBinaryData
+	}
+
+	start := decoder.offset
+	decoder.offset += int(length)
+	return decoder.data[start:decoder.offset], nil
+}
+
+func (decoder *binaryDecoder) readBool() (bool, error) {
+	boolBytes, err := decoder.readBytes(1)
+	if err != nil {
+		return false, err
+	}
+
+	switch boolBytes[0] {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, ErrInvalidBinaryData
+	}
+}
+
+func (decoder *binaryDecoder) readUvarint() (uint64, error) {
+	value, length := binary.Uvarint(decoder.data[decoder.offset:])
+	if length <= 0 {
+		return 0, ErrInvalidBinaryData
+	}
+
+	decoder.offset += length
+	return value, nil
+}
+
+func (decoder *binaryDecoder) readVarint() (int64, error) {
+	value, length := binary.Varint(decoder.data[decoder.offset:])
+	if length <= 0 {
+		return 0, ErrInvalidBinaryData
+	}
+
+	decoder.offset += length
+	return value, nil
+}
+
+// readLength reads the length of a slice or map, written as length + 1, with 0 for nil;
+// lengths larger than the remaining data are rejected, which bounds the allocations
+// (thus slices of empty structs, which take no bytes, are not supported)
+func (decoder *binaryDecoder) readLength() (int, bool, error) {
+	header, err := decoder.readUvarint()
+	if err != nil {
+		return 0, false, err
+	}
+	if header == 0 {
+		return 0, true, nil
+	}
+
+	length := header - 1
+	if length > uint64(decoder.remaining()) {
+		return 0, false, ErrInvalidBinaryData
+	}
+
+	return int(length), false, nil
+}
+
+func (decoder *binaryDecoder) readBigInt() (*big.Int, error) {
+	header, err := decoder.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if header == 0 {
+		return nil, nil
+	}
+
+	header--
+	magnitude, err := decoder.readBytes(header >> 1)
+	if err != nil {
+		return nil, err
+	}
+
+	bigInt := big.NewInt(0)
+	if len(magnitude) == 0 {
+		return bigInt, nil
+	}
+
+	bigInt.SetBytes(magnitude)
+	if header&1 == 1 {
+		bigInt.Neg(bigInt)
+	}
+
+	return bigInt, nil
+}
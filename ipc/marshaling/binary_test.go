@@ -0,0 +1,194 @@
+package marshaling
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+type binaryTestNode struct {
+	Name     string
+	Children []*binaryTestNode
+}
+
+type binaryTestMessage struct {
+	Kind         uint32
+	Delta        int64
+	Small        int8
+	Ratio        float64
+	Flag         bool
+	Text         string
+	Data         []byte
+	Empty        []byte
+	Missing      []byte
+	Chunks       [][]byte
+	Hash         [4]byte
+	Counters     [2]uint16
+	Schedule     map[string]map[string]uint64
+	Names        map[string]struct{}
+	NilMap       map[string]uint64
+	Value        *big.Int
+	Negative     *big.Int
+	Zero         *big.Int
+	NoValue      *big.Int
+	Embedded     big.Int
+	CallInput    *vmcommon.ContractCallInput
+	Token        *esdt.ESDigitalToken
+	Tree         *binaryTestNode
+	Skipped      string `json:"-"`
+	unexported   string
+	CallType     vm.CallType
+	NilCallInput *vmcommon.ContractCallInput
+}
+
+func createBinaryTestMessage() *binaryTestMessage {
+	message := &binaryTestMessage{
+		Kind:     42,
+		Delta:    -1234567,
+		Small:    -128,
+		Ratio:    0.5,
+		Flag:     true,
+		Text:     "hello",
+		Data:     []byte{0, 1, 128, 255},
+		Empty:    []byte{},
+		Chunks:   [][]byte{{1}, {}, nil},
+		Hash:     [4]byte{1, 2, 200, 255},
+		Counters: [2]uint16{7, 65535},
+		Schedule: map[string]map[string]uint64{
+			"BaseOperationCost": {"StorePerByte": 50, "DataCopyPerByte": 1},
+			"EthAPICost":        {},
+		},
+		Names:    map[string]struct{}{"ESDTTransfer": {}, "ClaimDeveloperRewards": {}},
+		Value:    big.NewInt(1000000),
+		Negative: big.NewInt(-255),
+		Zero:     big.NewInt(0),
+		CallInput: &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  []byte("caller"),
+				Arguments:   [][]byte{{1, 2}, {}},
+				CallValue:   big.NewInt(10),
+				CallType:    vm.AsynchronousCall,
+				GasProvided: 2000000,
+				ESDTTransfers: []*vmcommon.ESDTTransfer{
+					{ESDTValue: big.NewInt(5), ESDTTokenName: []byte("TOKEN-abcdef"), ESDTTokenNonce: 3},
+				},
+				AsyncArguments: &vmcommon.AsyncArguments{CallID: []byte("id"), GasAccumulated: 7},
+			},
+			RecipientAddr: []byte("recipient"),
+			Function:      "increment",
+		},
+		Token: &esdt.ESDigitalToken{
+			Value:         big.NewInt(20),
+			TokenMetaData: &esdt.MetaData{Nonce: 1, URIs: [][]byte{[]byte("uri")}},
+		},
+		Tree: &binaryTestNode{
+			Name:     "root",
+			Children: []*binaryTestNode{{Name: "left"}, {Name: "right", Children: []*binaryTestNode{}}},
+		},
+		CallType: vm.ESDTTransferAndExecute,
+	}
+	message.Embedded.SetInt64(-77)
+	return message
+}
+
+func TestBinaryMarshalizer_RoundTrip(t *testing.T) {
+	marshalizer := CreateMarshalizer(Binary)
+
+	message := createBinaryTestMessage()
+	serialized, err := marshalizer.Marshal(message)
+	require.Nil(t, err)
+
+	decoded := &binaryTestMessage{}
+	err = marshalizer.Unmarshal(decoded, serialized)
+	require.Nil(t, err)
+
+	message.Skipped = ""
+	require.Equal(t, message, decoded)
+	require.NotNil(t, decoded.Empty)
+	require.Nil(t, decoded.Missing)
+	require.Nil(t, decoded.NilMap)
+	require.Nil(t, decoded.NoValue)
+	require.Nil(t, decoded.NilCallInput)
+}
+
+func TestBinaryMarshalizer_SerializationIsStable(t *testing.T) {
+	marshalizer := CreateMarshalizer(Binary)
+
+	expected, err := marshalizer.Marshal(createBinaryTestMessage())
+	require.Nil(t, err)
+
+	for i := 0; i < 10; i++ {
+		serialized, err := marshalizer.Marshal(createBinaryTestMessage())
+		require.Nil(t, err)
+		require.Equal(t, expected, serialized)
+	}
+}
+
+func TestBinaryMarshalizer_IsMoreCompactThanJSONAndGob(t *testing.T) {
+	message := createBinaryTestMessage()
+
+	binarySerialized, err := CreateMarshalizer(Binary).Marshal(message)
+	require.Nil(t, err)
+	jsonSerialized, err := CreateMarshalizer(JSON).Marshal(message)
+	require.Nil(t, err)
+	gobSerialized, err := CreateMarshalizer(Gob).Marshal(message)
+	require.Nil(t, err)
+
+	require.Less(t, len(binarySerialized), len(jsonSerialized))
+	require.Less(t, len(binarySerialized), len(gobSerialized))
+}
+
+func TestBinaryMarshalizer_InvalidData(t *testing.T) {
+	marshalizer := CreateMarshalizer(Binary)
+
+	serialized, err := marshalizer.Marshal(createBinaryTestMessage())
+	require.Nil(t, err)
+
+	for length := 0; length < len(serialized); length++ {
+		err = marshalizer.Unmarshal(&binaryTestMessage{}, serialized[:length])
+		require.True(t, errors.Is(err, ErrInvalidBinaryData), "length %d", length)
+	}
+
+	err = marshalizer.Unmarshal(&binaryTestMessage{}, append(serialized, 0))
+	require.Equal(t, ErrInvalidBinaryData, err)
+
+	err = marshalizer.Unmarshal(&struct{ Small int8 }{}, []byte{0x80, 0x02})
+	require.Equal(t, ErrInvalidBinaryData, err)
+
+	err = marshalizer.Unmarshal(&struct{ Flag bool }{}, []byte{2})
+	require.Equal(t, ErrInvalidBinaryData, err)
+}
+
+func TestBinaryMarshalizer_UnsupportedTypes(t *testing.T) {
+	marshalizer := CreateMarshalizer(Binary)
+
+	_, err := marshalizer.Marshal(&struct{ Handler interface{} }{})
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+
+	_, err = marshalizer.Marshal(&struct{ Callback func() }{})
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+
+	_, err = marshalizer.Marshal((*binaryTestMessage)(nil))
+	require.True(t, errors.Is(err, ErrUnsupportedType))
+
+	// Fields excluded from JSON are excluded here as well
+	_, err = marshalizer.Marshal(&struct {
+		Handler interface{} `json:"-"`
+	}{})
+	require.Nil(t, err)
+
+	err = marshalizer.Unmarshal(binaryTestMessage{}, []byte{})
+	require.Equal(t, ErrInvalidUnmarshalTarget, err)
+}
+
+func TestParseKind(t *testing.T) {
+	require.Equal(t, JSON, ParseKind("json"))
+	require.Equal(t, Gob, ParseKind(" gob "))
+	require.Equal(t, Binary, ParseKind("Binary"))
+	require.Equal(t, JSON, ParseKind("unknown"))
+}
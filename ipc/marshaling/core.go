@@ -10,6 +10,8 @@ const (
 	JSON MarshalizerKind = iota
 	// Gob is a marshalizer kind
 	Gob
+	// Binary is a marshalizer kind, a compact encoding derived from the Go types of the messages
+	Binary
 )
 
 // ParseKind gets a kind from a string
@@ -22,6 +24,8 @@ func ParseKind(str string) MarshalizerKind {
 		return JSON
 	case "GOB":
 		return Gob
+	case "BINARY":
+		return Binary
 	default:
 		return JSON
 	}
@@ -0,0 +1,12 @@
+package marshaling
+
+import "errors"
+
+// ErrUnsupportedType signals that a type cannot be marshalized by the binary marshalizer
+var ErrUnsupportedType = errors.New("type not supported by the binary marshalizer")
+
+// ErrInvalidBinaryData signals that the data to unmarshal is truncated or malformed
+var ErrInvalidBinaryData = errors.New("invalid binary data")
+
+// ErrInvalidUnmarshalTarget signals that the data is unmarshalized into something other than a non-nil pointer
+var ErrInvalidUnmarshalTarget = errors.New("unmarshal target must be a non-nil pointer")
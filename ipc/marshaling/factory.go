@@ -7,6 +7,8 @@ func CreateMarshalizer(kind MarshalizerKind) Marshalizer {
 		return &jsonMarshalizer{}
 	case Gob:
 		return &gobMarshalizer{}
+	case Binary:
+		return &binaryMarshalizer{}
 	default:
 		return &jsonMarshalizer{}
 	}
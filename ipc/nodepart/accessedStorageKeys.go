@@ -0,0 +1,96 @@
+package nodepart
+
+import (
+	"bytes"
+	"sort"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const maxTrackedContracts = 1024
+const maxTrackedKeysPerContract = 256
+
+// accessedStorageKeys counts, for each contract, how many times VM has read each storage key,
+// so that the values of the most accessed keys can be shipped along with the next calls of the contract.
+// Since VM caches the storage during a transaction, a count is roughly the number of transactions which read the key.
+type accessedStorageKeys struct {
+	counters map[string]map[string]uint32
+}
+
+func newAccessedStorageKeys() *accessedStorageKeys {
+	return &accessedStorageKeys{
+		counters: make(map[string]map[string]uint32),
+	}
+}
+
+func (keys *accessedStorageKeys) add(address []byte, key []byte) {
+	contractCounters, ok := keys.counters[string(address)]
+	if !ok {
+		if len(keys.counters) >= maxTrackedContracts {
+			keys.counters = make(map[string]map[string]uint32)
+		}
+
+		contractCounters = make(map[string]uint32)
+		keys.counters[string(address)] = contractCounters
+	}
+
+	_, isTracked := contractCounters[string(key)]
+	if !isTracked && len(contractCounters) >= maxTrackedKeysPerContract {
+		ageCounters(contractCounters)
+		if len(contractCounters) >= maxTrackedKeysPerContract {
+			return
+		}
+	}
+
+	contractCounters[string(key)]++
+}
+
+// ageCounters halves the counters, and forgets the keys which were seldom accessed, to make room for new ones
+func ageCounters(contractCounters map[string]uint32) {
+	for key, counter := range contractCounters {
+		if counter <= 1 {
+			delete(contractCounters, key)
+			continue
+		}
+
+		contractCounters[key] = counter / 2
+	}
+}
+
+// mostAccessed returns the keys of the contract, the most accessed first
+func (keys *accessedStorageKeys) mostAccessed(address []byte, maxKeys int) [][]byte {
+	contractCounters := keys.counters[string(address)]
+
+	result := make([][]byte, 0, len(contractCounters))
+	for key := range contractCounters {
+		result = append(result, []byte(key))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		counterI := contractCounters[string(result[i])]
+		counterJ := contractCounters[string(result[j])]
+		if counterI != counterJ {
+			return counterI > counterJ
+		}
+
+		return bytes.Compare(result[i], result[j]) < 0
+	})
+
+	if len(result) > maxKeys {
+		result = result[:maxKeys]
+	}
+
+	return result
+}
+
+// storageAccessTracker is the hook of the Node part when the driver ships prefetch hints: it counts the storage reads of VM
+type storageAccessTracker struct {
+	vmcommon.BlockchainHook
+	keys *accessedStorageKeys
+}
+
+// GetStorageData counts the access, then reads the storage
+func (tracker *storageAccessTracker) GetStorageData(address []byte, key []byte) ([]byte, uint32, error) {
+	tracker.keys.add(address, key)
+	return tracker.BlockchainHook.GetStorageData(address, key)
+}
@@ -1,183 +1,174 @@
+// Code generated by ipc/codegen from vmcommon.BlockchainHook; DO NOT EDIT.
+
 package nodepart
 
 import (
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
-	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 )
 
+// addBlockchainRepliers sets the repliers of the hook calls
+func (part *NodePart) addBlockchainRepliers() {
+	part.Repliers[common.BlockchainNewAddressRequest] = part.replyToBlockchainNewAddress
+	part.Repliers[common.BlockchainGetStorageDataRequest] = part.replyToBlockchainGetStorageData
+	part.Repliers[common.BlockchainGetBlockhashRequest] = part.replyToBlockchainGetBlockhash
+	part.Repliers[common.BlockchainLastNonceRequest] = part.replyToBlockchainLastNonce
+	part.Repliers[common.BlockchainLastRoundRequest] = part.replyToBlockchainLastRound
+	part.Repliers[common.BlockchainLastTimeStampRequest] = part.replyToBlockchainLastTimeStamp
+	part.Repliers[common.BlockchainLastRandomSeedRequest] = part.replyToBlockchainLastRandomSeed
+	part.Repliers[common.BlockchainLastEpochRequest] = part.replyToBlockchainLastEpoch
+	part.Repliers[common.BlockchainGetStateRootHashRequest] = part.replyToBlockchainGetStateRootHash
+	part.Repliers[common.BlockchainCurrentNonceRequest] = part.replyToBlockchainCurrentNonce
+	part.Repliers[common.BlockchainCurrentRoundRequest] = part.replyToBlockchainCurrentRound
+	part.Repliers[common.BlockchainCurrentTimeStampRequest] = part.replyToBlockchainCurrentTimeStamp
+	part.Repliers[common.BlockchainCurrentRandomSeedRequest] = part.replyToBlockchainCurrentRandomSeed
+	part.Repliers[common.BlockchainCurrentEpochRequest] = part.replyToBlockchainCurrentEpoch
+	part.Repliers[common.BlockchainProcessBuiltinFunctionRequest] = part.replyToBlockchainProcessBuiltinFunction
+	part.Repliers[common.BlockchainGetBuiltinFunctionNamesRequest] = part.replyToBlockchainGetBuiltinFunctionNames
+	part.Repliers[common.BlockchainGetAllStateRequest] = part.replyToBlockchainGetAllState
+	part.Repliers[common.BlockchainGetUserAccountRequest] = part.replyToBlockchainGetUserAccount
+	part.Repliers[common.BlockchainGetCodeRequest] = part.replyToBlockchainGetCode
+	part.Repliers[common.BlockchainGetShardOfAddressRequest] = part.replyToBlockchainGetShardOfAddress
+	part.Repliers[common.BlockchainIsSmartContractRequest] = part.replyToBlockchainIsSmartContract
+	part.Repliers[common.BlockchainIsPayableRequest] = part.replyToBlockchainIsPayable
+	part.Repliers[common.BlockchainSaveCompiledCodeRequest] = part.replyToBlockchainSaveCompiledCode
+	part.Repliers[common.BlockchainGetCompiledCodeRequest] = part.replyToBlockchainGetCompiledCode
+	part.Repliers[common.BlockchainGetESDTTokenRequest] = part.replyToBlockchainGetESDTToken
+}
+
 func (part *NodePart) replyToBlockchainNewAddress(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainNewAddressRequest)
 	result, err := part.blockchain.NewAddress(typedRequest.CreatorAddress, typedRequest.CreatorNonce, typedRequest.VMType)
-	response := common.NewMessageBlockchainNewAddressResponse(result, err)
-	return response
+	return common.NewMessageBlockchainNewAddressResponse(result, err)
 }
 
 func (part *NodePart) replyToBlockchainGetStorageData(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainGetStorageDataRequest)
 	data, _, err := part.blockchain.GetStorageData(typedRequest.Address, typedRequest.Index)
-	response := common.NewMessageBlockchainGetStorageDataResponse(data, err)
-	return response
+	return common.NewMessageBlockchainGetStorageDataResponse(data, err)
 }
 
 func (part *NodePart) replyToBlockchainGetBlockhash(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainGetBlockhashRequest)
 	result, err := part.blockchain.GetBlockhash(typedRequest.Nonce)
-	response := common.NewMessageBlockchainGetBlockhashResponse(result, err)
-	return response
+	return common.NewMessageBlockchainGetBlockhashResponse(result, err)
 }
 
 func (part *NodePart) replyToBlockchainLastNonce(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.LastNonce()
-	response := common.NewMessageBlockchainLastNonceResponse(result)
-	return response
+	return common.NewMessageBlockchainLastNonceResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainLastRound(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.LastRound()
-	response := common.NewMessageBlockchainLastRoundResponse(result)
-	return response
+	return common.NewMessageBlockchainLastRoundResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainLastTimeStamp(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.LastTimeStamp()
-	response := common.NewMessageBlockchainLastTimeStampResponse(result)
-	return response
+	return common.NewMessageBlockchainLastTimeStampResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainLastRandomSeed(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.LastRandomSeed()
-	response := common.NewMessageBlockchainLastRandomSeedResponse(result)
-	return response
+	return common.NewMessageBlockchainLastRandomSeedResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainLastEpoch(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.LastEpoch()
-	response := common.NewMessageBlockchainLastEpochResponse(result)
-	return response
+	return common.NewMessageBlockchainLastEpochResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainGetStateRootHash(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.GetStateRootHash()
-	response := common.NewMessageBlockchainGetStateRootHashResponse(result)
-	return response
+	return common.NewMessageBlockchainGetStateRootHashResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainCurrentNonce(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.CurrentNonce()
-	response := common.NewMessageBlockchainCurrentNonceResponse(result)
-	return response
+	return common.NewMessageBlockchainCurrentNonceResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainCurrentRound(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.CurrentRound()
-	response := common.NewMessageBlockchainCurrentRoundResponse(result)
-	return response
+	return common.NewMessageBlockchainCurrentRoundResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainCurrentTimeStamp(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.CurrentTimeStamp()
-	response := common.NewMessageBlockchainCurrentTimeStampResponse(result)
-	return response
+	return common.NewMessageBlockchainCurrentTimeStampResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainCurrentRandomSeed(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.CurrentRandomSeed()
-	response := common.NewMessageBlockchainCurrentRandomSeedResponse(result)
-	return response
+	return common.NewMessageBlockchainCurrentRandomSeedResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainCurrentEpoch(_ common.MessageHandler) common.MessageHandler {
 	result := part.blockchain.CurrentEpoch()
-	response := common.NewMessageBlockchainCurrentEpochResponse(result)
-	return response
+	return common.NewMessageBlockchainCurrentEpochResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainProcessBuiltinFunction(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainProcessBuiltinFunctionRequest)
 	vmOutput, err := part.blockchain.ProcessBuiltInFunction(&typedRequest.CallInput)
-	response := common.NewMessageBlockchainProcessBuiltinFunctionResponse(vmOutput, err)
-	return response
-}
-
-func (part *NodePart) replyToBlockchainGetESDTToken(request common.MessageHandler) common.MessageHandler {
-	typedRequest := request.(*common.MessageBlockchainGetESDTTokenRequest)
-	esdtData, err := part.blockchain.GetESDTToken(typedRequest.Address, typedRequest.TokenID, typedRequest.Nonce)
-	response := common.NewMessageBlockchainGetESDTTokenResponse(esdtData, err)
-	return response
+	return common.NewMessageBlockchainProcessBuiltinFunctionResponse(vmOutput, err)
 }
 
 func (part *NodePart) replyToBlockchainGetBuiltinFunctionNames(_ common.MessageHandler) common.MessageHandler {
 	functionNames := part.blockchain.GetBuiltinFunctionNames()
-	response := common.NewMessageBlockchainGetBuiltinFunctionNamesResponse(functionNames)
-	return response
+	return common.NewMessageBlockchainGetBuiltinFunctionNamesResponse(functionNames)
 }
 
 func (part *NodePart) replyToBlockchainGetAllState(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainGetAllStateRequest)
-	state, err := part.blockchain.GetAllState(typedRequest.Address)
-	response := common.NewMessageBlockchainGetAllStateResponse(state, err)
-	return response
+	allState, err := part.blockchain.GetAllState(typedRequest.Address)
+	return common.NewMessageBlockchainGetAllStateResponse(allState, err)
 }
 
 func (part *NodePart) replyToBlockchainGetUserAccount(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainGetUserAccountRequest)
 	account, err := part.blockchain.GetUserAccount(typedRequest.Address)
-
-	if err != nil || vmhost.IfNil(account) {
-		return common.NewMessageBlockchainGetUserAccountResponse(nil, err)
-	}
-
-	return common.NewMessageBlockchainGetUserAccountResponse(&common.Account{
-		Nonce:           account.GetNonce(),
-		Address:         account.AddressBytes(),
-		Balance:         account.GetBalance(),
-		CodeMetadata:    account.GetCodeMetadata(),
-		CodeHash:        account.GetCodeHash(),
-		RootHash:        account.GetRootHash(),
-		DeveloperReward: account.GetDeveloperReward(),
-		OwnerAddress:    account.GetOwnerAddress(),
-		UserName:        account.GetUserName(),
-	}, err)
+	return common.NewMessageBlockchainGetUserAccountResponse(account, err)
 }
 
 func (part *NodePart) replyToBlockchainGetCode(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainGetCodeRequest)
 	code := part.blockchain.GetCode(typedRequest.Account)
-
 	return common.NewMessageBlockchainGetCodeResponse(code)
 }
 
 func (part *NodePart) replyToBlockchainGetShardOfAddress(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainGetShardOfAddressRequest)
-	result := part.blockchain.GetShardOfAddress(typedRequest.Address)
-	response := common.NewMessageBlockchainGetShardOfAddressResponse(result)
-	return response
+	shard := part.blockchain.GetShardOfAddress(typedRequest.Address)
+	return common.NewMessageBlockchainGetShardOfAddressResponse(shard)
 }
 
 func (part *NodePart) replyToBlockchainIsSmartContract(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainIsSmartContractRequest)
 	result := part.blockchain.IsSmartContract(typedRequest.Address)
-	response := common.NewMessageBlockchainIsSmartContractResponse(result)
-	return response
+	return common.NewMessageBlockchainIsSmartContractResponse(result)
 }
 
 func (part *NodePart) replyToBlockchainIsPayable(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainIsPayableRequest)
 	result, err := part.blockchain.IsPayable(nil, typedRequest.Address)
-	response := common.NewMessageBlockchainIsPayableResponse(result, err)
-	return response
+	return common.NewMessageBlockchainIsPayableResponse(result, err)
 }
 
 func (part *NodePart) replyToBlockchainSaveCompiledCode(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainSaveCompiledCodeRequest)
 	part.blockchain.SaveCompiledCode(typedRequest.CodeHash, typedRequest.Code)
-	response := common.NewMessageBlockchainSaveCompiledCodeResponse()
-	return response
+	return common.NewMessageBlockchainSaveCompiledCodeResponse()
 }
 
 func (part *NodePart) replyToBlockchainGetCompiledCode(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageBlockchainGetCompiledCodeRequest)
 	found, code := part.blockchain.GetCompiledCode(typedRequest.CodeHash)
-	response := common.NewMessageBlockchainGetCompiledCodeResponse(found, code)
-	return response
+	return common.NewMessageBlockchainGetCompiledCodeResponse(found, code)
+}
+
+func (part *NodePart) replyToBlockchainGetESDTToken(request common.MessageHandler) common.MessageHandler {
+	typedRequest := request.(*common.MessageBlockchainGetESDTTokenRequest)
+	esdtData, err := part.blockchain.GetESDTToken(typedRequest.Address, typedRequest.TokenID, typedRequest.Nonce)
+	return common.NewMessageBlockchainGetESDTTokenResponse(esdtData, err)
 }
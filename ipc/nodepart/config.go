@@ -1,6 +1,44 @@
 package nodepart
 
+import "github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+
+// The defaults of the heartbeats, in milliseconds
+const (
+	defaultHeartbeatPeriod  = 100
+	defaultHeartbeatTimeout = 1000
+)
+
 // Config is the configuration for the driver and for Node's part
 type Config struct {
+	// MaxLoopTime is the maximum duration, in milliseconds, of a dialogue with VM, unless set otherwise in Timeouts
 	MaxLoopTime int
+	// Timeouts are the maximum durations, in milliseconds, of the dialogues started by the requests of the given kinds
+	// (e.g. ContractDeployRequest and ContractCallRequest)
+	Timeouts map[common.MessageKind]int
+	// HookReplyTimeout is the time, in milliseconds, VM waits for the reply to a hook call; 0 means no timeout
+	HookReplyTimeout int
+	// HeartbeatPeriod is the period, in milliseconds, of the heartbeats VM sends while executing a request; 0 means the default period
+	HeartbeatPeriod int
+	// HeartbeatTimeout is the time, in milliseconds, after which VM is considered stuck when it sends no message, not even a heartbeat;
+	// 0 means the default timeout. When the timeout of a dialogue is exceeded while VM sends heartbeats, VM is considered slow.
+	HeartbeatTimeout int
+	// RestartAttempts is the number of attempts to restart VM, when it is closed; 0 means the default number
+	RestartAttempts int
+	// RestartBackoff is the delay, in milliseconds, after the first failed restart, doubled after each subsequent one
+	// up to MaxRestartBackoff; 0 means the default delay
+	RestartBackoff int
+	// MaxRestartBackoff is the maximum delay, in milliseconds, between the attempts to restart VM; 0 means the default delay
+	MaxRestartBackoff int
+	// VMAddress is the address of an already running VM server, unix://<path> or tcp://<host>:<port>;
+	// when empty, VM is started as a child process of the Node
+	VMAddress string
+	// DialTimeout is the timeout, in milliseconds, of connecting to the VM server; 0 means the default timeout
+	DialTimeout int
+	// PrefetchHints enables shipping, along with each contract call, the account and code of the contract
+	// and the values of the storage keys it reads most often, to spare VM the corresponding hook calls
+	PrefetchHints bool
+	// MaxPrefetchedKeys is the maximum number of storage entries shipped along with a contract call; 0 means the default
+	MaxPrefetchedKeys int
+	// RecordingPath is the replay file to which the messages exchanged with VM are appended (see cmd/vmreplay); empty means no recording
+	RecordingPath string
 }
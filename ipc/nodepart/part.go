@@ -1,6 +1,7 @@
 package nodepart
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -16,12 +17,14 @@ type NodePart struct {
 	blockchain vmcommon.BlockchainHook
 	Repliers   []common.MessageReplier
 	config     Config
+	// heartbeats tells whether VM sends heartbeats, as agreed upon in the handshake
+	heartbeats bool
 }
 
 // NewNodePart creates the Node part
 func NewNodePart(
-	input *os.File,
-	output *os.File,
+	input common.Transport,
+	output common.Transport,
 	blockchain vmcommon.BlockchainHook,
 	config Config,
 	marshalizer marshaling.Marshalizer,
@@ -35,52 +38,67 @@ func NewNodePart(
 	}
 
 	part.Repliers = common.CreateReplySlots(part.noopReplier)
-	part.Repliers[common.BlockchainNewAddressRequest] = part.replyToBlockchainNewAddress
-	part.Repliers[common.BlockchainGetStorageDataRequest] = part.replyToBlockchainGetStorageData
-	part.Repliers[common.BlockchainGetBlockhashRequest] = part.replyToBlockchainGetBlockhash
-	part.Repliers[common.BlockchainLastNonceRequest] = part.replyToBlockchainLastNonce
-	part.Repliers[common.BlockchainLastRoundRequest] = part.replyToBlockchainLastRound
-	part.Repliers[common.BlockchainLastTimeStampRequest] = part.replyToBlockchainLastTimeStamp
-	part.Repliers[common.BlockchainLastRandomSeedRequest] = part.replyToBlockchainLastRandomSeed
-	part.Repliers[common.BlockchainLastEpochRequest] = part.replyToBlockchainLastEpoch
-	part.Repliers[common.BlockchainGetStateRootHashRequest] = part.replyToBlockchainGetStateRootHash
-	part.Repliers[common.BlockchainCurrentNonceRequest] = part.replyToBlockchainCurrentNonce
-	part.Repliers[common.BlockchainCurrentRoundRequest] = part.replyToBlockchainCurrentRound
-	part.Repliers[common.BlockchainCurrentTimeStampRequest] = part.replyToBlockchainCurrentTimeStamp
-	part.Repliers[common.BlockchainCurrentRandomSeedRequest] = part.replyToBlockchainCurrentRandomSeed
-	part.Repliers[common.BlockchainCurrentEpochRequest] = part.replyToBlockchainCurrentEpoch
-	part.Repliers[common.BlockchainProcessBuiltinFunctionRequest] = part.replyToBlockchainProcessBuiltinFunction
-	part.Repliers[common.BlockchainGetESDTTokenRequest] = part.replyToBlockchainGetESDTToken
-	part.Repliers[common.BlockchainGetBuiltinFunctionNamesRequest] = part.replyToBlockchainGetBuiltinFunctionNames
-	part.Repliers[common.BlockchainGetAllStateRequest] = part.replyToBlockchainGetAllState
-	part.Repliers[common.BlockchainGetUserAccountRequest] = part.replyToBlockchainGetUserAccount
-	part.Repliers[common.BlockchainGetCodeRequest] = part.replyToBlockchainGetCode
-	part.Repliers[common.BlockchainGetShardOfAddressRequest] = part.replyToBlockchainGetShardOfAddress
-	part.Repliers[common.BlockchainIsSmartContractRequest] = part.replyToBlockchainIsSmartContract
-	part.Repliers[common.BlockchainIsPayableRequest] = part.replyToBlockchainIsPayable
-	part.Repliers[common.BlockchainSaveCompiledCodeRequest] = part.replyToBlockchainSaveCompiledCode
-	part.Repliers[common.BlockchainGetCompiledCodeRequest] = part.replyToBlockchainGetCompiledCode
+	part.addBlockchainRepliers()
+	part.Repliers[common.BlockchainBatchRequest] = part.replyToBlockchainBatch
 
 	return part, nil
 }
 
+// EnableCapabilities enables the capabilities agreed upon in the handshake with VM
+func (part *NodePart) EnableCapabilities(capabilities []string) {
+	if common.HasCapability(capabilities, common.CapabilityCompression) {
+		part.Messenger.EnableCompression()
+	}
+	part.heartbeats = common.HasCapability(capabilities, common.CapabilityHeartbeats)
+}
+
+// EnableRecording records the messages exchanged with VM, from now on
+func (part *NodePart) EnableRecording(recorder *common.DialogueRecorder) {
+	part.Messenger.SetRecorder(recorder)
+}
+
 func (part *NodePart) noopReplier(_ common.MessageHandler) common.MessageHandler {
 	log.Error("noopReplier called")
 	return common.CreateMessage(common.UndefinedRequestOrResponse)
 }
 
+// replyToBlockchainBatch answers each hook call of the batch, as if they were received one at a time
+func (part *NodePart) replyToBlockchainBatch(request common.MessageHandler) common.MessageHandler {
+	typedRequest := request.(*common.MessageBlockchainBatchRequest)
+	hookCalls, err := part.Messenger.UnpackMessages(typedRequest.Requests)
+	if err != nil {
+		return common.NewMessageBlockchainBatchResponse(nil, err)
+	}
+
+	responses := make([]common.MessageHandler, 0, len(hookCalls))
+	for _, hookCall := range hookCalls {
+		if !common.IsHookCall(hookCall) || hookCall.GetKind() == common.BlockchainBatchRequest {
+			return common.NewMessageBlockchainBatchResponse(nil, common.ErrBadBatchOfHookCalls)
+		}
+
+		replier := part.Repliers[hookCall.GetKind()]
+		responses = append(responses, replier(hookCall))
+	}
+
+	rawResponses, err := part.Messenger.PackMessages(responses)
+	return common.NewMessageBlockchainBatchResponse(rawResponses, err)
+}
+
 // StartLoop runs the main loop
 func (part *NodePart) StartLoop(request common.MessageHandler) (common.MessageHandler, error) {
-	defer part.timeTrack(time.Now(), "[NODE] end of loop")
+	start := time.Now()
+	defer part.timeTrack(start, "[NODE] end of loop")
 
 	err := part.Messenger.SendContractRequest(request)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := part.doLoop()
+	response, err := part.doLoop(request)
 	if err != nil {
 		log.Warn("[NODE]: end of loop", "err", err)
+	} else {
+		part.Messenger.GetStatsCollector().AddLatency(request.GetKindName(), time.Since(start))
 	}
 
 	part.Messenger.ResetDialogue()
@@ -90,20 +108,24 @@ func (part *NodePart) StartLoop(request common.MessageHandler) (common.MessageHa
 // doLoop ends when processing the transaction ends or in the case of a critical failure
 // Critical failure = VM timeouts or crashes
 // The error result is set only in case of critical failure
-func (part *NodePart) doLoop() (common.MessageHandler, error) {
-	remainingMilliseconds := part.config.MaxLoopTime
+func (part *NodePart) doLoop(request common.MessageHandler) (common.MessageHandler, error) {
+	deadline := time.Now().Add(time.Duration(part.getTimeout(request.GetKind())) * time.Millisecond)
 
 	for {
-		message, duration, err := part.Messenger.ReceiveHookCallRequestOrContractResponse(remainingMilliseconds)
+		message, _, err := part.Messenger.ReceiveHookCallRequestOrContractResponse(part.getWaitTime(deadline))
 		if err != nil {
-			return nil, err
+			return nil, part.checkReceiveError(err, deadline)
 		}
 
-		remainingMilliseconds -= duration
-		if remainingMilliseconds < 0 {
+		if time.Now().After(deadline) {
+			part.Messenger.GetStatsCollector().CountSlowVMTimeout()
 			return nil, common.ErrVMTimeExpired
 		}
 
+		if common.IsHeartbeat(message) {
+			continue
+		}
+
 		if common.IsHookCall(message) {
 			err := part.replyToHookCallRequest(message)
 			if err != nil {
@@ -130,11 +152,63 @@ func (part *NodePart) doLoop() (common.MessageHandler, error) {
 	}
 }
 
+// getTimeout returns the maximum duration, in milliseconds, of the dialogue started by a request of the given kind
+func (part *NodePart) getTimeout(kind common.MessageKind) int {
+	timeout, ok := part.config.Timeouts[kind]
+	if ok && timeout > 0 {
+		return timeout
+	}
+
+	return part.config.MaxLoopTime
+}
+
+// getWaitTime returns how long, in milliseconds, to wait for the next message of VM: until the deadline of the dialogue,
+// but, when VM sends heartbeats, no longer than the heartbeat timeout
+func (part *NodePart) getWaitTime(deadline time.Time) int {
+	// rounded up, so that the wait does not end before the deadline
+	waitTime := (time.Until(deadline) + time.Millisecond - 1).Milliseconds()
+	if part.heartbeats && waitTime > int64(part.getHeartbeatTimeout()) {
+		waitTime = int64(part.getHeartbeatTimeout())
+	}
+
+	// 0 would mean no timeout at all
+	if waitTime < 1 {
+		waitTime = 1
+	}
+
+	return int(waitTime)
+}
+
+func (part *NodePart) getHeartbeatTimeout() int {
+	if part.config.HeartbeatTimeout <= 0 {
+		return defaultHeartbeatTimeout
+	}
+
+	return part.config.HeartbeatTimeout
+}
+
+// checkReceiveError tells a slow VM (the deadline of the dialogue passed) apart from a stuck VM (no message before the heartbeat timeout)
+func (part *NodePart) checkReceiveError(err error, deadline time.Time) error {
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		return err
+	}
+
+	if part.heartbeats && time.Now().Before(deadline) {
+		part.Messenger.GetStatsCollector().CountStuckVMTimeout()
+		return common.ErrVMNotResponding
+	}
+
+	part.Messenger.GetStatsCollector().CountSlowVMTimeout()
+	return common.ErrVMTimeExpired
+}
+
 func (part *NodePart) replyToHookCallRequest(request common.MessageHandler) error {
-	defer part.timeTrack(time.Now(), fmt.Sprintf("replyToHookCallRequest %s", request.GetKindName()))
+	start := time.Now()
+	defer part.timeTrack(start, fmt.Sprintf("replyToHookCallRequest %s", request.GetKindName()))
 
 	replier := part.Repliers[request.GetKind()]
 	hookResponse := replier(request)
+	part.Messenger.GetStatsCollector().AddLatency(request.GetKindName(), time.Since(start))
 	err := part.Messenger.SendHookCallResponse(hookResponse)
 	return err
 }
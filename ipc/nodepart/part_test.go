@@ -0,0 +1,104 @@
+package nodepart
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVM answers the requests of a NodePart over pipes
+type fakeVM struct {
+	messenger *common.Messenger
+	part      *NodePart
+}
+
+func newFakeVM(t *testing.T, config Config, capabilities []string) *fakeVM {
+	nodeToVMRead, nodeToVMWrite, err := os.Pipe()
+	require.Nil(t, err)
+	vmToNodeRead, vmToNodeWrite, err := os.Pipe()
+	require.Nil(t, err)
+
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	part, err := NewNodePart(vmToNodeRead, nodeToVMWrite, nil, config, marshalizer)
+	require.Nil(t, err)
+	part.EnableCapabilities(capabilities)
+
+	vm := &fakeVM{
+		messenger: common.NewMessengerPipes("VM", nodeToVMRead, vmToNodeWrite, marshalizer),
+		part:      part,
+	}
+	t.Cleanup(func() {
+		vm.messenger.Shutdown()
+		part.Messenger.Shutdown()
+	})
+
+	return vm
+}
+
+// reply answers the next request after the given delay, sending heartbeats meanwhile (if any)
+func (vm *fakeVM) reply(delay time.Duration, heartbeatPeriod time.Duration) {
+	go func() {
+		_, err := vm.messenger.Receive(0)
+		if err != nil {
+			return
+		}
+
+		end := time.Now().Add(delay)
+		for heartbeatPeriod > 0 && time.Now().Add(heartbeatPeriod).Before(end) {
+			time.Sleep(heartbeatPeriod)
+			_ = vm.messenger.SendHeartbeat()
+		}
+
+		time.Sleep(time.Until(end))
+		_ = vm.messenger.Send(common.NewMessageVersionResponse("v1.2"))
+	}()
+}
+
+func TestNodePart_HeartbeatsKeepTheDialogueAlive(t *testing.T) {
+	vm := newFakeVM(t, Config{MaxLoopTime: 2000, HeartbeatTimeout: 100}, []string{common.CapabilityHeartbeats})
+	vm.reply(500*time.Millisecond, 20*time.Millisecond)
+
+	response, err := vm.part.StartLoop(common.NewMessageVersionRequest())
+	require.Nil(t, err)
+	require.Equal(t, common.VersionResponse, response.GetKind())
+
+	stats := vm.part.Messenger.GetStatsCollector().GetStats()
+	require.Greater(t, stats.Heartbeats, uint64(10))
+	require.Equal(t, uint64(1), stats.Latencies["VersionRequest"].Count)
+}
+
+func TestNodePart_StuckVM(t *testing.T) {
+	vm := newFakeVM(t, Config{MaxLoopTime: 2000, HeartbeatTimeout: 100}, []string{common.CapabilityHeartbeats})
+	vm.reply(500*time.Millisecond, 0)
+
+	_, err := vm.part.StartLoop(common.NewMessageVersionRequest())
+	require.Equal(t, common.ErrVMNotResponding, err)
+	require.Equal(t, uint64(1), vm.part.Messenger.GetStatsCollector().GetStats().StuckVMTimeouts)
+}
+
+func TestNodePart_SlowVM(t *testing.T) {
+	vm := newFakeVM(t, Config{MaxLoopTime: 300, HeartbeatTimeout: 100}, []string{common.CapabilityHeartbeats})
+	vm.reply(time.Second, 20*time.Millisecond)
+
+	_, err := vm.part.StartLoop(common.NewMessageVersionRequest())
+	require.Equal(t, common.ErrVMTimeExpired, err)
+	require.Equal(t, uint64(1), vm.part.Messenger.GetStatsCollector().GetStats().SlowVMTimeouts)
+}
+
+func TestNodePart_TimeoutsPerKind(t *testing.T) {
+	config := Config{MaxLoopTime: 100, Timeouts: map[common.MessageKind]int{common.VersionRequest: 1000}}
+
+	vm := newFakeVM(t, config, nil)
+	vm.reply(300*time.Millisecond, 0)
+	_, err := vm.part.StartLoop(common.NewMessageVersionRequest())
+	require.Nil(t, err)
+
+	vm = newFakeVM(t, config, nil)
+	vm.reply(300*time.Millisecond, 0)
+	_, err = vm.part.StartLoop(common.NewMessageDiagnoseStatsRequest())
+	require.Equal(t, common.ErrVMTimeExpired, err)
+}
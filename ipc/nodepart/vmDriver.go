@@ -1,21 +1,31 @@
 package nodepart
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"sync"
 	"syscall"
+	"time"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-logger-go/pipes"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 )
 
 var log = logger.GetOrCreate("vmDriver")
 
+const defaultDialTimeout = 5 * time.Second
+const defaultMaxPrefetchedKeys = 32
+const defaultRestartAttempts = 3
+const defaultRestartBackoff = 100 * time.Millisecond
+const defaultMaxRestartBackoff = 5 * time.Second
+
 var _ vmcommon.VMExecutionHandler = (*VMDriver)(nil)
 
 // VMDriver manages the execution of the VM process
@@ -40,6 +50,18 @@ type VMDriver struct {
 	part     *NodePart
 	logsPart ParentLogsPart
 
+	// connection is the connection to the VM server, when the driver is configured with a VMAddress
+	connection common.Transport
+
+	// accessedKeys is only used when the driver ships prefetch hints
+	accessedKeys *accessedStorageKeys
+	// capabilities are agreed upon in the handshake with VM, on each (re)start
+	capabilities []string
+	// recorder is only used when the driver is configured with a RecordingPath
+	recorder *common.DialogueRecorder
+	// stats are kept across the restarts of VM
+	stats *common.StatsCollector
+
 	// When the VMDriver is used to resolve contract queries, it might happen that a query request executes concurrently with other operations (such as "GasScheduleChange").
 	// Query requests are ordered sequentially within the API layer (see the QueryService dispatcher and other related components), but this sequence of queries might
 	// interleave with VM-management operations, which are or might be triggered within a different flow (e.g. the processing flow). For example, "GasScheduleChange" is triggered synchronously
@@ -62,6 +84,23 @@ func NewVMDriver(
 		config:              config,
 		logsMarshalizer:     marshaling.CreateMarshalizer(vmArguments.LogsMarshalizer),
 		messagesMarshalizer: marshaling.CreateMarshalizer(vmArguments.MessagesMarshalizer),
+		stats:               common.NewStatsCollector(),
+	}
+
+	driver.vmArguments.HeartbeatPeriod = driver.getHeartbeatPeriod()
+	driver.vmArguments.HookReplyTimeout = config.HookReplyTimeout
+
+	if config.PrefetchHints {
+		driver.accessedKeys = newAccessedStorageKeys()
+	}
+
+	if len(config.RecordingPath) > 0 {
+		recordingFile, err := os.OpenFile(config.RecordingPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		driver.recorder = common.NewDialogueRecorder(recordingFile, common.PartNode)
 	}
 
 	err := driver.startVM()
@@ -73,6 +112,10 @@ func NewVMDriver(
 }
 
 func (driver *VMDriver) startVM() error {
+	if driver.isRemoteVM() {
+		return driver.connectToVM()
+	}
+
 	log.Info("VMDriver.startVM()")
 
 	logsProfileReader, logsWriter, err := driver.resetLogsPart()
@@ -114,7 +157,24 @@ func (driver *VMDriver) startVM() error {
 		return err
 	}
 
-	err = common.SendVMArguments(driver.vmInitWrite, driver.vmArguments)
+	err = driver.initializeStartedVM(vmStdout, vmStderr)
+	if err != nil {
+		log.Error("VMDriver.startVM()", "err", err)
+		driver.abortStartedVM()
+		return err
+	}
+
+	return nil
+}
+
+// initializeStartedVM sends the arguments to the started VM process, performs the handshake and starts listening for its logs
+func (driver *VMDriver) initializeStartedVM(vmStdout io.Reader, vmStderr io.Reader) error {
+	err := common.SendVMArguments(driver.vmInitWrite, driver.vmArguments)
+	if err != nil {
+		return err
+	}
+
+	driver.capabilities, err = common.PerformHandshake(driver.vmOutputRead, driver.vmInputWrite, common.NewHandshake(), driver.vmArguments.MessagesMarshalizer)
 	if err != nil {
 		return err
 	}
@@ -124,7 +184,7 @@ func (driver *VMDriver) startVM() error {
 	driver.part, err = NewNodePart(
 		driver.vmOutputRead,
 		driver.vmInputWrite,
-		driver.blockchainHook,
+		driver.getPartBlockchainHook(),
 		driver.config,
 		driver.messagesMarshalizer,
 	)
@@ -132,14 +192,119 @@ func (driver *VMDriver) startVM() error {
 		return err
 	}
 
-	err = driver.logsPart.StartLoop(vmStdout, vmStderr)
+	driver.part.EnableCapabilities(driver.capabilities)
+	driver.part.Messenger.SetStatsCollector(driver.stats)
+
+	err = driver.beginRecording()
 	if err != nil {
 		return err
 	}
 
+	return driver.logsPart.StartLoop(vmStdout, vmStderr)
+}
+
+// abortStartedVM kills the VM process which could not be initialized, so that it is not left orphaned, and closes its pipes
+func (driver *VMDriver) abortStartedVM() {
+	driver.logsPart.StopLoop()
+
+	// the process might have already exited, yet it still has to be waited for;
+	// waiting also closes its stdout and stderr pipes
+	_ = driver.command.Process.Kill()
+	_ = driver.command.Wait()
+
+	driver.closePipeStreams()
+}
+
+// connectToVM connects to an already running VM server, which creates a new VM for each connection
+func (driver *VMDriver) connectToVM() error {
+	log.Info("VMDriver.connectToVM()", "address", driver.config.VMAddress)
+
+	connection, err := common.DialTransport(driver.config.VMAddress, driver.getDialTimeout())
+	if err != nil {
+		return err
+	}
+
+	err = common.SendVMArguments(connection, driver.vmArguments)
+	if err != nil {
+		_ = connection.Close()
+		return err
+	}
+
+	driver.capabilities, err = common.PerformHandshake(connection, connection, common.NewHandshake(), driver.vmArguments.MessagesMarshalizer)
+	if err != nil {
+		log.Error("VMDriver.connectToVM()", "err", err)
+		_ = connection.Close()
+		return err
+	}
+
+	driver.blockchainHook.ClearCompiledCodes()
+
+	driver.part, err = NewNodePart(
+		connection,
+		connection,
+		driver.getPartBlockchainHook(),
+		driver.config,
+		driver.messagesMarshalizer,
+	)
+	if err != nil {
+		_ = connection.Close()
+		return err
+	}
+
+	driver.part.EnableCapabilities(driver.capabilities)
+	driver.part.Messenger.SetStatsCollector(driver.stats)
+	driver.connection = connection
+
+	return driver.beginRecording()
+}
+
+// beginRecording starts a new session of the replay file, as VM was (re)started
+func (driver *VMDriver) beginRecording() error {
+	if driver.recorder == nil {
+		return nil
+	}
+
+	err := driver.recorder.BeginSession(common.ReplaySession{
+		VMArguments:  driver.vmArguments,
+		Capabilities: driver.capabilities,
+	})
+	if err != nil {
+		return err
+	}
+
+	driver.part.EnableRecording(driver.recorder)
 	return nil
 }
 
+// getPartBlockchainHook returns the hook which answers the calls of VM
+func (driver *VMDriver) getPartBlockchainHook() vmcommon.BlockchainHook {
+	if driver.accessedKeys == nil {
+		return driver.blockchainHook
+	}
+
+	return &storageAccessTracker{BlockchainHook: driver.blockchainHook, keys: driver.accessedKeys}
+}
+
+func (driver *VMDriver) isRemoteVM() bool {
+	return len(driver.config.VMAddress) > 0
+}
+
+func (driver *VMDriver) getHeartbeatPeriod() int {
+	if driver.config.HeartbeatPeriod <= 0 {
+		return defaultHeartbeatPeriod
+	}
+
+	return driver.config.HeartbeatPeriod
+}
+
+func (driver *VMDriver) getDialTimeout() time.Duration {
+	if driver.config.DialTimeout <= 0 {
+		return defaultDialTimeout
+	}
+
+	return time.Duration(driver.config.DialTimeout) * time.Millisecond
+}
+
 func (driver *VMDriver) resetLogsPart() (*os.File, *os.File, error) {
 	logsPart, err := pipes.NewParentPart("VM", driver.logsMarshalizer)
 	if err != nil {
@@ -152,12 +317,7 @@ func (driver *VMDriver) resetLogsPart() (*os.File, *os.File, error) {
 }
 
 func (driver *VMDriver) resetPipeStreams() error {
-	closeFile(driver.vmInitRead)
-	closeFile(driver.vmInitWrite)
-	closeFile(driver.vmInputRead)
-	closeFile(driver.vmInputWrite)
-	closeFile(driver.vmOutputRead)
-	closeFile(driver.vmOutputWrite)
+	driver.closePipeStreams()
 
 	var err error
 
@@ -179,6 +339,19 @@ func (driver *VMDriver) resetPipeStreams() error {
 	return nil
 }
 
+func (driver *VMDriver) closePipeStreams() {
+	closeFile(driver.vmInitRead)
+	closeFile(driver.vmInitWrite)
+	closeFile(driver.vmInputRead)
+	closeFile(driver.vmInputWrite)
+	closeFile(driver.vmOutputRead)
+	closeFile(driver.vmOutputWrite)
+
+	driver.vmInitRead, driver.vmInitWrite = nil, nil
+	driver.vmInputRead, driver.vmInputWrite = nil, nil
+	driver.vmOutputRead, driver.vmOutputWrite = nil, nil
+}
+
 func closeFile(file *os.File) {
 	if file != nil {
 		err := file.Close()
@@ -188,18 +361,75 @@ func closeFile(file *os.File) {
 	}
 }
 
-// RestartVMIfNecessary restarts VM if the process is closed
+// RestartVMIfNecessary restarts VM if the process is closed, or reconnects to the VM server if disconnected;
+// a failed restart is attempted again, after an exponentially growing delay, unless VM and the Node cannot talk to each other
 func (driver *VMDriver) RestartVMIfNecessary() error {
 	if !driver.IsClosed() {
 		return nil
 	}
 
-	err := driver.startVM()
+	backoff := driver.getRestartBackoff()
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = driver.startVM()
+		if err == nil {
+			driver.stats.CountRestart()
+			return nil
+		}
+
+		if errors.Is(err, common.ErrProtocolMismatch) {
+			log.Error("VMDriver.RestartVMIfNecessary()", "attempt", attempt, "err", err)
+			return err
+		}
+		if attempt >= driver.getRestartAttempts() {
+			break
+		}
+
+		log.Warn("VMDriver.RestartVMIfNecessary()", "attempt", attempt, "retry after", backoff, "err", err)
+		time.Sleep(backoff)
+		backoff = driver.getNextRestartBackoff(backoff)
+	}
+
+	log.Error("VMDriver.RestartVMIfNecessary()", "attempts", driver.getRestartAttempts(), "err", err)
 	return err
 }
 
-// IsClosed checks whether the VM process is closed
+func (driver *VMDriver) getRestartAttempts() int {
+	if driver.config.RestartAttempts <= 0 {
+		return defaultRestartAttempts
+	}
+
+	return driver.config.RestartAttempts
+}
+
+func (driver *VMDriver) getRestartBackoff() time.Duration {
+	if driver.config.RestartBackoff <= 0 {
+		return defaultRestartBackoff
+	}
+
+	return time.Duration(driver.config.RestartBackoff) * time.Millisecond
+}
+
+func (driver *VMDriver) getNextRestartBackoff(backoff time.Duration) time.Duration {
+	maxBackoff := defaultMaxRestartBackoff
+	if driver.config.MaxRestartBackoff > 0 {
+		maxBackoff = time.Duration(driver.config.MaxRestartBackoff) * time.Millisecond
+	}
+
+	backoff *= 2
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+
+	return backoff
+}
+
+// IsClosed checks whether the VM process is closed, or the connection to the VM server
 func (driver *VMDriver) IsClosed() bool {
+	if driver.isRemoteVM() {
+		return driver.connection == nil
+	}
+
 	pid := driver.command.Process.Pid
 	process, err := os.FindProcess(pid)
 	if err != nil {
@@ -307,6 +537,7 @@ func (driver *VMDriver) RunSmartContractCall(input *vmcommon.ContractCallInput)
 	}
 
 	request := common.NewMessageContractCallRequest(input)
+	request.Prefetch = driver.createPrefetchHint(input)
 	response, err := driver.part.StartLoop(request)
 	if err != nil {
 		log.Warn("RunSmartContractCall", "err", err)
@@ -323,6 +554,42 @@ func (driver *VMDriver) RunSmartContractCall(input *vmcommon.ContractCallInput)
 	return vmOutput, nil
 }
 
+// createPrefetchHint collects the data VM would otherwise request through hook calls, at the start of the contract call
+func (driver *VMDriver) createPrefetchHint(input *vmcommon.ContractCallInput) *common.PrefetchHint {
+	if !driver.config.PrefetchHints || !common.HasCapability(driver.capabilities, common.CapabilityPrefetchHints) {
+		return nil
+	}
+
+	account, err := driver.blockchainHook.GetUserAccount(input.RecipientAddr)
+	if err != nil || vmhost.IfNil(account) {
+		return nil
+	}
+
+	storage := make(map[string][]byte)
+	for _, key := range driver.accessedKeys.mostAccessed(input.RecipientAddr, driver.getMaxPrefetchedKeys()) {
+		data, _, err := driver.blockchainHook.GetStorageData(input.RecipientAddr, key)
+		if err != nil {
+			continue
+		}
+
+		storage[string(key)] = data
+	}
+
+	return &common.PrefetchHint{
+		Account: common.NewSerializableAccount(account),
+		Code:    driver.blockchainHook.GetCode(account),
+		Storage: common.NewSerializableMapStringBytes(storage),
+	}
+}
+
+func (driver *VMDriver) getMaxPrefetchedKeys() int {
+	if driver.config.MaxPrefetchedKeys <= 0 {
+		return defaultMaxPrefetchedKeys
+	}
+
+	return driver.config.MaxPrefetchedKeys
+}
+
 // DiagnoseWait sends a diagnose message to VM
 func (driver *VMDriver) DiagnoseWait(milliseconds uint32) error {
 	driver.operationsMutex.Lock()
@@ -344,8 +611,51 @@ func (driver *VMDriver) DiagnoseWait(milliseconds uint32) error {
 	return response.GetError()
 }
 
-// Close stops VM
+// DiagnoseStats holds the counters of both parts
+type DiagnoseStats struct {
+	Node *common.Stats
+	// VM is nil when VM does not support DiagnoseStatsRequest
+	VM *common.Stats
+}
+
+// DiagnoseStats returns the counters of the Node, kept across the restarts of VM, and the ones of the running VM
+func (driver *VMDriver) DiagnoseStats() (*DiagnoseStats, error) {
+	driver.operationsMutex.Lock()
+	defer driver.operationsMutex.Unlock()
+
+	err := driver.RestartVMIfNecessary()
+	if err != nil {
+		return nil, common.WrapCriticalError(err)
+	}
+
+	stats := &DiagnoseStats{}
+	if common.HasCapability(driver.capabilities, common.CapabilityDiagnoseStats) {
+		request := common.NewMessageDiagnoseStatsRequest()
+		response, err := driver.part.StartLoop(request)
+		if err != nil {
+			log.Error("DiagnoseStats", "err", err)
+			_ = driver.Close()
+			return nil, common.WrapCriticalError(err)
+		}
+
+		typedResponse, ok := response.(*common.MessageDiagnoseStatsResponse)
+		if !ok {
+			return nil, common.ErrBadMessageFromVM
+		}
+
+		stats.VM = typedResponse.Stats
+	}
+
+	stats.Node = driver.stats.GetStats()
+	return stats, nil
+}
+
+// Close stops VM; when connected to a VM server, it only closes the connection
 func (driver *VMDriver) Close() error {
+	if driver.isRemoteVM() {
+		return driver.disconnectFromVM()
+	}
+
 	driver.logsPart.StopLoop()
 
 	err := driver.stopVM()
@@ -357,6 +667,21 @@ func (driver *VMDriver) Close() error {
 	return nil
 }
 
+func (driver *VMDriver) disconnectFromVM() error {
+	if driver.connection == nil {
+		return nil
+	}
+
+	err := driver.connection.Close()
+	driver.connection = nil
+	if err != nil {
+		log.Error("VMDriver.Close()", "err", err)
+		return err
+	}
+
+	return nil
+}
+
 func (driver *VMDriver) stopVM() error {
 	err := driver.command.Process.Kill()
 	if err != nil {
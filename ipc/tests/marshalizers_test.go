@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+var benchmarkedMarshalizers = []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob, marshaling.Binary}
+
+var marshalizerNames = map[marshaling.MarshalizerKind]string{
+	marshaling.JSON:   "JSON",
+	marshaling.Gob:    "Gob",
+	marshaling.Binary: "Binary",
+}
+
+type marshalizerWorkload struct {
+	name    string
+	message common.MessageHandler
+}
+
+// createMarshalizerWorkloads creates the messages which dominate the traffic over the pipes:
+// contract code, storage and VMOutputs
+func createMarshalizerWorkloads() []marshalizerWorkload {
+	storage := make(map[string][]byte)
+	for i := 0; i < 1000; i++ {
+		storage[fmt.Sprintf("key-%04d", i)] = big.NewInt(int64(i) * 1000003).Bytes()
+	}
+
+	return []marshalizerWorkload{
+		{name: "Deploy", message: createDeployRequest(bytecodeCounter)},
+		{name: "Call", message: createCallRequest("increment")},
+		{name: "GetCode", message: common.NewMessageBlockchainGetCodeResponse(bytecodeCounter)},
+		{name: "GetStorage", message: common.NewMessageBlockchainGetStorageDataResponse([]byte("value"), nil)},
+		{name: "GetAllState", message: common.NewMessageBlockchainGetAllStateResponse(storage, nil)},
+		{name: "SmallVMOutput", message: common.NewMessageContractResponse(createVMOutputWorkload(1, 2), nil)},
+		{name: "LargeVMOutput", message: common.NewMessageContractResponse(createVMOutputWorkload(20, 100), nil)},
+	}
+}
+
+func createVMOutputWorkload(numAccounts int, numStorageUpdates int) *vmcommon.VMOutput {
+	vmOutput := &vmcommon.VMOutput{
+		ReturnData:     [][]byte{{1}, []byte("result")},
+		ReturnCode:     vmcommon.Ok,
+		GasRemaining:   1234567,
+		GasRefund:      big.NewInt(0),
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+		Logs: []*vmcommon.LogEntry{
+			{Identifier: []byte("transfer"), Address: []byte("contract"), Topics: [][]byte{[]byte("alice"), []byte("bob")}, Data: [][]byte{{}}},
+		},
+	}
+
+	for i := 0; i < numAccounts; i++ {
+		address := fmt.Sprintf("account-%026d", i)
+		account := &vmcommon.OutputAccount{
+			Address:        []byte(address),
+			Nonce:          uint64(i),
+			BalanceDelta:   big.NewInt(int64(-i * 1000)),
+			StorageUpdates: make(map[string]*vmcommon.StorageUpdate),
+			OutputTransfers: []vmcommon.OutputTransfer{
+				{Value: big.NewInt(1000000000000000000), GasLimit: 50000, Data: []byte("callBack@00"), CallType: vm.AsynchronousCallBack},
+			},
+		}
+		for j := 0; j < numStorageUpdates; j++ {
+			key := fmt.Sprintf("storage-%04d", j)
+			account.StorageUpdates[key] = &vmcommon.StorageUpdate{Offset: []byte(key), Data: big.NewInt(int64(i*j + 1)).Bytes()}
+		}
+
+		vmOutput.OutputAccounts[address] = account
+	}
+
+	return vmOutput
+}
+
+func TestMarshalizers_WorkloadsRoundTrip(t *testing.T) {
+	for _, workload := range createMarshalizerWorkloads() {
+		for _, kind := range benchmarkedMarshalizers {
+			marshalizer := marshaling.CreateMarshalizer(kind)
+
+			serialized, err := marshalizer.Marshal(workload.message)
+			require.Nil(t, err)
+
+			intoMessage := common.CreateMessage(workload.message.GetKind())
+			err = marshalizer.Unmarshal(intoMessage, serialized)
+			require.Nil(t, err)
+
+			t.Logf("%-14s %-7s %8d bytes", workload.name, marshalizerNames[kind], len(serialized))
+		}
+	}
+}
+
+// BenchmarkMarshalizers_RoundTrip measures a Marshal followed by an Unmarshal, and reports the size of the messages
+func BenchmarkMarshalizers_RoundTrip(b *testing.B) {
+	for _, workload := range createMarshalizerWorkloads() {
+		for _, kind := range benchmarkedMarshalizers {
+			workload := workload
+			marshalizer := marshaling.CreateMarshalizer(kind)
+
+			b.Run(fmt.Sprintf("%s/%s", workload.name, marshalizerNames[kind]), func(b *testing.B) {
+				length := 0
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					serialized, err := marshalizer.Marshal(workload.message)
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					err = marshalizer.Unmarshal(common.CreateMessage(workload.message.GetKind()), serialized)
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					length = len(serialized)
+				}
+
+				b.ReportMetric(float64(length), "bytes/msg")
+			})
+		}
+	}
+}
+
+// BenchmarkMarshalizers_Pipe measures sending the messages through a pipe, as done between the Node and VM
+func BenchmarkMarshalizers_Pipe(b *testing.B) {
+	for _, workload := range createMarshalizerWorkloads() {
+		for _, kind := range benchmarkedMarshalizers {
+			workload := workload
+			marshalizer := marshaling.CreateMarshalizer(kind)
+
+			b.Run(fmt.Sprintf("%s/%s", workload.name, marshalizerNames[kind]), func(b *testing.B) {
+				reader, writer, err := os.Pipe()
+				require.Nil(b, err)
+				defer func() {
+					_ = reader.Close()
+					_ = writer.Close()
+				}()
+
+				sender := common.NewSender(writer, marshalizer)
+				receiver := common.NewReceiver(reader, marshalizer)
+
+				done := make(chan error, 1)
+				go func() {
+					for i := 0; i < b.N; i++ {
+						_, _, receiveErr := receiver.Receive(0)
+						if receiveErr != nil {
+							done <- receiveErr
+							return
+						}
+					}
+					done <- nil
+				}()
+
+				length := 0
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					length, err = sender.Send(workload.message)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				require.Nil(b, <-done)
+				b.ReportMetric(float64(length), "bytes/msg")
+			})
+		}
+	}
+}
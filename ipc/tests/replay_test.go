@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/nodepart"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/vmpart"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/context"
+	worldmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay_RecordedDialogue(t *testing.T) {
+	sessions := recordCounterDialogue(t)
+	require.Len(t, sessions, 1)
+	require.NotEmpty(t, sessions[0].Messages)
+
+	err := vmpart.Replay(sessions[0], vmhost.VMVersion, 5000)
+	require.Nil(t, err)
+}
+
+func TestReplay_DivergentDialogue(t *testing.T) {
+	sessions := recordCounterDialogue(t)
+	require.Len(t, sessions, 1)
+
+	messages := sessions[0].Messages
+	lastMessage := messages[len(messages)-1]
+	require.Equal(t, common.PartVM, lastMessage.Sender)
+	require.Equal(t, common.ContractResponse, lastMessage.Message.GetKind())
+	lastMessage.Message.(*common.MessageContractResponse).SerializableVMOutput.GasRemaining++
+
+	err := vmpart.Replay(sessions[0], vmhost.VMVersion, 5000)
+	require.ErrorIs(t, err, common.ErrReplayDivergence)
+}
+
+func recordCounterDialogue(t *testing.T) []*common.RecordedSession {
+	recordingPath := filepath.Join(t.TempDir(), "dialogue.jsonl")
+	blockchain := &contextmock.BlockchainHookStub{}
+	blockchain.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &worldmock.Account{Code: bytecodeCounter}, nil
+	}
+
+	driver := newDriverWithConfig(t, blockchain, nodepart.Config{MaxLoopTime: 1000, RecordingPath: recordingPath})
+	_, err := driver.RunSmartContractCreate(createDeployInput(bytecodeCounter))
+	require.Nil(t, err)
+	_, err = driver.RunSmartContractCall(createCallInput("increment"))
+	require.Nil(t, err)
+	_ = driver.Close()
+
+	file, err := os.Open(recordingPath)
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	sessions, err := common.ReadReplayFile(file)
+	require.Nil(t, err)
+	return sessions
+}
@@ -4,11 +4,19 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"os"
 	"path/filepath"
+	"testing"
 
 	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 )
 
+var mxVirtualMachine = []byte{5, 0}
+
 var bytecodeCounter []byte
 
 func init() {
@@ -50,3 +58,26 @@ func createCallInput(function string) *vmcommon.ContractCallInput {
 		Function:      function,
 	}
 }
+
+func createVMArguments() common.VMArguments {
+	return common.VMArguments{
+		VMHostParameters: vmhost.VMHostParameters{
+			VMType:             mxVirtualMachine,
+			BlockGasLimit:      uint64(10000000),
+			GasSchedule:        config.MakeGasMapForTests(),
+			ProtectedKeyPrefix: []byte("E" + "L" + "R" + "O" + "N" + "D"),
+		},
+		LogsMarshalizer:     marshaling.JSON,
+		MessagesMarshalizer: marshaling.JSON,
+		EnableEpochs:        config.MakeDefaultEnableEpochs(),
+	}
+}
+
+// skipIfVMBinaryMissing skips the tests which start the VM binary when it was not built;
+// build it with `make vm` and set VM_PATH, or run the tests with `make test`
+func skipIfVMBinaryMissing(tb testing.TB) {
+	info, err := os.Stat(os.Getenv(common.EnvVarVMPath))
+	if err != nil || info.IsDir() {
+		tb.Skip("VM binary not found, see the \"vm\" target of the Makefile")
+	}
+}
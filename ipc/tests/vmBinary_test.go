@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/multiversx/mx-chain-logger-go/pipes"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/nodepart"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMBinary_ExitsCleanlyOnStop(t *testing.T) {
+	skipIfVMBinaryMissing(t)
+
+	vmArguments := createVMArguments()
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+
+	logsPart, err := pipes.NewParentPart("VM", marshalizer)
+	require.Nil(t, err)
+	readProfile, writeLogs := logsPart.GetChildPipes()
+
+	initRead, initWrite, err := os.Pipe()
+	require.Nil(t, err)
+	files := createTestFiles(t, "stop")
+
+	command := exec.Command(os.Getenv(common.EnvVarVMPath))
+	command.ExtraFiles = []*os.File{
+		initRead,
+		files.inputOfVM,
+		files.outputOfVM,
+		readProfile,
+		writeLogs,
+	}
+
+	stdout, err := command.StdoutPipe()
+	require.Nil(t, err)
+	stderr, err := command.StderrPipe()
+	require.Nil(t, err)
+
+	err = command.Start()
+	require.Nil(t, err)
+
+	err = logsPart.StartLoop(stdout, stderr)
+	require.Nil(t, err)
+	defer logsPart.StopLoop()
+
+	err = common.SendVMArguments(initWrite, vmArguments)
+	require.Nil(t, err)
+	_, err = common.PerformHandshake(files.inputOfNode, files.outputOfNode, common.NewHandshake(), vmArguments.MessagesMarshalizer)
+	require.Nil(t, err)
+
+	part, err := nodepart.NewNodePart(
+		files.inputOfNode,
+		files.outputOfNode,
+		&contextmock.BlockchainHookStub{},
+		nodepart.Config{MaxLoopTime: 1000},
+		marshalizer,
+	)
+	require.Nil(t, err)
+
+	response, err := part.StartLoop(common.NewMessageVersionRequest())
+	require.Nil(t, err)
+	require.Equal(t, vmhost.VMVersion, response.(*common.MessageVersionResponse).Version)
+
+	err = part.SendStopSignal()
+	require.Nil(t, err)
+
+	state, err := command.Process.Wait()
+	require.Nil(t, err)
+	require.Equal(t, common.ErrCodeSuccess, state.ExitCode())
+}
@@ -1,35 +1,37 @@
 package tests
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
 
-	"github.com/multiversx/mx-chain-core-go/core"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
-	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/nodepart"
-	"github.com/multiversx/mx-chain-vm-v1_2-go/mock"
 	contextmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/context"
 	worldmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/world"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
-	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
 	"github.com/stretchr/testify/require"
 )
 
-var mxVirtualMachine = []byte{5, 0}
-
 func TestVMDriver_DiagnoseWait(t *testing.T) {
-	t.Skip("driver not supported anymore")
 	blockchain := &contextmock.BlockchainHookStub{}
 	driver := newDriver(t, blockchain)
+	defer func() {
+		_ = driver.Close()
+	}()
 
 	err := driver.DiagnoseWait(100)
 	require.Nil(t, err)
 }
 
 func TestVMDriver_DiagnoseWaitWithTimeout(t *testing.T) {
-	t.Skip("driver not supported anymore: requires standalone binary")
 	blockchain := &contextmock.BlockchainHookStub{}
 	driver := newDriver(t, blockchain)
 
@@ -40,7 +42,6 @@ func TestVMDriver_DiagnoseWaitWithTimeout(t *testing.T) {
 }
 
 func TestVMDriver_RestartsIfStopped(t *testing.T) {
-	t.Skip("driver not supported anymore")
 	logger.ToggleLoggerName(true)
 	_ = logger.SetLogLevel("*:TRACE")
 
@@ -67,6 +68,7 @@ func TestVMDriver_RestartsIfStopped(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, vmOutput)
 	require.False(t, driver.IsClosed())
+	_ = driver.Close()
 }
 
 func BenchmarkVMDriver_RestartsIfStopped(b *testing.B) {
@@ -91,33 +93,148 @@ func BenchmarkVMDriver_RestartVMIfNecessary(b *testing.B) {
 }
 
 func TestVMDriver_GetVersion(t *testing.T) {
-	t.Skip("driver not supported anymore")
 	// This test requires `make vm` before running, or must be run directly
 	// with `make test`
 	blockchain := &contextmock.BlockchainHookStub{}
 	driver := newDriver(t, blockchain)
+	defer func() {
+		_ = driver.Close()
+	}()
+
 	version := driver.GetVersion()
-	require.NotZero(t, len(version))
-	require.NotEqual(t, "undefined", version)
+	require.Equal(t, vmhost.VMVersion, version)
+}
+
+func TestVMDriver_DiagnoseStats(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+	driver := newDriver(t, blockchain)
+	defer func() {
+		_ = driver.Close()
+	}()
+
+	blockchain.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &worldmock.Account{Code: bytecodeCounter}, nil
+	}
+
+	_, err := driver.RunSmartContractCreate(createDeployInput(bytecodeCounter))
+	require.Nil(t, err)
+	_ = driver.Close()
+	_, err = driver.RunSmartContractCall(createCallInput("increment"))
+	require.Nil(t, err)
+
+	stats, err := driver.DiagnoseStats()
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), stats.Node.Restarts)
+	require.Equal(t, uint64(1), stats.Node.Latencies["ContractDeployRequest"].Count)
+	require.Equal(t, uint64(1), stats.Node.Latencies["ContractCallRequest"].Count)
+	require.NotZero(t, stats.Node.ReceivedBytes)
+
+	// VM was restarted, thus it only counts the contract call
+	require.NotNil(t, stats.VM)
+	require.Equal(t, uint64(1), stats.VM.ReceivedMessages["ContractCallRequest"])
+	require.Zero(t, stats.VM.ReceivedMessages["ContractDeployRequest"])
+	require.Equal(t, uint64(1), stats.VM.Latencies["ContractCallRequest"].Count)
+}
+
+func TestVMDriver_SlowVMTimesOut(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+	config := nodepart.Config{
+		MaxLoopTime:      5000,
+		Timeouts:         map[common.MessageKind]int{common.DiagnoseWaitRequest: 500},
+		HeartbeatPeriod:  50,
+		HeartbeatTimeout: 200,
+	}
+	driver := newDriverWithConfig(t, blockchain, config)
+	defer func() {
+		_ = driver.Close()
+	}()
+
+	// VM keeps sending heartbeats, thus it is slow, not stuck
+	err := driver.DiagnoseWait(1000)
+	require.True(t, common.IsCriticalError(err))
+	require.ErrorIs(t, err, common.ErrVMTimeExpired)
+
+	stats, err := driver.DiagnoseStats()
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), stats.Node.SlowVMTimeouts)
+	require.Zero(t, stats.Node.StuckVMTimeouts)
+	require.NotZero(t, stats.Node.Heartbeats)
+}
+
+func TestVMDriver_PrefetchHints(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+	driver := newDriverWithConfig(t, blockchain, nodepart.Config{MaxLoopTime: 1000, PrefetchHints: true})
+	defer func() {
+		_ = driver.Close()
+	}()
+
+	blockchain.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &worldmock.Account{Address: address, Code: bytecodeCounter, CodeHash: []byte("counter")}, nil
+	}
+	blockchain.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return bytecodeCounter
+	}
+
+	storage := make(map[string][]byte)
+	blockchain.GetStorageDataCalled = func(address []byte, index []byte) ([]byte, uint32, error) {
+		return storage[string(index)], 0, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		vmOutput, err := driver.RunSmartContractCall(createCallInput("increment"))
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+		// As if the Node had committed the transaction
+		for _, outputAccount := range vmOutput.OutputAccounts {
+			for key, storageUpdate := range outputAccount.StorageUpdates {
+				storage[key] = storageUpdate.Data
+			}
+		}
+	}
+}
+
+func TestVMDriver_StopsVMOnProtocolMismatch(t *testing.T) {
+	dir := t.TempDir()
+
+	// the fake VM answers the handshake with another protocol version, then waits to be killed
+	handshakePath := filepath.Join(dir, "handshake")
+	handshakeFile, err := os.Create(handshakePath)
+	require.Nil(t, err)
+	handshake := common.NewHandshake()
+	handshake.ProtocolVersion++
+	_, err = common.NewSender(handshakeFile, marshaling.CreateMarshalizer(marshaling.JSON)).Send(common.NewMessageHandshakeResponse(handshake, "fake", nil))
+	require.Nil(t, err)
+	require.Nil(t, handshakeFile.Close())
+
+	pidPath := filepath.Join(dir, "pid")
+	vmPath := filepath.Join(dir, "vm")
+	script := fmt.Sprintf("#!/bin/sh\ncat <&3 >/dev/null &\necho $$ > %s\ncat %s >&5\nexec sleep 60\n", pidPath, handshakePath)
+	require.Nil(t, os.WriteFile(vmPath, []byte(script), 0755))
+	t.Setenv(common.EnvVarVMPath, vmPath)
+
+	driver, err := nodepart.NewVMDriver(&contextmock.BlockchainHookStub{}, createVMArguments(), nodepart.Config{MaxLoopTime: 1000})
+	require.Nil(t, driver)
+	require.ErrorIs(t, err, common.ErrProtocolMismatch)
+
+	pidBytes, err := os.ReadFile(pidPath)
+	require.Nil(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(pidBytes)))
+	require.Nil(t, err)
+	require.Equal(t, syscall.ESRCH, syscall.Kill(pid, 0))
 }
 
 func newDriver(tb testing.TB, blockchain *contextmock.BlockchainHookStub) *nodepart.VMDriver {
+	return newDriverWithConfig(tb, blockchain, nodepart.Config{MaxLoopTime: 1000})
+}
+
+func newDriverWithConfig(tb testing.TB, blockchain *contextmock.BlockchainHookStub, config nodepart.Config) *nodepart.VMDriver {
+	skipIfVMBinaryMissing(tb)
+
 	driver, err := nodepart.NewVMDriver(
 		blockchain,
-		common.VMArguments{
-			VMHostParameters: vmhost.VMHostParameters{
-				VMType:             mxVirtualMachine,
-				BlockGasLimit:      uint64(10000000),
-				GasSchedule:        config.MakeGasMapForTests(),
-				ProtectedKeyPrefix: []byte("E" + "L" + "R" + "O" + "N" + "D"),
-				EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
-					IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-						return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag
-					},
-				},
-			},
-		},
-		nodepart.Config{MaxLoopTime: 1000},
+		createVMArguments(),
+		config,
 	)
 	require.Nil(tb, err)
 	require.NotNil(tb, driver)
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/nodepart"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/vmpart"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/context"
+	worldmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMServer_UnixSocket(t *testing.T) {
+	address := "unix://" + filepath.Join(t.TempDir(), "vm.sock")
+	testVMServer(t, address)
+}
+
+func TestVMServer_TCP(t *testing.T) {
+	testVMServer(t, "tcp://127.0.0.1:0")
+}
+
+func TestVMServer_DriverReconnectsAfterServerRestart(t *testing.T) {
+	address := "unix://" + filepath.Join(t.TempDir(), "vm.sock")
+	server, _ := startVMServer(t, address)
+
+	blockchain := &contextmock.BlockchainHookStub{}
+	driver := newRemoteDriver(t, blockchain, address)
+	require.Equal(t, vmhost.VMVersion, driver.GetVersion())
+
+	// The VM is restarted independently of the Node
+	_ = server.Close()
+	version := driver.GetVersion()
+	require.Equal(t, "", version)
+	require.True(t, driver.IsClosed())
+
+	server, _ = startVMServer(t, address)
+	defer func() {
+		_ = server.Close()
+	}()
+
+	require.Equal(t, vmhost.VMVersion, driver.GetVersion())
+	require.False(t, driver.IsClosed())
+	_ = driver.Close()
+}
+
+func TestVMServer_ClosesConnectionNotStartingWithArguments(t *testing.T) {
+	address := "unix://" + filepath.Join(t.TempDir(), "vm.sock")
+	server, _ := startVMServer(t, address)
+	defer func() {
+		_ = server.Close()
+	}()
+
+	// The server goes on serving the next connections
+	for i := 0; i < 2; i++ {
+		connection, err := common.DialTransport(address, time.Second)
+		require.Nil(t, err)
+		sender := common.NewSender(connection, marshaling.CreateMarshalizer(marshaling.JSON))
+		_, err = sender.Send(common.NewMessageVersionRequest())
+		require.Nil(t, err)
+
+		_ = connection.SetReadDeadline(time.Now().Add(time.Second))
+		_, err = connection.Read(make([]byte, 1))
+		require.Equal(t, io.EOF, err)
+		_ = connection.Close()
+	}
+}
+
+func testVMServer(t *testing.T, address string) {
+	server, listener := startVMServer(t, address)
+	defer func() {
+		_ = server.Close()
+	}()
+
+	if listener.Addr().Network() == "tcp" {
+		address = "tcp://" + listener.Addr().String()
+	}
+
+	blockchain := &contextmock.BlockchainHookStub{}
+	blockchain.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &worldmock.Account{Code: bytecodeCounter}, nil
+	}
+
+	driver := newRemoteDriver(t, blockchain, address)
+	require.Equal(t, vmhost.VMVersion, driver.GetVersion())
+
+	vmOutput, err := driver.RunSmartContractCreate(createDeployInput(bytecodeCounter))
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	vmOutput, err = driver.RunSmartContractCall(createCallInput("increment"))
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+
+	err = driver.Close()
+	require.Nil(t, err)
+	require.True(t, driver.IsClosed())
+
+	// The driver connects again, and the server serves the new connection
+	vmOutput, err = driver.RunSmartContractCall(createCallInput("increment"))
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.False(t, driver.IsClosed())
+
+	err = driver.Close()
+	require.Nil(t, err)
+	require.True(t, driver.IsClosed())
+}
+
+func startVMServer(t *testing.T, address string) (*vmpart.VMServer, net.Listener) {
+	listener, err := common.ListenTransport(address)
+	require.Nil(t, err)
+
+	server := vmpart.NewVMServer(listener, vmhost.VMVersion)
+	go func() {
+		_ = server.Serve()
+	}()
+
+	return server, listener
+}
+
+func newRemoteDriver(t *testing.T, blockchain *contextmock.BlockchainHookStub, address string) *nodepart.VMDriver {
+	vmArguments := createVMArguments()
+	vmArguments.MessagesMarshalizer = marshaling.Binary
+
+	driver, err := nodepart.NewVMDriver(
+		blockchain,
+		vmArguments,
+		nodepart.Config{MaxLoopTime: 1000, VMAddress: address},
+	)
+	require.Nil(t, err)
+	require.NotNil(t, driver)
+	require.False(t, driver.IsClosed())
+	return driver
+}
@@ -161,6 +161,157 @@ func TestBlockchainHookGateway_SaveCompiledCode(t *testing.T) {
 	runHookScenario(t, callHook, handleHookCall)
 }
 
+func TestGateway_CachesHookCallsDuringTransaction(t *testing.T) {
+	callHook := func(gateway *BlockchainHookGateway) {
+		for i := 0; i < 3; i++ {
+			data, _, err := gateway.GetStorageData([]byte("alice"), []byte("foo"))
+			require.NoError(t, err)
+			require.Equal(t, "bar", string(data))
+		}
+
+		gateway.BeginTransaction(nil)
+		data, _, err := gateway.GetStorageData([]byte("alice"), []byte("foo"))
+		require.NoError(t, err)
+		require.Equal(t, "bar", string(data))
+	}
+
+	numRequests := 0
+	handleHookCall := func(request common.MessageHandler) common.MessageHandler {
+		numRequests++
+		require.Equal(t, "foo", string(request.(*common.MessageBlockchainGetStorageDataRequest).Index))
+		return common.NewMessageBlockchainGetStorageDataResponse([]byte("bar"), nil)
+	}
+
+	runHookCallsScenario(t, callHook, handleHookCall)
+	require.Equal(t, 2, numRequests)
+}
+
+func TestGateway_ProcessBuiltInFunctionInvalidatesCachedState(t *testing.T) {
+	callHook := func(gateway *BlockchainHookGateway) {
+		data, _, err := gateway.GetStorageData([]byte("alice"), []byte("balance"))
+		require.NoError(t, err)
+		require.Equal(t, []byte{10}, data)
+
+		_, err = gateway.ProcessBuiltInFunction(&vmcommon.ContractCallInput{Function: "ESDTTransfer"})
+		require.NoError(t, err)
+
+		data, _, err = gateway.GetStorageData([]byte("alice"), []byte("balance"))
+		require.NoError(t, err)
+		require.Equal(t, []byte{5}, data)
+	}
+
+	balance := byte(10)
+	handleHookCall := func(request common.MessageHandler) common.MessageHandler {
+		if request.GetKind() == common.BlockchainProcessBuiltinFunctionRequest {
+			balance = 5
+			return common.NewMessageBlockchainProcessBuiltinFunctionResponse(&vmcommon.VMOutput{}, nil)
+		}
+
+		return common.NewMessageBlockchainGetStorageDataResponse([]byte{balance}, nil)
+	}
+
+	runHookCallsScenario(t, callHook, handleHookCall)
+}
+
+func TestGateway_BeginTransactionWithPrefetchHint(t *testing.T) {
+	callHook := func(gateway *BlockchainHookGateway) {
+		gateway.BeginTransaction(&common.PrefetchHint{
+			Account: &common.Account{Address: []byte("contract"), OwnerAddress: []byte("alice"), CodeHash: []byte("hash")},
+			Code:    []byte("code"),
+			Storage: common.NewSerializableMapStringBytes(map[string][]byte{"counter": {42}}),
+		})
+
+		account, err := gateway.GetUserAccount([]byte("contract"))
+		require.NoError(t, err)
+		require.Equal(t, "alice", string(account.GetOwnerAddress()))
+		require.Equal(t, "code", string(gateway.GetCode(account)))
+
+		data, _, err := gateway.GetStorageData([]byte("contract"), []byte("counter"))
+		require.NoError(t, err)
+		require.Equal(t, []byte{42}, data)
+	}
+
+	handleHookCall := func(request common.MessageHandler) common.MessageHandler {
+		require.Fail(t, "unexpected hook call", request.GetKindName())
+		return common.NewUndefinedMessage()
+	}
+
+	runHookCallsScenario(t, callHook, handleHookCall)
+}
+
+func TestGateway_FetchesBlockInfoInOneBatch(t *testing.T) {
+	callHook := func(gateway *BlockchainHookGateway) {
+		require.Equal(t, uint32(7), gateway.CurrentEpoch())
+		require.Equal(t, uint64(100), gateway.CurrentNonce())
+		require.Equal(t, uint64(99), gateway.LastNonce())
+		require.Equal(t, uint32(7), gateway.CurrentEpoch())
+	}
+
+	numRequests := 0
+	handleHookCall := func(request common.MessageHandler) common.MessageHandler {
+		numRequests++
+		require.Equal(t, common.BlockchainBatchRequest, request.GetKind())
+		return handleBatchOfHookCalls(t, request, func(hookCall common.MessageHandler) common.MessageHandler {
+			switch hookCall.GetKind() {
+			case common.BlockchainCurrentEpochRequest:
+				return common.NewMessageBlockchainCurrentEpochResponse(7)
+			case common.BlockchainCurrentNonceRequest:
+				return common.NewMessageBlockchainCurrentNonceResponse(100)
+			case common.BlockchainLastNonceRequest:
+				return common.NewMessageBlockchainLastNonceResponse(99)
+			default:
+				return common.CreateMessage(hookCall.GetKind() + 1)
+			}
+		})
+	}
+
+	runHookCallsScenario(t, callHook, handleHookCall)
+	require.Equal(t, 1, numRequests)
+}
+
+func TestGateway_FallsBackToSingleHookCallsWhenBatchNotSupported(t *testing.T) {
+	callHook := func(gateway *BlockchainHookGateway) {
+		require.Equal(t, uint32(7), gateway.CurrentEpoch())
+		require.Equal(t, uint32(7), gateway.CurrentEpoch())
+
+		gateway.BeginTransaction(nil)
+		require.Equal(t, uint32(7), gateway.CurrentEpoch())
+	}
+
+	var kinds []common.MessageKind
+	handleHookCall := func(request common.MessageHandler) common.MessageHandler {
+		kinds = append(kinds, request.GetKind())
+		if request.GetKind() == common.BlockchainBatchRequest {
+			// As an older Node would answer
+			return common.NewUndefinedMessage()
+		}
+
+		return common.NewMessageBlockchainCurrentEpochResponse(7)
+	}
+
+	runHookCallsScenario(t, callHook, handleHookCall)
+	require.Equal(t, []common.MessageKind{
+		common.BlockchainBatchRequest,
+		common.BlockchainCurrentEpochRequest,
+		common.BlockchainCurrentEpochRequest,
+	}, kinds)
+}
+
+func handleBatchOfHookCalls(t *testing.T, request common.MessageHandler, handleHookCall func(common.MessageHandler) common.MessageHandler) common.MessageHandler {
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	hookCalls, err := common.UnpackMessages(request.(*common.MessageBlockchainBatchRequest).Requests, marshalizer)
+	require.NoError(t, err)
+
+	responses := make([]common.MessageHandler, 0, len(hookCalls))
+	for _, hookCall := range hookCalls {
+		responses = append(responses, handleHookCall(hookCall))
+	}
+
+	rawResponses, err := common.PackMessages(responses, marshalizer)
+	require.NoError(t, err)
+	return common.NewMessageBlockchainBatchResponse(rawResponses, nil)
+}
+
 func runHookScenario(t *testing.T, callHook func(*BlockchainHookGateway), handleHookCall func(common.MessageHandler) common.MessageHandler) {
 	testFiles := createTestFiles(t)
 	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
@@ -179,6 +330,33 @@ func runHookScenario(t *testing.T, callHook func(*BlockchainHookGateway), handle
 	callHook(gateway)
 }
 
+// runHookCallsScenario answers any number of hook calls, until callHook returns
+func runHookCallsScenario(t *testing.T, callHook func(*BlockchainHookGateway), handleHookCall func(common.MessageHandler) common.MessageHandler) {
+	testFiles := createTestFiles(t)
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	nodeMessenger := nodepart.NewNodeMessenger(testFiles.inputOfNode, testFiles.outputOfNode, marshalizer)
+	vmMessenger := NewVMMessenger(testFiles.inputOfVM, testFiles.outputOfVM, marshalizer)
+	gateway := NewBlockchainHookGateway(vmMessenger)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			request, err := nodeMessenger.Receive(0)
+			if err != nil {
+				return
+			}
+
+			err = nodeMessenger.SendHookCallResponse(handleHookCall(request))
+			require.NoError(t, err)
+		}
+	}()
+
+	callHook(gateway)
+	_ = testFiles.outputOfVM.Close()
+	<-done
+}
+
 type testFiles struct {
 	outputOfNode *os.File
 	inputOfVM    *os.File
@@ -0,0 +1,162 @@
+package vmpart
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+)
+
+type cachingPolicy int
+
+const (
+	notCached cachingPolicy = iota
+	// cachedForTransaction is the policy of the responses which do not change during a transaction
+	cachedForTransaction
+	// cachedUntilStateChanges is the policy of the responses which depend on the state of the accounts
+	cachedUntilStateChanges
+)
+
+// blockInfoKinds are the hook calls without arguments whose responses do not change during a transaction;
+// on the first such call, they are all made within a single batch
+var blockInfoKinds = []common.MessageKind{
+	common.BlockchainLastNonceRequest,
+	common.BlockchainLastRoundRequest,
+	common.BlockchainLastTimeStampRequest,
+	common.BlockchainLastRandomSeedRequest,
+	common.BlockchainLastEpochRequest,
+	common.BlockchainGetStateRootHashRequest,
+	common.BlockchainCurrentNonceRequest,
+	common.BlockchainCurrentRoundRequest,
+	common.BlockchainCurrentTimeStampRequest,
+	common.BlockchainCurrentRandomSeedRequest,
+	common.BlockchainCurrentEpochRequest,
+}
+
+func isBlockInfo(kind common.MessageKind) bool {
+	for _, blockInfoKind := range blockInfoKinds {
+		if kind == blockInfoKind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getCachingPolicy tells whether the response to a hook call can be cached, and under which key
+func getCachingPolicy(request common.MessageHandler) (cachingPolicy, string) {
+	switch typedRequest := request.(type) {
+	case *common.MessageBlockchainGetStorageDataRequest:
+		return cachedUntilStateChanges, fmt.Sprintf("%x/%x", typedRequest.Address, typedRequest.Index)
+	case *common.MessageBlockchainGetUserAccountRequest:
+		return cachedUntilStateChanges, fmt.Sprintf("%x", typedRequest.Address)
+	case *common.MessageBlockchainGetESDTTokenRequest:
+		return cachedUntilStateChanges, fmt.Sprintf("%x/%x/%d", typedRequest.Address, typedRequest.TokenID, typedRequest.Nonce)
+	case *common.MessageBlockchainIsSmartContractRequest:
+		return cachedUntilStateChanges, fmt.Sprintf("%x", typedRequest.Address)
+	case *common.MessageBlockchainIsPayableRequest:
+		return cachedUntilStateChanges, fmt.Sprintf("%x", typedRequest.Address)
+	case *common.MessageBlockchainGetCodeRequest:
+		// the code is cached by its hash; the code of an account without a code hash is not cached
+		if typedRequest.Account == nil || len(typedRequest.Account.CodeHash) == 0 {
+			return notCached, ""
+		}
+		return cachedForTransaction, fmt.Sprintf("%x", typedRequest.Account.CodeHash)
+	case *common.MessageBlockchainGetShardOfAddressRequest:
+		return cachedForTransaction, fmt.Sprintf("%x", typedRequest.Address)
+	case *common.MessageBlockchainGetBlockhashRequest:
+		return cachedForTransaction, fmt.Sprintf("%d", typedRequest.Nonce)
+	}
+
+	if isBlockInfo(request.GetKind()) {
+		return cachedForTransaction, ""
+	}
+
+	return notCached, ""
+}
+
+// hookCallCache holds the responses to the hook calls made while VM executes a transaction.
+// During a transaction, the state of the Node only changes when the Node executes a built-in function
+// (the writes of the contracts are held by VM until the end of the transaction), thus only then the cached state is discarded.
+type hookCallCache struct {
+	stateResponses       map[string]common.MessageHandler
+	transactionResponses map[string]common.MessageHandler
+	blockInfoFetched     bool
+}
+
+func newHookCallCache() *hookCallCache {
+	cache := &hookCallCache{}
+	cache.reset()
+	return cache
+}
+
+// reset discards all the responses, at the start of a transaction
+func (cache *hookCallCache) reset() {
+	cache.invalidateState()
+	cache.transactionResponses = make(map[string]common.MessageHandler)
+	cache.blockInfoFetched = false
+}
+
+// invalidateState discards the responses which depend on the state of the accounts
+func (cache *hookCallCache) invalidateState() {
+	cache.stateResponses = make(map[string]common.MessageHandler)
+}
+
+func (cache *hookCallCache) getResponses(request common.MessageHandler) (map[string]common.MessageHandler, string) {
+	policy, key := getCachingPolicy(request)
+	key = fmt.Sprintf("%d:%s", request.GetKind(), key)
+
+	switch policy {
+	case cachedForTransaction:
+		return cache.transactionResponses, key
+	case cachedUntilStateChanges:
+		return cache.stateResponses, key
+	default:
+		return nil, ""
+	}
+}
+
+func (cache *hookCallCache) get(request common.MessageHandler) (common.MessageHandler, bool) {
+	responses, key := cache.getResponses(request)
+	response, ok := responses[key]
+	return response, ok
+}
+
+// put caches the response, unless it holds an error
+func (cache *hookCallCache) put(request common.MessageHandler, response common.MessageHandler) {
+	responses, key := cache.getResponses(request)
+	if responses == nil || response.GetError() != nil {
+		return
+	}
+
+	responses[key] = response
+}
+
+// addPrefetchHint takes in the data shipped by the Node along with a contract call
+func (cache *hookCallCache) addPrefetchHint(hint *common.PrefetchHint) {
+	if hint == nil || hint.Account == nil {
+		return
+	}
+
+	address := hint.Account.Address
+	cache.put(
+		common.NewMessageBlockchainGetUserAccountRequest(address),
+		common.NewMessageBlockchainGetUserAccountResponse(hint.Account, nil),
+	)
+	if hint.Code != nil {
+		cache.put(
+			common.NewMessageBlockchainGetCodeRequest(hint.Account),
+			common.NewMessageBlockchainGetCodeResponse(hint.Code),
+		)
+	}
+
+	if hint.Storage == nil {
+		return
+	}
+
+	for i := 0; i < len(hint.Storage.Keys) && i < len(hint.Storage.Values); i++ {
+		cache.put(
+			common.NewMessageBlockchainGetStorageDataRequest(address, hint.Storage.Keys[i]),
+			common.NewMessageBlockchainGetStorageDataResponse(hint.Storage.Values[i], nil),
+		)
+	}
+}
@@ -1,7 +1,7 @@
 package vmpart
 
 import (
-	"os"
+	"time"
 
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
@@ -10,15 +10,22 @@ import (
 // VMMessenger is the messenger on VM's part of the pipe
 type VMMessenger struct {
 	common.Messenger
+	// hookReplyTimeout is the time, in milliseconds, to wait for the reply to a hook call; 0 means no timeout
+	hookReplyTimeout int
 }
 
 // NewVMMessenger creates a new messenger
-func NewVMMessenger(reader *os.File, writer *os.File, marshalizer marshaling.Marshalizer) *VMMessenger {
+func NewVMMessenger(reader common.Transport, writer common.Transport, marshalizer marshaling.Marshalizer) *VMMessenger {
 	return &VMMessenger{
 		Messenger: *common.NewMessengerPipes("VM", reader, writer, marshalizer),
 	}
 }
 
+// SetHookReplyTimeout sets the time, in milliseconds, to wait for the reply to a hook call; 0 means no timeout
+func (messenger *VMMessenger) SetHookReplyTimeout(timeout int) {
+	messenger.hookReplyTimeout = timeout
+}
+
 // ReceiveNodeRequest waits for a request from Node
 func (messenger *VMMessenger) ReceiveNodeRequest() (common.MessageHandler, error) {
 	message, err := messenger.Receive(0)
@@ -45,15 +52,50 @@ func (messenger *VMMessenger) SendContractResponse(response common.MessageHandle
 func (messenger *VMMessenger) SendHookCallRequest(request common.MessageHandler) (common.MessageHandler, error) {
 	log.Trace("[VM]: SendHookCallRequest", "request", request.DebugString())
 
+	start := time.Now()
 	err := messenger.Send(request)
 	if err != nil {
 		return nil, common.ErrCannotSendHookCallRequest
 	}
 
-	response, err := messenger.Receive(0)
+	response, err := messenger.Receive(messenger.hookReplyTimeout)
 	if err != nil {
 		return nil, common.ErrCannotReceiveHookCallResponse
 	}
 
+	messenger.GetStatsCollector().AddLatency(request.GetKindName(), time.Since(start))
 	return response, nil
 }
+
+// SendHookCallBatch makes several hook calls (over the pipe) in a single round trip, and returns the responses in the order of the requests
+func (messenger *VMMessenger) SendHookCallBatch(requests []common.MessageHandler) ([]common.MessageHandler, error) {
+	rawRequests, err := messenger.PackMessages(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	rawResponse, err := messenger.SendHookCallRequest(common.NewMessageBlockchainBatchRequest(rawRequests))
+	if err != nil {
+		return nil, err
+	}
+
+	if rawResponse.GetKind() != common.BlockchainBatchResponse {
+		return nil, common.ErrBatchOfHookCallsNotSupported
+	}
+
+	response := rawResponse.(*common.MessageBlockchainBatchResponse)
+	err = response.GetError()
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := messenger.UnpackMessages(response.Responses)
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) != len(requests) {
+		return nil, common.ErrBadHookResponseFromNode
+	}
+
+	return responses, nil
+}
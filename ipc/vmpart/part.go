@@ -1,7 +1,8 @@
 package vmpart
 
 import (
-	"os"
+	"io"
+	"sync"
 	"time"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -16,17 +17,21 @@ var log = logger.GetOrCreate("vm/part")
 
 // VMPart is the endpoint that implements the message loop on VM's side
 type VMPart struct {
-	Messenger *VMMessenger
-	VMHost    vmcommon.VMExecutionHandler
-	Repliers  []common.MessageReplier
-	Version   string
+	Messenger  *VMMessenger
+	VMHost     vmcommon.VMExecutionHandler
+	Repliers   []common.MessageReplier
+	Version    string
+	blockchain *BlockchainHookGateway
+	// heartbeats tells whether the Node accepts heartbeats, as agreed upon in the handshake
+	heartbeats      bool
+	heartbeatPeriod time.Duration
 }
 
 // NewVMPart creates the VM part
 func NewVMPart(
 	version string,
-	input *os.File,
-	output *os.File,
+	input common.Transport,
+	output common.Transport,
 	vmHostParameters *vmhost.VMHostParameters,
 	marshalizer marshaling.Marshalizer,
 ) (*VMPart, error) {
@@ -42,9 +47,10 @@ func NewVMPart(
 	}
 
 	part := &VMPart{
-		Messenger: messenger,
-		VMHost:    newVMHost,
-		Version:   version,
+		Messenger:  messenger,
+		VMHost:     newVMHost,
+		Version:    version,
+		blockchain: blockchain,
 	}
 
 	part.Repliers = common.CreateReplySlots(part.noopReplier)
@@ -53,10 +59,32 @@ func NewVMPart(
 	part.Repliers[common.DiagnoseWaitRequest] = part.replyToDiagnoseWait
 	part.Repliers[common.VersionRequest] = part.replyToVersionRequest
 	part.Repliers[common.GasScheduleChangeRequest] = part.replyToGasScheduleChange
+	part.Repliers[common.DiagnoseStatsRequest] = part.replyToDiagnoseStats
 
 	return part, nil
 }
 
+// EnableCapabilities enables the capabilities agreed upon in the handshake with the Node
+func (part *VMPart) EnableCapabilities(capabilities []string) {
+	part.blockchain.batchNotSupported = !common.HasCapability(capabilities, common.CapabilityBatchOfHookCalls)
+	if common.HasCapability(capabilities, common.CapabilityCompression) {
+		part.Messenger.EnableCompression()
+	}
+	part.heartbeats = common.HasCapability(capabilities, common.CapabilityHeartbeats)
+}
+
+// SetDialogueTimings sets the period of the heartbeats (if agreed upon in the handshake) and the timeout of the hook calls,
+// both in milliseconds, as received in the VMArguments
+func (part *VMPart) SetDialogueTimings(heartbeatPeriod int, hookReplyTimeout int) {
+	part.heartbeatPeriod = time.Duration(heartbeatPeriod) * time.Millisecond
+	part.Messenger.SetHookReplyTimeout(hookReplyTimeout)
+}
+
+// EnableRecording records the messages exchanged with the Node, from now on
+func (part *VMPart) EnableRecording(recorder *common.DialogueRecorder) {
+	part.Messenger.SetRecorder(recorder)
+}
+
 func (part *VMPart) noopReplier(_ common.MessageHandler) common.MessageHandler {
 	log.Error("noopReplier called")
 	return common.CreateMessage(common.UndefinedRequestOrResponse)
@@ -67,6 +95,11 @@ func (part *VMPart) StartLoop() error {
 	part.Messenger.Reset()
 	err := part.doLoop()
 	part.Messenger.Shutdown()
+	if err == common.ErrStopPerNodeRequest || err == io.EOF {
+		log.Info("end of loop", "err", err)
+		return err
+	}
+
 	log.Error("end of loop", "err", err)
 	return err
 }
@@ -82,7 +115,11 @@ func (part *VMPart) doLoop() error {
 			return common.ErrStopPerNodeRequest
 		}
 
+		start := time.Now()
+		stopHeartbeats := part.startHeartbeats()
 		response := part.replyToNodeRequest(request)
+		stopHeartbeats()
+		part.Messenger.GetStatsCollector().AddLatency(request.GetKindName(), time.Since(start))
 
 		// Successful execution, send response
 		err = part.Messenger.SendContractResponse(response)
@@ -94,6 +131,42 @@ func (part *VMPart) doLoop() error {
 	}
 }
 
+// startHeartbeats sends heartbeats, while a request is executed, until the returned function is called
+func (part *VMPart) startHeartbeats() func() {
+	if !part.heartbeats || part.heartbeatPeriod <= 0 {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(part.heartbeatPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := part.Messenger.SendHeartbeat()
+				if err != nil {
+					log.Debug("cannot send heartbeat", "err", err)
+					return
+				}
+			}
+		}
+	}()
+
+	// no heartbeat is sent after the response, thus the heartbeats never outlive the dialogue
+	return func() {
+		close(stop)
+		wg.Wait()
+	}
+}
+
 func (part *VMPart) replyToNodeRequest(request common.MessageHandler) common.MessageHandler {
 	replier := part.Repliers[request.GetKind()]
 	return replier(request)
@@ -101,12 +174,14 @@ func (part *VMPart) replyToNodeRequest(request common.MessageHandler) common.Mes
 
 func (part *VMPart) replyToRunSmartContractCreate(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageContractDeployRequest)
+	part.blockchain.BeginTransaction(nil)
 	vmOutput, err := part.VMHost.RunSmartContractCreate(typedRequest.CreateInput)
 	return common.NewMessageContractResponse(vmOutput, err)
 }
 
 func (part *VMPart) replyToRunSmartContractCall(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageContractCallRequest)
+	part.blockchain.BeginTransaction(typedRequest.Prefetch)
 	vmOutput, err := part.VMHost.RunSmartContractCall(typedRequest.CallInput)
 	return common.NewMessageContractResponse(vmOutput, err)
 }
@@ -122,6 +197,10 @@ func (part *VMPart) replyToVersionRequest(_ common.MessageHandler) common.Messag
 	return common.NewMessageVersionResponse(part.Version)
 }
 
+func (part *VMPart) replyToDiagnoseStats(_ common.MessageHandler) common.MessageHandler {
+	return common.NewMessageDiagnoseStatsResponse(part.Messenger.GetStatsCollector().GetStats())
+}
+
 func (part *VMPart) replyToGasScheduleChange(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageGasScheduleChangeRequest)
 	part.VMHost.GasScheduleChange(typedRequest.GasSchedule)
@@ -0,0 +1,142 @@
+package vmpart
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
+)
+
+// Replay runs a fresh VM through a recorded session: VM receives the messages recorded as sent by the Node,
+// and has to send back exactly the messages recorded as sent by VM (the hook calls and the final responses).
+// The timeout, in milliseconds, bounds the wait for each message of VM.
+func Replay(session *common.RecordedSession, version string, timeout int) error {
+	nodeToVMRead, nodeToVMWrite, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	vmToNodeRead, vmToNodeWrite, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	vmArguments := session.VMArguments
+	vmHostParameters := &vmArguments.VMHostParameters
+	vmHostParameters.EnableEpochsHandler = hostCore.NewEnableEpochsHandler(vmArguments.EnableEpochs)
+	marshalizer := marshaling.CreateMarshalizer(vmArguments.MessagesMarshalizer)
+
+	part, err := NewVMPart(version, nodeToVMRead, vmToNodeWrite, vmHostParameters, marshalizer)
+	if err != nil {
+		return err
+	}
+
+	part.EnableCapabilities(session.Capabilities)
+	part.SetDialogueTimings(vmArguments.HeartbeatPeriod, vmArguments.HookReplyTimeout)
+
+	node := common.NewMessengerPipes("REPLAY", vmToNodeRead, nodeToVMWrite, marshalizer)
+	if common.HasCapability(session.Capabilities, common.CapabilityCompression) {
+		node.EnableCompression()
+	}
+
+	loopEnded := make(chan error, 1)
+	go func() {
+		loopEnded <- part.StartLoop()
+	}()
+
+	err = replayMessages(node, session.Messages, timeout)
+	if err == nil {
+		err = node.Send(common.NewMessageStop())
+	}
+
+	// when the dialogue diverged, VM might still wait for a message; closing the pipes ends its loop
+	if err != nil {
+		node.Shutdown()
+	}
+
+	select {
+	case loopErr := <-loopEnded:
+		if err == nil && !errors.Is(loopErr, common.ErrStopPerNodeRequest) {
+			err = loopErr
+		}
+	case <-time.After(time.Duration(timeout) * time.Millisecond):
+		if err == nil {
+			err = fmt.Errorf("%w: VM did not stop", common.ErrReplayDivergence)
+		}
+	}
+
+	if err == nil {
+		node.Shutdown()
+	}
+
+	return err
+}
+
+func replayMessages(node *common.Messenger, messages []*common.RecordedMessage, timeout int) error {
+	for index, recorded := range messages {
+		if recorded.Sender == common.PartNode {
+			err := node.Send(recorded.Message)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		message, err := receiveFromVM(node, timeout)
+		if err != nil {
+			return fmt.Errorf("%w: message %d: expected %s, got error %v", common.ErrReplayDivergence, index, recorded.Message.GetKindName(), err)
+		}
+
+		err = compareMessages(index, recorded.Message, message)
+		if err != nil {
+			return err
+		}
+
+		// the end of a dialogue, as for the Node's part
+		if !common.IsHookCall(message) {
+			node.ResetDialogue()
+		}
+	}
+
+	return nil
+}
+
+// receiveFromVM skips the heartbeats, which are not recorded
+func receiveFromVM(node *common.Messenger, timeout int) (common.MessageHandler, error) {
+	for {
+		message, err := node.Receive(timeout)
+		if err != nil || !common.IsHeartbeat(message) {
+			return message, err
+		}
+	}
+}
+
+// compareMessages compares the messages in their JSON form, the one of the replay files
+func compareMessages(index int, expected common.MessageHandler, actual common.MessageHandler) error {
+	if actual.GetKind() != expected.GetKind() {
+		return fmt.Errorf("%w: message %d: expected %s, got %s", common.ErrReplayDivergence, index, expected.GetKindName(), actual.GetKindName())
+	}
+
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		return err
+	}
+
+	actualJSON, err := json.Marshal(actual)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(expectedJSON, actualJSON) {
+		return fmt.Errorf("%w: message %d (%s): expected %s, got %s", common.ErrReplayDivergence, index, expected.GetKindName(), expectedJSON, actualJSON)
+	}
+
+	return nil
+}
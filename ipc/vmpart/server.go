@@ -0,0 +1,141 @@
+package vmpart
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
+)
+
+// VMServer runs VM as a standalone process, which Nodes connect to through a Unix domain socket or TCP.
+// The connections are served one at a time: the Node sends the VMArguments as the first message,
+// then a new VMPart serves the connection until the Node stops it or disconnects.
+type VMServer struct {
+	listener net.Listener
+	version  string
+	recorder *common.DialogueRecorder
+
+	mutConnection sync.Mutex
+	connection    net.Conn
+	closed        bool
+}
+
+// NewVMServer creates a VM server which accepts the connections of the Nodes on the given listener
+func NewVMServer(listener net.Listener, version string) *VMServer {
+	return &VMServer{
+		listener: listener,
+		version:  version,
+	}
+}
+
+// SetRecorder records the dialogues of the connections served from now on, each as a session of the replay file
+func (server *VMServer) SetRecorder(recorder *common.DialogueRecorder) {
+	server.recorder = recorder
+}
+
+// Serve accepts and serves connections until the server is closed
+func (server *VMServer) Serve() error {
+	for {
+		connection, err := server.listener.Accept()
+		if err != nil {
+			if server.isClosed() {
+				return nil
+			}
+
+			return err
+		}
+
+		if !server.setConnection(connection) {
+			_ = connection.Close()
+			return nil
+		}
+
+		err = server.serveConnection(connection)
+		server.setConnection(nil)
+		if err != nil && !errors.Is(err, common.ErrStopPerNodeRequest) && !errors.Is(err, io.EOF) {
+			log.Warn("VMServer: connection ended", "remote", connection.RemoteAddr(), "err", err)
+		}
+	}
+}
+
+func (server *VMServer) serveConnection(connection net.Conn) error {
+	log.Info("VMServer: node connected", "remote", connection.RemoteAddr())
+
+	vmArguments, err := common.GetVMArguments(connection)
+	if err != nil {
+		_ = connection.Close()
+		return err
+	}
+
+	capabilities, err := common.AcceptHandshake(connection, connection, common.NewHandshake(), server.version)
+	if err != nil {
+		_ = connection.Close()
+		return err
+	}
+
+	vmHostParameters := &vmArguments.VMHostParameters
+	vmHostParameters.EnableEpochsHandler = hostCore.NewEnableEpochsHandler(vmArguments.EnableEpochs)
+
+	part, err := NewVMPart(
+		server.version,
+		connection,
+		connection,
+		vmHostParameters,
+		marshaling.CreateMarshalizer(vmArguments.MessagesMarshalizer),
+	)
+	if err != nil {
+		_ = connection.Close()
+		return err
+	}
+
+	part.EnableCapabilities(capabilities)
+	part.SetDialogueTimings(vmArguments.HeartbeatPeriod, vmArguments.HookReplyTimeout)
+
+	if server.recorder != nil {
+		err = server.recorder.BeginSession(common.ReplaySession{VMArguments: *vmArguments, Capabilities: capabilities})
+		if err != nil {
+			_ = connection.Close()
+			return err
+		}
+
+		part.EnableRecording(server.recorder)
+	}
+
+	return part.StartLoop()
+}
+
+// Close stops accepting connections and closes the connection being served
+func (server *VMServer) Close() error {
+	server.mutConnection.Lock()
+	server.closed = true
+	if server.connection != nil {
+		_ = server.connection.Close()
+	}
+	server.mutConnection.Unlock()
+
+	return server.listener.Close()
+}
+
+// setConnection returns false if the server was closed in the meantime
+func (server *VMServer) setConnection(connection net.Conn) bool {
+	server.mutConnection.Lock()
+	defer server.mutConnection.Unlock()
+
+	if server.closed {
+		return false
+	}
+
+	server.connection = connection
+	return true
+}
+
+func (server *VMServer) isClosed() bool {
+	server.mutConnection.Lock()
+	defer server.mutConnection.Unlock()
+
+	return server.closed
+}
@@ -31,6 +31,7 @@ type RuntimeContextMock struct {
 	FailBigIntAPI          bool
 	AsyncCallInfo          *vmhost.AsyncCallInfo
 	RunningInstances       uint64
+	MemoryPages            uint64
 	CurrentTxHash          []byte
 	OriginalTxHash         []byte
 }
@@ -105,6 +106,25 @@ func (r *RuntimeContextMock) RunningInstancesCount() uint64 {
 func (r *RuntimeContextMock) SetMaxInstanceCount(uint64) {
 }
 
+// SetMaxMemoryPages mocked method
+func (r *RuntimeContextMock) SetMaxMemoryPages(uint64) {
+}
+
+// UpdateMemoryUsage mocked method
+func (r *RuntimeContextMock) UpdateMemoryUsage() error {
+	return r.Err
+}
+
+// MemoryPagesInUse mocked method
+func (r *RuntimeContextMock) MemoryPagesInUse() uint64 {
+	return r.MemoryPages
+}
+
+// PeakMemoryPages mocked method
+func (r *RuntimeContextMock) PeakMemoryPages() uint64 {
+	return r.MemoryPages
+}
+
 // ClearInstanceStack mocked method
 func (r *RuntimeContextMock) ClearInstanceStack() {
 }
@@ -81,6 +81,14 @@ type runtimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetMaxInstanceCountFunc func(maxInstances uint64)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetMaxMemoryPagesFunc func(maxMemoryPages uint64)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	UpdateMemoryUsageFunc func() error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	MemoryPagesInUseFunc func() uint64
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	PeakMemoryPagesFunc func() uint64
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	VerifyContractCodeFunc func() error
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetInstanceExportsFunc func() wasmer.ExportsMap
@@ -268,6 +276,22 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *runti
 		runtimeWrapper.runtimeContext.SetMaxInstanceCount(maxInstances)
 	}
 
+	runtimeWrapper.SetMaxMemoryPagesFunc = func(maxMemoryPages uint64) {
+		runtimeWrapper.runtimeContext.SetMaxMemoryPages(maxMemoryPages)
+	}
+
+	runtimeWrapper.UpdateMemoryUsageFunc = func() error {
+		return runtimeWrapper.runtimeContext.UpdateMemoryUsage()
+	}
+
+	runtimeWrapper.MemoryPagesInUseFunc = func() uint64 {
+		return runtimeWrapper.runtimeContext.MemoryPagesInUse()
+	}
+
+	runtimeWrapper.PeakMemoryPagesFunc = func() uint64 {
+		return runtimeWrapper.runtimeContext.PeakMemoryPages()
+	}
+
 	runtimeWrapper.VerifyContractCodeFunc = func() error {
 		return runtimeWrapper.runtimeContext.VerifyContractCode()
 	}
@@ -526,6 +550,26 @@ func (contextWrapper *runtimeContextWrapper) SetMaxInstanceCount(maxInstances ui
 	contextWrapper.SetMaxInstanceCountFunc(maxInstances)
 }
 
+// SetMaxMemoryPages calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *runtimeContextWrapper) SetMaxMemoryPages(maxMemoryPages uint64) {
+	contextWrapper.SetMaxMemoryPagesFunc(maxMemoryPages)
+}
+
+// UpdateMemoryUsage calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *runtimeContextWrapper) UpdateMemoryUsage() error {
+	return contextWrapper.UpdateMemoryUsageFunc()
+}
+
+// MemoryPagesInUse calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *runtimeContextWrapper) MemoryPagesInUse() uint64 {
+	return contextWrapper.MemoryPagesInUseFunc()
+}
+
+// PeakMemoryPages calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *runtimeContextWrapper) PeakMemoryPages() uint64 {
+	return contextWrapper.PeakMemoryPagesFunc()
+}
+
 // VerifyContractCode calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *runtimeContextWrapper) VerifyContractCode() error {
 	return contextWrapper.VerifyContractCodeFunc()
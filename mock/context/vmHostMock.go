@@ -26,8 +26,13 @@ type VMHostMock struct {
 	StorageContext    vmhost.StorageContext
 	BigIntContext     vmhost.BigIntContext
 
-	SCAPIMethods  *wasmer.Imports
-	IsBuiltinFunc bool
+	SCAPIMethods      *wasmer.Imports
+	IsBuiltinFunc     bool
+	CoverageCollector vmhost.CoverageCollector
+
+	CodeMetadataEnforcementEnabled bool
+	MemoryAccountingEnabled        bool
+	EmitEventEnabled               bool
 }
 
 // Crypto mocked method
@@ -90,11 +95,36 @@ func (host *VMHostMock) IsESDTFunctionsEnabled() bool {
 	return true
 }
 
+// IsFloatingPointRejectionEnabled mocked method
+func (host *VMHostMock) IsFloatingPointRejectionEnabled() bool {
+	return false
+}
+
+// IsCodeMetadataEnforcementEnabled mocked method
+func (host *VMHostMock) IsCodeMetadataEnforcementEnabled() bool {
+	return host.CodeMetadataEnforcementEnabled
+}
+
+// IsMemoryAccountingEnabled mocked method
+func (host *VMHostMock) IsMemoryAccountingEnabled() bool {
+	return host.MemoryAccountingEnabled
+}
+
+// IsEmitEventEnabled mocked method
+func (host *VMHostMock) IsEmitEventEnabled() bool {
+	return host.EmitEventEnabled
+}
+
 // AreInSameShard mocked method
 func (host *VMHostMock) AreInSameShard(_ []byte, _ []byte) bool {
 	return true
 }
 
+// Coverage mocked method
+func (host *VMHostMock) Coverage() vmhost.CoverageCollector {
+	return host.CoverageCollector
+}
+
 // RevertESDTTransfer mocked method
 func (host *VMHostMock) RevertESDTTransfer(_ *vmcommon.ContractCallInput) {
 }
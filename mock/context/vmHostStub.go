@@ -35,6 +35,7 @@ type VMHostStub struct {
 	GetProtocolBuiltinFunctionsCalled func() vmcommon.FunctionNames
 	IsBuiltinFunctionNameCalled       func(functionName string) bool
 	AreInSameShardCalled              func(left []byte, right []byte) bool
+	CoverageCalled                    func() vmhost.CoverageCollector
 }
 
 // InitState mocked method
@@ -122,6 +123,26 @@ func (vhs *VMHostStub) IsESDTFunctionsEnabled() bool {
 	return true
 }
 
+// IsFloatingPointRejectionEnabled mocked method
+func (vhs *VMHostStub) IsFloatingPointRejectionEnabled() bool {
+	return false
+}
+
+// IsCodeMetadataEnforcementEnabled mocked method
+func (vhs *VMHostStub) IsCodeMetadataEnforcementEnabled() bool {
+	return false
+}
+
+// IsMemoryAccountingEnabled mocked method
+func (vhs *VMHostStub) IsMemoryAccountingEnabled() bool {
+	return false
+}
+
+// IsEmitEventEnabled mocked method
+func (vhs *VMHostStub) IsEmitEventEnabled() bool {
+	return false
+}
+
 // Output mocked method
 func (vhs *VMHostStub) Output() vmhost.OutputContext {
 	if vhs.OutputCalled != nil {
@@ -193,6 +214,14 @@ func (vhs *VMHostStub) AreInSameShard(left []byte, right []byte) bool {
 	return true
 }
 
+// Coverage mocked method
+func (vhs *VMHostStub) Coverage() vmhost.CoverageCollector {
+	if vhs.CoverageCalled != nil {
+		return vhs.CoverageCalled()
+	}
+	return nil
+}
+
 // GetAPIMethods mocked method
 func (vhs *VMHostStub) GetAPIMethods() *wasmer.Imports {
 	if vhs.GetAPIMethodsCalled != nil {
@@ -101,3 +101,53 @@ func (bf *BuiltinFunctionsWrapper) PerformDirectESDTTransfer(
 
 	return vmOutput.GasRemaining, nil
 }
+
+// PerformDirectMultiESDTTransfer calls the real MultiESDTNFTTransfer function immediately,
+// transferring all the given tokens at once; only works for in-shard transfers for now.
+func (bf *BuiltinFunctionsWrapper) PerformDirectMultiESDTTransfer(
+	sender []byte,
+	receiver []byte,
+	esdtTransfers []*vmcommon.ESDTTransfer,
+	callType vm.CallType,
+	gasLimit uint64,
+	gasPrice uint64,
+) (uint64, error) {
+	nrTransfers := len(esdtTransfers)
+	nrTransfersAsBytes := big.NewInt(0).SetUint64(uint64(nrTransfers)).Bytes()
+
+	multiTransferInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			Arguments:   make([][]byte, 0),
+			CallValue:   big.NewInt(0),
+			CallType:    callType,
+			GasPrice:    gasPrice,
+			GasProvided: gasLimit,
+			GasLocked:   0,
+		},
+		RecipientAddr:     sender,
+		Function:          core.BuiltInFunctionMultiESDTNFTTransfer,
+		AllowInitFunction: false,
+	}
+	multiTransferInput.Arguments = append(multiTransferInput.Arguments, receiver, nrTransfersAsBytes)
+
+	for _, esdtTransfer := range esdtTransfers {
+		nonceAsBytes := big.NewInt(0).SetUint64(esdtTransfer.ESDTTokenNonce).Bytes()
+		multiTransferInput.Arguments = append(multiTransferInput.Arguments,
+			esdtTransfer.ESDTTokenName, nonceAsBytes, esdtTransfer.ESDTValue.Bytes())
+	}
+
+	vmOutput, err := bf.ProcessBuiltInFunction(multiTransferInput)
+	if err != nil {
+		return 0, err
+	}
+
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return 0, fmt.Errorf(
+			"MultiESDTNFTTransfer failed: retcode = %d, msg = %s",
+			vmOutput.ReturnCode,
+			vmOutput.ReturnMessage)
+	}
+
+	return vmOutput.GasRemaining, nil
+}
@@ -84,6 +84,54 @@ func (b *MockWorld) Clear() {
 	b.CompiledCode = make(map[string][]byte)
 }
 
+// LoadStateFrom replaces the accounts, block info and new address mocks with deep copies of the ones in the other world.
+// The builtin functions and the guarded account handler are kept, since they are bound to this world.
+func (b *MockWorld) LoadStateFrom(other *MockWorld) {
+	b.SelfShardID = other.SelfShardID
+	b.AcctMap = other.AcctMap.Clone()
+	b.AccountsAdapter = NewMockAccountsAdapter(b)
+	b.PreviousBlockInfo = other.PreviousBlockInfo.Clone()
+	b.CurrentBlockInfo = other.CurrentBlockInfo.Clone()
+	b.Blockhashes = cloneBytesSlice(other.Blockhashes)
+	b.NewAddressMocks = make([]*NewAddressMock, len(other.NewAddressMocks))
+	copy(b.NewAddressMocks, other.NewAddressMocks)
+	b.StateRootHash = cloneBytes(other.StateRootHash)
+	b.Err = other.Err
+	b.LastCreatedContractAddress = cloneBytes(other.LastCreatedContractAddress)
+	b.CompiledCode = make(map[string][]byte, len(other.CompiledCode))
+	for key, code := range other.CompiledCode {
+		b.CompiledCode[key] = cloneBytes(code)
+	}
+}
+
+// Clone creates a copy of the block info.
+func (bi *BlockInfo) Clone() *BlockInfo {
+	if bi == nil {
+		return nil
+	}
+
+	clone := *bi
+	if bi.RandomSeed != nil {
+		randomSeed := *bi.RandomSeed
+		clone.RandomSeed = &randomSeed
+	}
+
+	return &clone
+}
+
+func cloneBytesSlice(values [][]byte) [][]byte {
+	if values == nil {
+		return nil
+	}
+
+	clone := make([][]byte, len(values))
+	for i, value := range values {
+		clone[i] = cloneBytes(value)
+	}
+
+	return clone
+}
+
 // SetCurrentBlockHash -
 func (b *MockWorld) SetCurrentBlockHash(blockHash []byte) {
 	if b.CurrentBlockInfo == nil {
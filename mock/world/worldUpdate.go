@@ -82,12 +82,16 @@ func (b *MockWorld) UpdateAccountFromOutputAccount(modAcct *vmcommon.OutputAccou
 		acct.Nonce = modAcct.Nonce
 	}
 	if len(modAcct.Code) > 0 {
-		// TODO: set CodeMetadata according to code metdata coming from VM
-		acct.SetCodeAndMetadata(modAcct.Code, &vmcommon.CodeMetadata{
+		codeMetadata := &vmcommon.CodeMetadata{
 			Payable:     true,
 			Upgradeable: true,
 			Readable:    true,
-		})
+		}
+		if len(modAcct.CodeMetadata) > 0 {
+			deployedMetadata := vmcommon.CodeMetadataFromBytes(modAcct.CodeMetadata)
+			codeMetadata = &deployedMetadata
+		}
+		acct.SetCodeAndMetadata(modAcct.Code, codeMetadata)
 	}
 	if len(modAcct.OutputTransfers) > 0 && len(modAcct.OutputTransfers[0].Data) > 0 {
 		acct.AsyncCallData = string(modAcct.OutputTransfers[0].Data)
@@ -0,0 +1,28 @@
+package scenarioexec
+
+import (
+	"path/filepath"
+	"strings"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
+)
+
+const codeFilePrefix = "file:"
+
+// CoverageCodeNamer is implemented by the coverage collectors that name the contracts in their reports,
+// which the VMTestExecutor names after the files their code was loaded from.
+type CoverageCodeNamer interface {
+	NameCode(code []byte, name string)
+}
+
+func (ae *VMTestExecutor) nameCoverageCode(code mj.JSONBytesFromString) {
+	if ae.coverageCodeNamer == nil || len(code.Value) == 0 {
+		return
+	}
+	if !strings.HasPrefix(code.Original, codeFilePrefix) {
+		return
+	}
+
+	path := code.Original[len(codeFilePrefix):]
+	ae.coverageCodeNamer.NameCode(code.Value, filepath.Base(path))
+}
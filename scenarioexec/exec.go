@@ -1,7 +1,9 @@
 package scenarioexec
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"path/filepath"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -32,21 +34,87 @@ type VMTestExecutor struct {
 	scenGasScheduleLoaded bool
 	fileResolver          fr.FileResolver
 	exprReconstructor     er.ExprReconstructor
+	lastTxOutput          *vmi.VMOutput
+	gasUsed               uint64
+	enableEpochsHandler   *hostCore.EnableEpochsHandler
+	initialEnableEpochs   config.EnableEpochs
+	enableEpochs          config.EnableEpochs
+	coverageCodeNamer     CoverageCodeNamer
 }
 
 var _ mc.TestExecutor = (*VMTestExecutor)(nil)
 var _ mc.ScenarioExecutor = (*VMTestExecutor)(nil)
+var _ mc.ScenarioGasReporter = (*VMTestExecutor)(nil)
+
+// VMTestExecutorArgs holds the configuration of the VM created by a VMTestExecutor.
+type VMTestExecutorArgs struct {
+	// EnableEpochsHandler decides which flags are active in the VM.
+	// When set, the scenarios cannot declare enable epochs.
+	EnableEpochsHandler vmhost.EnableEpochsHandler
+
+	// EnableEpochs, when no EnableEpochsHandler is given, holds the activation epochs of the flags,
+	// which are then followed from the current block epoch of the scenarios.
+	// Defaults to config.MakeDefaultEnableEpochs().
+	EnableEpochs *config.EnableEpochs
+
+	// GasSchedule, when set, is used instead of the gas schedules declared by the scenarios.
+	GasSchedule config.GasScheduleMap
+
+	// CoverageCollector, when set, collects the coverage of the contracts run by the VM.
+	// It can be shared by several executors.
+	CoverageCollector vmhost.CoverageCollector
+}
+
+// NewEnableEpochsHandlerForFlags creates an EnableEpochsHandler that only enables the given flags.
+func NewEnableEpochsHandlerForFlags(flags []core.EnableEpochFlag) vmhost.EnableEpochsHandler {
+	enabledFlags := make(map[core.EnableEpochFlag]struct{}, len(flags))
+	for _, flag := range flags {
+		enabledFlags[flag] = struct{}{}
+	}
+
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			_, isEnabled := enabledFlags[flag]
+			return isEnabled
+		},
+	}
+}
 
 // NewVMTestExecutor prepares a new VMTestExecutor instance.
 func NewVMTestExecutor(scenarioexecPath string) (*VMTestExecutor, error) {
+	return NewVMTestExecutorWithArgs(scenarioexecPath, VMTestExecutorArgs{
+		EnableEpochsHandler: nil,
+		EnableEpochs:        nil,
+		GasSchedule:         nil,
+		CoverageCollector:   nil,
+	})
+}
+
+// NewVMTestExecutorWithArgs prepares a new VMTestExecutor instance, with a custom VM configuration.
+func NewVMTestExecutorWithArgs(scenarioexecPath string, args VMTestExecutorArgs) (*VMTestExecutor, error) {
 	world := worldhook.NewMockWorld()
 
-	gasScheduleMap := config.MakeGasMapForTests()
+	gasScheduleMap := args.GasSchedule
+	if gasScheduleMap == nil {
+		gasScheduleMap = config.MakeGasMapForTests()
+	}
 	err := world.InitBuiltinFunctions(gasScheduleMap)
 	if err != nil {
 		return nil, err
 	}
 
+	enableEpochs := config.MakeDefaultEnableEpochs()
+	if args.EnableEpochs != nil {
+		enableEpochs = *args.EnableEpochs
+	}
+
+	var reconfigurableHandler *hostCore.EnableEpochsHandler
+	enableEpochsHandler := args.EnableEpochsHandler
+	if enableEpochsHandler == nil {
+		reconfigurableHandler = hostCore.NewEnableEpochsHandler(enableEpochs)
+		enableEpochsHandler = reconfigurableHandler
+	}
+
 	blockGasLimit := uint64(10000000)
 	vm, err := hostCore.NewVMHost(world, &vmhost.VMHostParameters{
 		VMType:                   TestVMType,
@@ -54,27 +122,35 @@ func NewVMTestExecutor(scenarioexecPath string) (*VMTestExecutor, error) {
 		GasSchedule:              gasScheduleMap,
 		ProtocolBuiltinFunctions: world.GetBuiltinFunctionNames(),
 		ProtectedKeyPrefix:       []byte(ProtectedKeyPrefix),
-		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
-			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag
-			},
-		},
+		EnableEpochsHandler:      enableEpochsHandler,
+		CoverageCollector:        args.CoverageCollector,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	coverageCodeNamer, _ := args.CoverageCollector.(CoverageCodeNamer)
+
 	return &VMTestExecutor{
 		World:                 world,
 		vm:                    vm,
 		checkGas:              true,
 		scenarioexecPath:      scenarioexecPath,
-		scenGasScheduleLoaded: false,
+		scenGasScheduleLoaded: args.GasSchedule != nil,
 		fileResolver:          nil,
 		exprReconstructor:     er.ExprReconstructor{},
+		enableEpochsHandler:   reconfigurableHandler,
+		initialEnableEpochs:   enableEpochs,
+		enableEpochs:          enableEpochs,
+		coverageCodeNamer:     coverageCodeNamer,
 	}, nil
 }
 
+// GasUsed yields the gas used by the transactions executed since the last Reset.
+func (ae *VMTestExecutor) GasUsed() uint64 {
+	return ae.gasUsed
+}
+
 // GetVM yields a reference to the VMExecutionHandler used.
 func (ae *VMTestExecutor) GetVM() vmi.VMExecutionHandler {
 	return ae.vm
@@ -112,3 +188,32 @@ func (ae *VMTestExecutor) SetScenariosGasSchedule(newGasSchedule mj.GasSchedule)
 	ae.vm.GasScheduleChange(gasSchedule)
 	return nil
 }
+
+// SetEnableEpochs updates the activation epochs of the flags, as declared by the scenarios
+func (ae *VMTestExecutor) SetEnableEpochs(enableEpochs []*mj.EnableEpoch) error {
+	if ae.enableEpochsHandler == nil {
+		return errors.New("cannot set enable epochs, the VM uses a custom EnableEpochsHandler")
+	}
+
+	for _, enableEpoch := range enableEpochs {
+		if enableEpoch.Epoch.Value > math.MaxUint32 {
+			return fmt.Errorf("enable epoch %s is too large: %d", enableEpoch.Name, enableEpoch.Epoch.Value)
+		}
+		err := ae.enableEpochs.SetEpoch(enableEpoch.Name, uint32(enableEpoch.Epoch.Value))
+		if err != nil {
+			return err
+		}
+	}
+
+	ae.enableEpochsHandler.SetEnableEpochs(ae.enableEpochs)
+	return nil
+}
+
+func (ae *VMTestExecutor) resetEnableEpochs() {
+	if ae.enableEpochsHandler == nil {
+		return
+	}
+
+	ae.enableEpochs = ae.initialEnableEpochs
+	ae.enableEpochsHandler.SetEnableEpochs(ae.enableEpochs)
+}
@@ -11,6 +11,9 @@ import (
 // Is called in RunAllJSONScenariosInDirectory, but not in RunSingleJSONScenario.
 func (ae *VMTestExecutor) Reset() {
 	ae.World.Clear()
+	ae.lastTxOutput = nil
+	ae.gasUsed = 0
+	ae.resetEnableEpochs()
 }
 
 // ExecuteScenario executes an individual test.
@@ -91,6 +94,7 @@ func (ae *VMTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 		}
 
 		ae.World.AcctMap.PutAccount(account)
+		ae.nameCoverageCode(acct.Code)
 	}
 
 	// replace block info
@@ -102,6 +106,10 @@ func (ae *VMTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 	addressMocksToAdd := convertNewAddressMocks(step.NewAddressMocks)
 	ae.World.NewAddressMocks = append(ae.World.NewAddressMocks, addressMocksToAdd...)
 
+	if len(step.EnableEpochs) > 0 {
+		return ae.SetEnableEpochs(step.EnableEpochs)
+	}
+
 	return nil
 }
 
@@ -116,6 +124,10 @@ func (ae *VMTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, error)
 	if err != nil {
 		return nil, err
 	}
+	ae.lastTxOutput = output
+	if step.Tx.GasLimit.Value > output.GasRemaining {
+		ae.gasUsed += step.Tx.GasLimit.Value - output.GasRemaining
+	}
 
 	// check results
 	if step.ExpectedResult != nil {
@@ -127,3 +139,10 @@ func (ae *VMTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, error)
 
 	return output, nil
 }
+
+// RunTxStep executes the transaction of a TxStep, without checking its expected result.
+func (ae *VMTestExecutor) RunTxStep(step *mj.TxStep) (*vmi.VMOutput, error) {
+	log.Trace("RunTxStep", "id", step.TxIdent)
+
+	return ae.executeTx(step.TxIdent, step.Tx)
+}
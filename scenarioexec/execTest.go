@@ -19,6 +19,7 @@ func (ae *VMTestExecutor) ExecuteTest(test *mj.Test) error {
 		}
 
 		ae.World.AcctMap.PutAccount(account)
+		ae.nameCoverageCode(acct.Code)
 	}
 
 	for _, block := range test.Blocks {
@@ -81,6 +81,16 @@ func (ae *VMTestExecutor) checkAccounts(checkAccounts *mj.CheckAccounts) error {
 				string(matchingAcct.Code))
 		}
 
+		if !expectedAcct.CodeMetadata.IsUnspecified() &&
+			!expectedAcct.CodeMetadata.Check(matchingAcct.CodeMetadata) {
+			return fmt.Errorf("bad account code metadata. Account: %s. Want: %s. Have: \"%s\"",
+				hex.EncodeToString(matchingAcct.Address),
+				oj.JSONString(expectedAcct.CodeMetadata.Original),
+				ae.exprReconstructor.Reconstruct(
+					matchingAcct.CodeMetadata,
+					er.NoHint))
+		}
+
 		// currently ignoring asyncCallData that is unspecified in the json
 		if !expectedAcct.AsyncCallData.IsUnspecified() &&
 			!expectedAcct.AsyncCallData.Check([]byte(matchingAcct.AsyncCallData)) {
@@ -253,10 +263,9 @@ func checkTokenInstances(
 		accountInstance := accountInstances[nonce]
 
 		if expectedInstance == nil {
-			expectedInstance = &mj.CheckESDTInstance{
-				Nonce:   mj.JSONCheckUint64{Value: nonce, Original: ""},
-				Balance: mj.JSONCheckBigInt{Value: big.NewInt(0), Original: ""},
-			}
+			expectedInstance = mj.NewCheckESDTInstance()
+			expectedInstance.Nonce = mj.JSONCheckUint64{Value: nonce, Original: ""}
+			expectedInstance.Balance = mj.JSONCheckBigInt{Value: big.NewInt(0), Original: ""}
 		} else if accountInstance == nil {
 			accountInstance = &esdt.ESDigitalToken{
 				Value: big.NewInt(0),
@@ -275,13 +284,66 @@ func checkTokenInstances(
 					accountInstance.Value))
 			}
 
-			// TODO: check metadata/properties
+			errors = append(errors, checkTokenInstanceMetadata(accountAddress, tokenName, nonce, expectedInstance, accountInstance.TokenMetaData)...)
 		}
 	}
 
 	return errors
 }
 
+func checkTokenInstanceMetadata(
+	accountAddress string,
+	tokenName string,
+	nonce uint64,
+	expectedInstance *mj.CheckESDTInstance,
+	metadata *esdt.MetaData) []error {
+
+	if metadata == nil {
+		metadata = &esdt.MetaData{}
+	}
+
+	var errors []error
+	checkBytesField := func(fieldName string, expected mj.JSONCheckBytes, have []byte) {
+		if !expected.IsUnspecified() && !expected.Check(have) {
+			errors = append(errors, fmt.Errorf("bad ESDT %s. Account: %s. Token: %s. Nonce: %d. Want: %s. Have: \"0x%s\"",
+				fieldName,
+				accountAddress,
+				tokenName,
+				nonce,
+				oj.JSONString(expected.Original),
+				hex.EncodeToString(have)))
+		}
+	}
+
+	checkBytesField("name", expectedInstance.Name, metadata.Name)
+	checkBytesField("creator", expectedInstance.Creator, metadata.Creator)
+	checkBytesField("hash", expectedInstance.Hash, metadata.Hash)
+	checkBytesField("attributes", expectedInstance.Attributes, metadata.Attributes)
+
+	if !expectedInstance.Royalties.IsUnspecified() && !expectedInstance.Royalties.Check(uint64(metadata.Royalties)) {
+		errors = append(errors, fmt.Errorf("bad ESDT royalties. Account: %s. Token: %s. Nonce: %d. Want: \"%s\". Have: %d",
+			accountAddress,
+			tokenName,
+			nonce,
+			expectedInstance.Royalties.Original,
+			metadata.Royalties))
+	}
+
+	if !expectedInstance.Uris.IsUnspecified() && !expectedInstance.Uris.Check(metadata.URIs) {
+		haveUris := make([]string, len(metadata.URIs))
+		for i, uri := range metadata.URIs {
+			haveUris[i] = "0x" + hex.EncodeToString(uri)
+		}
+		errors = append(errors, fmt.Errorf("bad ESDT URIs. Account: %s. Token: %s. Nonce: %d. Have: %v",
+			accountAddress,
+			tokenName,
+			nonce,
+			haveUris))
+	}
+
+	return errors
+}
+
 func checkTokenRoles(
 	accountAddress string,
 	tokenName string,
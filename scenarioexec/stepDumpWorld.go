@@ -1,16 +1,19 @@
 package scenarioexec
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
 	worldmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/world"
 	er "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/expression/reconstructor"
 	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
 	mjwrite "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/write"
 	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/vmoutput"
 )
 
 const includeProtectedStorage = false
@@ -60,15 +63,7 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 
 		var scenInstances []*mj.ESDTInstance
 		for _, mockInstance := range esdtObj.Instances {
-			var uri mj.JSONBytesFromTree
-			if len(mockInstance.TokenMetaData.URIs) > 0 {
-				uri = mj.JSONBytesFromTree{
-					Value:    mockInstance.TokenMetaData.URIs[0],
-					Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(mockInstance.TokenMetaData.URIs[0], er.NoHint)},
-				}
-			}
-
-			scenInstances = append(scenInstances, &mj.ESDTInstance{
+			scenInstance := &mj.ESDTInstance{
 				Nonce: mj.JSONUint64{
 					Value:    mockInstance.TokenMetaData.Nonce,
 					Original: ae.exprReconstructor.ReconstructFromUint64(mockInstance.TokenMetaData.Nonce),
@@ -77,8 +72,9 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 					Value:    mockInstance.Value,
 					Original: ae.exprReconstructor.ReconstructFromBigInt(mockInstance.Value),
 				},
-				Uri: uri,
-			})
+			}
+			ae.dumpESDTInstanceMetadata(esdtObj.TokenIdentifier, mockInstance.TokenMetaData, scenInstance)
+			scenInstances = append(scenInstances, scenInstance)
 		}
 
 		scenESDT = append(scenESDT, &mj.ESDTData{
@@ -113,13 +109,20 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 	}, nil
 }
 
-// DumpWorld prints the state of the MockWorld to stdout.
+// DumpWorld prints the state of the MockWorld to stdout, with the accounts sorted by address,
+// followed by the output of the last transaction in canonical form.
 func (ae *VMTestExecutor) DumpWorld() error {
 	fmt.Print("world state dump:\n")
 	var scenAccounts []*mj.Account
 
-	for _, account := range ae.World.AcctMap {
-		scenAccount, err := ae.convertMockAccountToScenarioFormat(account)
+	var addresses []string
+	for address := range ae.World.AcctMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		scenAccount, err := ae.convertMockAccountToScenarioFormat(ae.World.AcctMap[address])
 		if err != nil {
 			return err
 		}
@@ -130,5 +133,49 @@ func (ae *VMTestExecutor) DumpWorld() error {
 	s := oj.JSONString(ojAccount)
 	fmt.Println(s)
 
+	if ae.lastTxOutput != nil {
+		fmt.Print("last transaction output:\n")
+		fmt.Print(vmoutput.PrettyPrint(ae.lastTxOutput))
+	}
+
 	return nil
 }
+
+// dumpESDTInstanceMetadata only fills in the metadata fields that hold values,
+// the name is omitted when it is the token identifier, which is the default when setting the state
+func (ae *VMTestExecutor) dumpESDTInstanceMetadata(tokenIdentifier []byte, metadata *esdt.MetaData, scenInstance *mj.ESDTInstance) {
+	if !bytes.Equal(metadata.Name, tokenIdentifier) {
+		scenInstance.Name = mj.JSONBytesFromString{
+			Value:    metadata.Name,
+			Original: ae.exprReconstructor.Reconstruct(metadata.Name, er.StrHint),
+		}
+	}
+	if len(metadata.Creator) > 0 {
+		scenInstance.Creator = mj.JSONBytesFromString{
+			Value:    metadata.Creator,
+			Original: ae.exprReconstructor.Reconstruct(metadata.Creator, er.AddressHint),
+		}
+	}
+	if metadata.Royalties > 0 {
+		scenInstance.Royalties = mj.JSONUint64{
+			Value:    uint64(metadata.Royalties),
+			Original: ae.exprReconstructor.ReconstructFromUint64(uint64(metadata.Royalties)),
+		}
+	}
+	if len(metadata.Hash) > 0 {
+		scenInstance.Hash = ae.dumpBytesFromTree(metadata.Hash)
+	}
+	for _, uri := range metadata.URIs {
+		scenInstance.Uris = append(scenInstance.Uris, ae.dumpBytesFromTree(uri))
+	}
+	if len(metadata.Attributes) > 0 {
+		scenInstance.Attributes = ae.dumpBytesFromTree(metadata.Attributes)
+	}
+}
+
+func (ae *VMTestExecutor) dumpBytesFromTree(value []byte) mj.JSONBytesFromTree {
+	return mj.JSONBytesFromTree{
+		Value:    value,
+		Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(value, er.NoHint)},
+	}
+}
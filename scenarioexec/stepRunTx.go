@@ -43,7 +43,7 @@ func (ae *VMTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmcomm
 		}
 
 		gasForExecution = tx.GasLimit.Value
-		if tx.ESDTValue != nil {
+		if len(tx.ESDTValue) > 0 {
 			gasRemaining, err := ae.directESDTTransferFromTx(tx)
 			if err != nil {
 				return nil, err
@@ -197,6 +197,7 @@ func (ae *VMTestExecutor) scCreate(txIndex string, tx *mj.Transaction, gasLimit
 		ESDTTransfers:  make([]*vmcommon.ESDTTransfer, 0),
 	}
 	addESDTToVMInput(tx.ESDTValue, &vmInput)
+	ae.nameCoverageCode(tx.Code)
 	input := &vmcommon.ContractCreateInput{
 		ContractCode: tx.Code.Value,
 		VMInput:      vmInput,
@@ -235,12 +236,22 @@ func (ae *VMTestExecutor) scCall(txIndex string, tx *mj.Transaction, gasLimit ui
 }
 
 func (ae *VMTestExecutor) directESDTTransferFromTx(tx *mj.Transaction) (uint64, error) {
-	return ae.World.BuiltinFuncs.PerformDirectESDTTransfer(
+	if len(tx.ESDTValue) == 1 {
+		return ae.World.BuiltinFuncs.PerformDirectESDTTransfer(
+			tx.From.Value,
+			tx.To.Value,
+			tx.ESDTValue[0].TokenIdentifier.Value,
+			tx.ESDTValue[0].Nonce.Value,
+			tx.ESDTValue[0].Value.Value,
+			vm.DirectCall,
+			tx.GasLimit.Value,
+			tx.GasPrice.Value)
+	}
+
+	return ae.World.BuiltinFuncs.PerformDirectMultiESDTTransfer(
 		tx.From.Value,
 		tx.To.Value,
-		tx.ESDTValue.TokenIdentifier.Value,
-		tx.ESDTValue.Nonce.Value,
-		tx.ESDTValue.Value.Value,
+		esdtTransfersFromTx(tx.ESDTValue),
 		vm.DirectCall,
 		tx.GasLimit.Value,
 		tx.GasPrice.Value)
@@ -36,11 +36,14 @@ func convertAccount(testAcct *mj.Account) (*worldmock.Account, error) {
 		AsyncCallData:   testAcct.AsyncCallData,
 		ShardID:         uint32(testAcct.Shard.Value),
 		IsSmartContract: len(testAcct.Code.Value) > 0,
-		CodeMetadata: (&vmcommon.CodeMetadata{
+		CodeMetadata:    testAcct.CodeMetadata.Value,
+	}
+	if len(account.CodeMetadata) == 0 {
+		account.CodeMetadata = (&vmcommon.CodeMetadata{
 			Payable:     true,
 			Upgradeable: true,
 			Readable:    true,
-		}).ToBytes(), // TODO: add explicit fields in scenario JSON
+		}).ToBytes()
 	}
 
 	for _, scenESDTData := range testAcct.ESDTData {
@@ -51,13 +54,10 @@ func convertAccount(testAcct *mj.Account) (*worldmock.Account, error) {
 			tokenKey := worldmock.MakeTokenKey(tokenName, tokenNonce)
 			tokenBalance := instance.Balance.Value
 			tokenData := &esdt.ESDigitalToken{
-				Value:      tokenBalance,
-				Type:       uint32(core.Fungible),
-				Properties: makeESDTUserMetadataBytes(isFrozen),
-				TokenMetaData: &esdt.MetaData{
-					Name:  tokenName,
-					Nonce: tokenNonce,
-				},
+				Value:         tokenBalance,
+				Type:          uint32(core.Fungible),
+				Properties:    makeESDTUserMetadataBytes(isFrozen),
+				TokenMetaData: makeESDTMetaData(tokenName, instance),
 			}
 			err := account.SetTokenData(tokenKey, tokenData)
 			if err != nil {
@@ -77,6 +77,23 @@ func convertAccount(testAcct *mj.Account) (*worldmock.Account, error) {
 	return account, nil
 }
 
+func makeESDTMetaData(tokenName []byte, instance *mj.ESDTInstance) *esdt.MetaData {
+	name := instance.Name.Value
+	if len(instance.Name.Original) == 0 {
+		name = tokenName
+	}
+
+	return &esdt.MetaData{
+		Nonce:      instance.Nonce.Value,
+		Name:       name,
+		Creator:    instance.Creator.Value,
+		Royalties:  uint32(instance.Royalties.Value),
+		Hash:       instance.Hash.Value,
+		URIs:       mj.JSONBytesFromTreeValues(instance.Uris),
+		Attributes: instance.Attributes.Value,
+	}
+}
+
 func makeESDTUserMetadataBytes(frozen bool) []byte {
 	metadata := &builtInFunctions.ESDTUserMetadata{
 		Frozen: frozen,
@@ -144,17 +161,24 @@ func generateTxHash(txIndex string) []byte {
 	return txIndexBytes
 }
 
-func addESDTToVMInput(esdtData *mj.ESDTTxData, vmInput *vmcommon.VMInput) {
-	if esdtData != nil {
-		vmInput.ESDTTransfers = make([]*vmcommon.ESDTTransfer, 1)
-		vmInput.ESDTTransfers[0] = &vmcommon.ESDTTransfer{}
-		vmInput.ESDTTransfers[0].ESDTTokenName = esdtData.TokenIdentifier.Value
-		vmInput.ESDTTransfers[0].ESDTValue = esdtData.Value.Value
-		vmInput.ESDTTransfers[0].ESDTTokenNonce = esdtData.Nonce.Value
-		if vmInput.ESDTTransfers[0].ESDTTokenNonce != 0 {
-			vmInput.ESDTTransfers[0].ESDTTokenType = uint32(core.NonFungible)
-		} else {
-			vmInput.ESDTTransfers[0].ESDTTokenType = uint32(core.Fungible)
+func addESDTToVMInput(esdtData []*mj.ESDTTxData, vmInput *vmcommon.VMInput) {
+	if len(esdtData) > 0 {
+		vmInput.ESDTTransfers = esdtTransfersFromTx(esdtData)
+	}
+}
+
+func esdtTransfersFromTx(esdtData []*mj.ESDTTxData) []*vmcommon.ESDTTransfer {
+	esdtTransfers := make([]*vmcommon.ESDTTransfer, len(esdtData))
+	for i, esdtItem := range esdtData {
+		esdtTransfers[i] = &vmcommon.ESDTTransfer{
+			ESDTTokenName:  esdtItem.TokenIdentifier.Value,
+			ESDTValue:      esdtItem.Value.Value,
+			ESDTTokenNonce: esdtItem.Nonce.Value,
+			ESDTTokenType:  uint32(core.Fungible),
+		}
+		if esdtItem.Nonce.Value != 0 {
+			esdtTransfers[i].ESDTTokenType = uint32(core.NonFungible)
 		}
 	}
+	return esdtTransfers
 }
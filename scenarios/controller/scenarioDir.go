@@ -17,11 +17,30 @@ func (r *ScenarioRunner) RunAllJSONScenariosInDirectory(
 	allowedSuffix string,
 	excludedFilePatterns []string) error {
 
+	return r.runAllScenariosInDirectory(generalTestPath, specificTestPath, []string{allowedSuffix}, excludedFilePatterns)
+}
+
+// RunAllScenariosInDirectory walks directory, parses and prepares all json and yaml scenarios,
+// then calls scenarioExecutor for each of them.
+func (r *ScenarioRunner) RunAllScenariosInDirectory(
+	generalTestPath string,
+	specificTestPath string,
+	excludedFilePatterns []string) error {
+
+	return r.runAllScenariosInDirectory(generalTestPath, specificTestPath, DefaultScenarioSuffixes, excludedFilePatterns)
+}
+
+func (r *ScenarioRunner) runAllScenariosInDirectory(
+	generalTestPath string,
+	specificTestPath string,
+	allowedSuffixes []string,
+	excludedFilePatterns []string) error {
+
 	mainDirPath := path.Join(generalTestPath, specificTestPath)
 	var nrPassed, nrFailed, nrSkipped int
 
 	err := filepath.Walk(mainDirPath, func(testFilePath string, info os.FileInfo, err error) error {
-		if strings.HasSuffix(testFilePath, allowedSuffix) {
+		if hasAnySuffix(testFilePath, allowedSuffixes) {
 			fmt.Printf("Scenario: %s ... ", shortenTestPath(testFilePath, generalTestPath))
 			if isExcluded(excludedFilePatterns, testFilePath, generalTestPath) {
 				nrSkipped++
@@ -50,3 +69,12 @@ func (r *ScenarioRunner) RunAllJSONScenariosInDirectory(
 
 	return nil
 }
+
+func hasAnySuffix(path string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
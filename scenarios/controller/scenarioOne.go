@@ -10,6 +10,7 @@ import (
 )
 
 // RunSingleJSONScenario parses and prepares test, then calls testCallback.
+// Files with a yaml extension are parsed as yaml scenarios, all others as json scenarios.
 func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 	var err error
 	contextPath, err = filepath.Abs(contextPath)
@@ -34,7 +35,13 @@ func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 	}
 
 	r.Parser.ExprInterpreter.FileResolver.SetContext(contextPath)
-	scenario, parseErr := r.Parser.ParseScenarioFile(byteValue)
+	var scenario *mj.Scenario
+	var parseErr error
+	if isYAMLFile(contextPath) {
+		scenario, parseErr = r.Parser.ParseScenarioYAMLFile(byteValue)
+	} else {
+		scenario, parseErr = r.Parser.ParseScenarioFile(byteValue)
+	}
 	if parseErr != nil {
 		return parseErr
 	}
@@ -42,6 +49,11 @@ func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 	return r.Executor.ExecuteScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
 }
 
+func isYAMLFile(path string) bool {
+	extension := filepath.Ext(path)
+	return extension == ".yaml" || extension == ".yml"
+}
+
 // tool to modify scenarios
 // use with extreme caution
 func saveModifiedScenario(toPath string, scenario *mj.Scenario) {
@@ -0,0 +1,253 @@
+package scencontroller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/fileresolver"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/parse"
+)
+
+// skippedAfterFailure is the reason given for the scenarios not run because of FailFast.
+const skippedAfterFailure = "not run, a previous scenario failed"
+
+// excludedByFilter is the reason given for the scenarios matching an exclude pattern.
+const excludedByFilter = "excluded"
+
+// ParallelRunOptions configures a ParallelScenarioRunner.
+type ParallelRunOptions struct {
+	// NumWorkers is the number of scenarios run at the same time, each worker with its own executor.
+	// Defaults to the number of CPUs.
+	NumWorkers int
+
+	// IncludePatterns, when not empty, restricts the run to the scenarios matching any of them.
+	// The patterns are relative to the general test path, as in filepath.Match.
+	IncludePatterns []string
+
+	// ExcludePatterns skips the scenarios matching any of them; these are reported as skipped.
+	ExcludePatterns []string
+
+	// FailFast stops starting new scenarios after the first failure.
+	FailFast bool
+
+	// Output receives a line for each finished scenario and a summary; nothing is printed when nil.
+	Output io.Writer
+}
+
+// ParallelScenarioRunner runs the scenarios of a directory on a pool of workers.
+// Each worker owns its executor and its parser, so the scenarios run isolated from each other,
+// as they do with the ScenarioRunner, which resets its executor between them.
+type ParallelScenarioRunner struct {
+	executorFactory ScenarioExecutorFactory
+	fileResolver    fr.FileResolver
+	options         ParallelRunOptions
+	mutOutput       sync.Mutex
+}
+
+// NewParallelScenarioRunner creates a new ParallelScenarioRunner instance;
+// each worker gets its own clone of the file resolver.
+func NewParallelScenarioRunner(
+	executorFactory ScenarioExecutorFactory,
+	fileResolver fr.FileResolver,
+	options ParallelRunOptions,
+) (*ParallelScenarioRunner, error) {
+	if executorFactory == nil {
+		return nil, errors.New("nil scenario executor factory")
+	}
+	if fileResolver == nil {
+		return nil, errors.New("nil file resolver")
+	}
+	err := checkPatterns(options.IncludePatterns)
+	if err != nil {
+		return nil, err
+	}
+	err = checkPatterns(options.ExcludePatterns)
+	if err != nil {
+		return nil, err
+	}
+	if options.NumWorkers <= 0 {
+		options.NumWorkers = runtime.NumCPU()
+	}
+
+	return &ParallelScenarioRunner{
+		executorFactory: executorFactory,
+		fileResolver:    fileResolver,
+		options:         options,
+	}, nil
+}
+
+// RunAllScenariosInDirectory walks directory, then runs all the json and yaml scenarios found on the workers.
+// The error only signals that the run could not take place; the failed scenarios are in the report.
+func (r *ParallelScenarioRunner) RunAllScenariosInDirectory(
+	generalTestPath string,
+	specificTestPath string) (*ScenarioReport, error) {
+
+	startTime := time.Now()
+
+	results, err := r.collectScenarios(generalTestPath, specificTestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]int, 0, len(results))
+	for i, result := range results {
+		if result.Status == ScenarioSkipped {
+			r.printResult(result)
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	numWorkers := r.options.NumWorkers
+	if numWorkers > len(pending) {
+		numWorkers = len(pending)
+	}
+
+	// the executors are created up front, one after the other, since creating a VM is not thread safe
+	workers := make([]*ScenarioRunner, numWorkers)
+	for i := range workers {
+		executor, err := r.executorFactory()
+		if err != nil {
+			return nil, fmt.Errorf("cannot create the executor of worker %d: %w", i, err)
+		}
+		workers[i] = &ScenarioRunner{
+			Executor: executor,
+			Parser:   mjparse.NewParser(r.fileResolver.Clone()),
+		}
+	}
+
+	jobs := make(chan int, len(pending))
+	for _, index := range pending {
+		jobs <- index
+	}
+	close(jobs)
+
+	var failed uint32
+	var wg sync.WaitGroup
+	wg.Add(len(workers))
+	for _, worker := range workers {
+		go func(worker *ScenarioRunner) {
+			defer wg.Done()
+			for index := range jobs {
+				result := results[index]
+				if r.options.FailFast && atomic.LoadUint32(&failed) > 0 {
+					result.Status = ScenarioSkipped
+					result.Failure = skippedAfterFailure
+				} else {
+					runScenario(worker, result)
+					if result.Status == ScenarioFailed {
+						atomic.StoreUint32(&failed, 1)
+					}
+				}
+				r.printResult(result)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	report := newScenarioReport(results, time.Since(startTime))
+	r.printf("Done. Passed: %d. Failed: %d. Skipped: %d.\n", report.NumPassed, report.NumFailed, report.NumSkipped)
+
+	return report, nil
+}
+
+func (r *ParallelScenarioRunner) collectScenarios(generalTestPath string, specificTestPath string) ([]*ScenarioResult, error) {
+	mainDirPath := path.Join(generalTestPath, specificTestPath)
+	results := make([]*ScenarioResult, 0)
+
+	err := filepath.Walk(mainDirPath, func(testFilePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !hasAnySuffix(testFilePath, DefaultScenarioSuffixes) {
+			return nil
+		}
+		if len(r.options.IncludePatterns) > 0 && !matchesAnyPattern(r.options.IncludePatterns, testFilePath, generalTestPath) {
+			return nil
+		}
+
+		result := &ScenarioResult{
+			Path:     shortenTestPath(testFilePath, generalTestPath),
+			FullPath: testFilePath,
+		}
+		if matchesAnyPattern(r.options.ExcludePatterns, testFilePath, generalTestPath) {
+			result.Status = ScenarioSkipped
+			result.Failure = excludedByFilter
+		}
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func checkPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		_, err := filepath.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("invalid scenario pattern %s: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// runScenario runs a single scenario on the worker, filling in the result;
+// a panic only fails the scenario, not the whole run
+func runScenario(worker *ScenarioRunner, result *ScenarioResult) {
+	startTime := time.Now()
+	defer func() {
+		result.Duration = time.Since(startTime)
+		if gasReporter, ok := worker.Executor.(ScenarioGasReporter); ok {
+			result.GasUsed = gasReporter.GasUsed()
+		}
+
+		recovered := recover()
+		if recovered != nil {
+			result.Status = ScenarioFailed
+			result.Failure = fmt.Sprintf("panic: %v", recovered)
+		}
+	}()
+
+	worker.Executor.Reset()
+	err := worker.RunSingleJSONScenario(result.FullPath)
+	if err != nil {
+		result.Status = ScenarioFailed
+		result.Failure = err.Error()
+		return
+	}
+
+	result.Status = ScenarioPassed
+}
+
+func (r *ParallelScenarioRunner) printResult(result *ScenarioResult) {
+	switch result.Status {
+	case ScenarioPassed:
+		r.printf("Scenario: %s ...   ok (%s)\n", result.Path, result.Duration.Round(time.Millisecond))
+	case ScenarioFailed:
+		r.printf("Scenario: %s ...   FAIL: %s\n", result.Path, result.Failure)
+	default:
+		r.printf("Scenario: %s ...   skip (%s)\n", result.Path, result.Failure)
+	}
+}
+
+func (r *ParallelScenarioRunner) printf(format string, args ...interface{}) {
+	if r.options.Output == nil {
+		return
+	}
+
+	r.mutOutput.Lock()
+	defer r.mutOutput.Unlock()
+
+	_, _ = fmt.Fprintf(r.options.Output, format, args...)
+}
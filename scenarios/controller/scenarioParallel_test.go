@@ -0,0 +1,180 @@
+package scencontroller
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
+	"github.com/stretchr/testify/require"
+)
+
+type scenarioExecutorStub struct {
+	gasUsed uint64
+}
+
+func (executor *scenarioExecutorStub) Reset() {
+	executor.gasUsed = 0
+}
+
+func (executor *scenarioExecutorStub) ExecuteScenario(scenario *mj.Scenario, _ fr.FileResolver) error {
+	executor.gasUsed = 100
+	switch scenario.Name {
+	case "fail":
+		return errors.New("check failed\ndetails")
+	case "panic":
+		panic("boom")
+	}
+	return nil
+}
+
+func (executor *scenarioExecutorStub) GasUsed() uint64 {
+	return executor.gasUsed
+}
+
+func writeTestScenarios(t *testing.T, scenarios map[string]string) string {
+	dir := t.TempDir()
+	for name, scenarioName := range scenarios {
+		filePath := filepath.Join(dir, name)
+		require.Nil(t, os.MkdirAll(filepath.Dir(filePath), os.ModePerm))
+		content := `{"name": "` + scenarioName + `", "steps": []}`
+		require.Nil(t, os.WriteFile(filePath, []byte(content), 0644))
+	}
+	return dir
+}
+
+func createTestParallelRunner(t *testing.T, options ParallelRunOptions) (*ParallelScenarioRunner, *int) {
+	var mutCount sync.Mutex
+	numExecutors := 0
+	runner, err := NewParallelScenarioRunner(
+		func() (ScenarioExecutor, error) {
+			mutCount.Lock()
+			defer mutCount.Unlock()
+			numExecutors++
+			return &scenarioExecutorStub{}, nil
+		},
+		NewDefaultFileResolver(),
+		options,
+	)
+	require.Nil(t, err)
+	return runner, &numExecutors
+}
+
+func TestParallelScenarioRunner_RunAll(t *testing.T) {
+	dir := writeTestScenarios(t, map[string]string{
+		"a/ok1.scen.json":      "ok",
+		"a/ok2.scen.json":      "ok",
+		"b/fail.scen.json":     "fail",
+		"b/panic.scen.json":    "panic",
+		"c/excluded.scen.json": "ok",
+		"c/other.json":         "ok",
+	})
+
+	output := &bytes.Buffer{}
+	runner, numExecutors := createTestParallelRunner(t, ParallelRunOptions{
+		NumWorkers:      3,
+		ExcludePatterns: []string{"c/*"},
+		Output:          output,
+	})
+	report, err := runner.RunAllScenariosInDirectory(dir, "")
+	require.Nil(t, err)
+	require.Equal(t, 3, *numExecutors)
+
+	require.Equal(t, 5, len(report.Results))
+	require.Equal(t, 2, report.NumPassed)
+	require.Equal(t, 2, report.NumFailed)
+	require.Equal(t, 1, report.NumSkipped)
+	require.NotNil(t, report.Err())
+
+	statuses := make(map[string]ScenarioStatus)
+	for _, result := range report.Results {
+		statuses[result.Path] = result.Status
+	}
+	require.Equal(t, map[string]ScenarioStatus{
+		"a/ok1.scen.json":      ScenarioPassed,
+		"a/ok2.scen.json":      ScenarioPassed,
+		"b/fail.scen.json":     ScenarioFailed,
+		"b/panic.scen.json":    ScenarioFailed,
+		"c/excluded.scen.json": ScenarioSkipped,
+	}, statuses)
+	require.Equal(t, uint64(100), report.Results[0].GasUsed)
+	require.Equal(t, "panic: boom", report.Results[3].Failure)
+	require.True(t, strings.Contains(output.String(), "Done. Passed: 2. Failed: 2. Skipped: 1."))
+}
+
+func TestParallelScenarioRunner_IncludeAndFailFast(t *testing.T) {
+	dir := writeTestScenarios(t, map[string]string{
+		"a/fail.scen.json": "fail",
+		"a/ok1.scen.json":  "ok",
+		"a/ok2.scen.json":  "ok",
+		"b/ok.scen.json":   "ok",
+	})
+
+	runner, numExecutors := createTestParallelRunner(t, ParallelRunOptions{
+		NumWorkers:      1,
+		IncludePatterns: []string{"a/*"},
+		FailFast:        true,
+	})
+	report, err := runner.RunAllScenariosInDirectory(dir, "")
+	require.Nil(t, err)
+	require.Equal(t, 1, *numExecutors)
+
+	require.Equal(t, 3, len(report.Results))
+	require.Equal(t, ScenarioFailed, report.Results[0].Status)
+	require.Equal(t, ScenarioSkipped, report.Results[1].Status)
+	require.Equal(t, skippedAfterFailure, report.Results[1].Failure)
+	require.Equal(t, ScenarioSkipped, report.Results[2].Status)
+}
+
+func TestParallelScenarioRunner_InvalidPattern(t *testing.T) {
+	_, err := NewParallelScenarioRunner(
+		func() (ScenarioExecutor, error) { return &scenarioExecutorStub{}, nil },
+		NewDefaultFileResolver(),
+		ParallelRunOptions{ExcludePatterns: []string{"["}},
+	)
+	require.NotNil(t, err)
+}
+
+func TestScenarioReport_Write(t *testing.T) {
+	report := newScenarioReport([]*ScenarioResult{
+		{Path: "ok.scen.json", Status: ScenarioPassed, GasUsed: 5},
+		{Path: "fail.scen.json", Status: ScenarioFailed, Failure: "wrong <result>\ndetails"},
+		{Path: "skip.scen.json", Status: ScenarioSkipped, Failure: excludedByFilter},
+	}, 0)
+
+	junit := &bytes.Buffer{}
+	require.Nil(t, report.WriteJUnit(junit, "scenarios"))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1" skipped="1" time="0.000">
+	<testsuite name="scenarios" tests="3" failures="1" skipped="1" time="0.000">
+		<testcase name="ok.scen.json" classname="scenarios" time="0.000">
+			<properties>
+				<property name="gasUsed" value="5"></property>
+			</properties>
+		</testcase>
+		<testcase name="fail.scen.json" classname="scenarios" time="0.000">
+			<properties>
+				<property name="gasUsed" value="0"></property>
+			</properties>
+			<failure message="wrong &lt;result&gt;">wrong &lt;result&gt;&#xA;details</failure>
+		</testcase>
+		<testcase name="skip.scen.json" classname="scenarios" time="0.000">
+			<properties>
+				<property name="gasUsed" value="0"></property>
+			</properties>
+			<skipped message="excluded"></skipped>
+		</testcase>
+	</testsuite>
+</testsuites>
+`, junit.String())
+
+	jsonReport := &bytes.Buffer{}
+	require.Nil(t, report.WriteJSON(jsonReport))
+	require.True(t, strings.Contains(jsonReport.String(), `"failure": "wrong <result>\ndetails"`))
+	require.True(t, strings.Contains(jsonReport.String(), `"passed": 1`))
+}
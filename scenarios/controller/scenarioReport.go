@@ -0,0 +1,222 @@
+package scencontroller
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ScenarioStatus is the outcome of a scenario in a ScenarioReport.
+type ScenarioStatus string
+
+const (
+	// ScenarioPassed signals a scenario that ran without errors.
+	ScenarioPassed ScenarioStatus = "passed"
+
+	// ScenarioFailed signals a scenario that could not be parsed or whose checks failed.
+	ScenarioFailed ScenarioStatus = "failed"
+
+	// ScenarioSkipped signals a scenario that was excluded or not run because of FailFast.
+	ScenarioSkipped ScenarioStatus = "skipped"
+)
+
+// ScenarioResult holds the outcome of a single scenario.
+type ScenarioResult struct {
+	// Path is relative to the general test path.
+	Path     string
+	FullPath string
+	Status   ScenarioStatus
+	Duration time.Duration
+	GasUsed  uint64
+
+	// Failure is the error of a failed scenario, or the reason for skipping it.
+	Failure string
+}
+
+// ScenarioReport holds the outcome of a run of several scenarios, in the order the files were found.
+type ScenarioReport struct {
+	Results    []*ScenarioResult
+	Duration   time.Duration
+	NumPassed  int
+	NumFailed  int
+	NumSkipped int
+}
+
+func newScenarioReport(results []*ScenarioResult, duration time.Duration) *ScenarioReport {
+	report := &ScenarioReport{
+		Results:  results,
+		Duration: duration,
+	}
+
+	for _, result := range results {
+		switch result.Status {
+		case ScenarioPassed:
+			report.NumPassed++
+		case ScenarioFailed:
+			report.NumFailed++
+		default:
+			report.NumSkipped++
+		}
+	}
+
+	return report
+}
+
+// Err yields an error when some scenarios failed.
+func (report *ScenarioReport) Err() error {
+	if report.NumFailed > 0 {
+		return fmt.Errorf("%d of %d scenarios failed", report.NumFailed, len(report.Results))
+	}
+	return nil
+}
+
+type jsonScenarioResult struct {
+	Path            string         `json:"path"`
+	Status          ScenarioStatus `json:"status"`
+	DurationSeconds float64        `json:"durationSeconds"`
+	GasUsed         uint64         `json:"gasUsed"`
+	Failure         string         `json:"failure,omitempty"`
+}
+
+type jsonScenarioReport struct {
+	Passed          int                   `json:"passed"`
+	Failed          int                   `json:"failed"`
+	Skipped         int                   `json:"skipped"`
+	DurationSeconds float64               `json:"durationSeconds"`
+	Scenarios       []*jsonScenarioResult `json:"scenarios"`
+}
+
+// WriteJSON writes the report as indented JSON.
+func (report *ScenarioReport) WriteJSON(writer io.Writer) error {
+	jsonReport := &jsonScenarioReport{
+		Passed:          report.NumPassed,
+		Failed:          report.NumFailed,
+		Skipped:         report.NumSkipped,
+		DurationSeconds: report.Duration.Seconds(),
+		Scenarios:       make([]*jsonScenarioResult, len(report.Results)),
+	}
+	for i, result := range report.Results {
+		jsonReport.Scenarios[i] = &jsonScenarioResult{
+			Path:            result.Path,
+			Status:          result.Status,
+			DurationSeconds: result.Duration.Seconds(),
+			GasUsed:         result.GasUsed,
+			Failure:         result.Failure,
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(jsonReport)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+	Skipped    *junitMessage    `xml:"skipped,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []*junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report in the JUnit XML format, as a single test suite with the given name,
+// with a test case for each scenario; the gas used is given as a property of the test cases.
+func (report *ScenarioReport) WriteJUnit(writer io.Writer, suiteName string) error {
+	suite := &junitTestSuite{
+		Name:      suiteName,
+		Tests:     len(report.Results),
+		Failures:  report.NumFailed,
+		Skipped:   report.NumSkipped,
+		Time:      junitTime(report.Duration),
+		TestCases: make([]*junitTestCase, len(report.Results)),
+	}
+	for i, result := range report.Results {
+		testCase := &junitTestCase{
+			Name:      result.Path,
+			ClassName: suiteName,
+			Time:      junitTime(result.Duration),
+			Properties: &junitProperties{
+				Properties: []*junitProperty{
+					{Name: "gasUsed", Value: fmt.Sprintf("%d", result.GasUsed)},
+				},
+			},
+		}
+		switch result.Status {
+		case ScenarioFailed:
+			testCase.Failure = &junitMessage{Message: firstLine(result.Failure), Text: result.Failure}
+		case ScenarioSkipped:
+			testCase.Skipped = &junitMessage{Message: result.Failure}
+		}
+		suite.TestCases[i] = testCase
+	}
+
+	suites := &junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []*junitTestSuite{suite},
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "\t")
+	err = encoder.Encode(suites)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
+
+func junitTime(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+func firstLine(text string) string {
+	if index := strings.Index(text, "\n"); index >= 0 {
+		return text[:index]
+	}
+	return text
+}
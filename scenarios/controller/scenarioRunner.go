@@ -6,6 +6,15 @@ import (
 	mjparse "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/parse"
 )
 
+// ScenarioJSONSuffix is the suffix of the scenario files in the json format.
+const ScenarioJSONSuffix = ".scen.json"
+
+// ScenarioYAMLSuffix is the suffix of the scenario files in the yaml format.
+const ScenarioYAMLSuffix = ".scen.yaml"
+
+// DefaultScenarioSuffixes are the suffixes of the scenario files picked up from directories, in both formats.
+var DefaultScenarioSuffixes = []string{ScenarioJSONSuffix, ScenarioYAMLSuffix}
+
 // ScenarioExecutor describes a component that can run a VM scenario.
 type ScenarioExecutor interface {
 	// Reset clears state/world.
@@ -17,7 +26,16 @@ type ScenarioExecutor interface {
 	ExecuteScenario(*mj.Scenario, fr.FileResolver) error
 }
 
-// ScenarioRunner is a component that can run json scenarios, using a provided executor.
+// ScenarioGasReporter is implemented by the executors which can tell how much gas their transactions used.
+type ScenarioGasReporter interface {
+	// GasUsed yields the gas used since the last Reset.
+	GasUsed() uint64
+}
+
+// ScenarioExecutorFactory creates a new executor, one for each worker of a ParallelScenarioRunner.
+type ScenarioExecutorFactory func() (ScenarioExecutor, error)
+
+// ScenarioRunner is a component that can run json and yaml scenarios, using a provided executor.
 type ScenarioRunner struct {
 	Executor ScenarioExecutor
 	Parser   mjparse.Parser
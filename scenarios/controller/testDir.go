@@ -10,9 +10,14 @@ import (
 )
 
 func isExcluded(excludedFilePatterns []string, testPath string, generalTestPath string) bool {
-	for _, et := range excludedFilePatterns {
-		excludedFullPath := path.Join(generalTestPath, et)
-		match, err := filepath.Match(excludedFullPath, testPath)
+	return matchesAnyPattern(excludedFilePatterns, testPath, generalTestPath)
+}
+
+// matchesAnyPattern tells whether the test path matches any of the patterns, which are relative to generalTestPath
+func matchesAnyPattern(filePatterns []string, testPath string, generalTestPath string) bool {
+	for _, pattern := range filePatterns {
+		fullPattern := path.Join(generalTestPath, pattern)
+		match, err := filepath.Match(fullPattern, testPath)
 		if err != nil {
 			panic(err)
 		}
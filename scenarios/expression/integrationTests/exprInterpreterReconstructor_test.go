@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
 	mei "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/expression/interpreter"
 	mer "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/expression/reconstructor"
 	fr "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/fileresolver"
@@ -203,6 +204,36 @@ func TestSCAddressWithShardId(t *testing.T) {
 	require.Equal(t, "sc:12345678901234567890120#88", er.Reconstruct(result, mer.AddressHint))
 }
 
+func TestBech32Address(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	er := mer.ExprReconstructor{}
+
+	expected, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	result, err := ei.InterpretString("bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	require.Nil(t, err)
+	require.Equal(t, expected, result)
+	require.Equal(t, "bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", er.Reconstruct(result, mer.Bech32Hint))
+
+	// round trip
+	result, err = ei.InterpretString("sc:a#44")
+	require.Nil(t, err)
+	reconstructed := er.Reconstruct(result, mer.Bech32Hint)
+	roundTrip, err := ei.InterpretString(reconstructed)
+	require.Nil(t, err)
+	require.Equal(t, result, roundTrip)
+
+	// bad checksum
+	_, err = ei.InterpretString("bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6tx")
+	require.NotNil(t, err)
+
+	// other prefix
+	_, err = ei.InterpretString("bech32:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq")
+	require.NotNil(t, err)
+
+	// only addresses can be shown as bech32
+	require.Equal(t, "0x0102 (258)", er.Reconstruct([]byte{0x01, 0x02}, mer.Bech32Hint))
+}
+
 func TestUnsignedNumber(t *testing.T) {
 	ei := mei.ExprInterpreter{}
 	er := mer.ExprReconstructor{}
@@ -317,6 +348,27 @@ func TestUnsignedFixedWidth(t *testing.T) {
 	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05}, result)
 }
 
+func TestUnsignedFixedWidthLarge(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	result, err := ei.InterpretString("u128:0")
+	require.Nil(t, err)
+	require.Equal(t, make([]byte, 16), result)
+
+	result, err = ei.InterpretString("u256:0x1234")
+	require.Nil(t, err)
+	expected := make([]byte, 32)
+	expected[30] = 0x12
+	expected[31] = 0x34
+	require.Equal(t, expected, result)
+
+	result, err = ei.InterpretString("u128:340282366920938463463374607431768211455")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, result)
+
+	_, err = ei.InterpretString("u128:340282366920938463463374607431768211456")
+	require.NotNil(t, err)
+}
+
 func TestSignedFixedWidth(t *testing.T) {
 	ei := mei.ExprInterpreter{}
 	result, err := ei.InterpretString("i8:0")
@@ -382,6 +434,27 @@ func TestSignedFixedWidth(t *testing.T) {
 	require.Equal(t, []byte{0xfb}, result)
 }
 
+func TestSignedFixedWidthLarge(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	result, err := ei.InterpretString("i128:-1")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, result)
+
+	result, err = ei.InterpretString("i256:-256")
+	require.Nil(t, err)
+	expected := make([]byte, 32)
+	for i := 0; i < 31; i++ {
+		expected[i] = 0xff
+	}
+	require.Equal(t, expected, result)
+
+	result, err = ei.InterpretString("i256:+0xff")
+	require.Nil(t, err)
+	expected = make([]byte, 32)
+	expected[31] = 0xff
+	require.Equal(t, expected, result)
+}
+
 func TestBigUint(t *testing.T) {
 	ei := mei.ExprInterpreter{}
 	result, err := ei.InterpretString("biguint:0")
@@ -410,6 +483,197 @@ func TestBigUint(t *testing.T) {
 	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x01, 0xFF}, result)
 }
 
+func TestHex(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	result, err := ei.InterpretString("hex:0102ff")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0xff}, result)
+
+	result, err = ei.InterpretString("hex:01 02_03-04:05")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05}, result)
+
+	result, err = ei.InterpretString("hex:")
+	require.Nil(t, err)
+	require.Equal(t, []byte{}, result)
+
+	// leading zeros are kept, unlike for numbers
+	result, err = ei.InterpretString("hex:0000_01|hex:02")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x01, 0x02}, result)
+
+	_, err = ei.InterpretString("hex:012")
+	require.NotNil(t, err)
+
+	_, err = ei.InterpretString("hex:0g")
+	require.NotNil(t, err)
+}
+
+func TestBoolEncoding(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	result, err := ei.InterpretString("bool:true")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01}, result)
+
+	result, err = ei.InterpretString("bool:false")
+	require.Nil(t, err)
+	require.Equal(t, []byte{}, result)
+
+	result, err = ei.InterpretString("nested:bool:false|nested:bool:true")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x01}, result)
+
+	_, err = ei.InterpretString("bool:1")
+	require.NotNil(t, err)
+}
+
+func TestOption(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	result, err := ei.InterpretString("option:none")
+	require.Nil(t, err)
+	require.Equal(t, []byte{}, result)
+
+	result, err = ei.InterpretString("nested:option:none")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00}, result)
+
+	result, err = ei.InterpretString("option:u32:5")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0x05}, result)
+
+	result, err = ei.InterpretString("nested:option:u32:5")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0x05}, result)
+
+	result, err = ei.InterpretString("option:nested:str:ab")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0x02, 'a', 'b'}, result)
+
+	// the argument is nested
+	result, err = ei.InterpretString("option:bool:false")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00}, result)
+
+	result, err = ei.InterpretString("option:option:none")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00}, result)
+
+	// the argument takes the whole remaining expression
+	result, err = ei.InterpretString("option:u8:1|u8:2")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x01, 0x02}, result)
+}
+
+func TestVec(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	result, err := ei.InterpretString("vec:")
+	require.Nil(t, err)
+	require.Equal(t, []byte{}, result)
+
+	result, err = ei.InterpretString("nested:vec:")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x00}, result)
+
+	result, err = ei.InterpretString("vec:u16:1|u16:2|u16:3")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x01, 0x00, 0x02, 0x00, 0x03}, result)
+
+	result, err = ei.InterpretString("nested:vec:u16:1|u16:2|u16:3")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x03, 0x00, 0x01, 0x00, 0x02, 0x00, 0x03}, result)
+
+	result, err = ei.InterpretString("vec:bool:true|bool:false|option:none|option:u8:7|biguint:1")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00, 0x00, 0x01, 0x07, 0x00, 0x00, 0x00, 0x01, 0x01}, result)
+
+	result, err = ei.InterpretString("option:nested:vec:u8:1|u8:2")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0x02, 0x01, 0x02}, result)
+
+	_, err = ei.InterpretString("vec:u8:1|bool:2")
+	require.NotNil(t, err)
+}
+
+func TestABIValue(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	result, err := ei.InterpretString("abi:BigUint:1000")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x03, 0xE8}, result)
+
+	result, err = ei.InterpretString("nested:abi:BigUint:1000")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x02, 0x03, 0xE8}, result)
+
+	result, err = ei.InterpretString("abi:List<u16>:[1,2]")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x01, 0x00, 0x02}, result)
+
+	result, err = ei.InterpretString("option:abi:u16:1")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x00, 0x01}, result)
+
+	result, err = ei.InterpretString("abi:utf-8 string:abc")
+	require.Nil(t, err)
+	require.Equal(t, []byte("abc"), result)
+
+	_, err = ei.InterpretString("abi:Unknown:1")
+	require.NotNil(t, err)
+
+	_, err = ei.InterpretString("abi:u8")
+	require.NotNil(t, err)
+}
+
+func TestABIValueCustomTypes(t *testing.T) {
+	contractABI, err := abi.ParseABI([]byte(`{
+		"name": "Example",
+		"endpoints": [],
+		"types": {
+			"Point": {
+				"type": "struct",
+				"fields": [
+					{"name": "x", "type": "u8"},
+					{"name": "y", "type": "u8"}
+				]
+			},
+			"Color": {
+				"type": "enum",
+				"variants": [
+					{"name": "Red", "discriminant": 0},
+					{"name": "Green", "discriminant": 1}
+				]
+			}
+		}
+	}`))
+	require.Nil(t, err)
+	codec, err := abi.NewCodec(contractABI)
+	require.Nil(t, err)
+
+	ei := mei.ExprInterpreter{ABICodec: codec}
+	er := mer.ExprReconstructor{ABICodec: codec}
+
+	result, err := ei.InterpretString(`abi:Point:{"x":1,"y":2}`)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01, 0x02}, result)
+	require.Equal(t, `abi:Point:{"x":1,"y":2}`, er.ReconstructABIValue(result, "Point"))
+
+	// as in the scenario JSON strings, where the quotes stay escaped
+	result, err = ei.InterpretString(`abi:Point:{\"x\":3,\"y\":4}`)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x03, 0x04}, result)
+
+	result, err = ei.InterpretString("abi:Color:Green")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01}, result)
+	require.Equal(t, `abi:Color:"Green"`, er.ReconstructABIValue(result, "Color"))
+
+	result, err = ei.InterpretString(`abi:Color:"Green"`)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x01}, result)
+
+	require.Equal(t, "0x0102 (258)", er.ReconstructABIValue([]byte{0x01, 0x02}, "Color"))
+	require.Equal(t, "abi:BigUint:\"1000\"", er.ReconstructABIValue([]byte{0x03, 0xE8}, "BigUint"))
+}
+
 func TestConcat(t *testing.T) {
 	ei := mei.ExprInterpreter{}
 	result, err := ei.InterpretString("0x01|5")
@@ -0,0 +1,73 @@
+package scenexpressioninterpreter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
+)
+
+const abiPrefix = "abi:"
+
+// interpretABIValue encodes a value of an ABI type, written as "<type>:<JSON value>",
+// e.g. "BigUint:1000", "List<u32>:[1,2]" or "MyStruct:{"a":1,"b":"0x01"}".
+// A value which is not valid JSON is taken as a string, e.g. "Address:erd1..." or "utf-8 string:abc".
+// The structs and enums are those of the interpreter ABI, if any.
+func (ei *ExprInterpreter) interpretABIValue(strRaw string, nested bool) ([]byte, error) {
+	separatorIndex := strings.Index(strRaw, ":")
+	if separatorIndex < 0 {
+		return []byte{}, fmt.Errorf("abi value has no type: %s", strRaw)
+	}
+
+	description, err := abi.ParseTypeDescription(strRaw[:separatorIndex])
+	if err != nil {
+		return []byte{}, err
+	}
+
+	value := parseABIJSONValue(strRaw[separatorIndex+1:])
+
+	codec := ei.ABICodec
+	if codec == nil {
+		codec = abi.NewBuiltInCodec()
+	}
+
+	if nested {
+		return codec.EncodeNested(description, value)
+	}
+	return codec.EncodeTopLevel(description, value)
+}
+
+// parseABIJSONValue decodes the JSON value, also when its quotes are still escaped,
+// as they are when the value comes straight from a scenario JSON string, e.g. {\"a\":1}.
+func parseABIJSONValue(strRaw string) interface{} {
+	value, ok := decodeJSONValue(strRaw)
+	if ok {
+		return value
+	}
+
+	var unescaped string
+	err := json.Unmarshal([]byte("\""+strRaw+"\""), &unescaped)
+	if err == nil {
+		value, ok = decodeJSONValue(unescaped)
+		if ok {
+			return value
+		}
+	}
+
+	return strRaw
+}
+
+func decodeJSONValue(strRaw string) (interface{}, bool) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(strRaw)))
+	decoder.UseNumber()
+
+	var value interface{}
+	err := decoder.Decode(&value)
+	if err != nil || decoder.More() {
+		return nil, false
+	}
+
+	return value, true
+}
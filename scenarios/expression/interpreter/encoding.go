@@ -0,0 +1,112 @@
+package scenexpressioninterpreter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+const boolPrefix = "bool:"
+const optionPrefix = "option:"
+const vecPrefix = "vec:"
+
+const optionNone = "none"
+const vecItemSeparator = "|"
+
+// isCombinator tells whether the expression is an "option:..." or a "vec:...",
+// which take the whole remaining expression as argument, "|" included.
+func isCombinator(strRaw string) bool {
+	return strings.HasPrefix(strRaw, optionPrefix) || strings.HasPrefix(strRaw, vecPrefix)
+}
+
+// tryInterpretEncoded evaluates the "bool:...", "option:..." and "vec:..." expressions,
+// whose top and nested encodings differ:
+// - "bool:true" is 0x01 and "bool:false" is empty, or 0x00 when nested;
+// - "option:none" is empty, or 0x00 when nested, while "option:X" is 0x01 followed by X, nested;
+// - "vec:X|Y|..." is the concatenation of the nested items, preceded by their number on 4 bytes when nested.
+// The items of options and vectors are expected in their nested form (e.g. "u32:5", "biguint:5", "nested:str:abc"),
+// except for the bool, option, vec and abi items, which are nested automatically.
+func (ei *ExprInterpreter) tryInterpretEncoded(strRaw string, nested bool) (bool, []byte, error) {
+	if strings.HasPrefix(strRaw, boolPrefix) {
+		r, err := interpretBool(strRaw[len(boolPrefix):], nested)
+		return true, r, err
+	}
+
+	if strings.HasPrefix(strRaw, optionPrefix) {
+		r, err := ei.interpretOption(strRaw[len(optionPrefix):], nested)
+		return true, r, err
+	}
+
+	if strings.HasPrefix(strRaw, vecPrefix) {
+		r, err := ei.interpretVec(strRaw[len(vecPrefix):], nested)
+		return true, r, err
+	}
+
+	return false, []byte{}, nil
+}
+
+func interpretBool(strRaw string, nested bool) ([]byte, error) {
+	switch strRaw {
+	case "true":
+		return []byte{0x01}, nil
+	case "false":
+		if nested {
+			return []byte{0x00}, nil
+		}
+		return []byte{}, nil
+	default:
+		return []byte{}, fmt.Errorf("invalid bool value: %s", strRaw)
+	}
+}
+
+func (ei *ExprInterpreter) interpretOption(strRaw string, nested bool) ([]byte, error) {
+	if strRaw == optionNone {
+		if nested {
+			return []byte{0x00}, nil
+		}
+		return []byte{}, nil
+	}
+
+	value, err := ei.interpretNestedItem(strRaw)
+	if err != nil {
+		return []byte{}, fmt.Errorf("cannot parse option argument: %w", err)
+	}
+	return append([]byte{0x01}, value...), nil
+}
+
+func (ei *ExprInterpreter) interpretVec(strRaw string, nested bool) ([]byte, error) {
+	var items []string
+	if len(strRaw) > 0 {
+		items = strings.Split(strRaw, vecItemSeparator)
+	}
+
+	result := make([]byte, 0)
+	if nested {
+		lengthBytes := big.NewInt(int64(len(items))).Bytes()
+		result = append(result, twos.CopyAlignRight(lengthBytes, 4)...)
+	}
+
+	for _, item := range items {
+		value, err := ei.interpretNestedItem(item)
+		if err != nil {
+			return []byte{}, fmt.Errorf("cannot parse vec item: %w", err)
+		}
+		result = append(result, value...)
+	}
+	return result, nil
+}
+
+func (ei *ExprInterpreter) interpretNestedItem(strRaw string) ([]byte, error) {
+	if strings.HasPrefix(strRaw, abiPrefix) {
+		return ei.interpretABIValue(strRaw[len(abiPrefix):], true)
+	}
+
+	parsed, result, err := ei.tryInterpretEncoded(strRaw, true)
+	if parsed {
+		return result, err
+	}
+
+	return ei.InterpretString(strRaw)
+}
@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
 	"golang.org/x/crypto/sha3"
 )
 
 // SCAddressNumLeadingZeros is the number of zer obytes every smart contract address begins with
 const SCAddressNumLeadingZeros = 8
 
+// AddressLength is the length of all addresses, in bytes
+const AddressLength = 32
+
+// Bech32AddressPrefix is the human readable part of the bech32 addresses, as shown by the explorers
+const Bech32AddressPrefix = "erd"
+
 // Keccak256 cryptographic function
 // TODO: externalize the same way as the file resolver
 func Keccak256(data []byte) ([]byte, error) {
@@ -70,3 +77,27 @@ func addressExpression(input string) ([]byte, error) {
 func scExpression(input string) ([]byte, error) {
 	return createAddressOptionalShardId(input, SCAddressNumLeadingZeros)
 }
+
+// Decodes a bech32 address, e.g. "erd1qqqqqqqqqqqqqpgq...".
+func bech32Expression(input string) ([]byte, error) {
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(AddressLength, Bech32AddressPrefix)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	address, err := converter.Decode(input)
+	if err != nil {
+		return []byte{}, fmt.Errorf("could not parse bech32 address %s: %w", input, err)
+	}
+	return address, nil
+}
+
+// Bech32Encode renders a 32-byte address in the bech32 format.
+func Bech32Encode(address []byte) (string, error) {
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(AddressLength, Bech32AddressPrefix)
+	if err != nil {
+		return "", err
+	}
+
+	return converter.Encode(address)
+}
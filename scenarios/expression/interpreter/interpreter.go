@@ -9,6 +9,7 @@ import (
 
 	fr "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/fileresolver"
 	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
 	twos "github.com/multiversx/mx-components-big-int/twos-complement"
 )
 
@@ -16,14 +17,24 @@ var strPrefixes = []string{"str:", "``", "''"}
 
 const addrPrefix = "address:"
 const scAddrPrefix = "sc:"
+const bech32Prefix = "bech32:"
 
 const filePrefix = "file:"
 const keccak256Prefix = "keccak256:"
+const hexPrefix = "hex:"
+
+// hexSeparators can group the digits of the "hex:..." values
+var hexSeparators = []string{" ", "_", "-", ":"}
+
+const u256Prefix = "u256:"
+const u128Prefix = "u128:"
 
 const u64Prefix = "u64:"
 const u32Prefix = "u32:"
 const u16Prefix = "u16:"
 const u8Prefix = "u8:"
+const i256Prefix = "i256:"
+const i128Prefix = "i128:"
 const i64Prefix = "i64:"
 const i32Prefix = "i32:"
 const i16Prefix = "i16:"
@@ -35,6 +46,9 @@ const nestedPrefix = "nested:"
 // ExprInterpreter provides context for computing scenario values.
 type ExprInterpreter struct {
 	FileResolver fr.FileResolver
+
+	// ABICodec knows the structs and enums of the "abi:..." values; without it, only the built-in types are known
+	ABICodec *abi.Codec
 }
 
 // InterpretSubTree attempts to produce a value based on a JSON subtree.
@@ -83,13 +97,17 @@ func (ei *ExprInterpreter) InterpretSubTree(obj oj.OJsonObject) ([]byte, error)
 // InterpretString resolves a string to a byte slice according to the scenario value format.
 // Supported rules are:
 // - numbers: decimal, hex, binary, signed/unsigned
-// - fixed length numbers: "u32:5", "i8:-3", etc.
+// - fixed length numbers: "u32:5", "i8:-3", "u256:...", etc.
 // - ascii strings as "str:...", "``...", "''..."
-// - "true"/"false"
+// - raw bytes as "hex:...", with optional separators, e.g. "hex:0102_0304"
+// - "true"/"false", "bool:true"/"bool:false"
 // - "address:..."
 // - "sc:..." (also an address)
+// - "bech32:erd1..." (also an address)
 // - "file:..."
 // - "keccak256:..."
+// - "option:..." and "vec:...|...|..." (see tryInterpretEncoded)
+// - "abi:<type>:<value>" (see interpretABIValue)
 // - concatenation using |
 //
 func (ei *ExprInterpreter) InterpretString(strRaw string) ([]byte, error) {
@@ -124,6 +142,26 @@ func (ei *ExprInterpreter) InterpretString(strRaw string) ([]byte, error) {
 		return hash, nil
 	}
 
+	// ABI typed values, their JSON can contain |
+	// TODO: make this part of a proper parser
+	if strings.HasPrefix(strRaw, abiPrefix) {
+		return ei.interpretABIValue(strRaw[len(abiPrefix):], false)
+	}
+	if strings.HasPrefix(strRaw, nestedPrefix+abiPrefix) {
+		return ei.interpretABIValue(strRaw[len(nestedPrefix+abiPrefix):], true)
+	}
+
+	// option and vec, their arguments can contain |
+	// TODO: make this part of a proper parser
+	if isCombinator(strRaw) {
+		_, result, err := ei.tryInterpretEncoded(strRaw, false)
+		return result, err
+	}
+	if strings.HasPrefix(strRaw, nestedPrefix) && isCombinator(strRaw[len(nestedPrefix):]) {
+		_, result, err := ei.tryInterpretEncoded(strRaw[len(nestedPrefix):], true)
+		return result, err
+	}
+
 	// concatenate values of different formats
 	// TODO: make this part of a proper parser
 	parts := strings.Split(strRaw, "|")
@@ -155,6 +193,17 @@ func (ei *ExprInterpreter) InterpretString(strRaw string) ([]byte, error) {
 		}
 	}
 
+	// raw bytes
+	if strings.HasPrefix(strRaw, hexPrefix) {
+		return interpretHex(strRaw[len(hexPrefix):])
+	}
+
+	// bool, top encoded
+	parsed, result, err := ei.tryInterpretEncoded(strRaw, false)
+	if parsed {
+		return result, err
+	}
+
 	// address
 	if strings.HasPrefix(strRaw, addrPrefix) {
 		addrArgument := strRaw[len(addrPrefix):]
@@ -167,8 +216,13 @@ func (ei *ExprInterpreter) InterpretString(strRaw string) ([]byte, error) {
 		return scExpression(addrArgument)
 	}
 
+	// bech32 address, as shown by the explorers
+	if strings.HasPrefix(strRaw, bech32Prefix) {
+		return bech32Expression(strRaw[len(bech32Prefix):])
+	}
+
 	// fixed width numbers
-	parsed, result, err := ei.tryInterpretFixedWidth(strRaw)
+	parsed, result, err = ei.tryInterpretFixedWidth(strRaw)
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +316,28 @@ func (ei *ExprInterpreter) interpretUnsignedNumberFixedWidth(strRaw string, targ
 	return twos.CopyAlignRight(numberBytes, targetWidth), nil
 }
 
+func interpretHex(strRaw string) ([]byte, error) {
+	str := strRaw
+	for _, separator := range hexSeparators {
+		str = strings.ReplaceAll(str, separator, "")
+	}
+
+	result, err := hex.DecodeString(str)
+	if err != nil {
+		return []byte{}, fmt.Errorf("could not parse hex value %s: %w", strRaw, err)
+	}
+	return result, nil
+}
+
 func (ei *ExprInterpreter) tryInterpretFixedWidth(strRaw string) (bool, []byte, error) {
+	if strings.HasPrefix(strRaw, u256Prefix) {
+		r, err := ei.interpretUnsignedNumberFixedWidth(strRaw[len(u256Prefix):], 32)
+		return true, r, err
+	}
+	if strings.HasPrefix(strRaw, u128Prefix) {
+		r, err := ei.interpretUnsignedNumberFixedWidth(strRaw[len(u128Prefix):], 16)
+		return true, r, err
+	}
 	if strings.HasPrefix(strRaw, u64Prefix) {
 		r, err := ei.interpretUnsignedNumberFixedWidth(strRaw[len(u64Prefix):], 8)
 		return true, r, err
@@ -280,6 +355,14 @@ func (ei *ExprInterpreter) tryInterpretFixedWidth(strRaw string) (bool, []byte,
 		return true, r, err
 	}
 
+	if strings.HasPrefix(strRaw, i256Prefix) {
+		r, err := ei.interpretNumber(strRaw[len(i256Prefix):], 32)
+		return true, r, err
+	}
+	if strings.HasPrefix(strRaw, i128Prefix) {
+		r, err := ei.interpretNumber(strRaw[len(i128Prefix):], 16)
+		return true, r, err
+	}
 	if strings.HasPrefix(strRaw, i64Prefix) {
 		r, err := ei.interpretNumber(strRaw[len(i64Prefix):], 8)
 		return true, r, err
@@ -305,6 +388,12 @@ func (ei *ExprInterpreter) tryInterpretFixedWidth(strRaw string) (bool, []byte,
 	}
 
 	if strings.HasPrefix(strRaw, nestedPrefix) {
+		// bools have their own nested encoding
+		parsed, r, err := ei.tryInterpretEncoded(strRaw[len(nestedPrefix):], true)
+		if parsed {
+			return true, r, err
+		}
+
 		nestedBytes, err := ei.InterpretString(strRaw[len(nestedPrefix):])
 		lengthBytes := big.NewInt(int64(len(nestedBytes))).Bytes()
 		encodedLength := twos.CopyAlignRight(lengthBytes, 4)
@@ -3,11 +3,13 @@ package scenexpressionreconstructor
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
 
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
 	ei "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/expression/interpreter"
 )
 
@@ -25,12 +27,19 @@ const (
 
 	// StrHint hints that value should be a string expression, e.g. a username, "str:..."
 	StrHint
+
+	// Bech32Hint hints that value should be an address, shown as by the explorers, "bech32:erd1..."
+	Bech32Hint
 )
 
 const maxBytesInterpretedAsNumber = 15
 
 // ExprReconstructor is a component that attempts to convert raw bytes to a human-readable format.
-type ExprReconstructor struct{}
+type ExprReconstructor struct {
+	// ABICodec knows the structs and enums of the values reconstructed by ReconstructABIValue;
+	// without it, only the built-in types are known
+	ABICodec *abi.Codec
+}
 
 func (er *ExprReconstructor) Reconstruct(value []byte, hint ExprReconstructorHint) string {
 	switch hint {
@@ -40,6 +49,8 @@ func (er *ExprReconstructor) Reconstruct(value []byte, hint ExprReconstructorHin
 		return fmt.Sprintf("str:%s", string(value))
 	case AddressHint:
 		return addressPretty((value))
+	case Bech32Hint:
+		return bech32Pretty(value)
 	default:
 		return unknownByteArrayPretty(value)
 	}
@@ -53,6 +64,35 @@ func (er *ExprReconstructor) ReconstructFromUint64(value uint64) string {
 	return er.Reconstruct(big.NewInt(0).SetUint64(value).Bytes(), NumberHint)
 }
 
+// ReconstructABIValue renders a top level encoded value of an ABI type as "abi:<type>:<JSON value>",
+// which the interpreter reads back; values which cannot be decoded are rendered as if their type was unknown.
+func (er *ExprReconstructor) ReconstructABIValue(value []byte, typeName string) string {
+	description, err := abi.ParseTypeDescription(typeName)
+	if err != nil {
+		return unknownByteArrayPretty(value)
+	}
+
+	codec := er.ABICodec
+	if codec == nil {
+		codec = abi.NewBuiltInCodec()
+	}
+
+	decoded, err := codec.DecodeTopLevel(description, value)
+	if err != nil {
+		return unknownByteArrayPretty(value)
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(abi.JSONValue(decoded))
+	if err != nil {
+		return unknownByteArrayPretty(value)
+	}
+
+	return fmt.Sprintf("abi:%s:%s", description, strings.TrimSuffix(buffer.String(), "\n"))
+}
+
 func unknownByteArrayPretty(bytes []byte) string {
 	if len(bytes) == 0 {
 		return "[]"
@@ -108,6 +148,15 @@ func addressPretty(value []byte) string {
 	}
 }
 
+func bech32Pretty(value []byte) string {
+	bech32Address, err := ei.Bech32Encode(value)
+	if err != nil {
+		return unknownByteArrayPretty(value)
+	}
+
+	return fmt.Sprintf("bech32:%s", bech32Address)
+}
+
 func canInterpretAsString(bytes []byte) bool {
 	if len(bytes) == 0 {
 		return false
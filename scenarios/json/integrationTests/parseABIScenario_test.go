@@ -0,0 +1,58 @@
+package scenjsontest
+
+import (
+	"encoding/hex"
+	"testing"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/parse"
+	mjwrite "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/write"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWriteABIScenario(t *testing.T) {
+	contents, err := loadExampleFile("exampleABI.scen.json")
+	require.Nil(t, err)
+
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+
+	scenario, parseErr := p.ParseScenarioFile(contents)
+	require.Nil(t, parseErr)
+	require.Equal(t, "example.abi.json", scenario.ABIPath)
+
+	tx := scenario.Steps[0].(*mj.TxStep).Tx
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x02, 0x03, 0xE8, 0x01}, tx.Arguments[0].Value)
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	require.Equal(t, alice, tx.Arguments[1].Value)
+	require.Equal(t, []byte{0x00, 0x01}, tx.Arguments[2].Value)
+
+	expectedOut := scenario.Steps[0].(*mj.TxStep).ExpectedResult.Out
+	require.Equal(t, []byte{0x01}, expectedOut[0].Value)
+
+	require.Equal(t, contents, []byte(mjwrite.ScenarioToJSONString(scenario)))
+}
+
+func TestParseABIScenario_UnknownType(t *testing.T) {
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+
+	// without the ABI, the custom types are unknown
+	_, err := p.ParseScenarioFile([]byte(`{
+		"steps": [
+			{
+				"step": "setState",
+				"accounts": {
+					"address:an_address": {
+						"storage": {
+							"str:status": "abi:Status:Closed"
+						}
+					}
+				}
+			}
+		]
+	}`))
+	require.NotNil(t, err)
+
+	_, err = p.ParseScenarioFile([]byte(`{"abi": "missing.abi.json", "steps": []}`))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,47 @@
+package scenjsontest
+
+import (
+	"testing"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/fileresolver"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/parse"
+	mjwrite "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/write"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseYAMLScenario(t *testing.T) {
+	yamlContents, err := loadExampleFile("example.scen.yaml")
+	require.Nil(t, err)
+	jsonContents, err := loadExampleFile("exampleYAML.scen.json")
+	require.Nil(t, err)
+
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+
+	scenarioFromYAML, parseErr := p.ParseScenarioYAMLFile(yamlContents)
+	require.Nil(t, parseErr)
+	scenarioFromJSON, parseErr := p.ParseScenarioFile(jsonContents)
+	require.Nil(t, parseErr)
+
+	require.Equal(t, scenarioFromJSON, scenarioFromYAML)
+}
+
+func TestWriteYAMLScenario(t *testing.T) {
+	contents, err := loadExampleFile("example.scen.json")
+	require.Nil(t, err)
+
+	p := mjparse.NewParser(
+		fr.NewDefaultFileResolver().ReplacePath(
+			"smart-contract.wasm",
+			"exampleFile.txt"))
+
+	scenario, parseErr := p.ParseScenarioFile(contents)
+	require.Nil(t, parseErr)
+
+	serialized, err := mjwrite.ScenarioToYAMLString(scenario)
+	require.Nil(t, err)
+
+	scenarioFromYAML, parseErr := p.ParseScenarioYAMLFile([]byte(serialized))
+	require.Nil(t, parseErr)
+
+	require.Equal(t, contents, []byte(mjwrite.ScenarioToJSONString(scenarioFromYAML)))
+}
@@ -13,6 +13,7 @@ type Account struct {
 	Username        JSONBytesFromString
 	Storage         []*StorageKeyValuePair
 	Code            JSONBytesFromString
+	CodeMetadata    JSONBytesFromString
 	Owner           JSONBytesFromString
 	AsyncCallData   string
 	ESDTData        []*ESDTData
@@ -34,6 +35,7 @@ type CheckAccount struct {
 	IgnoreStorage bool
 	CheckStorage  []*StorageKeyValuePair
 	Code          JSONCheckBytes
+	CodeMetadata  JSONCheckBytes
 	Owner         JSONCheckBytes
 	AsyncCallData JSONCheckBytes
 	IgnoreESDT    bool
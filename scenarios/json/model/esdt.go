@@ -7,11 +7,16 @@ type ESDTTxData struct {
 	Value           JSONBigInt
 }
 
-// ESDTInstance models an instance of an NFT/SFT, with its own nonce
+// ESDTInstance models an instance of an NFT/SFT, with its own nonce and metadata
 type ESDTInstance struct {
-	Nonce   JSONUint64
-	Balance JSONBigInt
-	Uri     JSONBytesFromTree
+	Nonce      JSONUint64
+	Balance    JSONBigInt
+	Name       JSONBytesFromString
+	Creator    JSONBytesFromString
+	Royalties  JSONUint64
+	Hash       JSONBytesFromTree
+	Uris       []JSONBytesFromTree
+	Attributes JSONBytesFromTree
 }
 
 // ESDTData models an account holding an ESDT token
@@ -23,11 +28,30 @@ type ESDTData struct {
 	Frozen          JSONUint64
 }
 
-// CheckESDTInstance checks an instance of an NFT/SFT, with its own nonce
+// CheckESDTInstance checks an instance of an NFT/SFT, with its own nonce and metadata
 type CheckESDTInstance struct {
-	Nonce   JSONCheckUint64
-	Balance JSONCheckBigInt
-	Uri     JSONCheckBytes
+	Nonce      JSONCheckUint64
+	Balance    JSONCheckBigInt
+	Name       JSONCheckBytes
+	Creator    JSONCheckBytes
+	Royalties  JSONCheckUint64
+	Hash       JSONCheckBytes
+	Uris       JSONCheckValueList
+	Attributes JSONCheckBytes
+}
+
+// NewCheckESDTInstance creates an instance with all fields unspecified, i.e. not checked.
+func NewCheckESDTInstance() *CheckESDTInstance {
+	return &CheckESDTInstance{
+		Nonce:      JSONCheckUint64Unspecified(),
+		Balance:    JSONCheckBigIntUnspecified(),
+		Name:       JSONCheckBytesUnspecified(),
+		Creator:    JSONCheckBytesUnspecified(),
+		Royalties:  JSONCheckUint64Unspecified(),
+		Hash:       JSONCheckBytesUnspecified(),
+		Uris:       JSONCheckValueListUnspecified(),
+		Attributes: JSONCheckBytesUnspecified(),
+	}
 }
 
 // CheckESDTData checks the ESDT tokens held by an account
@@ -6,6 +6,7 @@ type Scenario struct {
 	Comment     string
 	CheckGas    bool
 	GasSchedule GasSchedule
+	ABIPath     string
 	Steps       []Step
 }
 
@@ -30,6 +31,12 @@ type BlockInfo struct {
 	BlockRandomSeed *JSONBytesFromTree
 }
 
+// EnableEpoch sets the activation epoch of a VM flag, by the name of its field in the enable epochs configuration
+type EnableEpoch struct {
+	Name  string
+	Epoch JSONUint64
+}
+
 // ExternalStepsStep allows including steps from another file
 type ExternalStepsStep struct {
 	Comment string
@@ -44,6 +51,7 @@ type SetStateStep struct {
 	CurrentBlockInfo  *BlockInfo
 	BlockHashes       []JSONBytesFromString
 	NewAddressMocks   []*NewAddressMock
+	EnableEpochs      []*EnableEpoch
 }
 
 // CheckStateStep is a step where the state of the blockchain mock is verified.
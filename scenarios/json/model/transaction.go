@@ -42,7 +42,7 @@ func (tt TransactionType) HasValue() bool {
 	return tt != ScQuery
 }
 
-// HasESDT is a helper function to indicate if transaction has `esdtValue` or `esdtToken` fields.
+// HasESDT is a helper function to indicate if transaction has `esdt` or `esdtValue` fields.
 func (tt TransactionType) HasESDT() bool {
 	return tt != ScQuery && tt != ValidatorReward
 }
@@ -62,14 +62,16 @@ type Transaction struct {
 	Type      TransactionType
 	Nonce     JSONUint64
 	Value     JSONBigInt
-	ESDTValue *ESDTTxData
-	From      JSONBytesFromString
-	To        JSONBytesFromString
-	Function  string
-	Code      JSONBytesFromString
-	Arguments []JSONBytesFromTree
-	GasPrice  JSONUint64
-	GasLimit  JSONUint64
+	ESDTValue []*ESDTTxData
+	// ESDTValueIsList is set when the ESDT payments were given as a list, so that they are written back as such
+	ESDTValueIsList bool
+	From            JSONBytesFromString
+	To              JSONBytesFromString
+	Function        string
+	Code            JSONBytesFromString
+	Arguments       []JSONBytesFromTree
+	GasPrice        JSONUint64
+	GasLimit        JSONUint64
 }
 
 // TransactionResult is a json object representing an expected transaction result.
@@ -145,3 +145,42 @@ func (jcu JSONCheckUint64) CheckBool(other bool) bool {
 	}
 	return jcu.Value > 0 == other
 }
+
+// JSONCheckValueList holds a list of byte slice conditions.
+// The lists are checked for equal length and then element by element.
+// "*" allows all lists.
+type JSONCheckValueList struct {
+	Values      []JSONCheckBytes
+	IsStar      bool
+	Unspecified bool
+}
+
+// JSONCheckValueListUnspecified yields JSONCheckValueList default "*" value.
+func JSONCheckValueListUnspecified() JSONCheckValueList {
+	return JSONCheckValueList{
+		Values:      nil,
+		IsStar:      false,
+		Unspecified: true,
+	}
+}
+
+// IsUnspecified yields true if the field was originally unspecified.
+func (jcvl JSONCheckValueList) IsUnspecified() bool {
+	return jcvl.Unspecified
+}
+
+// Check returns true if condition expressed in object holds for another list.
+func (jcvl JSONCheckValueList) Check(other [][]byte) bool {
+	if jcvl.IsStar {
+		return true
+	}
+	if len(jcvl.Values) != len(other) {
+		return false
+	}
+	for i, checkValue := range jcvl.Values {
+		if !checkValue.Check(other[i]) {
+			return false
+		}
+	}
+	return true
+}
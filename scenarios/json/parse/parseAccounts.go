@@ -97,6 +97,11 @@ func (p *Parser) processAccount(acctRaw oj.OJsonObject) (*mj.Account, error) {
 			if err != nil {
 				return nil, fmt.Errorf("invalid account code: %w", err)
 			}
+		case "codeMetadata":
+			acct.CodeMetadata, err = p.processStringAsByteArray(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid account code metadata: %w", err)
+			}
 		case "owner":
 			acct.Owner, err = p.processStringAsByteArray(kvp.Value)
 			if err != nil {
@@ -20,6 +20,7 @@ func (p *Parser) processCheckAccount(acctRaw oj.OJsonObject) (*mj.CheckAccount,
 		Username:      mj.JSONCheckBytesUnspecified(),
 		IgnoreStorage: true,
 		Code:          mj.JSONCheckBytesUnspecified(),
+		CodeMetadata:  mj.JSONCheckBytesUnspecified(),
 		Owner:         mj.JSONCheckBytesUnspecified(),
 		AsyncCallData: mj.JSONCheckBytesUnspecified(),
 	}
@@ -96,6 +97,11 @@ func (p *Parser) processCheckAccount(acctRaw oj.OJsonObject) (*mj.CheckAccount,
 			if err != nil {
 				return nil, fmt.Errorf("invalid account code: %w", err)
 			}
+		case "codeMetadata":
+			acct.CodeMetadata, err = p.parseCheckBytes(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid account code metadata: %w", err)
+			}
 		case "owner":
 			acct.Owner, err = p.parseCheckBytes(kvp.Value)
 			if err != nil {
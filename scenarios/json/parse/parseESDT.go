@@ -3,6 +3,7 @@ package scenjsonparse
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
 	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
@@ -107,6 +108,39 @@ func (p *Parser) tryProcessESDTInstanceField(kvp *oj.OJsonKeyValuePair, targetIn
 		if err != nil {
 			return false, fmt.Errorf("invalid ESDT balance: %w", err)
 		}
+	case "name":
+		targetInstance.Name, err = p.processStringAsByteArray(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT name: %w", err)
+		}
+	case "creator":
+		targetInstance.Creator, err = p.processStringAsByteArray(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT creator: %w", err)
+		}
+	case "royalties":
+		targetInstance.Royalties, err = p.processUint64(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT royalties: %w", err)
+		}
+		if targetInstance.Royalties.Value > math.MaxUint32 {
+			return false, errors.New("invalid ESDT royalties: value exceeds uint32")
+		}
+	case "hash":
+		targetInstance.Hash, err = p.processSubTreeAsByteArray(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT hash: %w", err)
+		}
+	case "uri":
+		targetInstance.Uris, err = p.parseSubTreeList(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT URI list: %w", err)
+		}
+	case "attributes":
+		targetInstance.Attributes, err = p.processSubTreeAsByteArray(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT attributes: %w", err)
+		}
 	default:
 		return false, nil
 	}
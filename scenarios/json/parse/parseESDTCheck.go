@@ -22,12 +22,10 @@ func (p *Parser) processCheckESDTData(
 		if err != nil {
 			return nil, fmt.Errorf("invalid ESDT balance: %w", err)
 		}
-		esdtData.Instances = []*mj.CheckESDTInstance{
-			{
-				Nonce:   mj.JSONCheckUint64{Value: 0, Original: ""},
-				Balance: balance,
-			},
-		}
+		instance := mj.NewCheckESDTInstance()
+		instance.Nonce = mj.JSONCheckUint64{Value: 0, Original: ""}
+		instance.Balance = balance
+		esdtData.Instances = []*mj.CheckESDTInstance{instance}
 		return &esdtData, nil
 	case *oj.OJsonMap:
 		return p.processCheckESDTDataMap(tokenName, data)
@@ -46,7 +44,7 @@ func (p *Parser) processCheckESDTDataMap(tokenName mj.JSONBytesFromString, esdtD
 		TokenIdentifier: tokenName,
 	}
 	// var err error
-	firstInstance := &mj.CheckESDTInstance{}
+	firstInstance := mj.NewCheckESDTInstance()
 	firstInstanceLoaded := false
 	var explicitInstances []*mj.CheckESDTInstance
 
@@ -107,6 +105,36 @@ func (p *Parser) tryProcessCheckESDTInstanceField(kvp *oj.OJsonKeyValuePair, tar
 		if err != nil {
 			return false, fmt.Errorf("invalid ESDT balance: %w", err)
 		}
+	case "name":
+		targetInstance.Name, err = p.parseCheckBytes(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT name: %w", err)
+		}
+	case "creator":
+		targetInstance.Creator, err = p.parseCheckBytes(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT creator: %w", err)
+		}
+	case "royalties":
+		targetInstance.Royalties, err = p.processCheckUint64(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT royalties: %w", err)
+		}
+	case "hash":
+		targetInstance.Hash, err = p.parseCheckBytes(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT hash: %w", err)
+		}
+	case "uri":
+		targetInstance.Uris, err = p.parseCheckValueList(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT URI list: %w", err)
+		}
+	case "attributes":
+		targetInstance.Attributes, err = p.parseCheckBytes(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT attributes: %w", err)
+		}
 	default:
 		return false, nil
 	}
@@ -125,7 +153,7 @@ func (p *Parser) processCheckESDTInstances(esdtInstancesRaw oj.OJsonObject) ([]*
 			return nil, errors.New("JSON map expected as esdt instances list item")
 		}
 
-		instance := &mj.CheckESDTInstance{}
+		instance := mj.NewCheckESDTInstance()
 
 		for _, kvp := range instanceAsMap.OrderedKV {
 			instanceFieldLoaded, err := p.tryProcessCheckESDTInstanceField(kvp, instance)
@@ -8,10 +8,37 @@ import (
 	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
 )
 
+// processTxESDTList accepts either a single ESDT payment (the legacy format) or a list of them
+func (p *Parser) processTxESDTList(txEsdtRaw oj.OJsonObject) ([]*mj.ESDTTxData, error) {
+	listRaw, isList := txEsdtRaw.(*oj.OJsonList)
+	if !isList {
+		esdtData, err := p.processTxESDT(txEsdtRaw)
+		if err != nil {
+			return nil, err
+		}
+		return []*mj.ESDTTxData{esdtData}, nil
+	}
+
+	esdtList := make([]*mj.ESDTTxData, 0, len(*listRaw))
+	for _, esdtRaw := range *listRaw {
+		esdtData, err := p.processTxESDT(esdtRaw)
+		if err != nil {
+			return nil, err
+		}
+		esdtList = append(esdtList, esdtData)
+	}
+
+	if len(esdtList) == 0 {
+		return nil, errors.New("empty ESDT payment list")
+	}
+
+	return esdtList, nil
+}
+
 func (p *Parser) processTxESDT(txEsdtRaw oj.OJsonObject) (*mj.ESDTTxData, error) {
 	fieldMap, isMap := txEsdtRaw.(*oj.OJsonMap)
 	if !isMap {
-		return nil, errors.New("unmarshalled account object is not a map")
+		return nil, errors.New("unmarshalled transaction ESDT object is not a map")
 	}
 
 	esdtData := mj.ESDTTxData{}
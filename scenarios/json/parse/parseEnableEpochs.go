@@ -0,0 +1,31 @@
+package scenjsonparse
+
+import (
+	"errors"
+	"fmt"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
+	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
+)
+
+func (p *Parser) processEnableEpochs(enableEpochsRaw oj.OJsonObject) ([]*mj.EnableEpoch, error) {
+	enableEpochsMap, isMap := enableEpochsRaw.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("unmarshalled enable epochs object is not a map")
+	}
+
+	var enableEpochs []*mj.EnableEpoch
+	for _, kvp := range enableEpochsMap.OrderedKV {
+		epoch, err := p.processUint64(kvp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", kvp.Key, err)
+		}
+
+		enableEpochs = append(enableEpochs, &mj.EnableEpoch{
+			Name:  kvp.Key,
+			Epoch: epoch,
+		})
+	}
+
+	return enableEpochs, nil
+}
@@ -70,3 +70,16 @@ func (p *Parser) parseCheckBytesList(obj interface{}) ([]mj.JSONCheckBytes, erro
 	}
 	return result, nil
 }
+
+func (p *Parser) parseCheckValueList(obj oj.OJsonObject) (mj.JSONCheckValueList, error) {
+	if IsStar(obj) {
+		// "*" means any list, skip checking it
+		return mj.JSONCheckValueList{IsStar: true}, nil
+	}
+
+	values, err := p.parseCheckBytesList(obj)
+	if err != nil {
+		return mj.JSONCheckValueList{}, err
+	}
+	return mj.JSONCheckValueList{Values: values}, nil
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
 	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
 	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
+	oy "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedyaml"
 )
 
 // ParseScenarioFile converts a scenario json string to scenario object representation
@@ -15,6 +17,22 @@ func (p *Parser) ParseScenarioFile(jsonString []byte) (*mj.Scenario, error) {
 		return nil, err
 	}
 
+	return p.processScenario(jobj)
+}
+
+// ParseScenarioYAMLFile converts a scenario yaml string to scenario object representation,
+// the same one the equivalent json scenario yields
+func (p *Parser) ParseScenarioYAMLFile(yamlString []byte) (*mj.Scenario, error) {
+	jobj, err := oy.ParseOrderedYAML(yamlString)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.processScenario(jobj)
+}
+
+func (p *Parser) processScenario(jobj oj.OJsonObject) (*mj.Scenario, error) {
+	var err error
 	topMap, isMap := jobj.(*oj.OJsonMap)
 	if !isMap {
 		return nil, errors.New("unmarshalled test top level object is not a map")
@@ -25,6 +43,22 @@ func (p *Parser) ParseScenarioFile(jsonString []byte) (*mj.Scenario, error) {
 		GasSchedule: mj.GasScheduleDefault,
 	}
 
+	// the ABI is loaded before the steps, wherever it is, because their "abi:..." values need its types
+	p.ExprInterpreter.ABICodec = nil
+	for _, kvp := range topMap.OrderedKV {
+		if kvp.Key != "abi" {
+			continue
+		}
+		scenario.ABIPath, err = p.parseString(kvp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("bad scenario abi path: %w", err)
+		}
+		p.ExprInterpreter.ABICodec, err = p.loadABICodec(scenario.ABIPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load scenario abi: %w", err)
+		}
+	}
+
 	for _, kvp := range topMap.OrderedKV {
 		switch kvp.Key {
 		case "name":
@@ -48,6 +82,7 @@ func (p *Parser) ParseScenarioFile(jsonString []byte) (*mj.Scenario, error) {
 			if err != nil {
 				return nil, fmt.Errorf("bad scenario gasSchedule: %w", err)
 			}
+		case "abi":
 		case "steps":
 			scenario.Steps, err = p.processScenarioStepList(kvp.Value)
 			if err != nil {
@@ -60,6 +95,24 @@ func (p *Parser) ParseScenarioFile(jsonString []byte) (*mj.Scenario, error) {
 	return scenario, nil
 }
 
+func (p *Parser) loadABICodec(abiPath string) (*abi.Codec, error) {
+	if p.ExprInterpreter.FileResolver == nil {
+		return nil, errors.New("parser FileResolver not provided")
+	}
+
+	abiJSON, err := p.ExprInterpreter.FileResolver.ResolveFileValue(abiPath)
+	if err != nil {
+		return nil, err
+	}
+
+	contractABI, err := abi.ParseABI(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return abi.NewCodec(contractABI)
+}
+
 func (p *Parser) parseGasSchedule(value oj.OJsonObject) (mj.GasSchedule, error) {
 	gasScheduleStr, err := p.parseString(value)
 	if err != nil {
@@ -183,6 +236,11 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 				if err != nil {
 					return nil, fmt.Errorf("error parsing block hashes: %w", err)
 				}
+			case "enableEpochs":
+				step.EnableEpochs, err = p.processEnableEpochs(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing enable epochs: %w", err)
+				}
 			default:
 				return nil, fmt.Errorf("invalid set state field: %s", kvp.Key)
 			}
@@ -3,6 +3,7 @@ package scenjsonparse
 import (
 	"testing"
 
+	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +52,73 @@ func TestParseScenario(t *testing.T) {
 	require.NotNil(t, step)
 	require.Equal(t, "scCall", step.StepTypeName())
 }
+
+func TestParseScenario_CodeMetadata(t *testing.T) {
+	setStateSnippet := `
+	{
+		"step": "setState",
+		"accounts": {
+			"''contract_address______________s1": {
+				"nonce": "0",
+				"balance": "0",
+				"storage": {},
+				"code": "''contract code",
+				"codeMetadata": "0x0104"
+			}
+		}
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(setStateSnippet)
+	require.Nil(t, parseErr)
+	require.Equal(t, "setState", step.StepTypeName())
+
+	setStateStep := step.(*mj.SetStateStep)
+	require.Equal(t, []byte{0x01, 0x04}, setStateStep.Accounts[0].CodeMetadata.Value)
+
+	checkStateSnippet := `
+	{
+		"step": "checkState",
+		"accounts": {
+			"''contract_address______________s1": {
+				"codeMetadata": "0x0104"
+			},
+			"+": ""
+		}
+	}`
+
+	step, parseErr = p.ParseScenarioStep(checkStateSnippet)
+	require.Nil(t, parseErr)
+	require.Equal(t, "checkState", step.StepTypeName())
+
+	checkStateStep := step.(*mj.CheckStateStep)
+	checkAccount := checkStateStep.CheckAccounts.Accounts[0]
+	require.False(t, checkAccount.CodeMetadata.IsUnspecified())
+	require.True(t, checkAccount.CodeMetadata.Check([]byte{0x01, 0x04}))
+	require.True(t, checkAccount.Code.IsUnspecified())
+}
+
+func TestParseScenario_EnableEpochs(t *testing.T) {
+	snippet := `
+	{
+		"step": "setState",
+		"enableEpochs": {
+			"RepairCallbackEnableEpoch": "5",
+			"FloatingPointRejectionEnableEpoch": "4294967295"
+		}
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(snippet)
+	require.Nil(t, parseErr)
+
+	setStateStep := step.(*mj.SetStateStep)
+	require.Len(t, setStateStep.EnableEpochs, 2)
+	require.Equal(t, "RepairCallbackEnableEpoch", setStateStep.EnableEpochs[0].Name)
+	require.Equal(t, uint64(5), setStateStep.EnableEpochs[0].Epoch.Value)
+	require.Equal(t, "FloatingPointRejectionEnableEpoch", setStateStep.EnableEpochs[1].Name)
+	require.Equal(t, uint64(4294967295), setStateStep.EnableEpochs[1].Epoch.Value)
+
+	_, parseErr = p.ParseScenarioStep(`{"step": "setState", "enableEpochs": ["5"]}`)
+	require.NotNil(t, parseErr)
+}
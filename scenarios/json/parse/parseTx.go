@@ -74,14 +74,15 @@ func (p *Parser) processTx(txType mj.TransactionType, blrRaw oj.OJsonObject) (*m
 			if err != nil {
 				return nil, fmt.Errorf("invalid transaction value: %w", err)
 			}
-		case "esdt":
+		case "esdt", "esdtValue":
 			if !txType.HasESDT() {
-				return nil, errors.New("`esdt` not allowed in this context")
+				return nil, fmt.Errorf("`%s` not allowed in this context", kvp.Key)
 			}
-			blt.ESDTValue, err = p.processTxESDT(kvp.Value)
+			blt.ESDTValue, err = p.processTxESDTList(kvp.Value)
 			if err != nil {
 				return nil, fmt.Errorf("invalid transaction ESDT value: %w", err)
 			}
+			_, blt.ESDTValueIsList = kvp.Value.(*oj.OJsonList)
 		case "arguments":
 			blt.Arguments, err = p.parseSubTreeList(kvp.Value)
 			if err != nil {
@@ -27,6 +27,9 @@ func AccountsToOJ(accounts []*mj.Account) oj.OJsonObject {
 		}
 		acctOJ.Put("storage", storageOJ)
 		acctOJ.Put("code", bytesFromStringToOJ(account.Code))
+		if len(account.CodeMetadata.Value) > 0 {
+			acctOJ.Put("codeMetadata", bytesFromStringToOJ(account.CodeMetadata))
+		}
 		if len(account.Owner.Value) > 0 {
 			acctOJ.Put("owner", bytesFromStringToOJ(account.Owner))
 		}
@@ -75,6 +78,9 @@ func checkAccountsToOJ(checkAccounts *mj.CheckAccounts) oj.OJsonObject {
 		if !checkAccount.Code.IsUnspecified() {
 			acctOJ.Put("code", checkBytesToOJ(checkAccount.Code))
 		}
+		if !checkAccount.CodeMetadata.IsUnspecified() {
+			acctOJ.Put("codeMetadata", checkBytesToOJ(checkAccount.CodeMetadata))
+		}
 		if !checkAccount.Owner.IsUnspecified() {
 			acctOJ.Put("owner", checkBytesToOJ(checkAccount.Owner))
 		}
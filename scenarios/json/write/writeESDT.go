@@ -5,6 +5,15 @@ import (
 	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
 )
 
+func esdtTxDataListToOJ(esdtItems []*mj.ESDTTxData) *oj.OJsonList {
+	var esdtItemList []oj.OJsonObject
+	for _, esdtItem := range esdtItems {
+		esdtItemList = append(esdtItemList, esdtTxDataToOJ(esdtItem))
+	}
+	esdtItemListOJ := oj.OJsonList(esdtItemList)
+	return &esdtItemListOJ
+}
+
 func esdtTxDataToOJ(esdtItem *mj.ESDTTxData) *oj.OJsonMap {
 	esdtItemOJ := oj.NewMap()
 	if len(esdtItem.TokenIdentifier.Original) > 0 {
@@ -75,6 +84,29 @@ func appendESDTInstanceToOJ(esdtInstance *mj.ESDTInstance, targetOj *oj.OJsonMap
 	if len(esdtInstance.Balance.Original) > 0 {
 		targetOj.Put("balance", bigIntToOJ(esdtInstance.Balance))
 	}
+	if len(esdtInstance.Name.Original) > 0 {
+		targetOj.Put("name", bytesFromStringToOJ(esdtInstance.Name))
+	}
+	if len(esdtInstance.Creator.Original) > 0 {
+		targetOj.Put("creator", bytesFromStringToOJ(esdtInstance.Creator))
+	}
+	if len(esdtInstance.Royalties.Original) > 0 {
+		targetOj.Put("royalties", uint64ToOJ(esdtInstance.Royalties))
+	}
+	if esdtInstance.Hash.Original != nil {
+		targetOj.Put("hash", bytesFromTreeToOJ(esdtInstance.Hash))
+	}
+	if esdtInstance.Uris != nil {
+		var convertedList []oj.OJsonObject
+		for _, uri := range esdtInstance.Uris {
+			convertedList = append(convertedList, bytesFromTreeToOJ(uri))
+		}
+		urisOJList := oj.OJsonList(convertedList)
+		targetOj.Put("uri", &urisOJList)
+	}
+	if esdtInstance.Attributes.Original != nil {
+		targetOj.Put("attributes", bytesFromTreeToOJ(esdtInstance.Attributes))
+	}
 }
 
 func isCompactESDT(esdtItem *mj.ESDTData) bool {
@@ -84,6 +116,9 @@ func isCompactESDT(esdtItem *mj.ESDTData) bool {
 	if len(esdtItem.Instances[0].Nonce.Original) > 0 {
 		return false
 	}
+	if hasESDTInstanceMetadata(esdtItem.Instances[0]) {
+		return false
+	}
 	if len(esdtItem.Roles) > 0 {
 		return false
 	}
@@ -92,3 +127,12 @@ func isCompactESDT(esdtItem *mj.ESDTData) bool {
 	}
 	return true
 }
+
+func hasESDTInstanceMetadata(esdtInstance *mj.ESDTInstance) bool {
+	return len(esdtInstance.Name.Original) > 0 ||
+		len(esdtInstance.Creator.Original) > 0 ||
+		len(esdtInstance.Royalties.Original) > 0 ||
+		esdtInstance.Hash.Original != nil ||
+		esdtInstance.Uris != nil ||
+		esdtInstance.Attributes.Original != nil
+}
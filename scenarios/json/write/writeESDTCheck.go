@@ -61,6 +61,37 @@ func appendCheckESDTInstanceToOJ(esdtInstance *mj.CheckESDTInstance, targetOj *o
 	if len(esdtInstance.Balance.Original) > 0 {
 		targetOj.Put("balance", checkBigIntToOJ(esdtInstance.Balance))
 	}
+	if !esdtInstance.Name.IsUnspecified() {
+		targetOj.Put("name", checkBytesToOJ(esdtInstance.Name))
+	}
+	if !esdtInstance.Creator.IsUnspecified() {
+		targetOj.Put("creator", checkBytesToOJ(esdtInstance.Creator))
+	}
+	if !esdtInstance.Royalties.IsUnspecified() {
+		targetOj.Put("royalties", checkUint64ToOJ(esdtInstance.Royalties))
+	}
+	if !esdtInstance.Hash.IsUnspecified() {
+		targetOj.Put("hash", checkBytesToOJ(esdtInstance.Hash))
+	}
+	if !esdtInstance.Uris.IsUnspecified() {
+		targetOj.Put("uri", checkValueListToOJ(esdtInstance.Uris))
+	}
+	if !esdtInstance.Attributes.IsUnspecified() {
+		targetOj.Put("attributes", checkBytesToOJ(esdtInstance.Attributes))
+	}
+}
+
+func checkValueListToOJ(checkValueList mj.JSONCheckValueList) oj.OJsonObject {
+	if checkValueList.IsStar {
+		return stringToOJ("*")
+	}
+
+	var convertedList []oj.OJsonObject
+	for _, checkValue := range checkValueList.Values {
+		convertedList = append(convertedList, checkBytesToOJ(checkValue))
+	}
+	valuesOJList := oj.OJsonList(convertedList)
+	return &valuesOJList
 }
 
 func isCompactCheckESDT(esdtItem *mj.CheckESDTData) bool {
@@ -70,6 +101,9 @@ func isCompactCheckESDT(esdtItem *mj.CheckESDTData) bool {
 	if len(esdtItem.Instances[0].Nonce.Original) > 0 {
 		return false
 	}
+	if hasCheckESDTInstanceMetadata(esdtItem.Instances[0]) {
+		return false
+	}
 	if len(esdtItem.Roles) > 0 {
 		return false
 	}
@@ -78,3 +112,12 @@ func isCompactCheckESDT(esdtItem *mj.CheckESDTData) bool {
 	}
 	return true
 }
+
+func hasCheckESDTInstanceMetadata(esdtInstance *mj.CheckESDTInstance) bool {
+	return !esdtInstance.Name.IsUnspecified() ||
+		!esdtInstance.Creator.IsUnspecified() ||
+		!esdtInstance.Royalties.IsUnspecified() ||
+		!esdtInstance.Hash.IsUnspecified() ||
+		!esdtInstance.Uris.IsUnspecified() ||
+		!esdtInstance.Attributes.IsUnspecified()
+}
@@ -3,6 +3,7 @@ package scenjsonwrite
 import (
 	mj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/json/model"
 	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
+	oy "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedyaml"
 )
 
 // ScenarioToJSONString converts a scenario object to its JSON representation.
@@ -11,6 +12,12 @@ func ScenarioToJSONString(scenario *mj.Scenario) string {
 	return oj.JSONString(jobj)
 }
 
+// ScenarioToYAMLString converts a scenario object to its YAML representation.
+func ScenarioToYAMLString(scenario *mj.Scenario) (string, error) {
+	jobj := ScenarioToOrderedJSON(scenario)
+	return oy.YAMLString(jobj)
+}
+
 // ScenarioToOrderedJSON converts a scenario object to an ordered JSON object.
 func ScenarioToOrderedJSON(scenario *mj.Scenario) oj.OJsonObject {
 	scenarioOJ := oj.NewMap()
@@ -30,6 +37,10 @@ func ScenarioToOrderedJSON(scenario *mj.Scenario) oj.OJsonObject {
 
 	scenarioOJ.Put("gasSchedule", gasScheduleToOJ(scenario.GasSchedule))
 
+	if len(scenario.ABIPath) > 0 {
+		scenarioOJ.Put("abi", stringToOJ(scenario.ABIPath))
+	}
+
 	var stepOJList []oj.OJsonObject
 
 	for _, generalStep := range scenario.Steps {
@@ -60,6 +71,9 @@ func ScenarioToOrderedJSON(scenario *mj.Scenario) oj.OJsonObject {
 			if len(step.BlockHashes) > 0 {
 				stepOJ.Put("blockHashes", blockHashesToOJ(step.BlockHashes))
 			}
+			if len(step.EnableEpochs) > 0 {
+				stepOJ.Put("enableEpochs", enableEpochsToOJ(step.EnableEpochs))
+			}
 		case *mj.CheckStateStep:
 			if len(step.Comment) > 0 {
 				stepOJ.Put("comment", stringToOJ(step.Comment))
@@ -102,9 +116,10 @@ func transactionToScenarioOJ(tx *mj.Transaction) oj.OJsonObject {
 	if tx.Type.HasValue() {
 		transactionOJ.Put("value", bigIntToOJ(tx.Value))
 	}
-	if tx.ESDTValue != nil {
-		esdtItemOJ := esdtTxDataToOJ(tx.ESDTValue)
-		transactionOJ.Put("esdt", esdtItemOJ)
+	if len(tx.ESDTValue) == 1 && !tx.ESDTValueIsList {
+		transactionOJ.Put("esdt", esdtTxDataToOJ(tx.ESDTValue[0]))
+	} else if len(tx.ESDTValue) > 0 {
+		transactionOJ.Put("esdtValue", esdtTxDataListToOJ(tx.ESDTValue))
 	}
 	if tx.Type.HasFunction() {
 		transactionOJ.Put("function", stringToOJ(tx.Function))
@@ -143,6 +158,15 @@ func newAddressMocksToOJ(newAddressMocks []*mj.NewAddressMock) oj.OJsonObject {
 	return &namOJList
 }
 
+func enableEpochsToOJ(enableEpochs []*mj.EnableEpoch) oj.OJsonObject {
+	enableEpochsOJ := oj.NewMap()
+	for _, enableEpoch := range enableEpochs {
+		enableEpochsOJ.Put(enableEpoch.Name, uint64ToOJ(enableEpoch.Epoch))
+	}
+
+	return enableEpochsOJ
+}
+
 func blockInfoToOJ(blockInfo *mj.BlockInfo) oj.OJsonObject {
 	blockInfoOJ := oj.NewMap()
 	if len(blockInfo.BlockTimestamp.Original) > 0 {
@@ -0,0 +1,132 @@
+package orderedyaml
+
+import (
+	"errors"
+	"fmt"
+
+	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
+	"gopkg.in/yaml.v3"
+)
+
+const mergeKey = "<<"
+
+// ParseOrderedYAML converts a YAML document to the ordered JSON tree the scenario parsers work with.
+// Keys keep their order, aliases are expanded and "<<" merges the aliased maps into the enclosing one.
+// Booleans become JSON booleans, nulls become empty strings and all other scalars become strings,
+// so that `nonce: 0` and `nonce: "0"` are equivalent.
+func ParseOrderedYAML(input []byte) (oj.OJsonObject, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(input, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return nil, errors.New("expected a single YAML document")
+	}
+
+	return convertNode(document.Content[0])
+}
+
+func convertNode(node *yaml.Node) (oj.OJsonObject, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return convertNode(node.Alias)
+	case yaml.MappingNode:
+		return convertMapping(node)
+	case yaml.SequenceNode:
+		list := make([]oj.OJsonObject, 0, len(node.Content))
+		for _, item := range node.Content {
+			converted, err := convertNode(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, converted)
+		}
+		ojList := oj.OJsonList(list)
+		return &ojList, nil
+	case yaml.ScalarNode:
+		return convertScalar(node)
+	default:
+		return nil, fmt.Errorf("line %d: unexpected YAML node", node.Line)
+	}
+}
+
+func convertScalar(node *yaml.Node) (oj.OJsonObject, error) {
+	switch node.ShortTag() {
+	case "!!bool":
+		var value bool
+		err := node.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+		ojBool := oj.OJsonBool(value)
+		return &ojBool, nil
+	case "!!null":
+		return &oj.OJsonString{Value: ""}, nil
+	default:
+		return &oj.OJsonString{Value: node.Value}, nil
+	}
+}
+
+func convertMapping(node *yaml.Node) (*oj.OJsonMap, error) {
+	explicitKeys := make(map[string]bool)
+	for i := 0; i < len(node.Content); i += 2 {
+		explicitKeys[node.Content[i].Value] = true
+	}
+
+	ojMap := oj.NewMap()
+	for i := 0; i < len(node.Content); i += 2 {
+		keyNode := node.Content[i]
+		valueNode := node.Content[i+1]
+
+		if keyNode.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: map keys must be scalars", keyNode.Line)
+		}
+		if keyNode.Value == mergeKey && keyNode.ShortTag() == "!!merge" {
+			err := mergeInto(ojMap, valueNode, explicitKeys)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if ojMap.KeySet[keyNode.Value] {
+			return nil, fmt.Errorf("line %d: duplicate key %s", keyNode.Line, keyNode.Value)
+		}
+		value, err := convertNode(valueNode)
+		if err != nil {
+			return nil, err
+		}
+		ojMap.Put(keyNode.Value, value)
+	}
+
+	return ojMap, nil
+}
+
+// mergeInto adds the fields of the merged maps, except for those set explicitly in the enclosing map
+func mergeInto(ojMap *oj.OJsonMap, valueNode *yaml.Node, explicitKeys map[string]bool) error {
+	mergedNodes := []*yaml.Node{valueNode}
+	if valueNode.Kind == yaml.SequenceNode {
+		mergedNodes = valueNode.Content
+	}
+
+	for _, mergedNode := range mergedNodes {
+		merged, err := convertNode(mergedNode)
+		if err != nil {
+			return err
+		}
+		mergedMap, isMap := merged.(*oj.OJsonMap)
+		if !isMap {
+			return fmt.Errorf("line %d: only maps can be merged", mergedNode.Line)
+		}
+
+		for _, kvp := range mergedMap.OrderedKV {
+			if !explicitKeys[kvp.Key] {
+				ojMap.Put(kvp.Key, kvp.Value)
+			}
+		}
+	}
+
+	return nil
+}
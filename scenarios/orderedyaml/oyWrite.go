@@ -0,0 +1,62 @@
+package orderedyaml
+
+import (
+	"bytes"
+
+	oj "github.com/multiversx/mx-chain-vm-v1_2-go/scenarios/orderedjson"
+	"gopkg.in/yaml.v3"
+)
+
+const yamlIndent = 2
+
+// YAMLString returns a formatted YAML representation of an ordered JSON tree, keeping the order of the keys
+func YAMLString(j oj.OJsonObject) (string, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(yamlIndent)
+
+	err := encoder.Encode(toNode(j))
+	if err != nil {
+		return "", err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+func toNode(j oj.OJsonObject) *yaml.Node {
+	switch value := j.(type) {
+	case *oj.OJsonMap:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if value.Size() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, kvp := range value.OrderedKV {
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: kvp.Key}
+			node.Content = append(node.Content, keyNode, toNode(kvp.Value))
+		}
+		return node
+	case *oj.OJsonList:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if len(*value) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, item := range value.AsList() {
+			node.Content = append(node.Content, toNode(item))
+		}
+		return node
+	case *oj.OJsonBool:
+		if *value {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+	case *oj.OJsonString:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value.Value}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
@@ -108,6 +108,41 @@ type CodeDeployInput struct {
 	CodeDeployerAddress  []byte
 }
 
+// ContractValidationRule names a constraint verified on contract code at deploy and upgrade
+type ContractValidationRule string
+
+const (
+	// RuleMaxCodeSize limits the size of the contract code, in bytes
+	RuleMaxCodeSize ContractValidationRule = "max code size"
+
+	// RuleMaxFunctions limits the number of functions defined by the contract
+	RuleMaxFunctions ContractValidationRule = "max functions"
+
+	// RuleMaxExports limits the number of exports of the contract
+	RuleMaxExports ContractValidationRule = "max exports"
+
+	// RuleMaxMemoryPages limits the initial size of the contract memory, in pages
+	RuleMaxMemoryPages ContractValidationRule = "max memory pages"
+
+	// RuleMaxTableSize limits the initial size of the contract tables
+	RuleMaxTableSize ContractValidationRule = "max table size"
+
+	// RuleAllowedImports restricts the imported functions to an allowlist
+	RuleAllowedImports ContractValidationRule = "allowed imports"
+
+	// RuleNoStartSection forbids the start section
+	RuleNoStartSection ContractValidationRule = "no start section"
+
+	// RuleNoFloatingPoint forbids floating-point opcodes
+	RuleNoFloatingPoint ContractValidationRule = "no floating point"
+
+	// RuleWellFormedModule requires the contract code to be a well-formed WASM module
+	RuleWellFormedModule ContractValidationRule = "well-formed module"
+)
+
+// WASMPageSize is the size of a page of the linear memory of a Wasmer instance
+const WASMPageSize = 65536
+
 // VMHostParameters represents the parameters to be passed to VMHost
 type VMHostParameters struct {
 	VMType                   []byte
@@ -117,7 +152,17 @@ type VMHostParameters struct {
 	ProtectedKeyPrefix       []byte
 	WasmerSIGSEGVPassthrough bool
 	UseWarmInstance          bool
-	EnableEpochsHandler      EnableEpochsHandler
+	// EnableEpochsHandler is not marshalized when the parameters are sent to a VM process,
+	// which creates its own handler from the enable epochs of the VMArguments
+	EnableEpochsHandler     EnableEpochsHandler `json:"-"`
+	ContractValidationRules *config.ContractValidationRules
+	// MaxMemoryPages is the limit of the linear memory pages used by all the running instances together;
+	// when 0, the default limit of the host is used
+	MaxMemoryPages uint64
+	// CoverageCollector, when set, instruments the contracts and records which of their parts run;
+	// it is meant for the test tools only: Wasmer's imports are shared by the whole process, so the hosts
+	// of a process must either all collect coverage or none of them
+	CoverageCollector CoverageCollector `json:"-"`
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
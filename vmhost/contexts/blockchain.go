@@ -270,6 +270,20 @@ func (context *blockchainContext) IsPayable(addr []byte) (bool, error) {
 	return context.blockChainHook.IsPayable(nil, addr)
 }
 
+// GetCodeMetadata returns the code metadata of the account at the given address.
+func (context *blockchainContext) GetCodeMetadata(address []byte) (*vmcommon.CodeMetadata, error) {
+	account, err := context.blockChainHook.GetUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if vmhost.IfNil(account) {
+		return nil, vmhost.ErrInvalidAccount
+	}
+
+	codeMetadata := vmcommon.CodeMetadataFromBytes(account.GetCodeMetadata())
+	return &codeMetadata, nil
+}
+
 // SaveCompiledCode saves the compiled code to cache and storage.
 func (context *blockchainContext) SaveCompiledCode(codeHash []byte, code []byte) {
 	context.blockChainHook.SaveCompiledCode(codeHash, code)
@@ -1,10 +1,47 @@
 package contexts
 
 import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/wasmer"
 )
 
+// wasmerConfigMutex guards the opcode costs, which Wasmer keeps for the whole process
+// and reads when compiling; hosts with different gas schedules may run side by side,
+// so each of them applies its own costs right before compiling
+var wasmerConfigMutex sync.Mutex
+
+// appliedOpcodeCosts are the opcode costs last set in Wasmer; they are never changed once applied
+var appliedOpcodeCosts *[wasmer.OPCODE_COUNT]uint32
+
+// SetWasmerOpcodeCosts sets the opcode costs used by Wasmer when compiling contracts,
+// without interfering with a compilation in progress
+func SetWasmerOpcodeCosts(opcodeCosts *[wasmer.OPCODE_COUNT]uint32) {
+	wasmerConfigMutex.Lock()
+	defer wasmerConfigMutex.Unlock()
+
+	applyWasmerOpcodeCosts(opcodeCosts)
+}
+
+// applyWasmerOpcodeCosts sets the opcode costs in Wasmer, unless they are already set; the caller holds wasmerConfigMutex
+func applyWasmerOpcodeCosts(opcodeCosts *[wasmer.OPCODE_COUNT]uint32) {
+	if opcodeCosts == appliedOpcodeCosts {
+		return
+	}
+
+	wasmer.SetOpcodeCosts(opcodeCosts)
+	appliedOpcodeCosts = opcodeCosts
+}
+
 type wasmerInstanceBuilder struct {
+	host vmhost.VMHost
+
+	// opcodeCosts are built from gasSchedule, the gas schedule of the host when they were last needed;
+	// they are rebuilt once GasScheduleChange gives the host a new gas schedule
+	gasSchedule *config.GasCost
+	opcodeCosts *[wasmer.OPCODE_COUNT]uint32
 }
 
 // NewInstanceWithOptions creates a new Wasmer instance from WASM bytecode,
@@ -13,6 +50,10 @@ func (builder *wasmerInstanceBuilder) NewInstanceWithOptions(
 	contractCode []byte,
 	options wasmer.CompilationOptions,
 ) (wasmer.InstanceHandler, error) {
+	wasmerConfigMutex.Lock()
+	defer wasmerConfigMutex.Unlock()
+
+	builder.applyOpcodeCosts()
 	return wasmer.NewInstanceWithOptions(contractCode, options)
 }
 
@@ -24,3 +65,23 @@ func (builder *wasmerInstanceBuilder) NewInstanceFromCompiledCodeWithOptions(
 ) (wasmer.InstanceHandler, error) {
 	return wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
 }
+
+// applyOpcodeCosts sets the opcode costs of the host in Wasmer; the caller holds wasmerConfigMutex
+func (builder *wasmerInstanceBuilder) applyOpcodeCosts() {
+	if builder.host == nil || builder.host.Metering() == nil {
+		return
+	}
+
+	gasSchedule := builder.host.Metering().GasSchedule()
+	if gasSchedule == nil {
+		return
+	}
+
+	if gasSchedule != builder.gasSchedule {
+		opcodeCosts := gasSchedule.WASMOpcodeCost.ToOpcodeCostsArray()
+		builder.gasSchedule = gasSchedule
+		builder.opcodeCosts = &opcodeCosts
+	}
+
+	applyWasmerOpcodeCosts(builder.opcodeCosts)
+}
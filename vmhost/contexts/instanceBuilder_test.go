@@ -0,0 +1,36 @@
+package contexts
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceBuilder_OpcodeCostsRebuiltOnlyOnGasScheduleChange(t *testing.T) {
+	metering := &contextmock.MeteringContextMock{}
+	metering.SetGasSchedule(config.MakeGasMapForTests())
+	builder := &wasmerInstanceBuilder{host: &contextmock.VMHostMock{MeteringContext: metering}}
+
+	wasmerConfigMutex.Lock()
+	defer wasmerConfigMutex.Unlock()
+
+	builder.applyOpcodeCosts()
+	opcodeCosts := builder.opcodeCosts
+	require.NotNil(t, opcodeCosts)
+	require.True(t, appliedOpcodeCosts == opcodeCosts)
+
+	builder.applyOpcodeCosts()
+	require.True(t, builder.opcodeCosts == opcodeCosts)
+	require.True(t, appliedOpcodeCosts == opcodeCosts)
+
+	gasSchedule := config.MakeGasMapForTests()
+	gasSchedule["WASMOpcodeCost"]["I32Add"] = 42
+	metering.SetGasSchedule(gasSchedule)
+
+	builder.applyOpcodeCosts()
+	require.False(t, builder.opcodeCosts == opcodeCosts)
+	require.True(t, appliedOpcodeCosts == builder.opcodeCosts)
+	require.Equal(t, metering.GasSchedule().WASMOpcodeCost.ToOpcodeCostsArray(), *builder.opcodeCosts)
+}
@@ -274,7 +274,14 @@ func (context *outputContext) TransferValueOnly(destination []byte, sender []byt
 	isAsyncCall := context.host.IsVMV3Enabled() && context.host.Runtime().GetVMInput().CallType == vm.AsynchronousCall
 	checkPayable = checkPayable || !context.host.IsESDTFunctionsEnabled()
 	hasValue := value.Cmp(vmhost.Zero) > 0
-	if checkPayable && !payable && hasValue && !isAsyncCall {
+	shouldCheckPayable := checkPayable && hasValue && !isAsyncCall
+	if shouldCheckPayable && context.host.IsCodeMetadataEnforcementEnabled() {
+		err = context.checkPayableByCodeMetadata(destination, sender)
+		if err != nil {
+			logOutput.Trace("transfer value", "error", err)
+			return err
+		}
+	} else if shouldCheckPayable && !payable {
 		logOutput.Trace("transfer value", "error", vmhost.ErrAccountNotPayable)
 		return vmhost.ErrAccountNotPayable
 	}
@@ -288,6 +295,36 @@ func (context *outputContext) TransferValueOnly(destination []byte, sender []byt
 	return nil
 }
 
+// checkPayableByCodeMetadata verifies whether the destination contract accepts value from the sender:
+// payable contracts accept value from any account, payable-by-SC contracts only from other contracts
+func (context *outputContext) checkPayableByCodeMetadata(destination []byte, sender []byte) error {
+	blockchain := context.host.Blockchain()
+	if !blockchain.IsSmartContract(destination) {
+		return nil
+	}
+
+	codeMetadata, err := blockchain.GetCodeMetadata(destination)
+	if err != nil {
+		return err
+	}
+	if codeMetadata.Payable {
+		return nil
+	}
+
+	senderIsContract := blockchain.IsSmartContract(sender)
+	if codeMetadata.PayableBySC && senderIsContract {
+		return nil
+	}
+	if codeMetadata.PayableBySC {
+		return vmhost.ErrAccountOnlyPayableBySC
+	}
+	if senderIsContract {
+		return vmhost.ErrAccountNotPayableBySC
+	}
+
+	return vmhost.ErrAccountNotPayable
+}
+
 // Transfer handles any necessary value transfer required and takes
 // the necessary steps to create accounts and reverses the state in case of an
 // execution error or failed value transfer.
@@ -330,6 +367,14 @@ func (context *outputContext) TransferESDT(
 		callType = vm.ESDTTransferAndExecute
 	}
 
+	if !isExecution && context.host.IsCodeMetadataEnforcementEnabled() {
+		err := context.checkPayableByCodeMetadata(destination, sender)
+		if err != nil {
+			logOutput.Trace("ESDT transfer", "error", err)
+			return 0, err
+		}
+	}
+
 	vmOutput, gasConsumedByTransfer, err := context.host.ExecuteESDTTransfer(destination, sender, tokenIdentifier, nonce, value, callType, false)
 	if err != nil {
 		return 0, err
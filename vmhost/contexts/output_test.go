@@ -527,6 +527,71 @@ func TestOutputContext_Transfer_IsAccountPayable(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestOutputContext_Transfer_IsAccountPayableBySC(t *testing.T) {
+	t.Parallel()
+
+	userSender := []byte("sender")
+	contractSender := make([]byte, 32)
+	contractSender[31] = 1
+	receiverNonPayable := make([]byte, 32)
+	receiverNonPayable[31] = 2
+	receiverPayableBySC := make([]byte, 32)
+	receiverPayableBySC[31] = 3
+
+	mockWorld := worldmock.NewMockWorld()
+	mockWorld.AcctMap.PutAccounts([]*worldmock.Account{
+		{
+			Address: userSender,
+			Balance: big.NewInt(2000),
+		},
+		{
+			Address:         contractSender,
+			Balance:         big.NewInt(2000),
+			Code:            []byte("contract_code"),
+			IsSmartContract: true,
+		},
+		{
+			Address:         receiverNonPayable,
+			Balance:         big.NewInt(0),
+			Code:            []byte("contract_code"),
+			IsSmartContract: true,
+		},
+		{
+			Address:         receiverPayableBySC,
+			Balance:         big.NewInt(0),
+			Code:            []byte("contract_code"),
+			CodeMetadata:    []byte{0, vmcommon.MetadataPayableBySC},
+			IsSmartContract: true,
+		},
+	})
+
+	host := &contextmock.VMHostMock{}
+	oc, _ := NewOutputContext(host)
+	bc, _ := NewBlockchainContext(host, mockWorld)
+
+	host.OutputContext = oc
+	host.BlockchainContext = bc
+	host.RuntimeContext = &contextmock.RuntimeContextMock{VMInput: &vmcommon.VMInput{}}
+
+	valueToTransfer := big.NewInt(10)
+	err := oc.Transfer(receiverPayableBySC, contractSender, 54, 0, valueToTransfer, []byte("txdata"), 0)
+	require.Equal(t, vmhost.ErrAccountNotPayable, err)
+
+	host.CodeMetadataEnforcementEnabled = true
+
+	err = oc.Transfer(receiverPayableBySC, contractSender, 54, 0, valueToTransfer, []byte("txdata"), 0)
+	require.Nil(t, err)
+
+	err = oc.Transfer(receiverPayableBySC, userSender, 54, 0, valueToTransfer, []byte("txdata"), 0)
+	require.Equal(t, vmhost.ErrAccountOnlyPayableBySC, err)
+
+	err = oc.Transfer(receiverNonPayable, contractSender, 54, 0, valueToTransfer, []byte("txdata"), 0)
+	require.Equal(t, vmhost.ErrAccountNotPayableBySC, err)
+
+	err = oc.Transfer(receiverNonPayable, userSender, 54, 0, valueToTransfer, []byte("txdata"), 0)
+	require.Equal(t, vmhost.ErrAccountNotPayable, err)
+}
+
 func TestOutputContext_WriteLog(t *testing.T) {
 	t.Parallel()
 
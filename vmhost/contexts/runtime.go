@@ -9,6 +9,7 @@ import (
 
 	logger "github.com/multiversx/mx-chain-logger-go"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/math"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/wasmer"
@@ -39,6 +40,11 @@ type runtimeContext struct {
 
 	maxWasmerInstances uint64
 
+	maxMemoryPages     uint64
+	chargedMemoryPages uint64
+	memoryUsageStack   []instanceMemoryUsage
+	peakMemoryPages    uint64
+
 	asyncCallInfo    *vmhost.AsyncCallInfo
 	asyncContextInfo *vmhost.AsyncContextInfo
 
@@ -51,8 +57,13 @@ type runtimeContext struct {
 	instanceBuilder vmhost.InstanceBuilder
 }
 
-// NewRuntimeContext creates a new runtimeContext
-func NewRuntimeContext(host vmhost.VMHost, vmType []byte, useWarmInstance bool) (*runtimeContext, error) {
+// NewRuntimeContext creates a new runtimeContext; the contract validation rules are optional
+func NewRuntimeContext(
+	host vmhost.VMHost,
+	vmType []byte,
+	useWarmInstance bool,
+	validationRules *config.ContractValidationRules,
+) (*runtimeContext, error) {
 	scAPINames := host.GetAPIMethods().Names()
 	protocolBuiltinFunctions := host.GetProtocolBuiltinFunctions()
 
@@ -61,13 +72,14 @@ func NewRuntimeContext(host vmhost.VMHost, vmType []byte, useWarmInstance bool)
 		vmType:              vmType,
 		stateStack:          make([]*runtimeContext, 0),
 		instanceStack:       make([]wasmer.InstanceHandler, 0),
-		validator:           newWASMValidator(scAPINames, protocolBuiltinFunctions),
+		memoryUsageStack:    make([]instanceMemoryUsage, 0),
+		validator:           newWASMValidator(scAPINames, protocolBuiltinFunctions, validationRules),
 		useWarmInstance:     useWarmInstance,
 		warmInstanceAddress: nil,
 		warmInstance:        nil,
 	}
 
-	context.instanceBuilder = &wasmerInstanceBuilder{}
+	context.instanceBuilder = &wasmerInstanceBuilder{host: host}
 	context.InitState()
 
 	return context, nil
@@ -84,6 +96,8 @@ func (context *runtimeContext) InitState() {
 	context.asyncContextInfo = &vmhost.AsyncContextInfo{
 		AsyncContextMap: make(map[string]*vmhost.AsyncContext),
 	}
+	context.chargedMemoryPages = 0
+	context.peakMemoryPages = 0
 
 	logRuntime.Trace("init state")
 }
@@ -113,14 +127,39 @@ func (context *runtimeContext) setWarmInstanceWhenNeeded(gasLimit uint64) bool {
 	return false
 }
 
-// StartWasmerInstance creates a new wasmer instance if the maxWasmerInstances has not been reached.
+// StartWasmerInstance creates a new wasmer instance if the maxWasmerInstances has not been reached;
+// the initial memory of the new instance counts towards the memory limit of the running instances.
 func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error {
+	err := context.startWasmerInstance(contract, gasLimit, newCode)
+	if err != nil {
+		return err
+	}
+
+	context.chargedMemoryPages = context.instanceMemoryPages()
+	return context.checkMemoryUsage()
+}
+
+func (context *runtimeContext) startWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error {
 	if context.RunningInstancesCount() >= context.maxWasmerInstances {
 		context.instance = nil
 		logRuntime.Error("create instance", "error", vmhost.ErrMaxInstancesReached)
 		return vmhost.ErrMaxInstancesReached
 	}
 
+	err := context.makeInstance(contract, gasLimit, newCode)
+	if err != nil {
+		return err
+	}
+
+	coverage := context.host.Coverage()
+	if coverage != nil {
+		coverage.ContractStarted(context.GetSCAddress(), contract)
+	}
+
+	return nil
+}
+
+func (context *runtimeContext) makeInstance(contract []byte, gasLimit uint64, newCode bool) error {
 	warmInstanceUsed := context.setWarmInstanceWhenNeeded(gasLimit)
 	if warmInstanceUsed {
 		return nil
@@ -178,7 +217,26 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 	return true
 }
 
+// instrumentForCoverage returns the code to compile, instrumented when the host collects coverage
+func (context *runtimeContext) instrumentForCoverage(contract []byte) ([]byte, error) {
+	coverage := context.host.Coverage()
+	if coverage == nil {
+		return contract, nil
+	}
+
+	return coverage.InstrumentCode(contract)
+}
+
 func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte, codeHash []byte, gasLimit uint64, newCode bool) error {
+	if newCode {
+		err := context.verifyContractRules(contract)
+		if err != nil {
+			context.instance = nil
+			logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
+			return err
+		}
+	}
+
 	gasSchedule := context.host.Metering().GasSchedule()
 	options := wasmer.CompilationOptions{
 		GasLimit:           gasLimit,
@@ -189,7 +247,14 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 		Metering:           true,
 		RuntimeBreakpoints: true,
 	}
-	newInstance, err := context.instanceBuilder.NewInstanceWithOptions(contract, options)
+	code, err := context.instrumentForCoverage(contract)
+	if err != nil {
+		context.instance = nil
+		logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
+		return err
+	}
+
+	newInstance, err := context.instanceBuilder.NewInstanceWithOptions(code, options)
 	if err != nil {
 		context.instance = nil
 		logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
@@ -293,6 +358,11 @@ func (context *runtimeContext) SetMaxInstanceCount(maxInstances uint64) {
 	context.maxWasmerInstances = maxInstances
 }
 
+// SetMaxMemoryPages sets the limit of the memory pages used by all the running instances together
+func (context *runtimeContext) SetMaxMemoryPages(maxMemoryPages uint64) {
+	context.maxMemoryPages = maxMemoryPages
+}
+
 // InitStateFromContractCallInput initializes the runtime context state with the values from the given input
 func (context *runtimeContext) InitStateFromContractCallInput(input *vmcommon.ContractCallInput) {
 	context.SetVMInput(&input.VMInput)
@@ -374,9 +444,14 @@ func (context *runtimeContext) ClearStateStack() {
 	context.stateStack = make([]*runtimeContext, 0)
 }
 
-// pushInstance appends the current wasmer instance to the instance stack.
+// pushInstance appends the current wasmer instance to the instance stack,
+// along with the memory it uses while suspended.
 func (context *runtimeContext) pushInstance() {
 	context.instanceStack = append(context.instanceStack, context.instance)
+	context.memoryUsageStack = append(context.memoryUsageStack, instanceMemoryUsage{
+		pages:        context.instanceMemoryPages(),
+		chargedPages: context.chargedMemoryPages,
+	})
 }
 
 // popInstance removes the latest entry from the wasmer instance stack and sets it
@@ -389,6 +464,7 @@ func (context *runtimeContext) popInstance() {
 
 	prevInstance := context.instanceStack[instanceStackLen-1]
 	context.instanceStack = context.instanceStack[:instanceStackLen-1]
+	context.popMemoryUsage()
 
 	if prevInstance == context.instance {
 		// The current Wasmer instance was previously pushed on the instance stack,
@@ -581,6 +657,12 @@ func (context *runtimeContext) VerifyContractCode() error {
 		return err
 	}
 
+	err = context.verifyCoverageHookNotImported()
+	if err != nil {
+		logRuntime.Trace("verify contract code", "error", err)
+		return err
+	}
+
 	err = context.checkBackwardCompatibility()
 	if err != nil {
 		logRuntime.Trace("verify contract code", "error", err)
@@ -592,7 +674,42 @@ func (context *runtimeContext) VerifyContractCode() error {
 	return nil
 }
 
+func (context *runtimeContext) verifyContractRules(contract []byte) error {
+	if !context.verifyCode {
+		return nil
+	}
+
+	code := newContractCode(contract)
+	if context.host.IsFloatingPointRejectionEnabled() {
+		err := context.validator.verifyDeterministicCode(code)
+		if err != nil {
+			return err
+		}
+	}
+
+	if context.validator.rules == nil {
+		return nil
+	}
+
+	epoch := context.host.Blockchain().CurrentEpoch()
+	return context.validator.verifyContractRules(code, epoch)
+}
+
+// verifyCoverageHookNotImported refuses the coverage hook to the contracts, unless they were instrumented for coverage;
+// it only matters when hosts with and without coverage are mixed in a process, as otherwise the hook is not registered
+func (context *runtimeContext) verifyCoverageHookNotImported() error {
+	if context.host.Coverage() == nil && context.instance.IsFunctionImported(vmhost.CoverageHookName) {
+		return vmhost.ErrContractInvalid
+	}
+
+	return nil
+}
+
 func (context *runtimeContext) checkBackwardCompatibility() error {
+	if !context.host.IsEmitEventEnabled() && context.instance.IsFunctionImported("emitEvent") {
+		return vmhost.ErrContractInvalid
+	}
+
 	if context.host.IsESDTFunctionsEnabled() {
 		return nil
 	}
@@ -902,6 +1019,10 @@ func (context *runtimeContext) MemStore(offset int32, data []byte) error {
 		if err != nil {
 			return err
 		}
+		err = context.UpdateMemoryUsage()
+		if err != nil {
+			return err
+		}
 
 		memoryView = memory.Data()
 		memoryLength = memory.Length()
@@ -916,6 +1037,96 @@ func (context *runtimeContext) MemStore(offset int32, data []byte) error {
 	return nil
 }
 
+// instanceMemoryUsage holds the memory accounting of an instance suspended on the instance stack
+type instanceMemoryUsage struct {
+	pages        uint64
+	chargedPages uint64
+}
+
+// UpdateMemoryUsage accounts for the memory grown by the current instance since it was last checked:
+// when memory accounting is enabled, the growth costs gas and the memory used by all the running
+// instances together must stay within the limit. It is called when the VM grows the memory, and
+// after every call to a contract function, whether the call failed or not.
+func (context *runtimeContext) UpdateMemoryUsage() error {
+	pages := context.instanceMemoryPages()
+	if pages > context.chargedMemoryPages {
+		grownPages := pages - context.chargedMemoryPages
+		context.chargedMemoryPages = pages
+
+		if context.host.IsMemoryAccountingEnabled() {
+			metering := context.host.Metering()
+			gasToUse := math.MulUint64(grownPages, metering.GasSchedule().BaseOperationCost.MemoryGrowPerPage)
+			err := metering.UseGasBounded(gasToUse)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return context.checkMemoryUsage()
+}
+
+func (context *runtimeContext) checkMemoryUsage() error {
+	pagesInUse := context.MemoryPagesInUse()
+	if pagesInUse > context.peakMemoryPages {
+		context.peakMemoryPages = pagesInUse
+	}
+
+	if !context.host.IsMemoryAccountingEnabled() {
+		return nil
+	}
+	if pagesInUse > context.maxMemoryPages {
+		logRuntime.Trace("memory usage", "pages", pagesInUse, "error", vmhost.ErrMaxMemoryPagesReached)
+		return vmhost.ErrMaxMemoryPagesReached
+	}
+
+	return nil
+}
+
+// MemoryPagesInUse returns the number of linear memory pages used by the current instance
+// together with the instances suspended on the instance stack.
+func (context *runtimeContext) MemoryPagesInUse() uint64 {
+	pagesInUse := context.instanceMemoryPages()
+	for i, instance := range context.instanceStack {
+		if instance == nil || instance == context.instance {
+			continue
+		}
+		pagesInUse += context.memoryUsageStack[i].pages
+	}
+
+	return pagesInUse
+}
+
+// PeakMemoryPages returns the largest number of linear memory pages used by the running
+// instances together, since the state was last initialized.
+func (context *runtimeContext) PeakMemoryPages() uint64 {
+	return context.peakMemoryPages
+}
+
+func (context *runtimeContext) instanceMemoryPages() uint64 {
+	if context.instance == nil {
+		return 0
+	}
+
+	memory := context.instance.GetInstanceCtxMemory()
+	if memory == nil {
+		return 0
+	}
+
+	return uint64(memory.Length()) / vmhost.WASMPageSize
+}
+
+func (context *runtimeContext) popMemoryUsage() {
+	memoryUsageStackLen := len(context.memoryUsageStack)
+	if memoryUsageStackLen == 0 {
+		return
+	}
+
+	prevMemoryUsage := context.memoryUsageStack[memoryUsageStackLen-1]
+	context.memoryUsageStack = context.memoryUsageStack[:memoryUsageStackLen-1]
+	context.chargedMemoryPages = prevMemoryUsage.chargedPages
+}
+
 // SetWarmInstance overwrites the warm Wasmer instance with the provided one.
 // TODO remove after implementing proper mocking of Wasmer instances; this is
 // used for tests only
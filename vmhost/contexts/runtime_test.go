@@ -57,7 +57,7 @@ func TestNewRuntimeContext(t *testing.T) {
 
 	vmType := []byte("type")
 
-	runtimeContext, err := NewRuntimeContext(host, vmType, false)
+	runtimeContext, err := NewRuntimeContext(host, vmType, false, nil)
 	require.Nil(t, err)
 	require.NotNil(t, runtimeContext)
 
@@ -73,7 +73,7 @@ func TestRuntimeContext_InitState(t *testing.T) {
 
 	vmType := []byte("type")
 
-	runtimeContext, err := NewRuntimeContext(host, vmType, false)
+	runtimeContext, err := NewRuntimeContext(host, vmType, false, nil)
 	require.Nil(t, err)
 	require.NotNil(t, runtimeContext)
 
@@ -97,7 +97,7 @@ func TestRuntimeContext_NewWasmerInstance(t *testing.T) {
 
 	vmType := []byte("type")
 
-	runtimeContext, err := NewRuntimeContext(host, vmType, false)
+	runtimeContext, err := NewRuntimeContext(host, vmType, false, nil)
 	require.Nil(t, err)
 
 	runtimeContext.SetMaxInstanceCount(1)
@@ -124,7 +124,7 @@ func TestRuntimeContext_IsFunctionImported(t *testing.T) {
 	host := InitializeVMAndWasmer()
 	vmType := []byte("type")
 
-	runtimeContext, err := NewRuntimeContext(host, vmType, false)
+	runtimeContext, err := NewRuntimeContext(host, vmType, false, nil)
 	require.Nil(t, err)
 
 	runtimeContext.SetMaxInstanceCount(1)
@@ -159,7 +159,7 @@ func TestRuntimeContext_StateSettersAndGetters(t *testing.T) {
 	host.SCAPIMethods = imports
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 
 	arguments := [][]byte{[]byte("argument 1"), []byte("argument 2")}
 	esdtTransfer := &vmcommon.ESDTTransfer{
@@ -209,7 +209,7 @@ func TestRuntimeContext_PushPopInstance(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	gasLimit := uint64(100000000)
@@ -239,7 +239,7 @@ func TestRuntimeContext_PushPopState(t *testing.T) {
 	host.SCAPIMethods = imports
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	vmInput := vmcommon.VMInput{
@@ -296,7 +296,7 @@ func TestRuntimeContext_Instance(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	gasLimit := uint64(100000000)
@@ -348,7 +348,7 @@ func TestRuntimeContext_Breakpoints(t *testing.T) {
 	host.OutputContext = mockOutput
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	gasLimit := uint64(100000000)
@@ -401,7 +401,7 @@ func TestRuntimeContext_MemLoadStoreOk(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	gasLimit := uint64(100000000)
@@ -433,7 +433,7 @@ func TestRuntimeContext_MemoryIsBlank(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	gasLimit := uint64(100000000)
@@ -463,7 +463,7 @@ func TestRuntimeContext_MemLoadCases(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	gasLimit := uint64(100000000)
@@ -527,7 +527,7 @@ func TestRuntimeContext_MemStoreCases(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(1)
 
 	gasLimit := uint64(100000000)
@@ -592,7 +592,7 @@ func TestRuntimeContext_MemLoadStoreVsInstanceStack(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.SetMaxInstanceCount(2)
 
 	gasLimit := uint64(100000000)
@@ -654,7 +654,7 @@ func TestRuntimeContext_PopSetActiveStateIfStackIsEmptyShouldNotPanic(t *testing
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.PopSetActiveState()
 
 	require.Equal(t, 0, len(runtimeContext.stateStack))
@@ -666,7 +666,7 @@ func TestRuntimeContext_PopDiscardIfStackIsEmptyShouldNotPanic(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.PopDiscard()
 
 	require.Equal(t, 0, len(runtimeContext.stateStack))
@@ -678,8 +678,75 @@ func TestRuntimeContext_PopInstanceIfStackIsEmptyShouldNotPanic(t *testing.T) {
 	host := InitializeVMAndWasmer()
 
 	vmType := []byte("type")
-	runtimeContext, _ := NewRuntimeContext(host, vmType, false)
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
 	runtimeContext.popInstance()
 
 	require.Equal(t, 0, len(runtimeContext.stateStack))
 }
+
+func TestRuntimeContext_MemoryAccounting(t *testing.T) {
+	host := InitializeVMAndWasmer()
+	host.MemoryAccountingEnabled = true
+
+	vmType := []byte("type")
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
+	runtimeContext.SetMaxMemoryPages(5)
+
+	parentInstance := contextmock.NewInstanceMock([]byte("parent"))
+	runtimeContext.instance = parentInstance
+	runtimeContext.chargedMemoryPages = runtimeContext.instanceMemoryPages()
+	require.Equal(t, uint64(2), runtimeContext.MemoryPagesInUse())
+
+	// the parent instance grows before calling a child contract
+	err := parentInstance.Memory.Grow(1)
+	require.Nil(t, err)
+	err = runtimeContext.UpdateMemoryUsage()
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), runtimeContext.chargedMemoryPages)
+	require.Equal(t, uint64(3), runtimeContext.PeakMemoryPages())
+
+	runtimeContext.pushInstance()
+	childInstance := contextmock.NewInstanceMock([]byte("child"))
+	runtimeContext.instance = childInstance
+	runtimeContext.chargedMemoryPages = runtimeContext.instanceMemoryPages()
+	require.Equal(t, uint64(5), runtimeContext.MemoryPagesInUse())
+
+	err = childInstance.Memory.Grow(1)
+	require.Nil(t, err)
+	err = runtimeContext.UpdateMemoryUsage()
+	require.Equal(t, vmhost.ErrMaxMemoryPagesReached, err)
+	require.Equal(t, uint64(6), runtimeContext.PeakMemoryPages())
+
+	runtimeContext.popInstance()
+	require.Equal(t, parentInstance, runtimeContext.instance)
+	require.Equal(t, uint64(3), runtimeContext.chargedMemoryPages)
+	require.Equal(t, uint64(3), runtimeContext.MemoryPagesInUse())
+	require.Equal(t, uint64(6), runtimeContext.PeakMemoryPages())
+
+	runtimeContext.InitState()
+	require.Equal(t, uint64(0), runtimeContext.PeakMemoryPages())
+}
+
+func TestRuntimeContext_MemoryAccountingDisabled(t *testing.T) {
+	host := InitializeVMAndWasmer()
+	host.MeteringContext.(*contextmock.MeteringContextMock).Err = vmhost.ErrNotEnoughGas
+
+	vmType := []byte("type")
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, nil)
+	runtimeContext.SetMaxMemoryPages(1)
+
+	instance := contextmock.NewInstanceMock([]byte("contract"))
+	runtimeContext.instance = instance
+	err := instance.Memory.Grow(3)
+	require.Nil(t, err)
+
+	err = runtimeContext.UpdateMemoryUsage()
+	require.Nil(t, err)
+	require.Equal(t, uint64(5), runtimeContext.PeakMemoryPages())
+
+	host.MemoryAccountingEnabled = true
+	err = instance.Memory.Grow(1)
+	require.Nil(t, err)
+	err = runtimeContext.UpdateMemoryUsage()
+	require.Equal(t, vmhost.ErrNotEnoughGas, err)
+}
@@ -112,7 +112,8 @@ func (context *storageContext) GetStorage(key []byte) []byte {
 }
 
 // GetStorageFromAddress returns the data under the given key from the account mapped to the given address.
-func (context *storageContext) GetStorageFromAddress(address []byte, key []byte) []byte {
+// Reading the storage of another contract which is not readable returns an error if the code metadata is enforced.
+func (context *storageContext) GetStorageFromAddress(address []byte, key []byte) ([]byte, error) {
 	metering := context.host.Metering()
 
 	extraBytes := len(key) - vmhost.AddressLen
@@ -124,12 +125,17 @@ func (context *storageContext) GetStorageFromAddress(address []byte, key []byte)
 	if !bytes.Equal(address, context.address) {
 		userAcc, err := context.blockChainHook.GetUserAccount(address)
 		if err != nil || check.IfNil(userAcc) {
-			return nil
+			return nil, nil
 		}
 
 		metadata := vmcommon.CodeMetadataFromBytes(userAcc.GetCodeMetadata())
 		if !metadata.Readable {
-			return nil
+			isContract := context.blockChainHook.IsSmartContract(address)
+			if isContract && context.host.IsCodeMetadataEnforcementEnabled() {
+				logStorage.Trace("get from address", "address", address, "error", vmhost.ErrStorageNotReadable)
+				return nil, vmhost.ErrStorageNotReadable
+			}
+			return nil, nil
 		}
 	}
 
@@ -150,7 +156,7 @@ func (context *storageContext) GetStorageFromAddress(address []byte, key []byte)
 	metering.UseGas(gasToUse)
 
 	logStorage.Trace("get from address", "address", address, "key", key, "value", value)
-	return value
+	return value, nil
 }
 
 func (context *storageContext) getStorageFromAddressUnmetered(address []byte, key []byte) []byte {
@@ -282,19 +282,35 @@ func TestStorageContext_GetStorageFromAddress(t *testing.T) {
 		GetStorageDataCalled: func(accountsAddress []byte, index []byte) ([]byte, uint32, error) {
 			return internalData, 0, nil
 		},
+		IsSmartContractCalled: func(address []byte) bool {
+			return true
+		},
 	}
 
 	storageContext, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
 	storageContext.SetAddress(scAddress)
 
 	key := []byte("key")
-	data := storageContext.GetStorageFromAddress(scAddress, key)
+	data, err := storageContext.GetStorageFromAddress(scAddress, key)
+	require.Nil(t, err)
+	require.Equal(t, data, internalData)
+
+	data, err = storageContext.GetStorageFromAddress(readable, key)
+	require.Nil(t, err)
 	require.Equal(t, data, internalData)
 
-	data = storageContext.GetStorageFromAddress(readable, key)
+	data, err = storageContext.GetStorageFromAddress(nonreadable, key)
+	require.Nil(t, err)
+	require.Nil(t, data)
+
+	host.CodeMetadataEnforcementEnabled = true
+
+	data, err = storageContext.GetStorageFromAddress(readable, key)
+	require.Nil(t, err)
 	require.Equal(t, data, internalData)
 
-	data = storageContext.GetStorageFromAddress(nonreadable, key)
+	data, err = storageContext.GetStorageFromAddress(nonreadable, key)
+	require.Equal(t, vmhost.ErrStorageNotReadable, err)
 	require.Nil(t, data)
 }
 
@@ -2,10 +2,14 @@ package contexts
 
 import (
 	"fmt"
+	builtinMath "math"
 	"unicode"
 
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/floatscan"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/wasmparser"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/wasmer"
 )
 
@@ -14,15 +18,210 @@ const noArity = -1
 // wasmValidator is a validator for WASM SmartContracts
 type wasmValidator struct {
 	reserved *reservedFunctions
+	rules    *config.ContractValidationRules
 }
 
-// newWASMValidator creates a new WASMValidator
-func newWASMValidator(scAPINames vmcommon.FunctionNames, protocolBuiltinFunctions vmcommon.FunctionNames) *wasmValidator {
+// newWASMValidator creates a new WASMValidator; the validation rules are optional
+func newWASMValidator(
+	scAPINames vmcommon.FunctionNames,
+	protocolBuiltinFunctions vmcommon.FunctionNames,
+	rules *config.ContractValidationRules,
+) *wasmValidator {
 	return &wasmValidator{
 		reserved: NewReservedFunctions(scAPINames, protocolBuiltinFunctions),
+		rules:    rules,
 	}
 }
 
+// contractCode is the code of a contract checked before compilation, decoded
+// at most once for all the checks which need its module
+type contractCode struct {
+	bytes     []byte
+	parsed    *wasmparser.Module
+	parsedErr error
+}
+
+func newContractCode(code []byte) *contractCode {
+	return &contractCode{bytes: code}
+}
+
+func (code *contractCode) module() (*wasmparser.Module, error) {
+	if code.parsed == nil && code.parsedErr == nil {
+		code.parsed, code.parsedErr = wasmparser.ParseModule(code.bytes)
+		if code.parsedErr != nil {
+			code.parsedErr = vmhost.NewContractValidationError(vmhost.RuleWellFormedModule, "%v", code.parsedErr)
+		}
+	}
+
+	return code.parsed, code.parsedErr
+}
+
+// verifyContractRules checks the contract code against the configured
+// ContractValidationRules, before any Wasmer instance is created for it
+func (validator *wasmValidator) verifyContractRules(code *contractCode, epoch uint32) error {
+	if validator.rules == nil {
+		return nil
+	}
+
+	if validator.rules.MaxCodeSize > 0 && uint64(len(code.bytes)) > validator.rules.MaxCodeSize {
+		return vmhost.NewContractValidationError(vmhost.RuleMaxCodeSize,
+			"code has %d bytes, the limit is %d", len(code.bytes), validator.rules.MaxCodeSize)
+	}
+
+	module, err := code.module()
+	if err != nil {
+		return err
+	}
+
+	verifications := []func(*wasmparser.Module) error{
+		validator.verifyFunctionsCount,
+		validator.verifyExportsCount,
+		validator.verifyMemoryPages,
+		validator.verifyTableSize,
+		func(module *wasmparser.Module) error {
+			return validator.verifyAllowedImports(module, epoch)
+		},
+		validator.verifyNoStartSection,
+		validator.verifyNoFloatingPoint,
+	}
+
+	for _, verify := range verifications {
+		err = verify(module)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyFunctionsCount(module *wasmparser.Module) error {
+	maxFunctions := validator.rules.MaxFunctions
+	if maxFunctions > 0 && uint32(len(module.Functions)) > maxFunctions {
+		return vmhost.NewContractValidationError(vmhost.RuleMaxFunctions,
+			"code defines %d functions, the limit is %d", len(module.Functions), maxFunctions)
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyExportsCount(module *wasmparser.Module) error {
+	maxExports := validator.rules.MaxExports
+	if maxExports > 0 && uint32(len(module.Exports)) > maxExports {
+		return vmhost.NewContractValidationError(vmhost.RuleMaxExports,
+			"code has %d exports, the limit is %d", len(module.Exports), maxExports)
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyMemoryPages(module *wasmparser.Module) error {
+	maxMemoryPages := validator.rules.MaxMemoryPages
+	if maxMemoryPages == 0 {
+		return nil
+	}
+
+	memories := module.Memories
+	for _, imp := range module.Imports {
+		if imp.Kind == wasmparser.ExternalMemory {
+			memories = append(memories, imp.Memory)
+		}
+	}
+
+	for _, memory := range memories {
+		if memory.Initial > maxMemoryPages {
+			return vmhost.NewContractValidationError(vmhost.RuleMaxMemoryPages,
+				"memory declares %d initial pages, the limit is %d", memory.Initial, maxMemoryPages)
+		}
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyTableSize(module *wasmparser.Module) error {
+	maxTableSize := validator.rules.MaxTableSize
+	if maxTableSize == 0 {
+		return nil
+	}
+
+	tables := module.Tables
+	for _, imp := range module.Imports {
+		if imp.Kind == wasmparser.ExternalTable {
+			table := imp.Table
+			tables = append(tables, &table)
+		}
+	}
+
+	for _, table := range tables {
+		if table.Limits.Initial > maxTableSize {
+			return vmhost.NewContractValidationError(vmhost.RuleMaxTableSize,
+				"table declares %d initial elements, the limit is %d", table.Limits.Initial, maxTableSize)
+		}
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyAllowedImports(module *wasmparser.Module, epoch uint32) error {
+	allowedImports := validator.rules.AllowedImportsInEpoch(epoch)
+	if allowedImports == nil {
+		return nil
+	}
+
+	for _, name := range module.ImportedFunctionNames() {
+		_, isAllowed := allowedImports[name]
+		if !isAllowed {
+			return vmhost.NewContractValidationError(vmhost.RuleAllowedImports,
+				"import %s is not allowed in epoch %d", name, epoch)
+		}
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyNoStartSection(module *wasmparser.Module) error {
+	if validator.rules.ForbidStartSection && module.HasStart {
+		return vmhost.NewContractValidationError(vmhost.RuleNoStartSection,
+			"code declares function %d as start function", module.StartFunction)
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyNoFloatingPoint(module *wasmparser.Module) error {
+	if !validator.rules.ForbidFloatingPoint {
+		return nil
+	}
+
+	return verifyNoFloatingPointUsage(module)
+}
+
+// verifyDeterministicCode rejects the contract code if it involves floating-point
+// values in any way; it does not depend on the configured validation rules
+func (validator *wasmValidator) verifyDeterministicCode(code *contractCode) error {
+	module, err := code.module()
+	if err != nil {
+		return err
+	}
+
+	return verifyNoFloatingPointUsage(module)
+}
+
+func verifyNoFloatingPointUsage(module *wasmparser.Module) error {
+	report, err := floatscan.ScanModule(module)
+	if err != nil {
+		return vmhost.NewContractValidationError(vmhost.RuleWellFormedModule, "%v", err)
+	}
+
+	if !report.HasFloatingPoint() {
+		return nil
+	}
+
+	return vmhost.NewContractValidationError(vmhost.RuleNoFloatingPoint,
+		"floating-point usage in functions %v, signatures %v, globals %v",
+		report.FunctionIndices(), report.Signatures, report.Globals)
+}
+
 func (validator *wasmValidator) verifyMemoryDeclaration(instance wasmer.InstanceHandler) error {
 	if !instance.HasMemory() {
 		return vmhost.ErrMemoryDeclarationMissing
@@ -112,3 +311,53 @@ func isASCIIString(input string) bool {
 
 	return true
 }
+
+// ValidatorCheckResult holds the outcome of one of the checks performed on contract code at deploy
+type ValidatorCheckResult struct {
+	Check string
+	Err   error
+	// Skipped is set for the checks not performed at deploy in the current configuration
+	Skipped bool
+}
+
+// RunValidatorChecks runs, outside of any execution, the checks performed by the validator on
+// contract code at deploy, and reports the outcome of each of them; the checks depending on
+// enable-epoch flags or on the epoch follow the current epoch of the host
+func RunValidatorChecks(
+	host vmhost.VMHost,
+	code []byte,
+	rules *config.ContractValidationRules,
+) []*ValidatorCheckResult {
+	validator := newWASMValidator(host.GetAPIMethods().Names(), host.GetProtocolBuiltinFunctions(), rules)
+	epoch := host.Blockchain().CurrentEpoch()
+
+	checkedCode := newContractCode(code)
+	results := []*ValidatorCheckResult{
+		{Check: "contract rules", Err: validator.verifyContractRules(checkedCode, epoch), Skipped: rules == nil},
+	}
+
+	if host.IsFloatingPointRejectionEnabled() {
+		results = append(results, &ValidatorCheckResult{Check: "deterministic code", Err: validator.verifyDeterministicCode(checkedCode)})
+	} else {
+		results = append(results, &ValidatorCheckResult{Check: "deterministic code", Skipped: true})
+	}
+
+	options := wasmer.CompilationOptions{
+		GasLimit:           builtinMath.MaxUint64,
+		MaxMemoryGrow:      MaxMemoryGrow,
+		MaxMemoryGrowDelta: MaxMemoryGrowDelta,
+		OpcodeTrace:        false,
+		Metering:           true,
+		RuntimeBreakpoints: true,
+	}
+	instance, err := wasmer.NewInstanceWithOptions(code, options)
+	if err != nil {
+		return append(results, &ValidatorCheckResult{Check: "compilation", Err: err})
+	}
+	defer instance.Clean()
+
+	return append(results,
+		&ValidatorCheckResult{Check: "memory declaration", Err: validator.verifyMemoryDeclaration(instance)},
+		&ValidatorCheckResult{Check: "exported functions", Err: validator.verifyFunctions(instance)},
+	)
+}
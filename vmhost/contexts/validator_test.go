@@ -1,10 +1,12 @@
 package contexts
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/wasmer"
 	"github.com/stretchr/testify/require"
@@ -18,7 +20,7 @@ func TestFunctionsGuard_isValidFunctionName(t *testing.T) {
 		"fromProtocolBar": {},
 	}
 
-	validator := newWASMValidator(imports.Names(), protocolBuiltinFunctions)
+	validator := newWASMValidator(imports.Names(), protocolBuiltinFunctions, nil)
 
 	require.Nil(t, validator.verifyValidFunctionName("foo"))
 	require.Nil(t, validator.verifyValidFunctionName("_"))
@@ -45,7 +47,7 @@ func TestFunctionsGuard_Arity(t *testing.T) {
 	host := InitializeVMAndWasmer()
 	imports := host.SCAPIMethods
 
-	validator := newWASMValidator(imports.Names(), make(vmcommon.FunctionNames))
+	validator := newWASMValidator(imports.Names(), make(vmcommon.FunctionNames), nil)
 
 	gasLimit := uint64(100000000)
 	path := "./../../test/contracts/signatures/output/signatures.wasm"
@@ -95,3 +97,87 @@ func TestFunctionsGuard_Arity(t *testing.T) {
 	err = validator.verifyVoidFunction(instance, "wrongParamsAndReturn")
 	require.NotNil(t, err)
 }
+
+func requireContractValidationError(t *testing.T, err error, rule vmhost.ContractValidationRule) {
+	require.NotNil(t, err)
+	require.True(t, errors.Is(err, vmhost.ErrContractInvalid))
+
+	var validationErr *vmhost.ContractValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Equal(t, rule, validationErr.Rule)
+	require.NotEmpty(t, validationErr.Reason)
+}
+
+func TestFunctionsGuard_ContractRules(t *testing.T) {
+	imports := MakeAPIImports()
+	counterCode := vmhost.GetSCCode("./../../test/contracts/counter/output/counter.wasm")
+	floatCode := vmhost.GetSCCode("./../../test/contracts/num-with-fp/output/num-with-fp.wasm")
+
+	validator := newWASMValidator(imports.Names(), make(vmcommon.FunctionNames), nil)
+	require.Nil(t, validator.verifyContractRules(newContractCode(counterCode), 0))
+	require.Nil(t, validator.verifyContractRules(newContractCode(floatCode), 0))
+
+	validator = newWASMValidator(imports.Names(), make(vmcommon.FunctionNames), &config.ContractValidationRules{})
+	require.Nil(t, validator.verifyContractRules(newContractCode(counterCode), 0))
+	requireContractValidationError(t, validator.verifyContractRules(newContractCode([]byte("not wasm")), 0), vmhost.RuleWellFormedModule)
+
+	validator.rules = &config.ContractValidationRules{MaxCodeSize: uint64(len(counterCode) - 1)}
+	requireContractValidationError(t, validator.verifyContractRules(newContractCode(counterCode), 0), vmhost.RuleMaxCodeSize)
+
+	validator.rules = &config.ContractValidationRules{MaxFunctions: 1}
+	requireContractValidationError(t, validator.verifyContractRules(newContractCode(counterCode), 0), vmhost.RuleMaxFunctions)
+
+	validator.rules = &config.ContractValidationRules{MaxExports: 1}
+	requireContractValidationError(t, validator.verifyContractRules(newContractCode(counterCode), 0), vmhost.RuleMaxExports)
+
+	validator.rules = &config.ContractValidationRules{ForbidFloatingPoint: true}
+	require.Nil(t, validator.verifyContractRules(newContractCode(counterCode), 0))
+	requireContractValidationError(t, validator.verifyContractRules(newContractCode(floatCode), 0), vmhost.RuleNoFloatingPoint)
+}
+
+func TestFunctionsGuard_AllowedImportsByEpoch(t *testing.T) {
+	imports := MakeAPIImports()
+	counterCode := vmhost.GetSCCode("./../../test/contracts/counter/output/counter.wasm")
+
+	rules := &config.ContractValidationRules{
+		AllowedImports: []config.AllowedImportsByEpoch{
+			{EnableEpoch: 0, Names: []string{"int64storageLoad", "int64finish"}},
+			{EnableEpoch: 5, Names: []string{"int64storageStore"}},
+		},
+	}
+	validator := newWASMValidator(imports.Names(), make(vmcommon.FunctionNames), rules)
+
+	err := validator.verifyContractRules(newContractCode(counterCode), 4)
+	requireContractValidationError(t, err, vmhost.RuleAllowedImports)
+	require.Contains(t, err.Error(), "int64storageStore")
+
+	require.Nil(t, validator.verifyContractRules(newContractCode(counterCode), 5))
+}
+
+func TestFunctionsGuard_DeterministicCode(t *testing.T) {
+	imports := MakeAPIImports()
+	counterCode := vmhost.GetSCCode("./../../test/contracts/counter/output/counter.wasm")
+	floatCode := vmhost.GetSCCode("./../../test/contracts/num-with-fp/output/num-with-fp.wasm")
+
+	validator := newWASMValidator(imports.Names(), make(vmcommon.FunctionNames), nil)
+	require.Nil(t, validator.verifyDeterministicCode(newContractCode(counterCode)))
+
+	err := validator.verifyDeterministicCode(newContractCode(floatCode))
+	requireContractValidationError(t, err, vmhost.RuleNoFloatingPoint)
+	require.Contains(t, err.Error(), "functions [")
+}
+
+func TestContractCode_ParsedOnce(t *testing.T) {
+	counterCode := newContractCode(vmhost.GetSCCode("./../../test/contracts/counter/output/counter.wasm"))
+	module, err := counterCode.module()
+	require.Nil(t, err)
+	again, err := counterCode.module()
+	require.Nil(t, err)
+	require.True(t, module == again)
+
+	invalidCode := newContractCode([]byte("not wasm"))
+	_, err = invalidCode.module()
+	requireContractValidationError(t, err, vmhost.RuleWellFormedModule)
+	_, err = invalidCode.module()
+	requireContractValidationError(t, err, vmhost.RuleWellFormedModule)
+}
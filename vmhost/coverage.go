@@ -0,0 +1,27 @@
+package vmhost
+
+// CoverageHookModule is the import module of the hook called by the functions of the instrumented contracts
+const CoverageHookModule = "env"
+
+// CoverageHookName is the name of the hook called by the functions of the instrumented contracts,
+// with the code identifier given by the CoverageCollector and the index of the function entered;
+// it is also called before the VM hooks, with their index among the imported functions
+const CoverageHookName = "coverageFunctionEntered"
+
+// CoverageCollector records which parts of the contracts run on a host, for coverage reports;
+// a collector can be shared by several hosts
+type CoverageCollector interface {
+	// InstrumentCode returns the code to compile instead of the given contract code,
+	// whose functions call the coverage hook when entered
+	InstrumentCode(code []byte) ([]byte, error)
+
+	// ContractStarted signals that an instance of the given code starts at the given address
+	ContractStarted(address []byte, code []byte)
+
+	// ExportCalled signals that the exported function of the contract at the given address is called
+	ExportCalled(address []byte, function string)
+
+	// FunctionEntered signals that a function of the instrumented code is entered,
+	// or that a VM hook is called, when the index is the one of an imported function
+	FunctionEntered(codeID int32, functionIndex int32)
+}
@@ -0,0 +1,227 @@
+package coverage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/wasmparser"
+)
+
+// shortHashLength is the number of bytes of the code hash naming the contracts without a better name
+const shortHashLength = 8
+
+var _ vmhost.CoverageCollector = (*Collector)(nil)
+
+// Collector records the coverage of the contracts run by the hosts it is given to, per contract code.
+// It is safe for concurrent use, so a single Collector can be shared by hosts running in parallel.
+type Collector struct {
+	mutex              sync.Mutex
+	contracts          []*contractCoverage
+	contractsByHash    map[string]*contractCoverage
+	contractsByAddress map[string]*contractCoverage
+	codeNames          map[string]string
+}
+
+type contractCoverage struct {
+	codeID           int32
+	codeHash         []byte
+	moduleName       string
+	instrumentedCode []byte
+
+	numImportedFunctions uint32
+	functionNames        []string
+	exports              []string
+	hooks                []string
+
+	functionEntries []uint64
+	exportCalls     map[string]uint64
+	calledHooks     map[string]struct{}
+}
+
+// NewCollector creates an empty coverage Collector
+func NewCollector() *Collector {
+	return &Collector{
+		contracts:          make([]*contractCoverage, 0),
+		contractsByHash:    make(map[string]*contractCoverage),
+		contractsByAddress: make(map[string]*contractCoverage),
+		codeNames:          make(map[string]string),
+	}
+}
+
+// NameCode sets the name under which the contract with the given code appears in the reports, e.g. its file;
+// without it, the module name from the name section or a short code hash is used
+func (collector *Collector) NameCode(code []byte, name string) {
+	codeHash := sha256.Sum256(code)
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	collector.codeNames[string(codeHash[:])] = name
+}
+
+// InstrumentCode returns the given code with each defined function calling the coverage hook when entered
+func (collector *Collector) InstrumentCode(code []byte) ([]byte, error) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	contract, err := collector.getOrAddContract(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if contract.instrumentedCode == nil {
+		hook := wasmparser.FunctionEntryHook{
+			Module:       vmhost.CoverageHookModule,
+			Name:         vmhost.CoverageHookName,
+			HookArgument: contract.codeID,
+		}
+		contract.instrumentedCode, err = wasmparser.InstrumentFunctionEntries(code, hook)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return contract.instrumentedCode, nil
+}
+
+// ContractStarted associates the address with the code, for the exports and hooks called at the address
+func (collector *Collector) ContractStarted(address []byte, code []byte) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	contract, err := collector.getOrAddContract(code)
+	if err != nil {
+		return
+	}
+
+	collector.contractsByAddress[string(address)] = contract
+}
+
+// ExportCalled counts a call of the exported function of the contract at the given address
+func (collector *Collector) ExportCalled(address []byte, function string) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	contract, ok := collector.contractsByAddress[string(address)]
+	if !ok {
+		return
+	}
+
+	contract.exportCalls[function]++
+}
+
+// FunctionEntered counts an entry in the function with the given index of the code instrumented under the given
+// identifier; the index of an imported function signals a call of that VM hook
+func (collector *Collector) FunctionEntered(codeID int32, functionIndex int32) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	if codeID < 0 || int(codeID) >= len(collector.contracts) || functionIndex < 0 {
+		return
+	}
+
+	contract := collector.contracts[codeID]
+	if uint32(functionIndex) < contract.numImportedFunctions {
+		contract.calledHooks[contract.hooks[functionIndex]] = struct{}{}
+		return
+	}
+
+	definedIndex := int64(functionIndex) - int64(contract.numImportedFunctions)
+	if definedIndex >= int64(len(contract.functionEntries)) {
+		return
+	}
+
+	contract.functionEntries[definedIndex]++
+}
+
+func (collector *Collector) getOrAddContract(code []byte) (*contractCoverage, error) {
+	codeHash := sha256.Sum256(code)
+	contract, ok := collector.contractsByHash[string(codeHash[:])]
+	if ok {
+		return contract, nil
+	}
+
+	contract, err := newContractCoverage(code, codeHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	contract.codeID = int32(len(collector.contracts))
+	collector.contracts = append(collector.contracts, contract)
+	collector.contractsByHash[string(codeHash[:])] = contract
+
+	return contract, nil
+}
+
+func newContractCoverage(code []byte, codeHash []byte) (*contractCoverage, error) {
+	module, err := wasmparser.ParseModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	// the names are only informative, so those decoded before an error are kept
+	names, _ := module.Names()
+
+	contract := &contractCoverage{
+		codeHash:             codeHash,
+		moduleName:           names.ModuleName,
+		numImportedFunctions: module.ImportedFunctionsCount(),
+		functionNames:        make([]string, len(module.Functions)),
+		exports:              make([]string, 0, len(module.Exports)),
+		hooks:                module.ImportedFunctionNames(),
+		functionEntries:      make([]uint64, len(module.Functions)),
+		exportCalls:          make(map[string]uint64),
+		calledHooks:          make(map[string]struct{}),
+	}
+
+	exportNames := make(map[uint32]string)
+	for _, export := range module.Exports {
+		if export.Kind != wasmparser.ExternalFunction {
+			continue
+		}
+		contract.exports = append(contract.exports, export.Name)
+		if _, ok := exportNames[export.Index]; !ok {
+			exportNames[export.Index] = export.Name
+		}
+	}
+	sort.Strings(contract.exports)
+
+	for definedIndex := range contract.functionNames {
+		functionIndex := contract.numImportedFunctions + uint32(definedIndex)
+		contract.functionNames[definedIndex] = functionName(functionIndex, names, exportNames)
+	}
+
+	return contract, nil
+}
+
+// functionName prefers the debug name of the function, then the name under which it is exported
+func functionName(functionIndex uint32, names *wasmparser.Names, exportNames map[uint32]string) string {
+	name, ok := names.FunctionNames[functionIndex]
+	if ok && len(name) > 0 {
+		return name
+	}
+
+	name, ok = exportNames[functionIndex]
+	if ok {
+		return name
+	}
+
+	return fmt.Sprintf("function[%d]", functionIndex)
+}
+
+func (collector *Collector) contractName(contract *contractCoverage) string {
+	name, ok := collector.codeNames[string(contract.codeHash)]
+	if ok {
+		return name
+	}
+
+	if len(contract.moduleName) > 0 {
+		return contract.moduleName
+	}
+
+	return hex.EncodeToString(contract.codeHash[:shortHashLength])
+}
@@ -0,0 +1,158 @@
+package coverage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/wasmparser"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestContract(t *testing.T, name string) []byte {
+	path := filepath.Join("..", "..", "test", "contracts", name, "output", name+".wasm")
+	code, err := os.ReadFile(path)
+	require.Nil(t, err)
+	return code
+}
+
+func findExport(t *testing.T, module *wasmparser.Module, name string) *wasmparser.Export {
+	for _, export := range module.Exports {
+		if export.Name == name {
+			return export
+		}
+	}
+	require.Fail(t, "export not found", name)
+	return nil
+}
+
+func findItem(summary *CoverageSummary, name string) *CoverageItem {
+	for _, item := range summary.Items {
+		if item.Name == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestCollector_InstrumentCode(t *testing.T) {
+	code := loadTestContract(t, "counter")
+	collector := NewCollector()
+
+	instrumented, err := collector.InstrumentCode(code)
+	require.Nil(t, err)
+
+	module, err := wasmparser.ParseModule(instrumented)
+	require.Nil(t, err)
+	lastImport := module.Imports[len(module.Imports)-1]
+	require.Equal(t, vmhost.CoverageHookModule, lastImport.Module)
+	require.Equal(t, vmhost.CoverageHookName, lastImport.Name)
+
+	again, err := collector.InstrumentCode(code)
+	require.Nil(t, err)
+	require.Equal(t, instrumented, again)
+	require.Len(t, collector.Report().Contracts, 1)
+
+	_, err = collector.InstrumentCode([]byte("not wasm"))
+	require.NotNil(t, err)
+}
+
+func TestCollector_Report(t *testing.T) {
+	code := loadTestContract(t, "counter")
+	module, err := wasmparser.ParseModule(code)
+	require.Nil(t, err)
+
+	collector := NewCollector()
+	collector.NameCode(code, "counter.wasm")
+	_, err = collector.InstrumentCode(code)
+	require.Nil(t, err)
+
+	address := []byte("counter-address")
+	collector.ContractStarted(address, code)
+
+	increment := findExport(t, module, "increment")
+	collector.ExportCalled(address, "increment")
+	collector.ExportCalled(address, "increment")
+	collector.FunctionEntered(0, int32(increment.Index))
+	collector.FunctionEntered(0, int32(increment.Index))
+	hook := module.ImportedFunctionNames()[0]
+	collector.FunctionEntered(0, 0)
+	collector.FunctionEntered(0, 0)
+
+	// ignored: unknown address, code and function
+	collector.ExportCalled([]byte("other"), "increment")
+	collector.FunctionEntered(1, 0)
+	collector.FunctionEntered(0, int32(len(module.ImportedFunctionNames())+len(module.Functions)))
+	collector.FunctionEntered(0, -1)
+
+	report := collector.Report()
+	require.Len(t, report.Contracts, 1)
+	contract := report.Contracts[0]
+	require.Equal(t, "counter.wasm", contract.Name)
+
+	require.Equal(t, 1, contract.Exports.Covered)
+	require.Equal(t, len(contract.Exports.Items), contract.Exports.Total)
+	require.Equal(t, &CoverageItem{Name: "increment", Covered: true, Calls: 2}, findItem(contract.Exports, "increment"))
+	require.False(t, findItem(contract.Exports, "decrement").Covered)
+
+	require.Equal(t, 1, contract.Functions.Covered)
+	require.Equal(t, len(module.Functions), contract.Functions.Total)
+	require.Equal(t, uint64(2), findItem(contract.Functions, "increment").Calls)
+
+	require.Equal(t, 1, contract.Hooks.Covered)
+	require.Equal(t, len(module.ImportedFunctionNames()), contract.Hooks.Total)
+	require.Equal(t, &CoverageItem{Name: hook, Covered: true}, findItem(contract.Hooks, hook))
+}
+
+func TestCollector_Concurrent(t *testing.T) {
+	code := loadTestContract(t, "counter")
+	collector := NewCollector()
+	_, err := collector.InstrumentCode(code)
+	require.Nil(t, err)
+
+	numWorkers := 8
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			address := []byte("counter-address")
+			collector.ContractStarted(address, code)
+			collector.ExportCalled(address, "increment")
+		}()
+	}
+	wg.Wait()
+
+	increment := findItem(collector.Report().Contracts[0].Exports, "increment")
+	require.Equal(t, uint64(numWorkers), increment.Calls)
+}
+
+func TestReport_Write(t *testing.T) {
+	code := loadTestContract(t, "counter")
+	collector := NewCollector()
+	collector.NameCode(code, "<counter>")
+	collector.ContractStarted([]byte("address"), code)
+	collector.ExportCalled([]byte("address"), "increment")
+	report := collector.Report()
+
+	jsonOutput := &bytes.Buffer{}
+	err := report.WriteJSON(jsonOutput)
+	require.Nil(t, err)
+	require.True(t, strings.Contains(jsonOutput.String(), `"name": "<counter>"`))
+	require.True(t, strings.Contains(jsonOutput.String(), `"calls": 1`))
+
+	htmlOutput := &bytes.Buffer{}
+	err = report.WriteHTML(htmlOutput)
+	require.Nil(t, err)
+	require.True(t, strings.Contains(htmlOutput.String(), "&lt;counter&gt;"))
+	require.True(t, strings.Contains(htmlOutput.String(), "<code>increment</code>"))
+}
+
+func TestCoverageSummary_Percent(t *testing.T) {
+	require.Equal(t, float64(100), (&CoverageSummary{}).Percent())
+	require.Equal(t, float64(25), (&CoverageSummary{Covered: 1, Total: 4}).Percent())
+}
@@ -0,0 +1,179 @@
+package coverage
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"html/template"
+	"io"
+	"sort"
+)
+
+// Report is the coverage of each contract code run while collecting, sorted by contract name
+type Report struct {
+	Contracts []*ContractReport `json:"contracts"`
+}
+
+// ContractReport is the coverage of a contract code
+type ContractReport struct {
+	Name      string           `json:"name"`
+	CodeHash  string           `json:"codeHash"`
+	Exports   *CoverageSummary `json:"exports"`
+	Functions *CoverageSummary `json:"functions"`
+	Hooks     *CoverageSummary `json:"hooks"`
+}
+
+// CoverageSummary lists the exports, functions or VM hooks of a contract, and how many of them were covered
+type CoverageSummary struct {
+	Covered int             `json:"covered"`
+	Total   int             `json:"total"`
+	Items   []*CoverageItem `json:"items"`
+}
+
+// CoverageItem is an export, function or VM hook of a contract;
+// the calls of the VM hooks are not counted, only whether they were called
+type CoverageItem struct {
+	Name    string `json:"name"`
+	Covered bool   `json:"covered"`
+	Calls   uint64 `json:"calls,omitempty"`
+}
+
+// Percent yields the percentage of the items covered, 100 when there are none
+func (summary *CoverageSummary) Percent() float64 {
+	if summary.Total == 0 {
+		return 100
+	}
+	return float64(summary.Covered) * 100 / float64(summary.Total)
+}
+
+func (summary *CoverageSummary) add(name string, calls uint64, covered bool) {
+	summary.Items = append(summary.Items, &CoverageItem{
+		Name:    name,
+		Covered: covered,
+		Calls:   calls,
+	})
+	summary.Total++
+	if covered {
+		summary.Covered++
+	}
+}
+
+func newCoverageSummary() *CoverageSummary {
+	return &CoverageSummary{
+		Items: make([]*CoverageItem, 0),
+	}
+}
+
+// Report aggregates the coverage collected so far
+func (collector *Collector) Report() *Report {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	report := &Report{
+		Contracts: make([]*ContractReport, 0, len(collector.contracts)),
+	}
+	for _, contract := range collector.contracts {
+		report.Contracts = append(report.Contracts, collector.contractReport(contract))
+	}
+
+	sort.SliceStable(report.Contracts, func(i, j int) bool {
+		return report.Contracts[i].Name < report.Contracts[j].Name
+	})
+
+	return report
+}
+
+func (collector *Collector) contractReport(contract *contractCoverage) *ContractReport {
+	contractReport := &ContractReport{
+		Name:      collector.contractName(contract),
+		CodeHash:  hex.EncodeToString(contract.codeHash),
+		Exports:   newCoverageSummary(),
+		Functions: newCoverageSummary(),
+		Hooks:     newCoverageSummary(),
+	}
+
+	for _, export := range contract.exports {
+		calls := contract.exportCalls[export]
+		contractReport.Exports.add(export, calls, calls > 0)
+	}
+
+	for definedIndex, name := range contract.functionNames {
+		calls := contract.functionEntries[definedIndex]
+		contractReport.Functions.add(name, calls, calls > 0)
+	}
+
+	for _, hook := range contract.hooks {
+		_, called := contract.calledHooks[hook]
+		contractReport.Hooks.add(hook, 0, called)
+	}
+
+	return contractReport
+}
+
+// WriteJSON writes the report as indented JSON
+func (report *Report) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// WriteHTML writes the report as a standalone HTML page
+func (report *Report) WriteHTML(writer io.Writer) error {
+	return htmlReportTemplate.Execute(writer, report)
+}
+
+// htmlSection is the detail of a CoverageSummary in the HTML report
+type htmlSection struct {
+	Title   string
+	Summary *CoverageSummary
+}
+
+var htmlTemplateFunctions = template.FuncMap{
+	"section": func(title string, summary *CoverageSummary) *htmlSection {
+		return &htmlSection{Title: title, Summary: summary}
+	},
+}
+
+var htmlReportTemplate = template.Must(template.New("coverage").Funcs(htmlTemplateFunctions).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Contract coverage</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.6em; text-align: left; }
+td.count { text-align: right; }
+.covered { background: #dfd; }
+.missed { background: #fdd; }
+details { margin-bottom: 1.5em; }
+code { font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Contract coverage</h1>
+<table>
+<tr><th>Contract</th><th>Exports</th><th>Functions</th><th>VM hooks</th></tr>
+{{- range .Contracts}}
+<tr><td><a href="#{{.CodeHash}}">{{.Name}}</a></td>{{template "summary" .Exports}}{{template "summary" .Functions}}{{template "summary" .Hooks}}</tr>
+{{- end}}
+</table>
+{{- range .Contracts}}
+<h2 id="{{.CodeHash}}">{{.Name}}</h2>
+<p>Code hash <code>{{.CodeHash}}</code></p>
+{{template "items" (section "Exports" .Exports)}}
+{{template "items" (section "Functions" .Functions)}}
+{{template "items" (section "VM hooks" .Hooks)}}
+{{- end}}
+</body>
+</html>
+{{define "summary"}}<td>{{.Covered}} / {{.Total}} ({{printf "%.1f" .Percent}}%)</td>{{end}}
+{{define "items"}}<details>
+<summary>{{.Title}}: {{.Summary.Covered}} / {{.Summary.Total}}</summary>
+<table>
+{{- range .Summary.Items}}
+<tr class="{{if .Covered}}covered{{else}}missed{{end}}"><td><code>{{.Name}}</code></td><td class="count">{{if .Calls}}{{.Calls}}{{else if .Covered}}&#10003;{{end}}</td></tr>
+{{- end}}
+</table>
+</details>{{end}}
+`))
@@ -83,6 +83,9 @@ var ErrMemoryDeclarationMissing = fmt.Errorf("%w (missing memory declaration)",
 // ErrMaxInstancesReached signals that the max number of Wasmer instances has been reached.
 var ErrMaxInstancesReached = fmt.Errorf("%w (max instances reached)", ErrExecutionFailed)
 
+// ErrMaxMemoryPagesReached signals that the running Wasmer instances use more memory pages than allowed.
+var ErrMaxMemoryPagesReached = fmt.Errorf("%w (max memory pages reached)", ErrExecutionFailed)
+
 // ErrStoreReservedKey signals that an attempt to write under an reserved key has been made
 var ErrStoreReservedKey = errors.New("cannot write to storage under reserved key")
 
@@ -125,6 +128,15 @@ var ErrDeploymentOverExistingAccount = errors.New("cannot deploy over existing a
 // ErrAccountNotPayable signals that the value transfer to a non payable contract is not possible
 var ErrAccountNotPayable = errors.New("sending value to non payable contract")
 
+// ErrAccountOnlyPayableBySC signals that a contract payable only by other contracts has received value from a user account
+var ErrAccountOnlyPayableBySC = fmt.Errorf("%w (only payable by smart contracts)", ErrAccountNotPayable)
+
+// ErrAccountNotPayableBySC signals that a contract has sent value to a contract which is not payable by smart contracts
+var ErrAccountNotPayableBySC = fmt.Errorf("%w (not payable by smart contracts)", ErrAccountNotPayable)
+
+// ErrStorageNotReadable signals that the storage of another contract was read, but that contract is not readable
+var ErrStorageNotReadable = errors.New("storage of the account is not readable")
+
 // ErrInvalidPublicKeySize signals that the public key size is invalid
 var ErrInvalidPublicKeySize = errors.New("invalid public key size")
 
@@ -134,6 +146,12 @@ var ErrNilCallbackFunction = errors.New("nil callback function")
 // ErrUpgradeNotAllowed signals that an upgrade is not allowed
 var ErrUpgradeNotAllowed = errors.New("upgrade not allowed")
 
+// ErrContractNotUpgradeable signals that an upgrade was attempted on a contract without the upgradeable flag
+var ErrContractNotUpgradeable = fmt.Errorf("%w (contract is not upgradeable)", ErrUpgradeNotAllowed)
+
+// ErrUpgradeCallerNotOwner signals that an upgrade was attempted by an account other than the contract owner
+var ErrUpgradeCallerNotOwner = fmt.Errorf("%w (caller is not the owner)", ErrUpgradeNotAllowed)
+
 // ErrNilContract signals that the contract is nil
 var ErrNilContract = errors.New("nil contract")
 
@@ -152,3 +170,61 @@ var ErrTransferValueOnESDTCall = errors.New("transfer value on esdt call")
 
 // ErrNilEnableEpochsHandler signals that enable epochs handler is nil
 var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrTooManyEventTopics signals that an event has more topics than the gas schedule allows
+var ErrTooManyEventTopics = errors.New("too many event topics")
+
+// ErrEventDataTooLarge signals that the data of an event is larger than the gas schedule allows
+var ErrEventDataTooLarge = errors.New("event data too large")
+
+// ErrEmptyEventIdentifier signals that an event was emitted without an identifier
+var ErrEmptyEventIdentifier = errors.New("empty event identifier")
+
+// ErrQueryChangesState signals that a query would have changed the state, thus its output is rejected
+var ErrQueryChangesState = errors.New("query changes the state")
+
+// ErrQueryQueueFull signals that a query was rejected, because too many queries wait for a free host
+var ErrQueryQueueFull = errors.New("too many queries waiting for execution")
+
+// ErrQueryTimeout signals that the query did not end before its timeout
+var ErrQueryTimeout = errors.New("query timeout")
+
+// ErrQueryExecutorClosed signals that the query executor was closed
+var ErrQueryExecutorClosed = errors.New("query executor closed")
+
+// ErrInvalidNumberOfHosts signals that a pool of hosts was configured without any host
+var ErrInvalidNumberOfHosts = errors.New("invalid number of hosts")
+
+// ErrNilContractCallInput signals that a nil contract call input has been provided
+var ErrNilContractCallInput = errors.New("nil contract call input")
+
+// ContractValidationError signals that the contract code breaks one of the ContractValidationRules;
+// it matches ErrContractInvalid when inspected with errors.Is()
+type ContractValidationError struct {
+	Rule   ContractValidationRule
+	Reason string
+}
+
+// NewContractValidationError creates a new ContractValidationError for the given rule
+func NewContractValidationError(rule ContractValidationRule, format string, args ...interface{}) *ContractValidationError {
+	return &ContractValidationError{
+		Rule:   rule,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error returns the human-readable description of the violation
+func (err *ContractValidationError) Error() string {
+	return fmt.Sprintf("%s (%s: %s)", ErrContractInvalid.Error(), err.Rule, err.Reason)
+}
+
+// Unwrap returns ErrContractInvalid
+func (err *ContractValidationError) Unwrap() error {
+	return ErrContractInvalid
+}
+
+// ErrNilBlockchainHook signals that a nil blockchain hook has been provided
+var ErrNilBlockchainHook = errors.New("nil blockchain hook")
+
+// ErrNilHostParameters signals that nil host parameters have been provided
+var ErrNilHostParameters = errors.New("nil host parameters")
@@ -0,0 +1,111 @@
+package floatscan
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/wasmparser"
+)
+
+// prefixedOpcode is the opcode of the instructions encoded as a prefix byte followed by a sub-opcode,
+// which is added to it
+const prefixedOpcode = int(wasmparser.OpcodePrefixMisc) << 8
+
+// floatOpcodeNames holds the text format names of the floating-point instructions, by opcode;
+// it only names the instructions, which are told apart by wasmparser.IsFloatInstruction
+var floatOpcodeNames = map[int]string{
+	0x2A:               "f32.load",
+	0x2B:               "f64.load",
+	0x38:               "f32.store",
+	0x39:               "f64.store",
+	0x43:               "f32.const",
+	0x44:               "f64.const",
+	0x5B:               "f32.eq",
+	0x5C:               "f32.ne",
+	0x5D:               "f32.lt",
+	0x5E:               "f32.gt",
+	0x5F:               "f32.le",
+	0x60:               "f32.ge",
+	0x61:               "f64.eq",
+	0x62:               "f64.ne",
+	0x63:               "f64.lt",
+	0x64:               "f64.gt",
+	0x65:               "f64.le",
+	0x66:               "f64.ge",
+	0x8B:               "f32.abs",
+	0x8C:               "f32.neg",
+	0x8D:               "f32.ceil",
+	0x8E:               "f32.floor",
+	0x8F:               "f32.trunc",
+	0x90:               "f32.nearest",
+	0x91:               "f32.sqrt",
+	0x92:               "f32.add",
+	0x93:               "f32.sub",
+	0x94:               "f32.mul",
+	0x95:               "f32.div",
+	0x96:               "f32.min",
+	0x97:               "f32.max",
+	0x98:               "f32.copysign",
+	0x99:               "f64.abs",
+	0x9A:               "f64.neg",
+	0x9B:               "f64.ceil",
+	0x9C:               "f64.floor",
+	0x9D:               "f64.trunc",
+	0x9E:               "f64.nearest",
+	0x9F:               "f64.sqrt",
+	0xA0:               "f64.add",
+	0xA1:               "f64.sub",
+	0xA2:               "f64.mul",
+	0xA3:               "f64.div",
+	0xA4:               "f64.min",
+	0xA5:               "f64.max",
+	0xA6:               "f64.copysign",
+	0xA8:               "i32.trunc_f32_s",
+	0xA9:               "i32.trunc_f32_u",
+	0xAA:               "i32.trunc_f64_s",
+	0xAB:               "i32.trunc_f64_u",
+	0xAE:               "i64.trunc_f32_s",
+	0xAF:               "i64.trunc_f32_u",
+	0xB0:               "i64.trunc_f64_s",
+	0xB1:               "i64.trunc_f64_u",
+	0xB2:               "f32.convert_i32_s",
+	0xB3:               "f32.convert_i32_u",
+	0xB4:               "f32.convert_i64_s",
+	0xB5:               "f32.convert_i64_u",
+	0xB6:               "f32.demote_f64",
+	0xB7:               "f64.convert_i32_s",
+	0xB8:               "f64.convert_i32_u",
+	0xB9:               "f64.convert_i64_s",
+	0xBA:               "f64.convert_i64_u",
+	0xBB:               "f64.promote_f32",
+	0xBC:               "i32.reinterpret_f32",
+	0xBD:               "i64.reinterpret_f64",
+	0xBE:               "f32.reinterpret_i32",
+	0xBF:               "f64.reinterpret_i64",
+	prefixedOpcode | 0: "i32.trunc_sat_f32_s",
+	prefixedOpcode | 1: "i32.trunc_sat_f32_u",
+	prefixedOpcode | 2: "i32.trunc_sat_f64_s",
+	prefixedOpcode | 3: "i32.trunc_sat_f64_u",
+	prefixedOpcode | 4: "i64.trunc_sat_f32_s",
+	prefixedOpcode | 5: "i64.trunc_sat_f32_u",
+	prefixedOpcode | 6: "i64.trunc_sat_f64_s",
+	prefixedOpcode | 7: "i64.trunc_sat_f64_u",
+}
+
+// floatOpcode returns the opcode and the name of the instruction, if it operates on f32 or f64 values
+func floatOpcode(instruction wasmparser.Instruction) (int, string, bool) {
+	if !wasmparser.IsFloatInstruction(instruction) {
+		return 0, "", false
+	}
+
+	opcode := int(instruction.Opcode)
+	if instruction.IsPrefixed() {
+		opcode = prefixedOpcode | int(instruction.SubOpcode)
+	}
+
+	name, found := floatOpcodeNames[opcode]
+	if !found {
+		name = fmt.Sprintf("0x%X", opcode)
+	}
+
+	return opcode, name, true
+}
@@ -0,0 +1,211 @@
+package floatscan
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/wasmparser"
+)
+
+// OpcodeUsage counts the occurrences of a floating-point opcode in a function;
+// the opcode is the one of the binary format, with the sub-opcode added to 0xFC00 for the prefixed instructions
+type OpcodeUsage struct {
+	Opcode int    `json:"opcode"`
+	Name   string `json:"name"`
+	Count  int    `json:"count"`
+}
+
+// FunctionReport describes the floating-point usage of a function defined by the contract
+type FunctionReport struct {
+	Index          uint32         `json:"index"`
+	ExportName     string         `json:"exportName,omitempty"`
+	FloatSignature bool           `json:"floatSignature"`
+	FloatLocals    bool           `json:"floatLocals"`
+	Opcodes        []*OpcodeUsage `json:"opcodes,omitempty"`
+}
+
+// Report describes all the floating-point usage found in a contract
+type Report struct {
+	Functions  []*FunctionReport `json:"functions"`
+	Signatures []uint32          `json:"signatures"`
+	Globals    []uint32          `json:"globals"`
+}
+
+// HasFloatingPoint returns true if anything in the contract involves floating-point values
+func (report *Report) HasFloatingPoint() bool {
+	return len(report.Functions) > 0 || len(report.Signatures) > 0 || len(report.Globals) > 0
+}
+
+// FunctionIndices returns the indices of the offending functions
+func (report *Report) FunctionIndices() []uint32 {
+	indices := make([]uint32, len(report.Functions))
+	for i, function := range report.Functions {
+		indices[i] = function.Index
+	}
+	return indices
+}
+
+// String renders the report in a human-readable form
+func (report *Report) String() string {
+	if !report.HasFloatingPoint() {
+		return "no floating-point usage found\n"
+	}
+
+	buffer := &bytes.Buffer{}
+	for _, typeIndex := range report.Signatures {
+		_, _ = fmt.Fprintf(buffer, "type %d: floating-point parameters or results\n", typeIndex)
+	}
+
+	for _, globalIndex := range report.Globals {
+		_, _ = fmt.Fprintf(buffer, "global %d: floating-point type\n", globalIndex)
+	}
+
+	for _, function := range report.Functions {
+		_, _ = fmt.Fprintf(buffer, "function %d", function.Index)
+		if len(function.ExportName) > 0 {
+			_, _ = fmt.Fprintf(buffer, " (%s)", function.ExportName)
+		}
+		buffer.WriteString(":")
+		if function.FloatSignature {
+			buffer.WriteString(" float signature;")
+		}
+		if function.FloatLocals {
+			buffer.WriteString(" float locals;")
+		}
+		for _, usage := range function.Opcodes {
+			_, _ = fmt.Fprintf(buffer, " %s x%d", usage.Name, usage.Count)
+		}
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
+
+// ScanCode parses the given contract code and reports its floating-point usage
+func ScanCode(code []byte) (*Report, error) {
+	module, err := wasmparser.ParseModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScanModule(module)
+}
+
+// ScanModule reports the floating-point usage of a parsed module: opcodes and
+// locals in the function bodies, float types in the signatures and in the globals
+func ScanModule(module *wasmparser.Module) (*Report, error) {
+	report := &Report{
+		Functions:  make([]*FunctionReport, 0),
+		Signatures: make([]uint32, 0),
+		Globals:    make([]uint32, 0),
+	}
+
+	floatTypes := make(map[uint32]bool)
+	for typeIndex, functionType := range module.Types {
+		if hasFloatValueType(functionType.Params) || hasFloatValueType(functionType.Results) {
+			floatTypes[uint32(typeIndex)] = true
+			report.Signatures = append(report.Signatures, uint32(typeIndex))
+		}
+	}
+
+	globalIndex := uint32(0)
+	for _, imp := range module.Imports {
+		if imp.Kind != wasmparser.ExternalGlobal {
+			continue
+		}
+		if imp.Global.Type.IsFloat() {
+			report.Globals = append(report.Globals, globalIndex)
+		}
+		globalIndex++
+	}
+	for _, global := range module.Globals {
+		if global.Type.IsFloat() {
+			report.Globals = append(report.Globals, globalIndex)
+		}
+		globalIndex++
+	}
+
+	exportNames := functionExportNames(module)
+	firstFunctionIndex := module.ImportedFunctionsCount()
+	for i, body := range module.Bodies {
+		functionIndex := firstFunctionIndex + uint32(i)
+		functionReport, err := scanFunction(body, functionIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		functionReport.FloatSignature = floatTypes[module.Functions[i]]
+		if !functionReport.FloatSignature && !functionReport.FloatLocals && len(functionReport.Opcodes) == 0 {
+			continue
+		}
+
+		functionReport.ExportName = exportNames[functionIndex]
+		report.Functions = append(report.Functions, functionReport)
+	}
+
+	return report, nil
+}
+
+func scanFunction(body *wasmparser.FunctionBody, functionIndex uint32) (*FunctionReport, error) {
+	functionReport := &FunctionReport{
+		Index: functionIndex,
+	}
+
+	for _, local := range body.Locals {
+		if local.Type.IsFloat() {
+			functionReport.FloatLocals = true
+		}
+	}
+
+	usages := make(map[int]*OpcodeUsage)
+	err := wasmparser.ReadInstructions(body.Code, func(instruction wasmparser.Instruction) error {
+		opcode, name, isFloat := floatOpcode(instruction)
+		if !isFloat {
+			return nil
+		}
+
+		usage, found := usages[opcode]
+		if !found {
+			usage = &OpcodeUsage{
+				Opcode: opcode,
+				Name:   name,
+			}
+			usages[opcode] = usage
+		}
+		usage.Count++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("function %d: %w", functionIndex, err)
+	}
+
+	functionReport.Opcodes = make([]*OpcodeUsage, 0, len(usages))
+	for _, usage := range usages {
+		functionReport.Opcodes = append(functionReport.Opcodes, usage)
+	}
+	sort.Slice(functionReport.Opcodes, func(i, j int) bool {
+		return functionReport.Opcodes[i].Opcode < functionReport.Opcodes[j].Opcode
+	})
+
+	return functionReport, nil
+}
+
+func hasFloatValueType(valueTypes []wasmparser.ValueType) bool {
+	for _, valueType := range valueTypes {
+		if valueType.IsFloat() {
+			return true
+		}
+	}
+	return false
+}
+
+func functionExportNames(module *wasmparser.Module) map[uint32]string {
+	names := make(map[uint32]string)
+	for _, export := range module.Exports {
+		if export.Kind == wasmparser.ExternalFunction {
+			names[export.Index] = export.Name
+		}
+	}
+	return names
+}
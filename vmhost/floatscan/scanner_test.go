@@ -0,0 +1,106 @@
+package floatscan
+
+import (
+	"os"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/wasmparser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanCode_NoFloatingPoint(t *testing.T) {
+	code, err := os.ReadFile("./../../test/contracts/counter/output/counter.wasm")
+	require.Nil(t, err)
+
+	report, err := ScanCode(code)
+	require.Nil(t, err)
+	require.False(t, report.HasFloatingPoint())
+	require.Empty(t, report.FunctionIndices())
+}
+
+func TestScanCode_FloatingPointOpcodes(t *testing.T) {
+	code, err := os.ReadFile("./../../test/contracts/num-with-fp/output/num-with-fp.wasm")
+	require.Nil(t, err)
+
+	report, err := ScanCode(code)
+	require.Nil(t, err)
+	require.True(t, report.HasFloatingPoint())
+	require.NotEmpty(t, report.FunctionIndices())
+
+	foundF32Add := false
+	for _, function := range report.Functions {
+		for _, usage := range function.Opcodes {
+			if usage.Opcode == 0x92 {
+				foundF32Add = true
+				require.Equal(t, "f32.add", usage.Name)
+			}
+		}
+	}
+	require.True(t, foundF32Add)
+}
+
+func TestScanModule_FloatTypes(t *testing.T) {
+	module := &wasmparser.Module{
+		Types: []*wasmparser.FunctionType{
+			{Params: []wasmparser.ValueType{wasmparser.ValueTypeI32}},
+			{Results: []wasmparser.ValueType{wasmparser.ValueTypeF64}},
+		},
+		Globals: []*wasmparser.Global{
+			{Type: wasmparser.ValueTypeI64},
+			{Type: wasmparser.ValueTypeF32},
+		},
+		Functions: []uint32{0, 1},
+		Bodies: []*wasmparser.FunctionBody{
+			{Code: []byte{0x0B}},
+			{Code: []byte{0x0B}},
+		},
+		Exports: []*wasmparser.Export{
+			{Name: "getRatio", Kind: wasmparser.ExternalFunction, Index: 1},
+		},
+	}
+
+	report, err := ScanModule(module)
+	require.Nil(t, err)
+	require.Equal(t, []uint32{1}, report.Signatures)
+	require.Equal(t, []uint32{1}, report.Globals)
+	require.Equal(t, []uint32{1}, report.FunctionIndices())
+	require.Equal(t, "getRatio", report.Functions[0].ExportName)
+	require.True(t, report.Functions[0].FloatSignature)
+}
+
+func TestFloatOpcode(t *testing.T) {
+	opcode, name, isFloat := floatOpcode(wasmparser.Instruction{Opcode: 0x92})
+	require.True(t, isFloat)
+	require.Equal(t, 0x92, opcode)
+	require.Equal(t, "f32.add", name)
+
+	opcode, name, isFloat = floatOpcode(wasmparser.Instruction{Opcode: wasmparser.OpcodePrefixMisc, SubOpcode: 7})
+	require.True(t, isFloat)
+	require.Equal(t, 0xFC07, opcode)
+	require.Equal(t, "i64.trunc_sat_f64_u", name)
+
+	_, _, isFloat = floatOpcode(wasmparser.Instruction{Opcode: 0x6A})
+	require.False(t, isFloat)
+}
+
+func TestFloatOpcode_AllFloatInstructionsAreNamed(t *testing.T) {
+	named := 0
+	for opcode := 0; opcode <= 0xFF; opcode++ {
+		instruction := wasmparser.Instruction{Opcode: byte(opcode)}
+		if instruction.IsPrefixed() {
+			continue
+		}
+		if wasmparser.IsFloatInstruction(instruction) {
+			require.Contains(t, floatOpcodeNames, opcode)
+			named++
+		}
+	}
+	for subOpcode := uint32(0); subOpcode <= 0xFF; subOpcode++ {
+		instruction := wasmparser.Instruction{Opcode: wasmparser.OpcodePrefixMisc, SubOpcode: subOpcode}
+		if wasmparser.IsFloatInstruction(instruction) {
+			require.Contains(t, floatOpcodeNames, prefixedOpcode|int(subOpcode))
+			named++
+		}
+	}
+	require.Equal(t, len(floatOpcodeNames), named)
+}
@@ -0,0 +1,113 @@
+package hostCore
+
+import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+)
+
+// EpochProvider provides the current epoch, such as the BlockchainContext of the VM
+type EpochProvider interface {
+	CurrentEpoch() uint32
+}
+
+// EpochProviderSetter is implemented by the enable epochs handlers which follow the epoch of the VM they are given to
+type EpochProviderSetter interface {
+	SetEpochProvider(epochProvider EpochProvider)
+}
+
+// EnableEpochsHandler is an EnableEpochsHandler which activates the flags starting with the configured epochs,
+// following the current epoch of the VM it is given to
+type EnableEpochsHandler struct {
+	mutFlags         sync.RWMutex
+	activationEpochs map[core.EnableEpochFlag]uint32
+	epochProvider    EpochProvider
+}
+
+// NewEnableEpochsHandler creates a new EnableEpochsHandler with the given activation epochs
+func NewEnableEpochsHandler(enableEpochs config.EnableEpochs) *EnableEpochsHandler {
+	handler := &EnableEpochsHandler{}
+	handler.SetEnableEpochs(enableEpochs)
+
+	return handler
+}
+
+// SetEnableEpochs replaces the activation epochs of the flags
+func (handler *EnableEpochsHandler) SetEnableEpochs(enableEpochs config.EnableEpochs) {
+	activationEpochs := map[core.EnableEpochFlag]uint32{
+		SCDeployFlag:                enableEpochs.SCDeployEnableEpoch,
+		BuiltInFunctionsFlag:        enableEpochs.BuiltInFunctionsEnableEpoch,
+		RepairCallbackFlag:          enableEpochs.RepairCallbackEnableEpoch,
+		AheadOfTimeGasUsageFlag:     enableEpochs.AheadOfTimeGasUsageEnableEpoch,
+		FloatingPointRejectionFlag:  enableEpochs.FloatingPointRejectionEnableEpoch,
+		CodeMetadataEnforcementFlag: enableEpochs.CodeMetadataEnforcementEnableEpoch,
+		MemoryAccountingFlag:        enableEpochs.MemoryAccountingEnableEpoch,
+		EmitEventFlag:               enableEpochs.EventLogEnableEpoch,
+	}
+
+	handler.mutFlags.Lock()
+	handler.activationEpochs = activationEpochs
+	handler.mutFlags.Unlock()
+}
+
+// SetEpochProvider sets the provider of the current epoch; without one, the current epoch is 0
+func (handler *EnableEpochsHandler) SetEpochProvider(epochProvider EpochProvider) {
+	handler.mutFlags.Lock()
+	handler.epochProvider = epochProvider
+	handler.mutFlags.Unlock()
+}
+
+// IsFlagDefined returns true if the flag is used by the VM
+func (handler *EnableEpochsHandler) IsFlagDefined(flag core.EnableEpochFlag) bool {
+	handler.mutFlags.RLock()
+	_, exists := handler.activationEpochs[flag]
+	handler.mutFlags.RUnlock()
+
+	return exists
+}
+
+// IsFlagEnabled returns true if the flag is active in the current epoch
+func (handler *EnableEpochsHandler) IsFlagEnabled(flag core.EnableEpochFlag) bool {
+	handler.mutFlags.RLock()
+	epochProvider := handler.epochProvider
+	handler.mutFlags.RUnlock()
+
+	currentEpoch := uint32(0)
+	if epochProvider != nil {
+		currentEpoch = epochProvider.CurrentEpoch()
+	}
+
+	return handler.IsFlagEnabledInEpoch(flag, currentEpoch)
+}
+
+// IsFlagEnabledInEpoch returns true if the flag is active in the given epoch
+func (handler *EnableEpochsHandler) IsFlagEnabledInEpoch(flag core.EnableEpochFlag, epoch uint32) bool {
+	handler.mutFlags.RLock()
+	activationEpoch, exists := handler.activationEpochs[flag]
+	handler.mutFlags.RUnlock()
+
+	return exists && activationEpoch != config.DisabledEpoch && epoch >= activationEpoch
+}
+
+// GetActivationEpoch returns the epoch starting with which the flag is active
+func (handler *EnableEpochsHandler) GetActivationEpoch(flag core.EnableEpochFlag) uint32 {
+	handler.mutFlags.RLock()
+	defer handler.mutFlags.RUnlock()
+
+	return handler.activationEpochs[flag]
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *EnableEpochsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
+
+func setEpochProviderIfSupported(enableEpochsHandler interface{}, epochProvider EpochProvider) {
+	setter, ok := enableEpochsHandler.(EpochProviderSetter)
+	if !ok {
+		return
+	}
+
+	setter.SetEpochProvider(epochProvider)
+}
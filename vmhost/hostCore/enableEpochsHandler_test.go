@@ -0,0 +1,63 @@
+package hostCore
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+type epochProviderStub struct {
+	epoch uint32
+}
+
+func (stub *epochProviderStub) CurrentEpoch() uint32 {
+	return stub.epoch
+}
+
+func TestEnableEpochsHandler_IsCompatibleWithTheVM(t *testing.T) {
+	handler := NewEnableEpochsHandler(config.MakeDefaultEnableEpochs())
+	require.Nil(t, core.CheckHandlerCompatibility(handler, allFlags))
+	require.False(t, handler.IsFlagDefined("UnknownFlag"))
+}
+
+func TestEnableEpochsHandler_DefaultEpochs(t *testing.T) {
+	handler := NewEnableEpochsHandler(config.MakeDefaultEnableEpochs())
+
+	require.True(t, handler.IsFlagEnabled(SCDeployFlag))
+	require.True(t, handler.IsFlagEnabled(BuiltInFunctionsFlag))
+	require.True(t, handler.IsFlagEnabled(RepairCallbackFlag))
+	require.True(t, handler.IsFlagEnabled(AheadOfTimeGasUsageFlag))
+	require.False(t, handler.IsFlagEnabled(FloatingPointRejectionFlag))
+	require.False(t, handler.IsFlagEnabled(EmitEventFlag))
+	require.False(t, handler.IsFlagEnabledInEpoch(CodeMetadataEnforcementFlag, config.DisabledEpoch))
+}
+
+func TestEnableEpochsHandler_FollowsTheCurrentEpoch(t *testing.T) {
+	enableEpochs := config.MakeDefaultEnableEpochs()
+	enableEpochs.RepairCallbackEnableEpoch = 5
+	handler := NewEnableEpochsHandler(enableEpochs)
+
+	epochProvider := &epochProviderStub{epoch: 4}
+	handler.SetEpochProvider(epochProvider)
+	require.False(t, handler.IsFlagEnabled(RepairCallbackFlag))
+	require.Equal(t, uint32(5), handler.GetActivationEpoch(RepairCallbackFlag))
+
+	epochProvider.epoch = 5
+	require.True(t, handler.IsFlagEnabled(RepairCallbackFlag))
+
+	enableEpochs.RepairCallbackEnableEpoch = 6
+	handler.SetEnableEpochs(enableEpochs)
+	require.False(t, handler.IsFlagEnabled(RepairCallbackFlag))
+}
+
+func TestSetEpochProviderIfSupported(t *testing.T) {
+	handler := NewEnableEpochsHandler(config.MakeDefaultEnableEpochs())
+	setEpochProviderIfSupported(handler, &epochProviderStub{epoch: 7})
+	require.Equal(t, uint32(7), handler.epochProvider.CurrentEpoch())
+
+	require.NotPanics(t, func() {
+		setEpochProviderIfSupported(struct{}{}, &epochProviderStub{})
+	})
+}
@@ -70,7 +70,7 @@ func (host *vmHost) performCodeDeployment(input vmhost.CodeDeployInput) (*vmcomm
 	err = runtime.StartWasmerInstance(input.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Debug("performCodeDeployment/StartWasmerInstance", "err", err)
-		return nil, vmhost.ErrContractInvalid
+		return nil, contractInvalidError(err)
 	}
 
 	err = host.callInitFunction()
@@ -84,6 +84,17 @@ func (host *vmHost) performCodeDeployment(input vmhost.CodeDeployInput) (*vmcomm
 	return vmOutput, nil
 }
 
+// contractInvalidError keeps the reason of a contract validation rule
+// violation, while all other instantiation errors are reported as ErrContractInvalid
+func contractInvalidError(err error) error {
+	var validationErr *vmhost.ContractValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr
+	}
+
+	return vmhost.ErrContractInvalid
+}
+
 // doRunSmartContractUpgrade upgrades a contract directly
 func (host *vmHost) doRunSmartContractUpgrade(input *vmcommon.ContractCallInput) *vmcommon.VMOutput {
 	host.InitState()
@@ -137,6 +148,7 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (v
 	_, _, metering, output, runtime, storage := host.GetContexts()
 
 	runtime.InitStateFromContractCallInput(input)
+	runtime.SetReadOnly(host.forceReadOnly)
 	metering.InitStateFromContractCallInput(&input.VMInput)
 	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
 	storage.SetAddress(runtime.GetSCAddress())
@@ -175,7 +187,8 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (v
 	log.Trace("doRunSmartContractCall finished",
 		"retCode", vmOutput.ReturnCode,
 		"message", vmOutput.ReturnMessage,
-		"data", vmOutput.ReturnData)
+		"data", vmOutput.ReturnData,
+		"peak memory pages", runtime.PeakMemoryPages())
 
 	runtime.CleanWasmerInstance()
 	return
@@ -476,6 +489,13 @@ func (host *vmHost) checkUpgradePermission(vmInput *vmcommon.ContractCallInput)
 		return nil
 	}
 
+	if host.IsCodeMetadataEnforcementEnabled() {
+		if !isUpgradeable {
+			return vmhost.ErrContractNotUpgradeable
+		}
+		return vmhost.ErrUpgradeCallerNotOwner
+	}
+
 	return vmhost.ErrUpgradeNotAllowed
 }
 
@@ -512,7 +532,7 @@ func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
 	err = runtime.StartWasmerInstance(codeDeployInput.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Debug("performCodeDeployment/StartWasmerInstance", "err", err)
-		return vmhost.ErrContractInvalid
+		return contractInvalidError(err)
 	}
 
 	err = host.callInitFunction()
@@ -636,14 +656,36 @@ func (host *vmHost) callSCMethodIndirect() error {
 		return err
 	}
 
+	host.recordExportCalled(host.Runtime().Function())
 	_, err = function()
 	if err != nil {
 		err = host.handleBreakpointIfAny(err)
 	}
+	err = host.updateMemoryUsageAfterCall(err)
+
+	return err
+}
+
+// updateMemoryUsageAfterCall accounts for the memory grown by a contract function, whether it
+// succeeded or not, so that the growth is charged before the remaining gas is given back;
+// the error of the function, if any, prevails
+func (host *vmHost) updateMemoryUsageAfterCall(callErr error) error {
+	err := host.Runtime().UpdateMemoryUsage()
+	if callErr != nil {
+		return callErr
+	}
 
 	return err
 }
 
+// recordExportCalled reports the exported function about to be called to the CoverageCollector, if any
+func (host *vmHost) recordExportCalled(function string) {
+	if host.coverage == nil {
+		return
+	}
+	host.coverage.ExportCalled(host.Runtime().GetSCAddress(), function)
+}
+
 // RevertESDTTransfer calls the ESDT/ESDTNFT transfer with reverted arguments
 func (host *vmHost) RevertESDTTransfer(input *vmcommon.ContractCallInput) {
 	isESDTTransfer := input.Function == core.BuiltInFunctionESDTTransfer || input.Function == core.BuiltInFunctionESDTNFTTransfer
@@ -890,11 +932,12 @@ func (host *vmHost) callInitFunction() error {
 		return nil
 	}
 
+	host.recordExportCalled(vmhost.InitFunctionName)
 	_, err := init()
 	if err != nil {
 		err = host.handleBreakpointIfAny(err)
 	}
-
+	err = host.updateMemoryUsageAfterCall(err)
 	if err == nil {
 		err = host.checkFinalGasAfterExit()
 	}
@@ -932,10 +975,12 @@ func (host *vmHost) callSCMethod() error {
 		return err
 	}
 
+	host.recordExportCalled(runtime.Function())
 	_, err = function()
 	if err != nil {
 		err = host.handleBreakpointIfAny(err)
 	}
+	err = host.updateMemoryUsageAfterCall(err)
 	if err == nil {
 		err = host.checkFinalGasAfterExit()
 	}
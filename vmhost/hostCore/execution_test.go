@@ -369,6 +369,74 @@ func TestExecution_Deploy_DisallowFloatingPoint(t *testing.T) {
 	require.Equal(t, vmcommon.ContractInvalid, vmOutput.ReturnCode)
 }
 
+// makeEmitEventImportingCode builds a contract importing env.emitEvent, with an empty init function
+func makeEmitEventImportingCode() []byte {
+	return []byte{
+		0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00,
+		// types: (i32 x 7) -> (), () -> ()
+		0x01, 0x0E, 0x02,
+		0x60, 0x07, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x00,
+		0x60, 0x00, 0x00,
+		// import env.emitEvent
+		0x02, 0x11, 0x01,
+		0x03, 'e', 'n', 'v',
+		0x09, 'e', 'm', 'i', 't', 'E', 'v', 'e', 'n', 't',
+		0x00, 0x00,
+		// one function of type 1
+		0x03, 0x02, 0x01, 0x01,
+		// one memory of 2 pages
+		0x05, 0x03, 0x01, 0x00, 0x02,
+		// export memory and init
+		0x07, 0x11, 0x02,
+		0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+		0x04, 'i', 'n', 'i', 't', 0x00, 0x01,
+		// init: empty body
+		0x0A, 0x04, 0x01, 0x02, 0x00, 0x0B,
+	}
+}
+
+func TestExecution_Deploy_EmitEventFlag(t *testing.T) {
+	enableEpochs := config.MakeDefaultEnableEpochs()
+	enableEpochs.EventLogEnableEpoch = 5
+
+	testCases := map[uint32]vmcommon.ReturnCode{
+		4: vmcommon.ContractInvalid,
+		5: vmcommon.Ok,
+	}
+	for epoch, expectedReturnCode := range testCases {
+		currentEpoch := epoch
+		stubBlockchainHook := &contextmock.BlockchainHookStub{
+			GetUserAccountCalled: func(address []byte) (vmcommon.UserAccountHandler, error) {
+				return &contextmock.StubAccount{Nonce: 24}, nil
+			},
+			NewAddressCalled: func(creatorAddress []byte, nonce uint64, vmType []byte) ([]byte, error) {
+				return []byte("new smartcontract"), nil
+			},
+			CurrentEpochCalled: func() uint32 {
+				return currentEpoch
+			},
+		}
+
+		host, err := NewVMHost(stubBlockchainHook, &vmhost.VMHostParameters{
+			VMType:                   defaultVMType,
+			BlockGasLimit:            uint64(1000),
+			GasSchedule:              config.MakeGasMapForTests(),
+			ProtocolBuiltinFunctions: make(vmcommon.FunctionNames),
+			ProtectedKeyPrefix:       []byte("E" + "L" + "R" + "O" + "N" + "D"),
+			EnableEpochsHandler:      NewEnableEpochsHandler(enableEpochs),
+		})
+		require.Nil(t, err)
+
+		input := DefaultTestContractCreateInput()
+		input.GasProvided = 1000
+		input.ContractCode = makeEmitEventImportingCode()
+
+		vmOutput, err := host.RunSmartContractCreate(input)
+		require.Nil(t, err)
+		require.Equal(t, expectedReturnCode, vmOutput.ReturnCode, "epoch %d", epoch)
+	}
+}
+
 func TestExecution_CallGetUserAccountErr(t *testing.T) {
 	stubBlockchainHook := &contextmock.BlockchainHookStub{}
 
@@ -1670,3 +1738,71 @@ func makeBytecodeWithLocals(numLocals uint64) []byte {
 
 	return result
 }
+
+type memoryUsageRuntimeStub struct {
+	*contextmock.RuntimeContextMock
+	memoryUsageUpdates int
+}
+
+func (runtime *memoryUsageRuntimeStub) UpdateMemoryUsage() error {
+	runtime.memoryUsageUpdates++
+	return runtime.Err
+}
+
+func TestExecution_MemoryGrowthChargedAfterFailingCall(t *testing.T) {
+	errMemory := errors.New("memory growth")
+	runtime := &memoryUsageRuntimeStub{RuntimeContextMock: &contextmock.RuntimeContextMock{Err: errMemory}}
+	host := &vmHost{runtimeContext: runtime}
+
+	err := host.updateMemoryUsageAfterCall(nil)
+	require.Equal(t, errMemory, err)
+	require.Equal(t, 1, runtime.memoryUsageUpdates)
+
+	err = host.updateMemoryUsageAfterCall(vmhost.ErrSignalError)
+	require.Equal(t, vmhost.ErrSignalError, err)
+	require.Equal(t, 2, runtime.memoryUsageUpdates)
+}
+
+func TestExecution_UpgradePermission(t *testing.T) {
+	owner := []byte("owner")
+	upgradeable := (&vmcommon.CodeMetadata{Upgradeable: true}).ToBytes()
+	notUpgradeable := (&vmcommon.CodeMetadata{}).ToBytes()
+
+	testCases := []struct {
+		name                  string
+		codeMetadata          []byte
+		caller                []byte
+		errWithEnforcement    error
+		errWithoutEnforcement error
+	}{
+		{"upgradeable, by owner", upgradeable, owner, nil, nil},
+		{"not upgradeable, by owner", notUpgradeable, owner, vmhost.ErrContractNotUpgradeable, vmhost.ErrUpgradeNotAllowed},
+		{"upgradeable, by other", upgradeable, []byte("other"), vmhost.ErrUpgradeCallerNotOwner, vmhost.ErrUpgradeNotAllowed},
+		{"not upgradeable, by other", notUpgradeable, []byte("other"), vmhost.ErrContractNotUpgradeable, vmhost.ErrUpgradeNotAllowed},
+	}
+
+	for _, testCase := range testCases {
+		blockchainHook := &contextmock.BlockchainHookStub{
+			GetUserAccountCalled: func(address []byte) (vmcommon.UserAccountHandler, error) {
+				return &worldmock.Account{Address: address, CodeMetadata: testCase.codeMetadata, OwnerAddress: owner}, nil
+			},
+		}
+		input := &vmcommon.ContractCallInput{
+			VMInput:       vmcommon.VMInput{CallerAddr: testCase.caller},
+			RecipientAddr: []byte("contract"),
+		}
+
+		enableEpochs := config.MakeDefaultEnableEpochs()
+		enableEpochs.CodeMetadataEnforcementEnableEpoch = 0
+		host := &vmHost{blockChainHook: blockchainHook, enableEpochsHandler: NewEnableEpochsHandler(enableEpochs)}
+		err := host.checkUpgradePermission(input)
+		require.Equal(t, testCase.errWithEnforcement, err, testCase.name)
+		if err != nil {
+			require.True(t, errors.Is(err, vmhost.ErrUpgradeNotAllowed), testCase.name)
+		}
+
+		host.enableEpochsHandler = NewEnableEpochsHandler(config.MakeDefaultEnableEpochs())
+		err = host.checkUpgradePermission(input)
+		require.Equal(t, testCase.errWithoutEnforcement, err, testCase.name)
+	}
+}
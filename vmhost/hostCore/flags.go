@@ -11,6 +11,14 @@ const (
 	RepairCallbackFlag core.EnableEpochFlag = "RepairCallbackFlag"
 	// AheadOfTimeGasUsageFlag defines the flag that activates the ahead of time gas usage fix
 	AheadOfTimeGasUsageFlag core.EnableEpochFlag = "AheadOfTimeGasUsageFlag"
+	// FloatingPointRejectionFlag defines the flag that activates the rejection of floating-point contract code on deploy
+	FloatingPointRejectionFlag core.EnableEpochFlag = "FloatingPointRejectionFlag"
+	// CodeMetadataEnforcementFlag defines the flag that activates the enforcement of the readable, payable-by-SC and upgradeable code metadata
+	CodeMetadataEnforcementFlag core.EnableEpochFlag = "CodeMetadataEnforcementFlag"
+	// MemoryAccountingFlag defines the flag that activates the gas for memory growth and the aggregate memory limit of the running instances
+	MemoryAccountingFlag core.EnableEpochFlag = "MemoryAccountingFlag"
+	// EmitEventFlag defines the flag that activates the emitEvent API and its event costs and limits
+	EmitEventFlag core.EnableEpochFlag = "EmitEventFlag"
 )
 
 // allFlags must have all flags used by mx-chain-vm-v1_2-go in the current version
@@ -19,4 +27,15 @@ var allFlags = []core.EnableEpochFlag{
 	BuiltInFunctionsFlag,
 	RepairCallbackFlag,
 	AheadOfTimeGasUsageFlag,
+	FloatingPointRejectionFlag,
+	CodeMetadataEnforcementFlag,
+	MemoryAccountingFlag,
+	EmitEventFlag,
+}
+
+// AllFlags returns all the enable-epoch flags used by mx-chain-vm-v1_2-go in the current version
+func AllFlags() []core.EnableEpochFlag {
+	flags := make([]core.EnableEpochFlag, len(allFlags))
+	copy(flags, allFlags)
+	return flags
 }
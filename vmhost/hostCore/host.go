@@ -23,6 +23,10 @@ var log = logger.GetOrCreate("vm/host")
 // MaximumWasmerInstanceCount represents the maximum number of Wasmer instances that can be active at the same time
 var MaximumWasmerInstanceCount = uint64(10)
 
+// DefaultMaxMemoryPages represents the maximum number of linear memory pages the active Wasmer instances
+// can use together (32 MiB), unless configured otherwise through the host parameters
+var DefaultMaxMemoryPages = uint64(512)
+
 // TryFunction corresponds to the try() part of a try / catch block
 type TryFunction func()
 
@@ -48,6 +52,10 @@ type vmHost struct {
 	scAPIMethods             *wasmer.Imports
 	protocolBuiltinFunctions vmcommon.FunctionNames
 	enableEpochsHandler      vmhost.EnableEpochsHandler
+	coverage                 vmhost.CoverageCollector
+
+	// forceReadOnly is set for the hosts of a QueryExecutor, whose contract calls start in read only mode
+	forceReadOnly bool
 }
 
 // NewVMHost creates a new VM vmHost
@@ -76,6 +84,7 @@ func NewVMHost(
 		scAPIMethods:             nil,
 		protocolBuiltinFunctions: hostParameters.ProtocolBuiltinFunctions,
 		enableEpochsHandler:      hostParameters.EnableEpochsHandler,
+		coverage:                 hostParameters.CoverageCollector,
 	}
 
 	imports, err := vmhooks.BaseOpsAPIImports()
@@ -98,6 +107,13 @@ func NewVMHost(
 		return nil, err
 	}
 
+	if host.coverage != nil {
+		imports, err = vmhooks.CoverageImports(imports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = wasmer.SetImports(imports)
 	if err != nil {
 		return nil, err
@@ -109,11 +125,13 @@ func NewVMHost(
 	if err != nil {
 		return nil, err
 	}
+	setEpochProviderIfSupported(host.enableEpochsHandler, host.blockchainContext)
 
 	host.runtimeContext, err = contexts.NewRuntimeContext(
 		host,
 		hostParameters.VMType,
 		hostParameters.UseWarmInstance,
+		hostParameters.ContractValidationRules,
 	)
 	if err != nil {
 		return nil, err
@@ -146,8 +164,14 @@ func NewVMHost(
 
 	host.runtimeContext.SetMaxInstanceCount(MaximumWasmerInstanceCount)
 
+	maxMemoryPages := hostParameters.MaxMemoryPages
+	if maxMemoryPages == 0 {
+		maxMemoryPages = DefaultMaxMemoryPages
+	}
+	host.runtimeContext.SetMaxMemoryPages(maxMemoryPages)
+
 	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
-	wasmer.SetOpcodeCosts(&opcodeCosts)
+	contexts.SetWasmerOpcodeCosts(&opcodeCosts)
 
 	if hostParameters.WasmerSIGSEGVPassthrough {
 		wasmer.SetSIGSEGVPassthrough()
@@ -225,6 +249,26 @@ func (host *vmHost) IsESDTFunctionsEnabled() bool {
 	return host.enableEpochsHandler.IsFlagEnabled(BuiltInFunctionsFlag)
 }
 
+// IsFloatingPointRejectionEnabled returns whether contract code using floating-point values is rejected on deploy
+func (host *vmHost) IsFloatingPointRejectionEnabled() bool {
+	return host.enableEpochsHandler.IsFlagEnabled(FloatingPointRejectionFlag)
+}
+
+// IsCodeMetadataEnforcementEnabled returns whether the readable, payable-by-SC and upgradeable code metadata are enforced
+func (host *vmHost) IsCodeMetadataEnforcementEnabled() bool {
+	return host.enableEpochsHandler.IsFlagEnabled(CodeMetadataEnforcementFlag)
+}
+
+// IsMemoryAccountingEnabled returns whether memory growth costs gas and the memory of the running instances is limited
+func (host *vmHost) IsMemoryAccountingEnabled() bool {
+	return host.enableEpochsHandler.IsFlagEnabled(MemoryAccountingFlag)
+}
+
+// IsEmitEventEnabled returns whether contracts may import the emitEvent API
+func (host *vmHost) IsEmitEventEnabled() bool {
+	return host.enableEpochsHandler.IsFlagEnabled(EmitEventFlag)
+}
+
 // GetContexts returns the main contexts of the host
 func (host *vmHost) GetContexts() (
 	vmhost.BigIntContext,
@@ -297,7 +341,7 @@ func (host *vmHost) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
 	}
 
 	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
-	wasmer.SetOpcodeCosts(&opcodeCosts)
+	contexts.SetWasmerOpcodeCosts(&opcodeCosts)
 
 	host.meteringContext.SetGasSchedule(newGasSchedule)
 }
@@ -395,6 +439,11 @@ func (host *vmHost) hasRetriableExecutionError(vmOutput *vmcommon.VMOutput) bool
 	return vmOutput.ReturnMessage == "allocation error"
 }
 
+// Coverage returns the CoverageCollector of the host, or nil when the coverage is not collected
+func (host *vmHost) Coverage() vmhost.CoverageCollector {
+	return host.coverage
+}
+
 // AreInSameShard returns true if the provided addresses are part of the same shard
 func (host *vmHost) AreInSameShard(leftAddress []byte, rightAddress []byte) bool {
 	blockchain := host.Blockchain()
@@ -0,0 +1,35 @@
+package hostCore
+
+import (
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+)
+
+// queryBlockchainHook is the read only view of the blockchain given to the hosts of a QueryExecutor:
+// the calls which would change the state of the Node (built-in functions, compiled codes, snapshots) are refused or ignored
+type queryBlockchainHook struct {
+	vmcommon.BlockchainHook
+}
+
+// ProcessBuiltInFunction refuses the built-in functions, which change the state
+func (hook *queryBlockchainHook) ProcessBuiltInFunction(_ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return nil, vmhost.ErrInvalidCallOnReadOnlyMode
+}
+
+// SaveCompiledCode does nothing
+func (hook *queryBlockchainHook) SaveCompiledCode(_ []byte, _ []byte) {
+}
+
+// ClearCompiledCodes does nothing
+func (hook *queryBlockchainHook) ClearCompiledCodes() {
+}
+
+// RevertToSnapshot does nothing, as a query never changes the state
+func (hook *queryBlockchainHook) RevertToSnapshot(_ int) error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hook *queryBlockchainHook) IsInterfaceNil() bool {
+	return hook == nil
+}
@@ -0,0 +1,294 @@
+package hostCore
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+)
+
+// QueryExecutorArgs holds the arguments of a QueryExecutor
+type QueryExecutorArgs struct {
+	// BlockchainHook must support concurrent reads, as the hosts call it concurrently
+	BlockchainHook vmcommon.BlockchainHook
+	HostParameters *vmhost.VMHostParameters
+	// NumHosts is the number of hosts, thus of queries executed concurrently
+	NumHosts int
+	// MaxQueuedQueries is the number of queries which can wait for a free host; beyond it, the queries are rejected
+	MaxQueuedQueries int
+	// MaxGasPerQuery caps the gas provided to each query; 0 means no cap
+	MaxGasPerQuery uint64
+	// QueryTimeout bounds the wait for the output of a query, in the queue included; 0 means no timeout.
+	// The execution of a query which exceeded its timeout is not interrupted (it is bounded by its gas),
+	// only its output is discarded.
+	QueryTimeout time.Duration
+	// MaxCachedResults is the number of query outputs held by the cache; 0 disables the cache
+	MaxCachedResults int
+}
+
+// QueryExecutor executes the read only contract calls (queries) concurrently, on a pool of independent hosts.
+// The calls start in read only mode and their built-in functions are refused, while the outputs which would
+// change the state anyway are rejected with ErrQueryChangesState.
+type QueryExecutor struct {
+	blockchainHook vmcommon.BlockchainHook
+	hosts          chan *vmHost
+	numHosts       int
+	admitted       chan struct{}
+	maxGasPerQuery uint64
+	queryTimeout   time.Duration
+	cache          *queryResultsCache
+
+	// mutHosts serializes the operations which take all the hosts out of the pool
+	mutHosts  sync.Mutex
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+type queryResult struct {
+	vmOutput *vmcommon.VMOutput
+	err      error
+}
+
+// NewQueryExecutor creates the hosts of the pool
+func NewQueryExecutor(args QueryExecutorArgs) (*QueryExecutor, error) {
+	if check.IfNil(args.BlockchainHook) {
+		return nil, vmhost.ErrNilBlockchainHook
+	}
+	if args.HostParameters == nil {
+		return nil, vmhost.ErrNilHostParameters
+	}
+	if args.NumHosts <= 0 {
+		return nil, vmhost.ErrInvalidNumberOfHosts
+	}
+
+	executor := &QueryExecutor{
+		blockchainHook: args.BlockchainHook,
+		hosts:          make(chan *vmHost, args.NumHosts),
+		numHosts:       args.NumHosts,
+		admitted:       make(chan struct{}, args.NumHosts+args.MaxQueuedQueries),
+		maxGasPerQuery: args.MaxGasPerQuery,
+		queryTimeout:   args.QueryTimeout,
+		closing:        make(chan struct{}),
+	}
+
+	if args.MaxCachedResults > 0 {
+		executor.cache = newQueryResultsCache(args.MaxCachedResults)
+	}
+
+	hook := &queryBlockchainHook{BlockchainHook: args.BlockchainHook}
+	for i := 0; i < args.NumHosts; i++ {
+		host, err := NewVMHost(hook, args.HostParameters)
+		if err != nil {
+			return nil, err
+		}
+
+		host.forceReadOnly = true
+		executor.hosts <- host
+	}
+
+	return executor, nil
+}
+
+// RunQuery executes a read only contract call; each call gets its own output, even when it is served from the cache
+func (executor *QueryExecutor) RunQuery(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if input == nil {
+		return nil, vmhost.ErrNilContractCallInput
+	}
+	if input.Function == vmhost.UpgradeFunctionName {
+		return nil, vmhost.ErrInvalidCallOnReadOnlyMode
+	}
+
+	query := executor.capGas(input)
+	if executor.cache == nil {
+		return executor.execute(query)
+	}
+
+	stateRootHash := executor.blockchainHook.GetStateRootHash()
+	if len(stateRootHash) == 0 {
+		return executor.execute(query)
+	}
+
+	key := createQueryKey(stateRootHash, query)
+	vmOutput, ok := executor.cache.get(key)
+	if ok {
+		return vmOutput, nil
+	}
+
+	vmOutput, err := executor.execute(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// the output is only cached if the state did not change in the meantime
+	if bytes.Equal(stateRootHash, executor.blockchainHook.GetStateRootHash()) {
+		executor.cache.put(key, vmOutput)
+	}
+
+	return vmOutput, nil
+}
+
+func (executor *QueryExecutor) capGas(input *vmcommon.ContractCallInput) *vmcommon.ContractCallInput {
+	if executor.maxGasPerQuery == 0 || input.GasProvided <= executor.maxGasPerQuery {
+		return input
+	}
+
+	query := *input
+	query.GasProvided = executor.maxGasPerQuery
+	return &query
+}
+
+func (executor *QueryExecutor) execute(query *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	select {
+	case <-executor.closing:
+		return nil, vmhost.ErrQueryExecutorClosed
+	default:
+	}
+
+	select {
+	case executor.admitted <- struct{}{}:
+	default:
+		return nil, vmhost.ErrQueryQueueFull
+	}
+
+	cancelled := make(chan struct{})
+	results := make(chan queryResult, 1)
+	go executor.executeOnFreeHost(query, cancelled, results)
+
+	var timeout <-chan time.Time
+	if executor.queryTimeout > 0 {
+		timer := time.NewTimer(executor.queryTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case result := <-results:
+		return result.vmOutput, result.err
+	case <-timeout:
+		close(cancelled)
+		return nil, vmhost.ErrQueryTimeout
+	}
+}
+
+func (executor *QueryExecutor) executeOnFreeHost(query *vmcommon.ContractCallInput, cancelled chan struct{}, results chan queryResult) {
+	defer func() {
+		<-executor.admitted
+	}()
+
+	var host *vmHost
+	select {
+	case host = <-executor.hosts:
+	case <-cancelled:
+		return
+	case <-executor.closing:
+		results <- queryResult{err: vmhost.ErrQueryExecutorClosed}
+		return
+	}
+	defer func() {
+		executor.hosts <- host
+	}()
+
+	vmOutput, err := host.RunSmartContractCall(query)
+	if err == nil {
+		err = checkQueryOutput(executor.blockchainHook, vmOutput)
+	}
+	if err != nil {
+		vmOutput = nil
+	}
+
+	results <- queryResult{vmOutput: vmOutput, err: err}
+}
+
+// checkQueryOutput rejects the outputs which would change the state, had they been committed
+func checkQueryOutput(blockchainHook vmcommon.BlockchainHook, vmOutput *vmcommon.VMOutput) error {
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return nil
+	}
+	if len(vmOutput.DeletedAccounts) > 0 {
+		return fmt.Errorf("%w: deleted accounts", vmhost.ErrQueryChangesState)
+	}
+
+	for _, account := range vmOutput.OutputAccounts {
+		if account.BalanceDelta != nil && account.BalanceDelta.Sign() != 0 {
+			return fmt.Errorf("%w: balance of %x", vmhost.ErrQueryChangesState, account.Address)
+		}
+		if len(account.OutputTransfers) > 0 {
+			return fmt.Errorf("%w: transfers from %x", vmhost.ErrQueryChangesState, account.Address)
+		}
+		if len(account.Code) > 0 {
+			return fmt.Errorf("%w: code of %x", vmhost.ErrQueryChangesState, account.Address)
+		}
+
+		// the storage read by the query is among the updates, as well
+		for _, update := range account.StorageUpdates {
+			data, _, err := blockchainHook.GetStorageData(account.Address, update.Offset)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(data, update.Data) {
+				return fmt.Errorf("%w: storage of %x", vmhost.ErrQueryChangesState, account.Address)
+			}
+		}
+	}
+
+	return nil
+}
+
+// takeAllHosts waits for the running queries to end
+func (executor *QueryExecutor) takeAllHosts() []*vmHost {
+	hosts := make([]*vmHost, 0, executor.numHosts)
+	for len(hosts) < executor.numHosts {
+		hosts = append(hosts, <-executor.hosts)
+	}
+
+	return hosts
+}
+
+// GasScheduleChange applies a new gas schedule to all the hosts, once the running queries end, and discards the cached outputs
+func (executor *QueryExecutor) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
+	executor.mutHosts.Lock()
+	defer executor.mutHosts.Unlock()
+
+	hosts := executor.takeAllHosts()
+	for _, host := range hosts {
+		host.GasScheduleChange(newGasSchedule)
+	}
+
+	if executor.cache != nil {
+		executor.cache.clear()
+	}
+
+	for _, host := range hosts {
+		executor.hosts <- host
+	}
+}
+
+// Close rejects the new queries and closes the hosts, once the running queries end
+func (executor *QueryExecutor) Close() error {
+	executor.closeOnce.Do(func() {
+		close(executor.closing)
+	})
+
+	executor.mutHosts.Lock()
+	defer executor.mutHosts.Unlock()
+
+	var lastErr error
+	for _, host := range executor.takeAllHosts() {
+		err := host.Close()
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (executor *QueryExecutor) IsInterfaceNil() bool {
+	return executor == nil
+}
@@ -0,0 +1,304 @@
+package hostCore
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/mock"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQueryExecutor_InvalidArguments(t *testing.T) {
+	args := defaultQueryExecutorArgs(&contextmock.BlockchainHookStub{})
+	args.BlockchainHook = nil
+	executor, err := NewQueryExecutor(args)
+	require.Nil(t, executor)
+	require.Equal(t, vmhost.ErrNilBlockchainHook, err)
+
+	args = defaultQueryExecutorArgs(&contextmock.BlockchainHookStub{})
+	args.HostParameters = nil
+	executor, err = NewQueryExecutor(args)
+	require.Nil(t, executor)
+	require.Equal(t, vmhost.ErrNilHostParameters, err)
+
+	args = defaultQueryExecutorArgs(&contextmock.BlockchainHookStub{})
+	args.NumHosts = 0
+	executor, err = NewQueryExecutor(args)
+	require.Nil(t, executor)
+	require.Equal(t, vmhost.ErrInvalidNumberOfHosts, err)
+}
+
+func TestQueryExecutor_ConcurrentQueries(t *testing.T) {
+	blockchain := counterBlockchainHook(t)
+	args := defaultQueryExecutorArgs(blockchain)
+	args.NumHosts = 4
+	args.MaxQueuedQueries = 100
+	executor, err := NewQueryExecutor(args)
+	require.Nil(t, err)
+	defer func() {
+		_ = executor.Close()
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			input := DefaultTestContractCallInput()
+			input.GasProvided = 1000000
+			input.Function = get
+			vmOutput, err := executor.RunQuery(input)
+			require.Nil(t, err)
+			require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+			require.Equal(t, [][]byte{{42}}, vmOutput.ReturnData)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestQueryExecutor_StateChangesAreRefused(t *testing.T) {
+	executor, err := NewQueryExecutor(defaultQueryExecutorArgs(counterBlockchainHook(t)))
+	require.Nil(t, err)
+	defer func() {
+		_ = executor.Close()
+	}()
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+	vmOutput, err := executor.RunQuery(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// the storage writes are ignored in read only mode, thus only the read of the counter is in the output
+	for _, account := range vmOutput.OutputAccounts {
+		for _, update := range account.StorageUpdates {
+			require.Equal(t, []byte{42}, update.Data)
+		}
+	}
+
+	input.Function = vmhost.UpgradeFunctionName
+	vmOutput, err = executor.RunQuery(input)
+	require.Nil(t, vmOutput)
+	require.Equal(t, vmhost.ErrInvalidCallOnReadOnlyMode, err)
+}
+
+func TestQueryExecutor_CachesResultsPerStateRootHash(t *testing.T) {
+	blockchain := counterBlockchainHook(t)
+	rootHash := []byte("root hash 1")
+	blockchain.GetStateRootHashCalled = func() []byte {
+		return rootHash
+	}
+	storageReads := 0
+	blockchain.GetStorageDataCalled = func(_ []byte, _ []byte) ([]byte, uint32, error) {
+		storageReads++
+		return []byte{42}, 0, nil
+	}
+
+	args := defaultQueryExecutorArgs(blockchain)
+	args.MaxCachedResults = 10
+	executor, err := NewQueryExecutor(args)
+	require.Nil(t, err)
+	defer func() {
+		_ = executor.Close()
+	}()
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+	vmOutput, err := executor.RunQuery(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	reads := storageReads
+
+	_, err = executor.RunQuery(input)
+	require.Nil(t, err)
+	require.Equal(t, reads, storageReads)
+
+	rootHash = []byte("root hash 2")
+	_, err = executor.RunQuery(input)
+	require.Nil(t, err)
+	require.Greater(t, storageReads, reads)
+}
+
+func TestQueryExecutor_GasIsCapped(t *testing.T) {
+	args := defaultQueryExecutorArgs(counterBlockchainHook(t))
+	args.MaxGasPerQuery = 1
+	executor, err := NewQueryExecutor(args)
+	require.Nil(t, err)
+	defer func() {
+		_ = executor.Close()
+	}()
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+	vmOutput, err := executor.RunQuery(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+	require.Equal(t, uint64(1000000), input.GasProvided)
+}
+
+func TestQueryExecutor_QueueFullAndTimeout(t *testing.T) {
+	blockchain := counterBlockchainHook(t)
+	release := make(chan struct{})
+	blockchain.GetStorageDataCalled = func(_ []byte, _ []byte) ([]byte, uint32, error) {
+		<-release
+		return []byte{42}, 0, nil
+	}
+
+	args := defaultQueryExecutorArgs(blockchain)
+	args.QueryTimeout = 100 * time.Millisecond
+	executor, err := NewQueryExecutor(args)
+	require.Nil(t, err)
+
+	input := DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	// the only host is blocked by the first query, which times out
+	_, err = executor.RunQuery(input)
+	require.Equal(t, vmhost.ErrQueryTimeout, err)
+
+	_, err = executor.RunQuery(input)
+	require.Equal(t, vmhost.ErrQueryQueueFull, err)
+
+	close(release)
+	require.Nil(t, executor.Close())
+
+	_, err = executor.RunQuery(input)
+	require.Equal(t, vmhost.ErrQueryExecutorClosed, err)
+}
+
+func TestCheckQueryOutput(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{
+		GetStorageDataCalled: func(_ []byte, index []byte) ([]byte, uint32, error) {
+			return []byte("stored"), 0, nil
+		},
+	}
+
+	vmOutput := MakeVMOutput()
+	account := AddNewOutputAccount(vmOutput, userAddress, parentAddress, 0, nil)
+	SetStorageUpdate(account, []byte("key"), []byte("stored"))
+	require.Nil(t, checkQueryOutput(blockchain, vmOutput))
+
+	SetStorageUpdate(account, []byte("key"), []byte("changed"))
+	require.ErrorIs(t, checkQueryOutput(blockchain, vmOutput), vmhost.ErrQueryChangesState)
+
+	vmOutput = MakeVMOutput()
+	AddNewOutputAccount(vmOutput, userAddress, parentAddress, 10, nil)
+	require.ErrorIs(t, checkQueryOutput(blockchain, vmOutput), vmhost.ErrQueryChangesState)
+
+	vmOutput = MakeVMOutput()
+	AddNewOutputAccount(vmOutput, userAddress, childAddress, 0, []byte("data"))
+	require.ErrorIs(t, checkQueryOutput(blockchain, vmOutput), vmhost.ErrQueryChangesState)
+
+	vmOutput = MakeVMOutput()
+	vmOutput.DeletedAccounts = [][]byte{parentAddress}
+	require.ErrorIs(t, checkQueryOutput(blockchain, vmOutput), vmhost.ErrQueryChangesState)
+
+	// failed executions are never committed
+	vmOutput.ReturnCode = vmcommon.UserError
+	require.Nil(t, checkQueryOutput(blockchain, vmOutput))
+}
+
+func TestQueryResultsCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newQueryResultsCache(2)
+	output1 := MakeVMOutput()
+	output2 := MakeVMOutput()
+	output3 := MakeVMOutput()
+
+	cache.put("1", output1)
+	cache.put("2", output2)
+	_, ok := cache.get("1")
+	require.True(t, ok)
+
+	cache.put("3", output3)
+	_, ok = cache.get("2")
+	require.False(t, ok)
+	vmOutput, ok := cache.get("1")
+	require.True(t, ok)
+	require.Equal(t, output1, vmOutput)
+
+	cache.clear()
+	_, ok = cache.get("3")
+	require.False(t, ok)
+}
+
+func TestQueryResultsCache_HitsGetTheirOwnCopy(t *testing.T) {
+	cache := newQueryResultsCache(1)
+	output := MakeVMOutput()
+	output.ReturnData = [][]byte{[]byte("data")}
+	cache.put("1", output)
+
+	output.ReturnData[0][0] = 'D'
+	first, ok := cache.get("1")
+	require.True(t, ok)
+	require.False(t, first == output)
+	require.Equal(t, []byte("data"), first.ReturnData[0])
+
+	first.ReturnData[0][0] = 'D'
+	first.ReturnMessage = "changed"
+	second, ok := cache.get("1")
+	require.True(t, ok)
+	require.Equal(t, []byte("data"), second.ReturnData[0])
+	require.Empty(t, second.ReturnMessage)
+}
+
+func TestCreateQueryKey(t *testing.T) {
+	input := DefaultTestContractCallInput()
+	input.Arguments = [][]byte{{1}, {2, 3}}
+	key := createQueryKey([]byte("root"), input)
+	require.Equal(t, key, createQueryKey([]byte("root"), input))
+	require.NotEqual(t, key, createQueryKey([]byte("other root"), input))
+
+	other := *input
+	other.Arguments = [][]byte{{1, 2}, {3}}
+	require.NotEqual(t, key, createQueryKey([]byte("root"), &other))
+
+	other = *input
+	other.CallValue = big.NewInt(1)
+	require.NotEqual(t, key, createQueryKey([]byte("root"), &other))
+}
+
+func counterBlockchainHook(t *testing.T) *contextmock.BlockchainHookStub {
+	code := GetTestSCCode("counter", "../../")
+	return &contextmock.BlockchainHookStub{
+		GetUserAccountCalled: func(_ []byte) (vmcommon.UserAccountHandler, error) {
+			return &contextmock.StubAccount{}, nil
+		},
+		GetCodeCalled: func(_ vmcommon.UserAccountHandler) []byte {
+			return code
+		},
+		GetStorageDataCalled: func(_ []byte, _ []byte) ([]byte, uint32, error) {
+			return []byte{42}, 0, nil
+		},
+	}
+}
+
+func defaultQueryExecutorArgs(blockchain vmcommon.BlockchainHook) QueryExecutorArgs {
+	return QueryExecutorArgs{
+		BlockchainHook: blockchain,
+		HostParameters: &vmhost.VMHostParameters{
+			VMType:                   defaultVMType,
+			BlockGasLimit:            uint64(1000),
+			GasSchedule:              config.MakeGasMapForTests(),
+			ProtocolBuiltinFunctions: make(vmcommon.FunctionNames),
+			ProtectedKeyPrefix:       []byte("E" + "L" + "R" + "O" + "N" + "D"),
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+					return flag == SCDeployFlag || flag == AheadOfTimeGasUsageFlag || flag == RepairCallbackFlag || flag == BuiltInFunctionsFlag
+				},
+			},
+		},
+		NumHosts: 1,
+	}
+}
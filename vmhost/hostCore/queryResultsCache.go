@@ -0,0 +1,102 @@
+package hostCore
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/vmoutput"
+)
+
+// queryResultsCache holds the outputs of the latest queries, evicting the least recently used ones;
+// the outputs are held in their canonical form, so that each hit gets its own copy
+type queryResultsCache struct {
+	mutex    sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type queryResultsCacheEntry struct {
+	key      string
+	vmOutput *vmoutput.VMOutput
+}
+
+func newQueryResultsCache(capacity int) *queryResultsCache {
+	return &queryResultsCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// createQueryKey identifies a query within a state: besides the contract, function and arguments,
+// the caller, the value and the gas are part of the key, as the output of the query depends on them, too
+func createQueryKey(stateRootHash []byte, input *vmcommon.ContractCallInput) string {
+	builder := &strings.Builder{}
+	_, _ = fmt.Fprintf(builder, "%x/%x/%s", stateRootHash, input.RecipientAddr, input.Function)
+	for _, argument := range input.Arguments {
+		_, _ = fmt.Fprintf(builder, "@%x", argument)
+	}
+
+	callValue := input.CallValue
+	if callValue == nil {
+		callValue = big.NewInt(0)
+	}
+	_, _ = fmt.Fprintf(builder, "/%x/%s/%d", input.CallerAddr, callValue.String(), input.GasProvided)
+	return builder.String()
+}
+
+func (cache *queryResultsCache) get(key string) (*vmcommon.VMOutput, bool) {
+	canonical, ok := cache.getCanonical(key)
+	if !ok {
+		return nil, false
+	}
+
+	return canonical.ToVMOutput(), true
+}
+
+func (cache *queryResultsCache) getCanonical(key string) (*vmoutput.VMOutput, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	cache.order.MoveToFront(element)
+	return element.Value.(*queryResultsCacheEntry).vmOutput, true
+}
+
+func (cache *queryResultsCache) put(key string, vmOutput *vmcommon.VMOutput) {
+	canonical := vmoutput.NewVMOutput(vmOutput)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+	if ok {
+		element.Value.(*queryResultsCacheEntry).vmOutput = canonical
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(&queryResultsCacheEntry{key: key, vmOutput: canonical})
+	if cache.order.Len() > cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*queryResultsCacheEntry).key)
+	}
+}
+
+func (cache *queryResultsCache) clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.order.Init()
+	cache.entries = make(map[string]*list.Element)
+}
@@ -435,6 +435,23 @@ func LoadTomlFileToMap(relativePath string) (map[string]interface{}, error) {
 	return loadedMap, nil
 }
 
+// LoadGasScheduleByName loads the gas schedule selected by the argument of a tool: "dummy" for the
+// test costs, "v1", "v2" or "v3" for the schedule files of the given directory, or else a file path.
+func LoadGasScheduleByName(name string, gasSchedulesDir string) (config.GasScheduleMap, error) {
+	switch name {
+	case "dummy":
+		return config.MakeGasMapForTests(), nil
+	case "v1", "v2", "v3":
+		if len(gasSchedulesDir) == 0 {
+			return nil, fmt.Errorf("no directory given to load gas schedule %s from", name)
+		}
+		fileName := fmt.Sprintf("gasScheduleV%s.toml", name[1:])
+		return LoadGasScheduleConfig(filepath.Join(gasSchedulesDir, fileName))
+	default:
+		return LoadGasScheduleConfig(name)
+	}
+}
+
 // LoadGasScheduleConfig parses and prepares a gas schedule read from file.
 func LoadGasScheduleConfig(filepath string) (config.GasScheduleMap, error) {
 	gasScheduleConfig, err := LoadTomlFileToMap(filepath)
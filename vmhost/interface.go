@@ -41,6 +41,10 @@ type VMHost interface {
 	IsDynamicGasLockingEnabled() bool
 	IsVMV3Enabled() bool
 	IsESDTFunctionsEnabled() bool
+	IsFloatingPointRejectionEnabled() bool
+	IsCodeMetadataEnforcementEnabled() bool
+	IsMemoryAccountingEnabled() bool
+	IsEmitEventEnabled() bool
 
 	ExecuteESDTTransfer(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callType vm.CallType, isRevert bool) (*vmcommon.VMOutput, uint64, error)
 	RevertESDTTransfer(input *vmcommon.ContractCallInput)
@@ -51,6 +55,7 @@ type VMHost interface {
 	GetProtocolBuiltinFunctions() vmcommon.FunctionNames
 	IsBuiltinFunctionName(functionName string) bool
 	AreInSameShard(leftAddress []byte, rightAddress []byte) bool
+	Coverage() CoverageCollector
 }
 
 // BlockchainContext defines the functionality needed for interacting with the blockchain context
@@ -80,6 +85,7 @@ type BlockchainContext interface {
 	GetShardOfAddress(addr []byte) uint32
 	IsSmartContract(addr []byte) bool
 	IsPayable(address []byte) (bool, error)
+	GetCodeMetadata(address []byte) (*vmcommon.CodeMetadata, error)
 	SaveCompiledCode(codeHash []byte, code []byte)
 	GetCompiledCode(codeHash []byte) (bool, []byte)
 	GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error)
@@ -123,6 +129,10 @@ type RuntimeContext interface {
 	StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error
 	CleanWasmerInstance()
 	SetMaxInstanceCount(uint64)
+	SetMaxMemoryPages(maxMemoryPages uint64)
+	UpdateMemoryUsage() error
+	MemoryPagesInUse() uint64
+	PeakMemoryPages() uint64
 	VerifyContractCode() error
 	GetInstanceExports() wasmer.ExportsMap
 	GetInitFunction() wasmer.ExportedFunctionCallback
@@ -239,7 +249,7 @@ type StorageContext interface {
 
 	SetAddress(address []byte)
 	GetStorageUpdates(address []byte) map[string]*vmcommon.StorageUpdate
-	GetStorageFromAddress(address []byte, key []byte) []byte
+	GetStorageFromAddress(address []byte, key []byte) ([]byte, error)
 	GetStorage(key []byte) []byte
 	GetStorageUnmetered(key []byte) []byte
 	SetStorage(key []byte, value []byte) (StorageStatus, error)
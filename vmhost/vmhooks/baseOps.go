@@ -36,6 +36,7 @@ package vmhooks
 // extern int32_t		v1_2_getCallValueTokenName(void *context, int32_t callValueOffset, int32_t tokenNameOffset);
 // extern void			v1_2_writeLog(void *context, int32_t pointer, int32_t length, int32_t topicPtr, int32_t numTopics);
 // extern void 			v1_2_writeEventLog(void *context, int32_t numTopics, int32_t topicLengthsOffset, int32_t topicOffset, int32_t dataOffset, int32_t dataLength);
+// extern void 			v1_2_emitEvent(void *context, int32_t identifierOffset, int32_t identifierLength, int32_t numTopics, int32_t topicLengthsOffset, int32_t topicOffset, int32_t dataOffset, int32_t dataLength);
 // extern void 			v1_2_returnData(void* context, int32_t dataOffset, int32_t length);
 // extern void 			v1_2_signalError(void* context, int32_t messageOffset, int32_t messageLength);
 // extern long long v1_2_getGasLeft(void *context);
@@ -302,6 +303,11 @@ func BaseOpsAPIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("emitEvent", v1_2_emitEvent, C.v1_2_emitEvent)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("finish", v1_2_returnData, C.v1_2_returnData)
 	if err != nil {
 		return nil, err
@@ -1397,7 +1403,10 @@ func v1_2_storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, ke
 		return -1
 	}
 
-	data := storage.GetStorageFromAddress(address, key)
+	data, err := storage.GetStorageFromAddress(address, key)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
 
 	err = runtime.MemStore(dataOffset, data)
 	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
@@ -1626,7 +1635,10 @@ func v1_2_getCurrentESDTNFTNonce(context unsafe.Pointer, addressOffset int32, to
 	}
 
 	key := []byte(core.ProtectedKeyPrefix + core.ESDTNFTLatestNonceIdentifier + string(tokenID))
-	data := storage.GetStorageFromAddress(destination, key)
+	data, err := storage.GetStorageFromAddress(destination, key)
+	if err != nil {
+		return 0
+	}
 
 	nonce := big.NewInt(0).SetBytes(data).Uint64()
 	return int64(nonce)
@@ -1747,6 +1759,76 @@ func v1_2_writeEventLog(
 	output.WriteLog(runtime.GetSCAddress(), topics, data)
 }
 
+//export v1_2_emitEvent
+func v1_2_emitEvent(
+	context unsafe.Pointer,
+	identifierOffset int32,
+	identifierLength int32,
+	numTopics int32,
+	topicLengthsOffset int32,
+	topicOffset int32,
+	dataOffset int32,
+	dataLength int32) {
+
+	host := vmhost.GetVMHost(context)
+	runtime := vmhost.GetRuntimeContext(context)
+	output := vmhost.GetOutputContext(context)
+	metering := vmhost.GetMeteringContext(context)
+
+	eventCosts := metering.GasSchedule().EventLogCost
+	gasToUse := math.AddUint64(
+		eventCosts.EmitEvent,
+		math.MulUint64(eventCosts.PerTopic, uint64(numTopics)))
+	metering.UseGas(gasToUse)
+
+	if numTopics >= 0 && uint64(numTopics) > eventCosts.MaxTopics {
+		_ = vmhost.WithFault(vmhost.ErrTooManyEventTopics, context, true)
+		return
+	}
+
+	if dataLength >= 0 && uint64(dataLength) > eventCosts.MaxDataLength {
+		_ = vmhost.WithFault(vmhost.ErrEventDataTooLarge, context, true)
+		return
+	}
+
+	identifier, err := runtime.MemLoad(identifierOffset, identifierLength)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	if len(identifier) == 0 {
+		_ = vmhost.WithFault(vmhost.ErrEmptyEventIdentifier, context, true)
+		return
+	}
+
+	topics, topicDataTotalLen, err := getArgumentsFromMemory(
+		host,
+		numTopics,
+		topicLengthsOffset,
+		topicOffset,
+	)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if vmhost.WithFault(err, context, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	gasForData := math.MulUint64(
+		eventCosts.PerDataByte,
+		uint64(identifierLength+topicDataTotalLen+dataLength))
+	metering.UseGas(gasForData)
+
+	// the identifier is passed as the first topic, which the output context
+	// moves into the dedicated field of the log entry
+	identifierAndTopics := make([][]byte, 0, len(topics)+1)
+	identifierAndTopics = append(identifierAndTopics, identifier)
+	identifierAndTopics = append(identifierAndTopics, topics...)
+	output.WriteLog(runtime.GetSCAddress(), identifierAndTopics, data)
+}
+
 //export v1_2_getBlockTimestamp
 func v1_2_getBlockTimestamp(context unsafe.Pointer) int64 {
 	blockchain := vmhost.GetBlockchainContext(context)
@@ -0,0 +1,45 @@
+package vmhooks
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef int int32_t;
+//
+// extern void v1_2_coverageFunctionEntered(void *context, int32_t codeID, int32_t functionIndex);
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/wasmer"
+)
+
+// CoverageImports adds the hook called by the functions of the contracts instrumented for coverage
+func CoverageImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace(vmhost.CoverageHookModule)
+
+	imports, err := imports.Append(vmhost.CoverageHookName, v1_2_coverageFunctionEntered, C.v1_2_coverageFunctionEntered)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+//export v1_2_coverageFunctionEntered
+func v1_2_coverageFunctionEntered(context unsafe.Pointer, codeID int32, functionIndex int32) {
+	host := vmhost.GetVMHost(context)
+	metering := host.Metering()
+
+	// the call to this hook is not part of the contract, so its gas is given back; it is charged
+	// beforehand though, so a contract instrumented for coverage may run out of gas where it would not otherwise
+	opcodeCosts := metering.GasSchedule().WASMOpcodeCost
+	metering.RestoreGas(uint64(2*opcodeCosts.I32Const + opcodeCosts.Call))
+
+	coverage := host.Coverage()
+	if coverage == nil {
+		return
+	}
+	coverage.FunctionEntered(codeID, functionIndex)
+}
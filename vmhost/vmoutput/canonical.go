@@ -0,0 +1,438 @@
+package vmoutput
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// Bytes is a byte slice encoded as a hex string; a nil slice is encoded as null,
+// while an empty slice is encoded as an empty string
+type Bytes []byte
+
+// MarshalJSON encodes the bytes as a hex string, or as null if they are nil
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(hex.EncodeToString(b))
+}
+
+// UnmarshalJSON decodes the bytes from a hex string, or from null
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*b = nil
+		return nil
+	}
+
+	var encoded string
+	err := json.Unmarshal(data, &encoded)
+	if err != nil {
+		return err
+	}
+
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	*b = make(Bytes, len(decoded))
+	copy(*b, decoded)
+	return nil
+}
+
+// BigInt is a big integer encoded as a decimal string; a nil value is encoded as null
+type BigInt struct {
+	value *big.Int
+}
+
+// NewBigInt wraps the given big integer
+func NewBigInt(value *big.Int) *BigInt {
+	if value == nil {
+		return nil
+	}
+
+	return &BigInt{value: big.NewInt(0).Set(value)}
+}
+
+// Value returns a copy of the wrapped big integer
+func (b *BigInt) Value() *big.Int {
+	if b == nil || b.value == nil {
+		return nil
+	}
+
+	return big.NewInt(0).Set(b.value)
+}
+
+// String returns the decimal representation of the big integer
+func (b *BigInt) String() string {
+	if b == nil || b.value == nil {
+		return "nil"
+	}
+
+	return b.value.String()
+}
+
+// MarshalJSON encodes the big integer as a decimal string
+func (b *BigInt) MarshalJSON() ([]byte, error) {
+	if b.value == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(b.value.String())
+}
+
+// UnmarshalJSON decodes the big integer from a decimal string
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	var encoded string
+	err := json.Unmarshal(data, &encoded)
+	if err != nil {
+		return err
+	}
+
+	value, ok := big.NewInt(0).SetString(encoded, 10)
+	if !ok {
+		return ErrInvalidBigInt
+	}
+
+	b.value = value
+	return nil
+}
+
+// VMOutput is the canonical form of a vmcommon.VMOutput: the maps are replaced by
+// lists sorted by key, so that the encoding of equal outputs is always the same
+type VMOutput struct {
+	ReturnData      []Bytes          `json:"returnData"`
+	ReturnCode      uint64           `json:"returnCode"`
+	ReturnMessage   string           `json:"returnMessage"`
+	GasRemaining    uint64           `json:"gasRemaining"`
+	GasRefund       *BigInt          `json:"gasRefund"`
+	OutputAccounts  []*OutputAccount `json:"outputAccounts"`
+	DeletedAccounts []Bytes          `json:"deletedAccounts"`
+	TouchedAccounts []Bytes          `json:"touchedAccounts"`
+	Logs            []*LogEntry      `json:"logs"`
+}
+
+// OutputAccount is the canonical form of a vmcommon.OutputAccount
+type OutputAccount struct {
+	Key                           Bytes             `json:"key"`
+	Address                       Bytes             `json:"address"`
+	Nonce                         uint64            `json:"nonce"`
+	Balance                       *BigInt           `json:"balance"`
+	BalanceDelta                  *BigInt           `json:"balanceDelta"`
+	StorageUpdates                []*StorageUpdate  `json:"storageUpdates"`
+	Code                          Bytes             `json:"code"`
+	CodeMetadata                  Bytes             `json:"codeMetadata"`
+	CodeDeployerAddress           Bytes             `json:"codeDeployerAddress"`
+	OutputTransfers               []*OutputTransfer `json:"outputTransfers"`
+	GasUsed                       uint64            `json:"gasUsed"`
+	BytesAddedToStorage           uint64            `json:"bytesAddedToStorage"`
+	BytesDeletedFromStorage       uint64            `json:"bytesDeletedFromStorage"`
+	BytesConsumedByTxAsNetworking uint64            `json:"bytesConsumedByTxAsNetworking"`
+}
+
+// StorageUpdate is the canonical form of a vmcommon.StorageUpdate
+type StorageUpdate struct {
+	Key     Bytes `json:"key"`
+	Offset  Bytes `json:"offset"`
+	Data    Bytes `json:"data"`
+	Written bool  `json:"written"`
+}
+
+// OutputTransfer is the canonical form of a vmcommon.OutputTransfer
+type OutputTransfer struct {
+	Index         uint32  `json:"index"`
+	Value         *BigInt `json:"value"`
+	GasLimit      uint64  `json:"gasLimit"`
+	GasLocked     uint64  `json:"gasLocked"`
+	AsyncData     Bytes   `json:"asyncData"`
+	Data          Bytes   `json:"data"`
+	CallType      uint64  `json:"callType"`
+	SenderAddress Bytes   `json:"senderAddress"`
+}
+
+// LogEntry is the canonical form of a vmcommon.LogEntry
+type LogEntry struct {
+	Identifier Bytes   `json:"identifier"`
+	Address    Bytes   `json:"address"`
+	Topics     []Bytes `json:"topics"`
+	Data       []Bytes `json:"data"`
+}
+
+// NewVMOutput converts a vmcommon.VMOutput to its canonical form
+func NewVMOutput(vmOutput *vmcommon.VMOutput) *VMOutput {
+	if vmOutput == nil {
+		return nil
+	}
+
+	canonical := &VMOutput{
+		ReturnData:      newBytesList(vmOutput.ReturnData),
+		ReturnCode:      uint64(vmOutput.ReturnCode),
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       NewBigInt(vmOutput.GasRefund),
+		DeletedAccounts: newBytesList(vmOutput.DeletedAccounts),
+		TouchedAccounts: newBytesList(vmOutput.TouchedAccounts),
+	}
+
+	if vmOutput.OutputAccounts != nil {
+		canonical.OutputAccounts = make([]*OutputAccount, 0, len(vmOutput.OutputAccounts))
+		for _, key := range sortedKeys(outputAccountKeys(vmOutput.OutputAccounts)) {
+			canonical.OutputAccounts = append(canonical.OutputAccounts, newOutputAccount(key, vmOutput.OutputAccounts[key]))
+		}
+	}
+
+	if vmOutput.Logs != nil {
+		canonical.Logs = make([]*LogEntry, len(vmOutput.Logs))
+		for i, entry := range vmOutput.Logs {
+			canonical.Logs[i] = newLogEntry(entry)
+		}
+	}
+
+	return canonical
+}
+
+func newOutputAccount(key string, account *vmcommon.OutputAccount) *OutputAccount {
+	if account == nil {
+		return &OutputAccount{Key: Bytes(key)}
+	}
+
+	canonical := &OutputAccount{
+		Key:                           Bytes(key),
+		Address:                       cloneBytes(account.Address),
+		Nonce:                         account.Nonce,
+		Balance:                       NewBigInt(account.Balance),
+		BalanceDelta:                  NewBigInt(account.BalanceDelta),
+		Code:                          cloneBytes(account.Code),
+		CodeMetadata:                  cloneBytes(account.CodeMetadata),
+		CodeDeployerAddress:           cloneBytes(account.CodeDeployerAddress),
+		GasUsed:                       account.GasUsed,
+		BytesAddedToStorage:           account.BytesAddedToStorage,
+		BytesDeletedFromStorage:       account.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: account.BytesConsumedByTxAsNetworking,
+	}
+
+	if account.StorageUpdates != nil {
+		canonical.StorageUpdates = make([]*StorageUpdate, 0, len(account.StorageUpdates))
+		for _, storageKey := range sortedKeys(storageUpdateKeys(account.StorageUpdates)) {
+			canonical.StorageUpdates = append(canonical.StorageUpdates, newStorageUpdate(storageKey, account.StorageUpdates[storageKey]))
+		}
+	}
+
+	if account.OutputTransfers != nil {
+		canonical.OutputTransfers = make([]*OutputTransfer, len(account.OutputTransfers))
+		for i, transfer := range account.OutputTransfers {
+			canonical.OutputTransfers[i] = &OutputTransfer{
+				Index:         transfer.Index,
+				Value:         NewBigInt(transfer.Value),
+				GasLimit:      transfer.GasLimit,
+				GasLocked:     transfer.GasLocked,
+				AsyncData:     cloneBytes(transfer.AsyncData),
+				Data:          cloneBytes(transfer.Data),
+				CallType:      uint64(transfer.CallType),
+				SenderAddress: cloneBytes(transfer.SenderAddress),
+			}
+		}
+	}
+
+	return canonical
+}
+
+func newStorageUpdate(key string, update *vmcommon.StorageUpdate) *StorageUpdate {
+	if update == nil {
+		return &StorageUpdate{Key: Bytes(key)}
+	}
+
+	return &StorageUpdate{
+		Key:     Bytes(key),
+		Offset:  cloneBytes(update.Offset),
+		Data:    cloneBytes(update.Data),
+		Written: update.Written,
+	}
+}
+
+func newLogEntry(entry *vmcommon.LogEntry) *LogEntry {
+	if entry == nil {
+		return nil
+	}
+
+	return &LogEntry{
+		Identifier: cloneBytes(entry.Identifier),
+		Address:    cloneBytes(entry.Address),
+		Topics:     newBytesList(entry.Topics),
+		Data:       newBytesList(entry.Data),
+	}
+}
+
+// ToVMOutput converts the canonical form back to a vmcommon.VMOutput
+func (output *VMOutput) ToVMOutput() *vmcommon.VMOutput {
+	if output == nil {
+		return nil
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnData:      toBytesList(output.ReturnData),
+		ReturnCode:      vmcommon.ReturnCode(output.ReturnCode),
+		ReturnMessage:   output.ReturnMessage,
+		GasRemaining:    output.GasRemaining,
+		GasRefund:       output.GasRefund.Value(),
+		DeletedAccounts: toBytesList(output.DeletedAccounts),
+		TouchedAccounts: toBytesList(output.TouchedAccounts),
+	}
+
+	if output.OutputAccounts != nil {
+		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount, len(output.OutputAccounts))
+		for _, account := range output.OutputAccounts {
+			vmOutput.OutputAccounts[string(account.Key)] = account.toOutputAccount()
+		}
+	}
+
+	if output.Logs != nil {
+		vmOutput.Logs = make([]*vmcommon.LogEntry, len(output.Logs))
+		for i, entry := range output.Logs {
+			vmOutput.Logs[i] = entry.toLogEntry()
+		}
+	}
+
+	return vmOutput
+}
+
+func (account *OutputAccount) toOutputAccount() *vmcommon.OutputAccount {
+	outputAccount := &vmcommon.OutputAccount{
+		Address:                       cloneBytes(account.Address),
+		Nonce:                         account.Nonce,
+		Balance:                       account.Balance.Value(),
+		BalanceDelta:                  account.BalanceDelta.Value(),
+		Code:                          cloneBytes(account.Code),
+		CodeMetadata:                  cloneBytes(account.CodeMetadata),
+		CodeDeployerAddress:           cloneBytes(account.CodeDeployerAddress),
+		GasUsed:                       account.GasUsed,
+		BytesAddedToStorage:           account.BytesAddedToStorage,
+		BytesDeletedFromStorage:       account.BytesDeletedFromStorage,
+		BytesConsumedByTxAsNetworking: account.BytesConsumedByTxAsNetworking,
+	}
+
+	if account.StorageUpdates != nil {
+		outputAccount.StorageUpdates = make(map[string]*vmcommon.StorageUpdate, len(account.StorageUpdates))
+		for _, update := range account.StorageUpdates {
+			outputAccount.StorageUpdates[string(update.Key)] = &vmcommon.StorageUpdate{
+				Offset:  cloneBytes(update.Offset),
+				Data:    cloneBytes(update.Data),
+				Written: update.Written,
+			}
+		}
+	}
+
+	if account.OutputTransfers != nil {
+		outputAccount.OutputTransfers = make([]vmcommon.OutputTransfer, len(account.OutputTransfers))
+		for i, transfer := range account.OutputTransfers {
+			outputAccount.OutputTransfers[i] = vmcommon.OutputTransfer{
+				Index:         transfer.Index,
+				Value:         transfer.Value.Value(),
+				GasLimit:      transfer.GasLimit,
+				GasLocked:     transfer.GasLocked,
+				AsyncData:     cloneBytes(transfer.AsyncData),
+				Data:          cloneBytes(transfer.Data),
+				CallType:      vm.CallType(transfer.CallType),
+				SenderAddress: cloneBytes(transfer.SenderAddress),
+			}
+		}
+	}
+
+	return outputAccount
+}
+
+func (entry *LogEntry) toLogEntry() *vmcommon.LogEntry {
+	if entry == nil {
+		return nil
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: cloneBytes(entry.Identifier),
+		Address:    cloneBytes(entry.Address),
+		Topics:     toBytesList(entry.Topics),
+		Data:       toBytesList(entry.Data),
+	}
+}
+
+// SortedOutputAccounts returns the output accounts of a VMOutput, sorted by their key in the map
+func SortedOutputAccounts(outputAccounts map[string]*vmcommon.OutputAccount) []*vmcommon.OutputAccount {
+	sorted := make([]*vmcommon.OutputAccount, 0, len(outputAccounts))
+	for _, key := range sortedKeys(outputAccountKeys(outputAccounts)) {
+		sorted = append(sorted, outputAccounts[key])
+	}
+
+	return sorted
+}
+
+// SortedStorageUpdates returns the storage updates of an output account, sorted by their key in the map
+func SortedStorageUpdates(storageUpdates map[string]*vmcommon.StorageUpdate) []*vmcommon.StorageUpdate {
+	sorted := make([]*vmcommon.StorageUpdate, 0, len(storageUpdates))
+	for _, key := range sortedKeys(storageUpdateKeys(storageUpdates)) {
+		sorted = append(sorted, storageUpdates[key])
+	}
+
+	return sorted
+}
+
+func outputAccountKeys(outputAccounts map[string]*vmcommon.OutputAccount) []string {
+	keys := make([]string, 0, len(outputAccounts))
+	for key := range outputAccounts {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func storageUpdateKeys(storageUpdates map[string]*vmcommon.StorageUpdate) []string {
+	keys := make([]string, 0, len(storageUpdates))
+	for key := range storageUpdates {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+func newBytesList(values [][]byte) []Bytes {
+	if values == nil {
+		return nil
+	}
+
+	list := make([]Bytes, len(values))
+	for i, value := range values {
+		list[i] = cloneBytes(value)
+	}
+
+	return list
+}
+
+func toBytesList(values []Bytes) [][]byte {
+	if values == nil {
+		return nil
+	}
+
+	list := make([][]byte, len(values))
+	for i, value := range values {
+		list[i] = cloneBytes(value)
+	}
+
+	return list
+}
+
+func cloneBytes(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+
+	clone := make([]byte, len(value))
+	copy(clone, value)
+	return clone
+}
@@ -0,0 +1,269 @@
+package vmoutput
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	nilValue     = "nil"
+	missingValue = "<missing>"
+	presentValue = "present"
+)
+
+// FieldDiff is a single field that differs between two VMOutputs
+type FieldDiff struct {
+	Field  string `json:"field"`
+	First  string `json:"first"`
+	Second string `json:"second"`
+}
+
+type differ struct {
+	diffs []*FieldDiff
+}
+
+// Diff compares two VMOutputs field by field, in their canonical form; the output accounts,
+// storage updates, transfers and logs are compared entry by entry. It returns no differences
+// exactly when the canonical encodings of the two outputs are equal.
+func Diff(first *vmcommon.VMOutput, second *vmcommon.VMOutput) []*FieldDiff {
+	d := &differ{
+		diffs: make([]*FieldDiff, 0),
+	}
+	d.diffVMOutputs(NewVMOutput(first), NewVMOutput(second))
+
+	return d.diffs
+}
+
+func (d *differ) add(field string, first string, second string) {
+	d.diffs = append(d.diffs, &FieldDiff{
+		Field:  field,
+		First:  first,
+		Second: second,
+	})
+}
+
+func (d *differ) diffVMOutputs(first *VMOutput, second *VMOutput) {
+	if first == nil || second == nil {
+		if first != second {
+			d.add("vmOutput", nilness(first == nil), nilness(second == nil))
+		}
+		return
+	}
+
+	d.diffUint64("returnCode", first.ReturnCode, second.ReturnCode)
+	d.diffString("returnMessage", first.ReturnMessage, second.ReturnMessage)
+	d.diffBytesList("returnData", first.ReturnData, second.ReturnData)
+	d.diffUint64("gasRemaining", first.GasRemaining, second.GasRemaining)
+	d.diffBigInt("gasRefund", first.GasRefund, second.GasRefund)
+	d.diffOutputAccounts(first.OutputAccounts, second.OutputAccounts)
+	d.diffBytesList("deletedAccounts", first.DeletedAccounts, second.DeletedAccounts)
+	d.diffBytesList("touchedAccounts", first.TouchedAccounts, second.TouchedAccounts)
+	d.diffLogs(first.Logs, second.Logs)
+}
+
+func (d *differ) diffString(field string, first string, second string) {
+	if first != second {
+		d.add(field, first, second)
+	}
+}
+
+func (d *differ) diffUint64(field string, first uint64, second uint64) {
+	if first != second {
+		d.add(field, fmt.Sprintf("%d", first), fmt.Sprintf("%d", second))
+	}
+}
+
+func (d *differ) diffBool(field string, first bool, second bool) {
+	if first != second {
+		d.add(field, fmt.Sprintf("%t", first), fmt.Sprintf("%t", second))
+	}
+}
+
+func (d *differ) diffBigInt(field string, first *BigInt, second *BigInt) {
+	if first.String() != second.String() {
+		d.add(field, first.String(), second.String())
+	}
+}
+
+func (d *differ) diffBytes(field string, first Bytes, second Bytes) {
+	if !bytes.Equal(first, second) || (first == nil) != (second == nil) {
+		d.add(field, FormatBytes(first), FormatBytes(second))
+	}
+}
+
+func (d *differ) diffBytesList(field string, first []Bytes, second []Bytes) {
+	if (first == nil) != (second == nil) {
+		d.add(field, nilness(first == nil), nilness(second == nil))
+		return
+	}
+
+	d.diffUint64(field+".length", uint64(len(first)), uint64(len(second)))
+	for i := 0; i < len(first) && i < len(second); i++ {
+		d.diffBytes(fmt.Sprintf("%s[%d]", field, i), first[i], second[i])
+	}
+}
+
+func (d *differ) diffOutputAccounts(first []*OutputAccount, second []*OutputAccount) {
+	if (first == nil) != (second == nil) {
+		d.add("outputAccounts", nilness(first == nil), nilness(second == nil))
+		return
+	}
+
+	firstByKey := make(map[string]*OutputAccount, len(first))
+	keys := make([]string, 0, len(first)+len(second))
+	for _, account := range first {
+		firstByKey[string(account.Key)] = account
+		keys = append(keys, string(account.Key))
+	}
+	secondByKey := make(map[string]*OutputAccount, len(second))
+	for _, account := range second {
+		secondByKey[string(account.Key)] = account
+		_, exists := firstByKey[string(account.Key)]
+		if !exists {
+			keys = append(keys, string(account.Key))
+		}
+	}
+
+	for _, key := range sortedKeys(keys) {
+		field := fmt.Sprintf("outputAccounts[%s]", FormatBytes([]byte(key)))
+		firstAccount := firstByKey[key]
+		secondAccount := secondByKey[key]
+		if firstAccount == nil || secondAccount == nil {
+			d.add(field, presence(firstAccount != nil), presence(secondAccount != nil))
+			continue
+		}
+
+		d.diffOutputAccount(field, firstAccount, secondAccount)
+	}
+}
+
+func (d *differ) diffOutputAccount(field string, first *OutputAccount, second *OutputAccount) {
+	d.diffBytes(field+".address", first.Address, second.Address)
+	d.diffUint64(field+".nonce", first.Nonce, second.Nonce)
+	d.diffBigInt(field+".balance", first.Balance, second.Balance)
+	d.diffBigInt(field+".balanceDelta", first.BalanceDelta, second.BalanceDelta)
+	d.diffStorageUpdates(field+".storage", first.StorageUpdates, second.StorageUpdates)
+	d.diffBytes(field+".code", first.Code, second.Code)
+	d.diffBytes(field+".codeMetadata", first.CodeMetadata, second.CodeMetadata)
+	d.diffBytes(field+".codeDeployerAddress", first.CodeDeployerAddress, second.CodeDeployerAddress)
+	d.diffOutputTransfers(field+".outputTransfers", first.OutputTransfers, second.OutputTransfers)
+	d.diffUint64(field+".gasUsed", first.GasUsed, second.GasUsed)
+	d.diffUint64(field+".bytesAddedToStorage", first.BytesAddedToStorage, second.BytesAddedToStorage)
+	d.diffUint64(field+".bytesDeletedFromStorage", first.BytesDeletedFromStorage, second.BytesDeletedFromStorage)
+	d.diffUint64(field+".bytesConsumedByTxAsNetworking", first.BytesConsumedByTxAsNetworking, second.BytesConsumedByTxAsNetworking)
+}
+
+func (d *differ) diffStorageUpdates(field string, first []*StorageUpdate, second []*StorageUpdate) {
+	if (first == nil) != (second == nil) {
+		d.add(field, nilness(first == nil), nilness(second == nil))
+		return
+	}
+
+	firstByKey := make(map[string]*StorageUpdate, len(first))
+	keys := make([]string, 0, len(first)+len(second))
+	for _, update := range first {
+		firstByKey[string(update.Key)] = update
+		keys = append(keys, string(update.Key))
+	}
+	secondByKey := make(map[string]*StorageUpdate, len(second))
+	for _, update := range second {
+		secondByKey[string(update.Key)] = update
+		_, exists := firstByKey[string(update.Key)]
+		if !exists {
+			keys = append(keys, string(update.Key))
+		}
+	}
+
+	for _, key := range sortedKeys(keys) {
+		name := fmt.Sprintf("%s[%s]", field, FormatBytes([]byte(key)))
+		firstUpdate := firstByKey[key]
+		secondUpdate := secondByKey[key]
+		if firstUpdate == nil || secondUpdate == nil {
+			d.add(name, storageValue(firstUpdate), storageValue(secondUpdate))
+			continue
+		}
+
+		d.diffBytes(name+".offset", firstUpdate.Offset, secondUpdate.Offset)
+		d.diffBytes(name, firstUpdate.Data, secondUpdate.Data)
+		d.diffBool(name+".written", firstUpdate.Written, secondUpdate.Written)
+	}
+}
+
+func (d *differ) diffOutputTransfers(field string, first []*OutputTransfer, second []*OutputTransfer) {
+	if (first == nil) != (second == nil) {
+		d.add(field, nilness(first == nil), nilness(second == nil))
+		return
+	}
+
+	d.diffUint64(field+".length", uint64(len(first)), uint64(len(second)))
+	for i := 0; i < len(first) && i < len(second); i++ {
+		name := fmt.Sprintf("%s[%d]", field, i)
+		d.diffUint64(name+".index", uint64(first[i].Index), uint64(second[i].Index))
+		d.diffBigInt(name+".value", first[i].Value, second[i].Value)
+		d.diffUint64(name+".gasLimit", first[i].GasLimit, second[i].GasLimit)
+		d.diffUint64(name+".gasLocked", first[i].GasLocked, second[i].GasLocked)
+		d.diffBytes(name+".asyncData", first[i].AsyncData, second[i].AsyncData)
+		d.diffBytes(name+".data", first[i].Data, second[i].Data)
+		d.diffUint64(name+".callType", first[i].CallType, second[i].CallType)
+		d.diffBytes(name+".senderAddress", first[i].SenderAddress, second[i].SenderAddress)
+	}
+}
+
+func (d *differ) diffLogs(first []*LogEntry, second []*LogEntry) {
+	if (first == nil) != (second == nil) {
+		d.add("logs", nilness(first == nil), nilness(second == nil))
+		return
+	}
+
+	d.diffUint64("logs.length", uint64(len(first)), uint64(len(second)))
+	for i := 0; i < len(first) && i < len(second); i++ {
+		name := fmt.Sprintf("logs[%d]", i)
+		if first[i] == nil || second[i] == nil {
+			if first[i] != second[i] {
+				d.add(name, nilness(first[i] == nil), nilness(second[i] == nil))
+			}
+			continue
+		}
+
+		d.diffBytes(name+".identifier", first[i].Identifier, second[i].Identifier)
+		d.diffBytes(name+".address", first[i].Address, second[i].Address)
+		d.diffBytesList(name+".topics", first[i].Topics, second[i].Topics)
+		d.diffBytesList(name+".data", first[i].Data, second[i].Data)
+	}
+}
+
+// FormatBytes renders bytes as a 0x-prefixed hex string, telling nil and empty slices apart
+func FormatBytes(value []byte) string {
+	if value == nil {
+		return nilValue
+	}
+	if len(value) == 0 {
+		return `""`
+	}
+
+	return "0x" + hex.EncodeToString(value)
+}
+
+func storageValue(update *StorageUpdate) string {
+	if update == nil {
+		return missingValue
+	}
+	return FormatBytes(update.Data)
+}
+
+func nilness(isNil bool) string {
+	if isNil {
+		return nilValue
+	}
+	return presentValue
+}
+
+func presence(isPresent bool) string {
+	if isPresent {
+		return presentValue
+	}
+	return missingValue
+}
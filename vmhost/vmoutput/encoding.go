@@ -0,0 +1,37 @@
+package vmoutput
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// Marshal encodes a VMOutput canonically: the output accounts and the storage updates
+// are sorted by key, the byte slices are hex strings, the big integers are decimal strings,
+// and nil values are kept apart from empty ones
+func Marshal(vmOutput *vmcommon.VMOutput) ([]byte, error) {
+	return json.Marshal(NewVMOutput(vmOutput))
+}
+
+// Unmarshal decodes a VMOutput encoded by Marshal
+func Unmarshal(data []byte) (*vmcommon.VMOutput, error) {
+	canonical := &VMOutput{}
+	err := json.Unmarshal(data, &canonical)
+	if err != nil {
+		return nil, err
+	}
+
+	return canonical.ToVMOutput(), nil
+}
+
+// Hash computes a stable hash of a VMOutput, over its canonical encoding
+func Hash(vmOutput *vmcommon.VMOutput) ([]byte, error) {
+	encoded, err := Marshal(vmOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(encoded)
+	return hash[:], nil
+}
@@ -0,0 +1,6 @@
+package vmoutput
+
+import "errors"
+
+// ErrInvalidBigInt signals that an encoded big integer is not a decimal number
+var ErrInvalidBigInt = errors.New("invalid encoded big integer")
@@ -0,0 +1,83 @@
+package vmoutput
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// PrettyPrint renders a VMOutput in a human-readable form, in canonical order
+func PrettyPrint(vmOutput *vmcommon.VMOutput) string {
+	output := NewVMOutput(vmOutput)
+	if output == nil {
+		return "vmOutput: nil\n"
+	}
+
+	buffer := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buffer, "returnCode: %d (%s)\n", output.ReturnCode, vmcommon.ReturnCode(output.ReturnCode).String())
+	_, _ = fmt.Fprintf(buffer, "returnMessage: %q\n", output.ReturnMessage)
+	writeBytesList(buffer, "", "returnData", output.ReturnData)
+	_, _ = fmt.Fprintf(buffer, "gasRemaining: %d\n", output.GasRemaining)
+	_, _ = fmt.Fprintf(buffer, "gasRefund: %s\n", output.GasRefund)
+
+	_, _ = fmt.Fprintf(buffer, "outputAccounts (%d):\n", len(output.OutputAccounts))
+	for _, account := range output.OutputAccounts {
+		writeOutputAccount(buffer, account)
+	}
+
+	writeBytesList(buffer, "", "deletedAccounts", output.DeletedAccounts)
+	writeBytesList(buffer, "", "touchedAccounts", output.TouchedAccounts)
+
+	_, _ = fmt.Fprintf(buffer, "logs (%d):\n", len(output.Logs))
+	for i, entry := range output.Logs {
+		if entry == nil {
+			_, _ = fmt.Fprintf(buffer, "  [%d] nil\n", i)
+			continue
+		}
+		_, _ = fmt.Fprintf(buffer, "  [%d] identifier: %s, address: %s\n", i, FormatBytes(entry.Identifier), FormatBytes(entry.Address))
+		writeBytesList(buffer, "      ", "topics", entry.Topics)
+		writeBytesList(buffer, "      ", "data", entry.Data)
+	}
+
+	return buffer.String()
+}
+
+func writeOutputAccount(buffer *bytes.Buffer, account *OutputAccount) {
+	_, _ = fmt.Fprintf(buffer, "  %s:\n", FormatBytes(account.Key))
+	if !bytes.Equal(account.Key, account.Address) {
+		_, _ = fmt.Fprintf(buffer, "    address: %s\n", FormatBytes(account.Address))
+	}
+	_, _ = fmt.Fprintf(buffer, "    nonce: %d\n", account.Nonce)
+	_, _ = fmt.Fprintf(buffer, "    balance: %s, balanceDelta: %s\n", account.Balance, account.BalanceDelta)
+	if account.Code != nil {
+		_, _ = fmt.Fprintf(buffer, "    code: %d bytes, metadata: %s, deployer: %s\n",
+			len(account.Code), FormatBytes(account.CodeMetadata), FormatBytes(account.CodeDeployerAddress))
+	}
+	_, _ = fmt.Fprintf(buffer, "    gasUsed: %d\n", account.GasUsed)
+
+	_, _ = fmt.Fprintf(buffer, "    storageUpdates (%d):\n", len(account.StorageUpdates))
+	for _, update := range account.StorageUpdates {
+		_, _ = fmt.Fprintf(buffer, "      %s: %s (written: %t)\n", FormatBytes(update.Key), FormatBytes(update.Data), update.Written)
+	}
+
+	_, _ = fmt.Fprintf(buffer, "    outputTransfers (%d):\n", len(account.OutputTransfers))
+	for i, transfer := range account.OutputTransfers {
+		_, _ = fmt.Fprintf(buffer, "      [%d] value: %s, gasLimit: %d, gasLocked: %d, callType: %s, sender: %s, data: %s\n",
+			i, transfer.Value, transfer.GasLimit, transfer.GasLocked, vm.CallType(transfer.CallType).ToString(),
+			FormatBytes(transfer.SenderAddress), FormatBytes(transfer.Data))
+	}
+}
+
+func writeBytesList(buffer *bytes.Buffer, indent string, name string, values []Bytes) {
+	if values == nil {
+		_, _ = fmt.Fprintf(buffer, "%s%s: nil\n", indent, name)
+		return
+	}
+
+	_, _ = fmt.Fprintf(buffer, "%s%s (%d):\n", indent, name, len(values))
+	for i, value := range values {
+		_, _ = fmt.Fprintf(buffer, "%s  [%d] %s\n", indent, i, FormatBytes(value))
+	}
+}
@@ -0,0 +1,164 @@
+package vmoutput
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVMOutput() *vmcommon.VMOutput {
+	return &vmcommon.VMOutput{
+		ReturnData:    [][]byte{{1}, {}},
+		ReturnCode:    vmcommon.Ok,
+		ReturnMessage: "",
+		GasRemaining:  1000,
+		GasRefund:     big.NewInt(0),
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"bob": {
+				Address:      []byte("bob"),
+				BalanceDelta: big.NewInt(-5),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"b": {Offset: []byte("b"), Data: []byte{2}, Written: true},
+					"a": {Offset: []byte("a"), Data: []byte{}},
+				},
+				OutputTransfers: []vmcommon.OutputTransfer{
+					{Value: big.NewInt(7), GasLimit: 10, Data: []byte("call"), CallType: vm.AsynchronousCall},
+				},
+			},
+			"alice": {
+				Address:      []byte("alice"),
+				Nonce:        3,
+				BalanceDelta: big.NewInt(5),
+			},
+		},
+		DeletedAccounts: make([][]byte, 0),
+		TouchedAccounts: [][]byte{[]byte("alice")},
+		Logs: []*vmcommon.LogEntry{
+			{Identifier: []byte("event"), Address: []byte("bob"), Topics: [][]byte{{3}}, Data: [][]byte{{4}}},
+		},
+	}
+}
+
+func TestMarshal_IsStable(t *testing.T) {
+	first, err := Marshal(newTestVMOutput())
+	require.Nil(t, err)
+
+	for i := 0; i < 20; i++ {
+		encoded, err := Marshal(newTestVMOutput())
+		require.Nil(t, err)
+		require.Equal(t, first, encoded)
+	}
+
+	firstHash, err := Hash(newTestVMOutput())
+	require.Nil(t, err)
+	secondHash, err := Hash(newTestVMOutput())
+	require.Nil(t, err)
+	require.Equal(t, firstHash, secondHash)
+	require.Len(t, firstHash, 32)
+}
+
+func TestMarshal_SortsAccountsAndStorage(t *testing.T) {
+	canonical := NewVMOutput(newTestVMOutput())
+	require.Equal(t, Bytes("alice"), canonical.OutputAccounts[0].Key)
+	require.Equal(t, Bytes("bob"), canonical.OutputAccounts[1].Key)
+	require.Equal(t, Bytes("a"), canonical.OutputAccounts[1].StorageUpdates[0].Key)
+	require.Equal(t, Bytes("b"), canonical.OutputAccounts[1].StorageUpdates[1].Key)
+}
+
+func TestMarshal_NilVersusEmpty(t *testing.T) {
+	withEmpty := newTestVMOutput()
+	withNil := newTestVMOutput()
+	withNil.DeletedAccounts = nil
+
+	emptyHash, err := Hash(withEmpty)
+	require.Nil(t, err)
+	nilHash, err := Hash(withNil)
+	require.Nil(t, err)
+	require.NotEqual(t, emptyHash, nilHash)
+
+	encoded, err := Marshal(withNil)
+	require.Nil(t, err)
+	require.Contains(t, string(encoded), `"deletedAccounts":null`)
+	require.Contains(t, string(encoded), `"returnData":["01",""]`)
+
+	diffs := Diff(withEmpty, withNil)
+	require.Len(t, diffs, 1)
+	require.Equal(t, "deletedAccounts", diffs[0].Field)
+}
+
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	original := newTestVMOutput()
+	encoded, err := Marshal(original)
+	require.Nil(t, err)
+
+	decoded, err := Unmarshal(encoded)
+	require.Nil(t, err)
+	require.Equal(t, original, decoded)
+
+	reencoded, err := Marshal(decoded)
+	require.Nil(t, err)
+	require.Equal(t, encoded, reencoded)
+}
+
+func TestUnmarshal_InvalidBigInt(t *testing.T) {
+	_, err := Unmarshal([]byte(`{"gasRefund":"12a"}`))
+	require.Equal(t, ErrInvalidBigInt, err)
+}
+
+func TestDiff_Identical(t *testing.T) {
+	require.Empty(t, Diff(newTestVMOutput(), newTestVMOutput()))
+	require.Empty(t, Diff(nil, nil))
+}
+
+func TestDiff_Fields(t *testing.T) {
+	second := newTestVMOutput()
+	second.ReturnCode = vmcommon.UserError
+	second.ReturnData = [][]byte{{1}, {5}, {6}}
+	second.OutputAccounts["bob"].StorageUpdates["b"].Data = []byte{9}
+	second.OutputAccounts["bob"].OutputTransfers[0].Value = big.NewInt(8)
+	delete(second.OutputAccounts, "alice")
+	second.Logs[0].Topics = [][]byte{{7}}
+
+	diffs := Diff(newTestVMOutput(), second)
+	fields := make([]string, len(diffs))
+	for i, diff := range diffs {
+		fields[i] = diff.Field
+	}
+
+	require.Equal(t, []string{
+		"returnCode",
+		"returnData.length",
+		"returnData[1]",
+		"outputAccounts[0x616c696365]",
+		"outputAccounts[0x626f62].storage[0x62]",
+		"outputAccounts[0x626f62].outputTransfers[0].value",
+		"logs[0].topics[0]",
+	}, fields)
+	require.Equal(t, `""`, diffs[2].First)
+	require.Equal(t, "0x05", diffs[2].Second)
+	require.Equal(t, "present", diffs[3].First)
+	require.Equal(t, "<missing>", diffs[3].Second)
+}
+
+func TestDiff_NilOutput(t *testing.T) {
+	diffs := Diff(newTestVMOutput(), nil)
+	require.Len(t, diffs, 1)
+	require.Equal(t, "vmOutput", diffs[0].Field)
+	require.Equal(t, "nil", diffs[0].Second)
+}
+
+func TestPrettyPrint(t *testing.T) {
+	text := PrettyPrint(newTestVMOutput())
+	require.Contains(t, text, "returnCode: 0 (ok)")
+	require.Contains(t, text, "outputAccounts (2):")
+	require.Contains(t, text, "balance: nil, balanceDelta: -5")
+	require.Contains(t, text, "0x61: \"\" (written: false)")
+	require.Contains(t, text, "deletedAccounts (0):")
+	require.Less(t, strings.Index(text, "0x616c696365:"), strings.Index(text, "0x626f62:"))
+
+	require.Equal(t, "vmOutput: nil\n", PrettyPrint(nil))
+}
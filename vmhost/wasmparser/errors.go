@@ -0,0 +1,33 @@
+package wasmparser
+
+import "errors"
+
+// ErrInvalidMagicNumber signals that the code does not start with the WASM magic number
+var ErrInvalidMagicNumber = errors.New("invalid WASM magic number")
+
+// ErrUnsupportedVersion signals that the WASM binary version is not supported
+var ErrUnsupportedVersion = errors.New("unsupported WASM binary version")
+
+// ErrUnexpectedEnd signals that the code ended before a complete structure could be read
+var ErrUnexpectedEnd = errors.New("unexpected end of WASM code")
+
+// ErrInvalidLEB128 signals that a LEB128-encoded integer is malformed or too large
+var ErrInvalidLEB128 = errors.New("invalid LEB128 integer")
+
+// ErrInvalidSection signals that a section is malformed or out of order
+var ErrInvalidSection = errors.New("invalid WASM section")
+
+// ErrInvalidValueType signals that an unknown value type was encountered
+var ErrInvalidValueType = errors.New("invalid WASM value type")
+
+// ErrInvalidImportKind signals that an unknown import or export kind was encountered
+var ErrInvalidImportKind = errors.New("invalid WASM import or export kind")
+
+// ErrUnsupportedOpcode signals that an opcode cannot be decoded by the parser
+var ErrUnsupportedOpcode = errors.New("unsupported WASM opcode")
+
+// ErrFunctionCountMismatch signals that the function and code sections disagree on the number of functions
+var ErrFunctionCountMismatch = errors.New("function and code section sizes differ")
+
+// ErrUnsupportedInstrumentation signals that the module uses a construct the instrumentation cannot rewrite
+var ErrUnsupportedInstrumentation = errors.New("module cannot be instrumented")
@@ -0,0 +1,253 @@
+package wasmparser
+
+import "fmt"
+
+const (
+	opcodeBlock        = 0x02
+	opcodeLoop         = 0x03
+	opcodeIf           = 0x04
+	opcodeEnd          = 0x0B
+	opcodeBr           = 0x0C
+	opcodeBrIf         = 0x0D
+	opcodeBrTable      = 0x0E
+	opcodeCall         = 0x10
+	opcodeCallIndirect = 0x11
+	opcodeTypedSelect  = 0x1C
+	opcodeLocalGet     = 0x20
+	opcodeTableSet     = 0x26
+	opcodeI32Load      = 0x28
+	opcodeI64Store32   = 0x3E
+	opcodeMemorySize   = 0x3F
+	opcodeMemoryGrow   = 0x40
+	opcodeI32Const     = 0x41
+	opcodeI64Const     = 0x42
+	opcodeF32Const     = 0x43
+	opcodeF64Const     = 0x44
+	opcodeI32Eqz       = 0x45
+	opcodeI64Extend32S = 0xC4
+	opcodeRefNull      = 0xD0
+	opcodeRefIsNull    = 0xD1
+	opcodeRefFunc      = 0xD2
+
+	// OpcodePrefixMisc prefixes the saturating truncation, bulk memory and table instructions
+	OpcodePrefixMisc = 0xFC
+)
+
+// Instruction is a decoded instruction of a function body; the immediates
+// are skipped, only the opcode and its position are kept
+type Instruction struct {
+	Opcode    byte
+	SubOpcode uint32
+	Offset    int
+}
+
+// IsPrefixed returns true if the instruction is encoded as a prefix byte followed by a sub-opcode
+func (instruction Instruction) IsPrefixed() bool {
+	return instruction.Opcode == OpcodePrefixMisc
+}
+
+// InstructionHandler is called for every instruction decoded from a function body
+type InstructionHandler func(instruction Instruction) error
+
+// ReadInstructions decodes the instructions of the given function body code in order
+func ReadInstructions(code []byte, handler InstructionHandler) error {
+	reader := newByteReader(code)
+
+	for reader.hasMore() {
+		instruction := Instruction{
+			Offset: reader.offset,
+		}
+
+		var err error
+		instruction.Opcode, err = reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		if instruction.Opcode == OpcodePrefixMisc {
+			instruction.SubOpcode, err = reader.readU32()
+			if err != nil {
+				return err
+			}
+			err = skipMiscImmediates(reader, instruction.SubOpcode)
+		} else {
+			err = skipImmediates(reader, instruction.Opcode)
+		}
+		if err != nil {
+			return fmt.Errorf("offset %d: %w", instruction.Offset, err)
+		}
+
+		err = handler(instruction)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func skipImmediates(reader *byteReader, opcode byte) error {
+	var err error
+
+	switch {
+	case opcode <= 0x01, opcode == 0x05, opcode == opcodeEnd, opcode == 0x0F:
+		// unreachable, nop, else, end, return
+	case opcode == opcodeBlock, opcode == opcodeLoop, opcode == opcodeIf:
+		err = skipBlockType(reader)
+	case opcode == opcodeBr, opcode == opcodeBrIf, opcode == opcodeCall:
+		_, err = reader.readU32()
+	case opcode == opcodeBrTable:
+		err = skipBrTable(reader)
+	case opcode == opcodeCallIndirect:
+		err = skipU32s(reader, 2)
+	case opcode == 0x1A, opcode == 0x1B:
+		// drop, select
+	case opcode == opcodeTypedSelect:
+		_, err = readValueTypes(reader)
+	case opcode >= opcodeLocalGet && opcode <= opcodeTableSet:
+		// local.get/set/tee, global.get/set, table.get/set
+		_, err = reader.readU32()
+	case opcode >= opcodeI32Load && opcode <= opcodeI64Store32:
+		// memory argument: alignment and offset
+		err = skipU32s(reader, 2)
+	case opcode == opcodeMemorySize, opcode == opcodeMemoryGrow:
+		_, err = reader.readU32()
+	case opcode == opcodeI32Const:
+		_, err = reader.readS32()
+	case opcode == opcodeI64Const:
+		_, err = reader.readS64()
+	case opcode == opcodeF32Const:
+		err = reader.skip(4)
+	case opcode == opcodeF64Const:
+		err = reader.skip(8)
+	case opcode >= opcodeI32Eqz && opcode <= opcodeI64Extend32S:
+		// numeric instructions carry no immediates
+	case opcode == opcodeRefNull:
+		_, err = reader.readByte()
+	case opcode == opcodeRefIsNull:
+	case opcode == opcodeRefFunc:
+		_, err = reader.readU32()
+	default:
+		err = fmt.Errorf("%w: 0x%02x", ErrUnsupportedOpcode, opcode)
+	}
+
+	return err
+}
+
+func skipMiscImmediates(reader *byteReader, subOpcode uint32) error {
+	var err error
+
+	switch {
+	case subOpcode <= 7:
+		// saturating float to int truncations
+	case subOpcode == 8:
+		// memory.init: data index and memory index
+		err = skipU32s(reader, 2)
+	case subOpcode == 9:
+		// data.drop
+		_, err = reader.readU32()
+	case subOpcode == 10:
+		// memory.copy: destination and source memory indices
+		err = skipU32s(reader, 2)
+	case subOpcode == 11:
+		// memory.fill
+		_, err = reader.readU32()
+	case subOpcode == 12, subOpcode == 14:
+		// table.init, table.copy
+		err = skipU32s(reader, 2)
+	case subOpcode == 13, subOpcode >= 15 && subOpcode <= 17:
+		// elem.drop, table.grow, table.size, table.fill
+		_, err = reader.readU32()
+	default:
+		err = fmt.Errorf("%w: 0x%02x %d", ErrUnsupportedOpcode, OpcodePrefixMisc, subOpcode)
+	}
+
+	return err
+}
+
+func skipBlockType(reader *byteReader) error {
+	b, err := reader.peekByte()
+	if err != nil {
+		return err
+	}
+
+	if b == 0x40 || ValueType(b).isValid() {
+		_, err = reader.readByte()
+		return err
+	}
+
+	_, err = reader.readS33()
+	return err
+}
+
+func skipBrTable(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	// the branch targets, followed by the default target
+	return skipU32s(reader, uint64(count)+1)
+}
+
+func skipU32s(reader *byteReader, count uint64) error {
+	for i := uint64(0); i < count; i++ {
+		_, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipConstantExpression(reader *byteReader) error {
+	for {
+		opcode, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		if opcode == opcodeEnd {
+			return nil
+		}
+
+		err = skipImmediates(reader, opcode)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// IsFloatInstruction returns true if the instruction operates on f32 or f64 values
+func IsFloatInstruction(instruction Instruction) bool {
+	if instruction.IsPrefixed() {
+		// i32/i64.trunc_sat_f32/f64_s/u
+		return instruction.SubOpcode <= 7
+	}
+
+	opcode := instruction.Opcode
+	switch {
+	case opcode == 0x2A, opcode == 0x2B:
+		// f32.load, f64.load
+		return true
+	case opcode == 0x38, opcode == 0x39:
+		// f32.store, f64.store
+		return true
+	case opcode == opcodeF32Const, opcode == opcodeF64Const:
+		return true
+	case opcode >= 0x5B && opcode <= 0x66:
+		// f32 and f64 comparisons
+		return true
+	case opcode >= 0x8B && opcode <= 0xA6:
+		// f32 and f64 arithmetic
+		return true
+	case opcode >= 0xA8 && opcode <= 0xAB:
+		// i32.trunc_f32/f64_s/u
+		return true
+	case opcode >= 0xAE && opcode <= 0xBF:
+		// i64.trunc_f32/f64_s/u, conversions, demotion, promotion and reinterpretations
+		return true
+	}
+
+	return false
+}
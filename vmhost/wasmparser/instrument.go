@@ -0,0 +1,342 @@
+package wasmparser
+
+import (
+	"fmt"
+)
+
+// FunctionEntryHook describes the function imported by an instrumented module,
+// which every defined function calls on entry with (hookArgument, functionIndex), both i32;
+// it is also called right before each direct call of an imported function, with the index of the import
+type FunctionEntryHook struct {
+	Module       string
+	Name         string
+	HookArgument int32
+}
+
+// InstrumentFunctionEntries rewrites the module so that each defined function starts by calling the given hook,
+// which is appended to the imported functions, and so that each direct call of an imported function is preceded
+// by a call of the hook. The function index passed to the hook is the index in the original module; since the
+// defined functions move up by one, the calls, exports, start function and elements are adjusted.
+// The name section is dropped, its indices no longer being valid.
+func InstrumentFunctionEntries(code []byte, hook FunctionEntryHook) ([]byte, error) {
+	module, err := ParseModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, imp := range module.Imports {
+		if imp.Module == hook.Module && imp.Name == hook.Name {
+			return nil, fmt.Errorf("%w: the module already imports %s", ErrUnsupportedInstrumentation, hook.Name)
+		}
+	}
+
+	instrumenter := &functionEntryInstrumenter{
+		module:        module,
+		hook:          hook,
+		hookIndex:     module.ImportedFunctionsCount(),
+		hookTypeIndex: uint32(len(module.Types)),
+	}
+	return instrumenter.instrument(code)
+}
+
+type functionEntryInstrumenter struct {
+	module        *Module
+	hook          FunctionEntryHook
+	hookIndex     uint32
+	hookTypeIndex uint32
+}
+
+func (instrumenter *functionEntryInstrumenter) instrument(code []byte) ([]byte, error) {
+	reader := newByteReader(code)
+	reader.offset = len(wasmMagicNumber) + len(wasmVersion)
+
+	result := make([]byte, 0, len(code)+len(instrumenter.module.Bodies)*8+64)
+	result = append(result, wasmMagicNumber...)
+	result = append(result, wasmVersion...)
+
+	typeWritten := false
+	importWritten := false
+	for reader.hasMore() {
+		idByte, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := reader.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+
+		sectionID := SectionID(idByte)
+		if sectionID != SectionCustom {
+			// the type and import sections are created when the module has none
+			if !typeWritten && isSectionAfter(sectionID, SectionType) {
+				result = appendSection(result, SectionType, instrumenter.appendHookType(nil))
+				typeWritten = true
+			}
+			if !importWritten && isSectionAfter(sectionID, SectionImport) {
+				result = appendSection(result, SectionImport, instrumenter.appendHookImport(nil))
+				importWritten = true
+			}
+		}
+
+		var newData []byte
+		switch sectionID {
+		case SectionCustom:
+			if isNameSection(data) {
+				continue
+			}
+			newData = data
+		case SectionType:
+			newData, err = instrumenter.appendHookType(data), nil
+			typeWritten = true
+		case SectionImport:
+			newData, err = instrumenter.appendHookImport(data), nil
+			importWritten = true
+		case SectionExport:
+			newData = instrumenter.rewriteExportSection()
+		case SectionStart:
+			newData = instrumenter.rewriteStartSection()
+		case SectionElement:
+			newData, err = instrumenter.rewriteElementSection(data)
+		case SectionCode:
+			newData, err = instrumenter.rewriteCodeSection(data)
+		default:
+			newData = data
+		}
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", idByte, err)
+		}
+
+		result = appendSection(result, sectionID, newData)
+	}
+
+	if !typeWritten {
+		result = appendSection(result, SectionType, instrumenter.appendHookType(nil))
+	}
+	if !importWritten {
+		result = appendSection(result, SectionImport, instrumenter.appendHookImport(nil))
+	}
+
+	return result, nil
+}
+
+// shiftFunctionIndex maps an index of the original module to the instrumented one
+func (instrumenter *functionEntryInstrumenter) shiftFunctionIndex(index uint32) uint32 {
+	if index >= instrumenter.hookIndex {
+		return index + 1
+	}
+	return index
+}
+
+// appendHookType appends the (i32, i32) -> () signature of the hook to the type section
+func (instrumenter *functionEntryInstrumenter) appendHookType(data []byte) []byte {
+	hookType := []byte{0x60, 0x02, byte(ValueTypeI32), byte(ValueTypeI32), 0x00}
+	return appendToVector(data, uint32(len(instrumenter.module.Types)), hookType)
+}
+
+// appendHookImport appends the hook to the import section, after all the other imports
+func (instrumenter *functionEntryInstrumenter) appendHookImport(data []byte) []byte {
+	hookImport := appendName(nil, instrumenter.hook.Module)
+	hookImport = appendName(hookImport, instrumenter.hook.Name)
+	hookImport = append(hookImport, byte(ExternalFunction))
+	hookImport = appendU32(hookImport, instrumenter.hookTypeIndex)
+	return appendToVector(data, uint32(len(instrumenter.module.Imports)), hookImport)
+}
+
+func isNameSection(data []byte) bool {
+	name, err := newByteReader(data).readName()
+	return err == nil && name == nameSectionName
+}
+
+func (instrumenter *functionEntryInstrumenter) rewriteExportSection() []byte {
+	result := appendU32(nil, uint32(len(instrumenter.module.Exports)))
+	for _, export := range instrumenter.module.Exports {
+		index := export.Index
+		if export.Kind == ExternalFunction {
+			index = instrumenter.shiftFunctionIndex(index)
+		}
+
+		result = appendName(result, export.Name)
+		result = append(result, byte(export.Kind))
+		result = appendU32(result, index)
+	}
+
+	return result
+}
+
+func (instrumenter *functionEntryInstrumenter) rewriteStartSection() []byte {
+	return appendU32(nil, instrumenter.shiftFunctionIndex(instrumenter.module.StartFunction))
+}
+
+// rewriteElementSection adjusts the function indices of the active element segments of table 0,
+// the only ones of the MVP; the other kinds of segments are not supported
+func (instrumenter *functionEntryInstrumenter) rewriteElementSection(data []byte) ([]byte, error) {
+	reader := newByteReader(data)
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	result := appendU32(nil, count)
+	for i := uint32(0); i < count; i++ {
+		flags, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		if flags != 0 {
+			return nil, fmt.Errorf("%w: element segment with flags %d", ErrUnsupportedInstrumentation, flags)
+		}
+
+		offsetStart := reader.offset
+		err = skipConstantExpression(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		result = appendU32(result, flags)
+		result = append(result, reader.data[offsetStart:reader.offset]...)
+
+		indicesCount, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+
+		result = appendU32(result, indicesCount)
+		for j := uint32(0); j < indicesCount; j++ {
+			index, err := reader.readU32()
+			if err != nil {
+				return nil, err
+			}
+			result = appendU32(result, instrumenter.shiftFunctionIndex(index))
+		}
+	}
+
+	return result, nil
+}
+
+func (instrumenter *functionEntryInstrumenter) rewriteCodeSection(data []byte) ([]byte, error) {
+	importedFunctionsCount := instrumenter.hookIndex
+	result := appendU32(nil, uint32(len(instrumenter.module.Bodies)))
+
+	for i, body := range instrumenter.module.Bodies {
+		functionIndex := importedFunctionsCount + uint32(i)
+		newBody, err := instrumenter.rewriteFunctionBody(body, functionIndex)
+		if err != nil {
+			return nil, fmt.Errorf("function body %d: %w", i, err)
+		}
+
+		result = appendU32(result, uint32(len(newBody)))
+		result = append(result, newBody...)
+	}
+
+	return result, nil
+}
+
+// rewriteFunctionBody keeps the locals, inserts the call to the hook, then copies the instructions,
+// adjusting the function indices of call and ref.func, and calling the hook before the calls of imported functions
+func (instrumenter *functionEntryInstrumenter) rewriteFunctionBody(body *FunctionBody, functionIndex uint32) ([]byte, error) {
+	result := make([]byte, 0, len(body.Code)+16)
+	for _, locals := range body.Locals {
+		result = appendU32(result, locals.Count)
+		result = append(result, byte(locals.Type))
+	}
+	result = append(appendU32(nil, uint32(len(body.Locals))), result...)
+
+	result = instrumenter.appendHookCall(result, functionIndex)
+
+	offsets := make([]int, 0)
+	err := ReadInstructions(body.Code, func(instruction Instruction) error {
+		offsets = append(offsets, instruction.Offset)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	offsets = append(offsets, len(body.Code))
+
+	for i := 0; i < len(offsets)-1; i++ {
+		instructionBytes := body.Code[offsets[i]:offsets[i+1]]
+		opcode := instructionBytes[0]
+		if opcode != opcodeCall && opcode != opcodeRefFunc {
+			result = append(result, instructionBytes...)
+			continue
+		}
+
+		index, err := newByteReader(instructionBytes[1:]).readU32()
+		if err != nil {
+			return nil, err
+		}
+		if opcode == opcodeCall && index < instrumenter.hookIndex {
+			result = instrumenter.appendHookCall(result, index)
+		}
+		result = append(result, opcode)
+		result = appendU32(result, instrumenter.shiftFunctionIndex(index))
+	}
+
+	return result, nil
+}
+
+// appendHookCall appends the call of the hook with (hookArgument, functionIndex)
+func (instrumenter *functionEntryInstrumenter) appendHookCall(result []byte, functionIndex uint32) []byte {
+	result = append(result, opcodeI32Const)
+	result = appendS32(result, instrumenter.hook.HookArgument)
+	result = append(result, opcodeI32Const)
+	result = appendS32(result, int32(functionIndex))
+	result = append(result, opcodeCall)
+	return appendU32(result, instrumenter.hookIndex)
+}
+
+// appendToVector appends an encoded item to the encoded vector, which holds count items;
+// a nil vector is taken as empty
+func appendToVector(vector []byte, count uint32, item []byte) []byte {
+	items := []byte{}
+	if vector != nil {
+		reader := newByteReader(vector)
+		_, _ = reader.readU32()
+		items = vector[reader.offset:]
+	}
+
+	result := appendU32(nil, count+1)
+	result = append(result, items...)
+	return append(result, item...)
+}
+
+func appendSection(result []byte, sectionID SectionID, data []byte) []byte {
+	result = append(result, byte(sectionID))
+	result = appendU32(result, uint32(len(data)))
+	return append(result, data...)
+}
+
+func appendName(result []byte, name string) []byte {
+	result = appendU32(result, uint32(len(name)))
+	return append(result, name...)
+}
+
+func appendU32(result []byte, value uint32) []byte {
+	for {
+		b := byte(value & 0x7F)
+		value >>= 7
+		if value == 0 {
+			return append(result, b)
+		}
+		result = append(result, b|0x80)
+	}
+}
+
+func appendS32(result []byte, value int32) []byte {
+	for {
+		b := byte(value & 0x7F)
+		value >>= 7
+		signBitSet := b&0x40 != 0
+		if (value == 0 && !signBitSet) || (value == -1 && signBitSet) {
+			return append(result, b)
+		}
+		result = append(result, b|0x80)
+	}
+}
@@ -0,0 +1,129 @@
+package wasmparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestSection(sectionID SectionID, data ...byte) []byte {
+	return appendSection(nil, sectionID, data)
+}
+
+// makeTestModule builds a module importing env.f and defining 2 functions,
+// which are exported, referenced by the start section and by the table, and named
+func makeTestModule() []byte {
+	code := append([]byte{}, wasmMagicNumber...)
+	code = append(code, wasmVersion...)
+	code = append(code, makeTestSection(SectionType, 0x01, 0x60, 0x00, 0x00)...)
+	code = append(code, makeTestSection(SectionImport, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'f', 0x00, 0x00)...)
+	code = append(code, makeTestSection(SectionFunction, 0x02, 0x00, 0x00)...)
+	code = append(code, makeTestSection(SectionTable, 0x01, 0x70, 0x00, 0x02)...)
+	code = append(code, makeTestSection(SectionExport, 0x01, 0x04, 'm', 'a', 'i', 'n', 0x00, 0x02)...)
+	code = append(code, makeTestSection(SectionStart, 0x01)...)
+	code = append(code, makeTestSection(SectionElement, 0x01, 0x00, 0x41, 0x00, 0x0B, 0x02, 0x01, 0x02)...)
+	code = append(code, makeTestSection(SectionCode,
+		0x02,
+		0x06, 0x00, 0x10, 0x00, 0x10, 0x02, 0x0B, // call 0, call 2
+		0x04, 0x01, 0x01, 0x7F, 0x0B, // one i32 local
+	)...)
+	code = append(code, makeTestSection(SectionCustom,
+		0x04, 'n', 'a', 'm', 'e',
+		0x00, 0x02, 0x01, 'm',
+		0x01, 0x0E, 0x02, 0x01, 0x05, 'f', 'i', 'r', 's', 't', 0x02, 0x04, 'l', 'a', 's', 't',
+	)...)
+	return code
+}
+
+func TestModule_Names(t *testing.T) {
+	module, err := ParseModule(makeTestModule())
+	require.Nil(t, err)
+
+	names, err := module.Names()
+	require.Nil(t, err)
+	require.Equal(t, "m", names.ModuleName)
+	require.Equal(t, map[uint32]string{1: "first", 2: "last"}, names.FunctionNames)
+
+	module.CustomSections[0].Data = module.CustomSections[0].Data[:len(module.CustomSections[0].Data)-2]
+	names, err = module.Names()
+	require.NotNil(t, err)
+	require.Equal(t, "m", names.ModuleName)
+}
+
+func TestInstrumentFunctionEntries(t *testing.T) {
+	hook := FunctionEntryHook{Module: "env", Name: "hook", HookArgument: 300}
+	instrumented, err := InstrumentFunctionEntries(makeTestModule(), hook)
+	require.Nil(t, err)
+
+	module, err := ParseModule(instrumented)
+	require.Nil(t, err)
+	require.Len(t, module.Types, 2)
+	require.Equal(t, "(i32, i32) -> ()", module.Types[1].String())
+	require.Equal(t, []string{"f", "hook"}, module.ImportedFunctionNames())
+	require.Equal(t, uint32(1), module.Imports[1].TypeIndex)
+	require.Equal(t, uint32(3), module.Exports[0].Index)
+	require.Equal(t, uint32(2), module.StartFunction)
+	require.Len(t, module.CustomSections, 0)
+
+	require.Equal(t, []byte{
+		0x41, 0xAC, 0x02, // i32.const 300
+		0x41, 0x01, // i32.const 1
+		0x10, 0x01, // call hook
+		0x41, 0xAC, 0x02, // i32.const 300
+		0x41, 0x00, // i32.const 0
+		0x10, 0x01, // call hook, before f
+		0x10, 0x00, // call f
+		0x10, 0x03, // call the second function
+		0x0B,
+	}, module.Bodies[0].Code)
+	require.Equal(t, []LocalEntry{{Count: 1, Type: ValueTypeI32}}, module.Bodies[1].Locals)
+	require.Equal(t, []byte{0x41, 0xAC, 0x02, 0x41, 0x02, 0x10, 0x01, 0x0B}, module.Bodies[1].Code)
+
+	elementSection := []byte{0x09, 0x08, 0x01, 0x00, 0x41, 0x00, 0x0B, 0x02, 0x02, 0x03}
+	require.Contains(t, string(instrumented), string(elementSection))
+
+	_, err = InstrumentFunctionEntries(instrumented, hook)
+	require.ErrorIs(t, err, ErrUnsupportedInstrumentation)
+}
+
+func TestInstrumentFunctionEntries_NoImports(t *testing.T) {
+	code := append([]byte{}, wasmMagicNumber...)
+	code = append(code, wasmVersion...)
+	code = append(code, makeTestSection(SectionType, 0x01, 0x60, 0x00, 0x00)...)
+	code = append(code, makeTestSection(SectionFunction, 0x01, 0x00)...)
+	code = append(code, makeTestSection(SectionCode, 0x01, 0x04, 0x00, 0x10, 0x00, 0x0B)...)
+
+	instrumented, err := InstrumentFunctionEntries(code, FunctionEntryHook{Module: "env", Name: "hook"})
+	require.Nil(t, err)
+
+	module, err := ParseModule(instrumented)
+	require.Nil(t, err)
+	require.Equal(t, []string{"hook"}, module.ImportedFunctionNames())
+	require.Equal(t, []byte{0x41, 0x00, 0x41, 0x00, 0x10, 0x00, 0x10, 0x01, 0x0B}, module.Bodies[0].Code)
+}
+
+func TestInstrumentFunctionEntries_TestContracts(t *testing.T) {
+	names := []string{"counter", "erc20", "memoryless"}
+
+	for _, name := range names {
+		code := loadTestContract(t, name)
+		original, err := ParseModule(code)
+		require.Nil(t, err, name)
+
+		instrumented, err := InstrumentFunctionEntries(code, FunctionEntryHook{Module: "env", Name: "hook"})
+		require.Nil(t, err, name)
+
+		module, err := ParseModule(instrumented)
+		require.Nil(t, err, name)
+		require.Equal(t, len(original.Bodies), len(module.Bodies), name)
+		require.Equal(t, original.ImportedFunctionsCount()+1, module.ImportedFunctionsCount(), name)
+		require.Equal(t, len(original.Exports), len(module.Exports), name)
+
+		for _, body := range module.Bodies {
+			err = ReadInstructions(body.Code, func(instruction Instruction) error {
+				return nil
+			})
+			require.Nil(t, err, name)
+		}
+	}
+}
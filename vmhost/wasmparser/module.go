@@ -0,0 +1,762 @@
+package wasmparser
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var wasmMagicNumber = []byte{0x00, 0x61, 0x73, 0x6D}
+var wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+
+// SectionID identifies a section of a WASM module
+type SectionID byte
+
+const (
+	// SectionCustom identifies a custom section, such as the name section
+	SectionCustom SectionID = iota
+
+	// SectionType identifies the section holding the function signatures
+	SectionType
+
+	// SectionImport identifies the section holding the imports
+	SectionImport
+
+	// SectionFunction identifies the section mapping defined functions to signatures
+	SectionFunction
+
+	// SectionTable identifies the section holding the table declarations
+	SectionTable
+
+	// SectionMemory identifies the section holding the memory declarations
+	SectionMemory
+
+	// SectionGlobal identifies the section holding the global declarations
+	SectionGlobal
+
+	// SectionExport identifies the section holding the exports
+	SectionExport
+
+	// SectionStart identifies the section holding the start function index
+	SectionStart
+
+	// SectionElement identifies the section holding the table element segments
+	SectionElement
+
+	// SectionCode identifies the section holding the function bodies
+	SectionCode
+
+	// SectionData identifies the section holding the memory data segments
+	SectionData
+
+	// SectionDataCount identifies the section holding the number of data segments
+	SectionDataCount
+)
+
+// ValueType encodes the type of a WASM value
+type ValueType byte
+
+const (
+	// ValueTypeI32 is the 32-bit integer type
+	ValueTypeI32 ValueType = 0x7F
+
+	// ValueTypeI64 is the 64-bit integer type
+	ValueTypeI64 ValueType = 0x7E
+
+	// ValueTypeF32 is the 32-bit floating-point type
+	ValueTypeF32 ValueType = 0x7D
+
+	// ValueTypeF64 is the 64-bit floating-point type
+	ValueTypeF64 ValueType = 0x7C
+
+	// ValueTypeV128 is the 128-bit vector type
+	ValueTypeV128 ValueType = 0x7B
+
+	// ValueTypeFuncRef is the function reference type
+	ValueTypeFuncRef ValueType = 0x70
+
+	// ValueTypeExternRef is the external reference type
+	ValueTypeExternRef ValueType = 0x6F
+)
+
+func (valueType ValueType) isValid() bool {
+	switch valueType {
+	case ValueTypeI32, ValueTypeI64, ValueTypeF32, ValueTypeF64, ValueTypeV128, ValueTypeFuncRef, ValueTypeExternRef:
+		return true
+	}
+	return false
+}
+
+// IsFloat returns true if the value type is f32 or f64
+func (valueType ValueType) IsFloat() bool {
+	return valueType == ValueTypeF32 || valueType == ValueTypeF64
+}
+
+// String returns the name of the value type as written in the WASM text format
+func (valueType ValueType) String() string {
+	switch valueType {
+	case ValueTypeI32:
+		return "i32"
+	case ValueTypeI64:
+		return "i64"
+	case ValueTypeF32:
+		return "f32"
+	case ValueTypeF64:
+		return "f64"
+	case ValueTypeV128:
+		return "v128"
+	case ValueTypeFuncRef:
+		return "funcref"
+	case ValueTypeExternRef:
+		return "externref"
+	}
+	return fmt.Sprintf("unknown(0x%02x)", byte(valueType))
+}
+
+// ExternalKind encodes the kind of an import or an export
+type ExternalKind byte
+
+const (
+	// ExternalFunction is an imported or exported function
+	ExternalFunction ExternalKind = iota
+
+	// ExternalTable is an imported or exported table
+	ExternalTable
+
+	// ExternalMemory is an imported or exported memory
+	ExternalMemory
+
+	// ExternalGlobal is an imported or exported global
+	ExternalGlobal
+)
+
+// String returns the name of the external kind
+func (kind ExternalKind) String() string {
+	switch kind {
+	case ExternalFunction:
+		return "func"
+	case ExternalTable:
+		return "table"
+	case ExternalMemory:
+		return "memory"
+	case ExternalGlobal:
+		return "global"
+	}
+	return fmt.Sprintf("unknown(%d)", byte(kind))
+}
+
+// FunctionType is a function signature declared in the type section
+type FunctionType struct {
+	Params  []ValueType
+	Results []ValueType
+}
+
+// String returns the signature as written in the WASM text format
+func (functionType *FunctionType) String() string {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("(")
+	for i, param := range functionType.Params {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(param.String())
+	}
+	buffer.WriteString(") -> (")
+	for i, result := range functionType.Results {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(result.String())
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+// Limits holds the size limits of a table or of a memory
+type Limits struct {
+	Initial    uint32
+	Maximum    uint32
+	HasMaximum bool
+}
+
+// Table is a table declaration
+type Table struct {
+	ElementType ValueType
+	Limits      Limits
+}
+
+// Global is a global variable declaration
+type Global struct {
+	Type    ValueType
+	Mutable bool
+	Init    []byte
+}
+
+// Import is an entry of the import section
+type Import struct {
+	Module    string
+	Name      string
+	Kind      ExternalKind
+	TypeIndex uint32
+	Table     Table
+	Memory    Limits
+	Global    Global
+}
+
+// Export is an entry of the export section
+type Export struct {
+	Name  string
+	Kind  ExternalKind
+	Index uint32
+}
+
+// LocalEntry declares a run of locals of the same type in a function body
+type LocalEntry struct {
+	Count uint32
+	Type  ValueType
+}
+
+// FunctionBody is an entry of the code section
+type FunctionBody struct {
+	Locals []LocalEntry
+	Code   []byte
+	Offset int
+}
+
+// CustomSection is a custom section, kept unparsed
+type CustomSection struct {
+	Name string
+	Data []byte
+}
+
+// Module is the structure of a parsed WASM module
+type Module struct {
+	Types          []*FunctionType
+	Imports        []*Import
+	Functions      []uint32
+	Tables         []*Table
+	Memories       []Limits
+	Globals        []*Global
+	Exports        []*Export
+	StartFunction  uint32
+	HasStart       bool
+	Bodies         []*FunctionBody
+	CustomSections []*CustomSection
+	CodeSize       int
+}
+
+// ParseModule decodes the structure of the given WASM module, without compiling it
+func ParseModule(code []byte) (*Module, error) {
+	reader := newByteReader(code)
+
+	magic, err := reader.readBytes(uint32(len(wasmMagicNumber)))
+	if err != nil || !bytes.Equal(magic, wasmMagicNumber) {
+		return nil, ErrInvalidMagicNumber
+	}
+
+	version, err := reader.readBytes(uint32(len(wasmVersion)))
+	if err != nil || !bytes.Equal(version, wasmVersion) {
+		return nil, ErrUnsupportedVersion
+	}
+
+	module := &Module{
+		CodeSize: len(code),
+	}
+
+	lastSectionID := SectionCustom
+	for reader.hasMore() {
+		idByte, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+
+		sectionOffset := reader.offset
+		sectionData, err := reader.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+
+		sectionID := SectionID(idByte)
+		if sectionID > SectionDataCount {
+			return nil, fmt.Errorf("%w: unknown section id %d", ErrInvalidSection, idByte)
+		}
+
+		if sectionID != SectionCustom {
+			if !isSectionAfter(sectionID, lastSectionID) {
+				return nil, fmt.Errorf("%w: section %d is out of order", ErrInvalidSection, idByte)
+			}
+			lastSectionID = sectionID
+		}
+
+		err = module.parseSection(sectionID, sectionData, sectionOffset)
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", idByte, err)
+		}
+	}
+
+	if len(module.Functions) != len(module.Bodies) {
+		return nil, ErrFunctionCountMismatch
+	}
+
+	return module, nil
+}
+
+// the data count section is placed between the element and the code sections
+func sectionOrder(sectionID SectionID) int {
+	switch sectionID {
+	case SectionDataCount:
+		return int(SectionElement)*2 + 1
+	default:
+		return int(sectionID) * 2
+	}
+}
+
+func isSectionAfter(current SectionID, previous SectionID) bool {
+	if previous == SectionCustom {
+		return true
+	}
+	return sectionOrder(current) > sectionOrder(previous)
+}
+
+func (module *Module) parseSection(sectionID SectionID, data []byte, offset int) error {
+	reader := newByteReader(data)
+
+	var err error
+	switch sectionID {
+	case SectionCustom:
+		err = module.parseCustomSection(reader)
+	case SectionType:
+		err = module.parseTypeSection(reader)
+	case SectionImport:
+		err = module.parseImportSection(reader)
+	case SectionFunction:
+		err = module.parseFunctionSection(reader)
+	case SectionTable:
+		err = module.parseTableSection(reader)
+	case SectionMemory:
+		err = module.parseMemorySection(reader)
+	case SectionGlobal:
+		err = module.parseGlobalSection(reader)
+	case SectionExport:
+		err = module.parseExportSection(reader)
+	case SectionStart:
+		err = module.parseStartSection(reader)
+	case SectionCode:
+		err = module.parseCodeSection(reader, offset)
+	default:
+		// element, data and data count sections carry no information needed by the validator
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if reader.hasMore() {
+		return fmt.Errorf("%w: trailing bytes", ErrInvalidSection)
+	}
+
+	return nil
+}
+
+func (module *Module) parseCustomSection(reader *byteReader) error {
+	name, err := reader.readName()
+	if err != nil {
+		return err
+	}
+
+	module.CustomSections = append(module.CustomSections, &CustomSection{
+		Name: name,
+		Data: reader.data[reader.offset:],
+	})
+	reader.offset = len(reader.data)
+
+	return nil
+}
+
+func (module *Module) parseTypeSection(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		form, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if form != 0x60 {
+			return fmt.Errorf("%w: unknown type form 0x%02x", ErrInvalidSection, form)
+		}
+
+		params, err := readValueTypes(reader)
+		if err != nil {
+			return err
+		}
+
+		results, err := readValueTypes(reader)
+		if err != nil {
+			return err
+		}
+
+		module.Types = append(module.Types, &FunctionType{
+			Params:  params,
+			Results: results,
+		})
+	}
+
+	return nil
+}
+
+func readValueTypes(reader *byteReader) ([]ValueType, error) {
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	valueTypes := make([]ValueType, 0, minInt(int(count), len(reader.data)))
+	for i := uint32(0); i < count; i++ {
+		valueType, err := reader.readValueType()
+		if err != nil {
+			return nil, err
+		}
+		valueTypes = append(valueTypes, valueType)
+	}
+
+	return valueTypes, nil
+}
+
+func (module *Module) parseImportSection(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		moduleName, err := reader.readName()
+		if err != nil {
+			return err
+		}
+
+		name, err := reader.readName()
+		if err != nil {
+			return err
+		}
+
+		kind, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		imp := &Import{
+			Module: moduleName,
+			Name:   name,
+			Kind:   ExternalKind(kind),
+		}
+
+		switch imp.Kind {
+		case ExternalFunction:
+			imp.TypeIndex, err = reader.readU32()
+		case ExternalTable:
+			var table *Table
+			table, err = readTable(reader)
+			if err == nil {
+				imp.Table = *table
+			}
+		case ExternalMemory:
+			imp.Memory, err = reader.readLimits()
+		case ExternalGlobal:
+			imp.Global.Type, imp.Global.Mutable, err = readGlobalType(reader)
+		default:
+			err = fmt.Errorf("%w: %d", ErrInvalidImportKind, kind)
+		}
+		if err != nil {
+			return err
+		}
+
+		module.Imports = append(module.Imports, imp)
+	}
+
+	return nil
+}
+
+func (module *Module) parseFunctionSection(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		typeIndex, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+		module.Functions = append(module.Functions, typeIndex)
+	}
+
+	return nil
+}
+
+func readTable(reader *byteReader) (*Table, error) {
+	elementType, err := reader.readValueType()
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := reader.readLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{
+		ElementType: elementType,
+		Limits:      limits,
+	}, nil
+}
+
+func (module *Module) parseTableSection(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		table, err := readTable(reader)
+		if err != nil {
+			return err
+		}
+		module.Tables = append(module.Tables, table)
+	}
+
+	return nil
+}
+
+func (module *Module) parseMemorySection(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		limits, err := reader.readLimits()
+		if err != nil {
+			return err
+		}
+		module.Memories = append(module.Memories, limits)
+	}
+
+	return nil
+}
+
+func readGlobalType(reader *byteReader) (ValueType, bool, error) {
+	valueType, err := reader.readValueType()
+	if err != nil {
+		return 0, false, err
+	}
+
+	mutability, err := reader.readByte()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return valueType, mutability == 0x01, nil
+}
+
+func (module *Module) parseGlobalSection(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		valueType, mutable, err := readGlobalType(reader)
+		if err != nil {
+			return err
+		}
+
+		initStart := reader.offset
+		err = skipConstantExpression(reader)
+		if err != nil {
+			return err
+		}
+
+		module.Globals = append(module.Globals, &Global{
+			Type:    valueType,
+			Mutable: mutable,
+			Init:    reader.data[initStart:reader.offset],
+		})
+	}
+
+	return nil
+}
+
+func (module *Module) parseExportSection(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		name, err := reader.readName()
+		if err != nil {
+			return err
+		}
+
+		kind, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if ExternalKind(kind) > ExternalGlobal {
+			return fmt.Errorf("%w: %d", ErrInvalidImportKind, kind)
+		}
+
+		index, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+
+		module.Exports = append(module.Exports, &Export{
+			Name:  name,
+			Kind:  ExternalKind(kind),
+			Index: index,
+		})
+	}
+
+	return nil
+}
+
+func (module *Module) parseStartSection(reader *byteReader) error {
+	index, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	module.StartFunction = index
+	module.HasStart = true
+	return nil
+}
+
+func (module *Module) parseCodeSection(reader *byteReader, sectionOffset int) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		size, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+
+		bodyOffset := sectionOffset + reader.offset
+		bodyData, err := reader.readBytes(size)
+		if err != nil {
+			return err
+		}
+
+		body, err := parseFunctionBody(bodyData, bodyOffset)
+		if err != nil {
+			return fmt.Errorf("function body %d: %w", i, err)
+		}
+
+		module.Bodies = append(module.Bodies, body)
+	}
+
+	return nil
+}
+
+func parseFunctionBody(data []byte, offset int) (*FunctionBody, error) {
+	reader := newByteReader(data)
+
+	localEntriesCount, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	locals := make([]LocalEntry, 0, minInt(int(localEntriesCount), len(data)))
+	for i := uint32(0); i < localEntriesCount; i++ {
+		count, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+
+		valueType, err := reader.readValueType()
+		if err != nil {
+			return nil, err
+		}
+
+		locals = append(locals, LocalEntry{
+			Count: count,
+			Type:  valueType,
+		})
+	}
+
+	return &FunctionBody{
+		Locals: locals,
+		Code:   reader.data[reader.offset:],
+		Offset: offset + reader.offset,
+	}, nil
+}
+
+// ImportedFunctionsCount returns the number of functions imported by the module
+func (module *Module) ImportedFunctionsCount() uint32 {
+	count := uint32(0)
+	for _, imp := range module.Imports {
+		if imp.Kind == ExternalFunction {
+			count++
+		}
+	}
+	return count
+}
+
+// ImportedFunctionNames returns the names of the functions imported by the module
+func (module *Module) ImportedFunctionNames() []string {
+	names := make([]string, 0, len(module.Imports))
+	for _, imp := range module.Imports {
+		if imp.Kind == ExternalFunction {
+			names = append(names, imp.Name)
+		}
+	}
+	return names
+}
+
+// GetFunctionType returns the signature of the function with the given index,
+// where imported functions come first, followed by the defined functions
+func (module *Module) GetFunctionType(functionIndex uint32) (*FunctionType, bool) {
+	typeIndex, ok := module.getFunctionTypeIndex(functionIndex)
+	if !ok || typeIndex >= uint32(len(module.Types)) {
+		return nil, false
+	}
+
+	return module.Types[typeIndex], true
+}
+
+func (module *Module) getFunctionTypeIndex(functionIndex uint32) (uint32, bool) {
+	importedFunctionIndex := uint32(0)
+	for _, imp := range module.Imports {
+		if imp.Kind != ExternalFunction {
+			continue
+		}
+		if importedFunctionIndex == functionIndex {
+			return imp.TypeIndex, true
+		}
+		importedFunctionIndex++
+	}
+
+	definedIndex := functionIndex - importedFunctionIndex
+	if functionIndex < importedFunctionIndex || definedIndex >= uint32(len(module.Functions)) {
+		return 0, false
+	}
+
+	return module.Functions[definedIndex], true
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
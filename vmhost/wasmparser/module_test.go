@@ -0,0 +1,152 @@
+package wasmparser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestContract(t *testing.T, name string) []byte {
+	path := filepath.Join("..", "..", "test", "contracts", name, "output", name+".wasm")
+	code, err := os.ReadFile(path)
+	require.Nil(t, err)
+	return code
+}
+
+func TestParseModule_InvalidHeader(t *testing.T) {
+	_, err := ParseModule([]byte{})
+	require.Equal(t, ErrInvalidMagicNumber, err)
+
+	_, err = ParseModule([]byte{0x00, 0x61, 0x73, 0x6D, 0x02, 0x00, 0x00, 0x00})
+	require.Equal(t, ErrUnsupportedVersion, err)
+}
+
+func TestParseModule_Empty(t *testing.T) {
+	module, err := ParseModule([]byte{0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00})
+	require.Nil(t, err)
+	require.Len(t, module.Types, 0)
+	require.Len(t, module.Exports, 0)
+	require.False(t, module.HasStart)
+}
+
+func TestParseModule_Truncated(t *testing.T) {
+	code := loadTestContract(t, "counter")
+
+	_, err := ParseModule(code[:len(code)-3])
+	require.NotNil(t, err)
+}
+
+func TestParseModule_TestContracts(t *testing.T) {
+	module, err := ParseModule(loadTestContract(t, "counter"))
+	require.Nil(t, err)
+	require.Len(t, module.Memories, 1)
+	require.Equal(t, len(module.Functions), len(module.Bodies))
+
+	exportNames := make(map[string]bool)
+	for _, export := range module.Exports {
+		exportNames[export.Name] = true
+	}
+	require.True(t, exportNames["increment"])
+	require.True(t, exportNames["init"])
+
+	for _, imp := range module.Imports {
+		require.Equal(t, "env", imp.Module)
+	}
+	require.Contains(t, module.ImportedFunctionNames(), "int64storageLoad")
+}
+
+func TestReadInstructions_TestContracts(t *testing.T) {
+	names := []string{"counter", "signatures", "num-with-fp", "erc20"}
+
+	for _, name := range names {
+		module, err := ParseModule(loadTestContract(t, name))
+		require.Nil(t, err, name)
+
+		instructionsCount := 0
+		for _, body := range module.Bodies {
+			err = ReadInstructions(body.Code, func(instruction Instruction) error {
+				instructionsCount++
+				return nil
+			})
+			require.Nil(t, err, name)
+		}
+		require.Greater(t, instructionsCount, 0, name)
+	}
+}
+
+func TestReadInstructions_FloatDetection(t *testing.T) {
+	testCases := map[string]bool{
+		"counter":     false,
+		"signatures":  false,
+		"num-with-fp": true,
+	}
+
+	for name, expectedFloat := range testCases {
+		module, err := ParseModule(loadTestContract(t, name))
+		require.Nil(t, err, name)
+
+		foundFloat := false
+		for _, body := range module.Bodies {
+			err = ReadInstructions(body.Code, func(instruction Instruction) error {
+				if IsFloatInstruction(instruction) {
+					foundFloat = true
+				}
+				return nil
+			})
+			require.Nil(t, err, name)
+		}
+		require.Equal(t, expectedFloat, foundFloat, name)
+	}
+}
+
+func TestReadInstructions_Immediates(t *testing.T) {
+	code := []byte{
+		0x41, 0xFF, 0x00, // i32.const 127
+		0x02, 0x40, // block
+		0x0E, 0x02, 0x00, 0x01, 0x00, // br_table 0 1 0
+		0x0B,             // end
+		0x28, 0x02, 0x08, // i32.load align=2 offset=8
+		0xFC, 0x0A, 0x00, 0x00, // memory.copy
+		0x0B, // end
+	}
+
+	opcodes := make([]byte, 0)
+	err := ReadInstructions(code, func(instruction Instruction) error {
+		opcodes = append(opcodes, instruction.Opcode)
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x41, 0x02, 0x0E, 0x0B, 0x28, 0xFC, 0x0B}, opcodes)
+
+	err = ReadInstructions([]byte{0xFD, 0x00}, func(instruction Instruction) error {
+		return nil
+	})
+	require.ErrorIs(t, err, ErrUnsupportedOpcode)
+}
+
+func TestModule_GetFunctionType(t *testing.T) {
+	module := &Module{
+		Types: []*FunctionType{
+			{Params: []ValueType{ValueTypeI64}},
+			{Results: []ValueType{ValueTypeI32}},
+		},
+		Imports: []*Import{
+			{Module: "env", Name: "int64finish", Kind: ExternalFunction, TypeIndex: 0},
+			{Module: "env", Name: "memory", Kind: ExternalMemory},
+		},
+		Functions: []uint32{1},
+	}
+
+	functionType, ok := module.GetFunctionType(0)
+	require.True(t, ok)
+	require.Equal(t, "(i64) -> ()", functionType.String())
+
+	functionType, ok = module.GetFunctionType(1)
+	require.True(t, ok)
+	require.Equal(t, "() -> (i32)", functionType.String())
+
+	_, ok = module.GetFunctionType(2)
+	require.False(t, ok)
+}
@@ -0,0 +1,93 @@
+package wasmparser
+
+// nameSectionName is the name of the custom section holding the debug names
+const nameSectionName = "name"
+
+const (
+	nameSubsectionModule   = 0
+	nameSubsectionFunction = 1
+)
+
+// Names holds the debug names of the name section, when the module has one
+type Names struct {
+	ModuleName string
+	// FunctionNames maps function indices, imports included, to their names
+	FunctionNames map[uint32]string
+}
+
+// Names decodes the name section of the module; the names are only informative,
+// so a malformed name section yields the names decoded before the error, along with the error
+func (module *Module) Names() (*Names, error) {
+	names := &Names{
+		FunctionNames: make(map[uint32]string),
+	}
+
+	for _, section := range module.CustomSections {
+		if section.Name != nameSectionName {
+			continue
+		}
+
+		err := names.parseNameSection(section.Data)
+		if err != nil {
+			return names, err
+		}
+	}
+
+	return names, nil
+}
+
+func (names *Names) parseNameSection(data []byte) error {
+	reader := newByteReader(data)
+
+	for reader.hasMore() {
+		subsectionID, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		size, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+
+		subsection, err := reader.readBytes(size)
+		if err != nil {
+			return err
+		}
+
+		switch subsectionID {
+		case nameSubsectionModule:
+			names.ModuleName, err = newByteReader(subsection).readName()
+		case nameSubsectionFunction:
+			err = names.parseFunctionNames(newByteReader(subsection))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (names *Names) parseFunctionNames(reader *byteReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		index, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+
+		name, err := reader.readName()
+		if err != nil {
+			return err
+		}
+
+		names.FunctionNames[index] = name
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package wasmparser
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// byteReader reads the primitive encodings of the WASM binary format from a byte slice
+type byteReader struct {
+	data   []byte
+	offset int
+}
+
+func newByteReader(data []byte) *byteReader {
+	return &byteReader{
+		data:   data,
+		offset: 0,
+	}
+}
+
+func (reader *byteReader) hasMore() bool {
+	return reader.offset < len(reader.data)
+}
+
+func (reader *byteReader) readByte() (byte, error) {
+	if reader.offset >= len(reader.data) {
+		return 0, ErrUnexpectedEnd
+	}
+
+	b := reader.data[reader.offset]
+	reader.offset++
+	return b, nil
+}
+
+func (reader *byteReader) peekByte() (byte, error) {
+	if reader.offset >= len(reader.data) {
+		return 0, ErrUnexpectedEnd
+	}
+
+	return reader.data[reader.offset], nil
+}
+
+func (reader *byteReader) readBytes(length uint32) ([]byte, error) {
+	end := reader.offset + int(length)
+	if int(length) < 0 || end > len(reader.data) {
+		return nil, ErrUnexpectedEnd
+	}
+
+	result := reader.data[reader.offset:end]
+	reader.offset = end
+	return result, nil
+}
+
+func (reader *byteReader) skip(length uint32) error {
+	_, err := reader.readBytes(length)
+	return err
+}
+
+func (reader *byteReader) readU32() (uint32, error) {
+	value, err := reader.readUnsignedLEB128(32)
+	return uint32(value), err
+}
+
+func (reader *byteReader) readS32() (int32, error) {
+	value, err := reader.readSignedLEB128(32)
+	return int32(value), err
+}
+
+func (reader *byteReader) readS33() (int64, error) {
+	return reader.readSignedLEB128(33)
+}
+
+func (reader *byteReader) readS64() (int64, error) {
+	return reader.readSignedLEB128(64)
+}
+
+func (reader *byteReader) readUnsignedLEB128(maxBits uint) (uint64, error) {
+	var result uint64
+	var shift uint
+
+	for {
+		b, err := reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		if shift >= maxBits {
+			return 0, ErrInvalidLEB128
+		}
+
+		result |= uint64(b&0x7F) << shift
+		shift += 7
+
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if maxBits < 64 && result>>maxBits != 0 {
+		return 0, ErrInvalidLEB128
+	}
+
+	return result, nil
+}
+
+func (reader *byteReader) readSignedLEB128(maxBits uint) (int64, error) {
+	var result int64
+	var shift uint
+	var b byte
+	var err error
+
+	for {
+		b, err = reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		if shift >= maxBits {
+			return 0, ErrInvalidLEB128
+		}
+
+		result |= int64(b&0x7F) << shift
+		shift += 7
+
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if shift < 64 && b&0x40 != 0 {
+		result |= -1 << shift
+	}
+
+	return result, nil
+}
+
+func (reader *byteReader) readName() (string, error) {
+	length, err := reader.readU32()
+	if err != nil {
+		return "", err
+	}
+
+	nameBytes, err := reader.readBytes(length)
+	if err != nil {
+		return "", err
+	}
+
+	if !utf8.Valid(nameBytes) {
+		return "", fmt.Errorf("%w: name is not valid UTF-8", ErrInvalidSection)
+	}
+
+	return string(nameBytes), nil
+}
+
+func (reader *byteReader) readValueType() (ValueType, error) {
+	b, err := reader.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	valueType := ValueType(b)
+	if !valueType.isValid() {
+		return 0, fmt.Errorf("%w: 0x%02x", ErrInvalidValueType, b)
+	}
+
+	return valueType, nil
+}
+
+func (reader *byteReader) readLimits() (Limits, error) {
+	flags, err := reader.readByte()
+	if err != nil {
+		return Limits{}, err
+	}
+
+	initial, err := reader.readU32()
+	if err != nil {
+		return Limits{}, err
+	}
+
+	limits := Limits{
+		Initial:    initial,
+		Maximum:    0,
+		HasMaximum: false,
+	}
+
+	if flags&0x01 != 0 {
+		limits.Maximum, err = reader.readU32()
+		if err != nil {
+			return Limits{}, err
+		}
+		limits.HasMaximum = true
+	}
+
+	return limits, nil
+}
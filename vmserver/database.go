@@ -6,15 +6,19 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 )
 
 type database struct {
-	rootPath string
+	rootPath     string
+	enableEpochs config.EnableEpochs
 }
 
-// newDatabase creates a new debugging database (basically, a folder with JSON files)
-func newDatabase(rootPath string) *database {
-	db := &database{rootPath: rootPath}
+// newDatabase creates a new debugging database (basically, a folder with JSON files),
+// whose worlds activate the VM flags starting with the given epochs
+func newDatabase(rootPath string, enableEpochs config.EnableEpochs) *database {
+	db := &database{rootPath: rootPath, enableEpochs: enableEpochs}
 	db.initFolders()
 	return db
 }
@@ -43,7 +47,7 @@ func (db *database) loadWorld(worldID string) (*world, error) {
 		}
 	}
 
-	world, err := newWorld(dataModel)
+	world, err := newWorld(dataModel, db.enableEpochs)
 	if err != nil {
 		return nil, err
 	}
@@ -5,17 +5,26 @@ import (
 	"fmt"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 )
 
 var log = logger.GetOrCreate("vmserver")
 
 // DebugFacade is the debug facade
 type DebugFacade struct {
+	enableEpochs config.EnableEpochs
 }
 
 // NewDebugFacade creates a new debug facade
 func NewDebugFacade() *DebugFacade {
-	return &DebugFacade{}
+	return &DebugFacade{
+		enableEpochs: config.MakeDefaultEnableEpochs(),
+	}
+}
+
+// SetEnableEpochs sets the activation epochs of the VM flags, used by the worlds loaded afterwards
+func (f *DebugFacade) SetEnableEpochs(enableEpochs config.EnableEpochs) {
+	f.enableEpochs = enableEpochs
 }
 
 // DeploySmartContract deploys a smart contract
@@ -50,7 +59,7 @@ func (f *DebugFacade) DeploySmartContract(request DeployRequest) (*DeployRespons
 }
 
 func (f *DebugFacade) loadDatabase(rootPath string) *database {
-	database := newDatabase(rootPath)
+	database := newDatabase(rootPath, f.enableEpochs)
 	return database
 }
 
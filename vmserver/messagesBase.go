@@ -1,9 +1,12 @@
 package vmserver
 
 import (
+	"encoding/hex"
 	"math/big"
 
 	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/vmoutput"
 )
 
 // RequestBase is a CLI / REST request message
@@ -72,22 +75,45 @@ func (request *ContractRequestBase) digest() error {
 	return nil
 }
 
-// ContractResponseBase is a CLI / REST response message
+// ContractResponseBase is a CLI / REST response message;
+// the output is serialized in its canonical form, along with its hash,
+// and, when the request has an ABI, the results and events are also decoded into typed values
 type ContractResponseBase struct {
 	ResponseBase
 	Input            *vmcommon.VMInput
-	Output           *vmcommon.VMOutput
+	Output           *vmcommon.VMOutput `json:"-"`
+	CanonicalOutput  *vmoutput.VMOutput `json:"Output"`
+	OutputHash       string
 	ReturnCodeString string
+	Results          []interface{} `json:",omitempty"`
+	Events           []*abi.Event  `json:",omitempty"`
+	DecodingError    string        `json:",omitempty"`
+	Debug            *DebugInfo    `json:",omitempty"`
 }
 
-func createContractResponseBase(input *vmcommon.VMInput, output *vmcommon.VMOutput) ContractResponseBase {
+// DebugInfo holds execution details which are not part of the VMOutput
+type DebugInfo struct {
+	PeakMemoryPages uint64
+	PeakMemoryBytes uint64
+}
+
+func createContractResponseBase(input *vmcommon.VMInput, output *vmcommon.VMOutput, debug *DebugInfo) ContractResponseBase {
 	response := ContractResponseBase{
-		Input:  input,
-		Output: output,
+		Input:           input,
+		Output:          output,
+		CanonicalOutput: vmoutput.NewVMOutput(output),
+		Debug:           debug,
 	}
 
 	if output != nil {
 		response.ReturnCodeString = output.ReturnCode.String()
+
+		outputHash, err := vmoutput.Hash(output)
+		if err != nil {
+			log.Warn("createContractResponseBase: could not hash the output", "err", err)
+		} else {
+			response.OutputHash = hex.EncodeToString(outputHash)
+		}
 	}
 
 	return response
@@ -1,5 +1,14 @@
 package vmserver
 
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
+)
+
 // RunRequest is a CLI / REST request message
 type RunRequest struct {
 	ContractRequestBase
@@ -7,7 +16,12 @@ type RunRequest struct {
 	ContractAddress    []byte
 	Function           string
 	ArgumentsHex       []string
+	ArgumentsJSON      []json.RawMessage
 	Arguments          [][]byte
+	ABIPath            string
+	contractABI        *abi.ABI
+	codec              *abi.Codec
+	endpoint           *abi.EndpointDescription
 }
 
 func (request *RunRequest) digest() error {
@@ -16,11 +30,27 @@ func (request *RunRequest) digest() error {
 		return err
 	}
 
-	request.Arguments, err = decodeArguments(request.ArgumentsHex)
+	err = request.loadABI()
 	if err != nil {
 		return err
 	}
 
+	if len(request.ArgumentsJSON) > 0 {
+		if len(request.ArgumentsHex) > 0 {
+			return NewRequestError("both hex and JSON arguments given")
+		}
+
+		request.Arguments, err = request.encodeJSONArguments()
+		if err != nil {
+			return err
+		}
+	} else {
+		request.Arguments, err = decodeArguments(request.ArgumentsHex)
+		if err != nil {
+			return err
+		}
+	}
+
 	request.ContractAddress, err = fromHex(request.ContractAddressHex)
 	if err != nil {
 		return err
@@ -29,6 +59,90 @@ func (request *RunRequest) digest() error {
 	return nil
 }
 
+func (request *RunRequest) loadABI() error {
+	if request.ABIPath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(request.ABIPath)
+	if err != nil {
+		return NewRequestErrorMessageInner("cannot read the ABI", err)
+	}
+
+	request.contractABI, err = abi.ParseABI(data)
+	if err != nil {
+		return NewRequestErrorMessageInner("invalid ABI", err)
+	}
+
+	request.codec, err = abi.NewCodec(request.contractABI)
+	if err != nil {
+		return NewRequestErrorMessageInner("invalid ABI", err)
+	}
+
+	endpoint, ok := request.contractABI.Endpoint(request.Function)
+	if !ok {
+		return NewRequestErrorMessageInner(request.Function, abi.ErrUnknownEndpoint)
+	}
+
+	request.endpoint = endpoint
+	return nil
+}
+
+func (request *RunRequest) encodeJSONArguments() ([][]byte, error) {
+	if request.codec == nil {
+		return nil, NewRequestError("JSON arguments require an ABI")
+	}
+
+	values := make([]interface{}, len(request.ArgumentsJSON))
+	for i, argument := range request.ArgumentsJSON {
+		decoder := json.NewDecoder(bytes.NewReader(argument))
+		decoder.UseNumber()
+
+		err := decoder.Decode(&values[i])
+		if err != nil {
+			return nil, NewRequestErrorMessageInner("invalid JSON argument", err)
+		}
+	}
+
+	arguments, err := request.codec.EncodeArguments(request.endpoint, values)
+	if err != nil {
+		return nil, NewRequestErrorMessageInner("cannot encode the JSON arguments", err)
+	}
+
+	return arguments, nil
+}
+
+// decodeOutput fills in the typed results and events of a successful call, when the ABI is known;
+// a decoding failure does not fail the call, it is only reported in the response
+func (request *RunRequest) decodeOutput(response *ContractResponseBase) {
+	output := response.Output
+	if request.codec == nil || output == nil || output.ReturnCode != vmcommon.Ok {
+		return
+	}
+
+	results, err := request.codec.DecodeResults(request.endpoint, output.ReturnData)
+	if err != nil {
+		response.DecodingError = err.Error()
+		return
+	}
+
+	response.Results = make([]interface{}, len(results))
+	for i, result := range results {
+		response.Results[i] = abi.JSONValue(result)
+	}
+
+	eventDecoder, err := abi.NewEventDecoder(request.contractABI)
+	if err != nil {
+		response.DecodingError = err.Error()
+		return
+	}
+
+	response.Events, err = eventDecoder.DecodeVMOutput(output)
+	if err != nil {
+		response.DecodingError = err.Error()
+	}
+}
+
 // RunResponse is a CLI / REST response message
 type RunResponse struct {
 	ContractResponseBase
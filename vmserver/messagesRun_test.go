@@ -0,0 +1,90 @@
+package vmserver
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/abi"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `{
+	"name": "Adder",
+	"endpoints": [
+		{
+			"name": "add",
+			"inputs": [{"name": "values", "type": "List<u32>"}],
+			"outputs": [{"type": "BigUint"}]
+		}
+	]
+}`
+
+func createRunRequestWithABI(t *testing.T, function string) RunRequest {
+	abiPath := filepath.Join(t.TempDir(), "adder.abi.json")
+	err := os.WriteFile(abiPath, []byte(testABI), 0644)
+	require.Nil(t, err)
+
+	return RunRequest{
+		ContractRequestBase: ContractRequestBase{
+			ImpersonatedHex: "1234",
+			GasLimit:        1000,
+		},
+		ContractAddressHex: "abcd",
+		Function:           function,
+		ABIPath:            abiPath,
+	}
+}
+
+func Test_RunRequest_JSONArguments(t *testing.T) {
+	request := createRunRequestWithABI(t, "add")
+	request.ArgumentsJSON = []json.RawMessage{json.RawMessage(`[1, "2"]`)}
+
+	err := request.digest()
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{0, 0, 0, 1, 0, 0, 0, 2}}, request.Arguments)
+
+	request = createRunRequestWithABI(t, "add")
+	request.ArgumentsJSON = []json.RawMessage{json.RawMessage(`[1]`)}
+	request.ArgumentsHex = []string{"01"}
+	require.NotNil(t, request.digest())
+
+	request = createRunRequestWithABI(t, "missing")
+	err = request.digest()
+	require.True(t, errors.Is(err, abi.ErrUnknownEndpoint))
+
+	request = createRunRequestWithABI(t, "add")
+	request.ABIPath = ""
+	request.ArgumentsJSON = []json.RawMessage{json.RawMessage(`[1]`)}
+	require.NotNil(t, request.digest())
+}
+
+func Test_RunRequest_DecodeOutput(t *testing.T) {
+	request := createRunRequestWithABI(t, "add")
+	err := request.digest()
+	require.Nil(t, err)
+
+	response := ContractResponseBase{
+		Output: &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, ReturnData: [][]byte{{0x03}}},
+	}
+	request.decodeOutput(&response)
+	require.Equal(t, "", response.DecodingError)
+	require.Equal(t, []interface{}{"3"}, response.Results)
+
+	response = ContractResponseBase{
+		Output: &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, ReturnData: [][]byte{}},
+	}
+	request.decodeOutput(&response)
+	require.NotEqual(t, "", response.DecodingError)
+	require.Nil(t, response.Results)
+
+	response = ContractResponseBase{
+		Output: &vmcommon.VMOutput{ReturnCode: vmcommon.UserError},
+	}
+	request.decodeOutput(&response)
+	require.Nil(t, response.Results)
+	require.Equal(t, "", response.DecodingError)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -171,7 +172,7 @@ func (context *testContext) createRequestBase() RequestBase {
 }
 
 func (context *testContext) loadWorld() *world {
-	database := newDatabase(databasePath)
+	database := newDatabase(databasePath, config.MakeDefaultEnableEpochs())
 	world, err := database.loadWorld(context.worldID)
 	require.Nil(context.t, err)
 
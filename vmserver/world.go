@@ -1,10 +1,8 @@
 package vmserver
 
 import (
-	"github.com/multiversx/mx-chain-core-go/core"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/config"
-	"github.com/multiversx/mx-chain-vm-v1_2-go/mock"
 	worldmock "github.com/multiversx/mx-chain-vm-v1_2-go/mock/world"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-v1_2-go/vmhost/hostCore"
@@ -19,6 +17,7 @@ type world struct {
 	id             string
 	blockchainHook *worldmock.MockWorld
 	vm             vmcommon.VMExecutionHandler
+	runtime        vmhost.RuntimeContext
 }
 
 func newWorldDataModel(worldID string) *worldDataModel {
@@ -29,13 +28,13 @@ func newWorldDataModel(worldID string) *worldDataModel {
 }
 
 // newWorld creates a new debugging world
-func newWorld(dataModel *worldDataModel) (*world, error) {
+func newWorld(dataModel *worldDataModel, enableEpochs config.EnableEpochs) (*world, error) {
 	blockchainHook := worldmock.NewMockWorld()
 	blockchainHook.AcctMap = dataModel.Accounts
 
 	vm, err := hostCore.NewVMHost(
 		blockchainHook,
-		getHostParameters(),
+		getHostParameters(enableEpochs),
 	)
 	if err != nil {
 		return nil, err
@@ -45,20 +44,26 @@ func newWorld(dataModel *worldDataModel) (*world, error) {
 		id:             dataModel.ID,
 		blockchainHook: blockchainHook,
 		vm:             vm,
+		runtime:        vm.Runtime(),
 	}, nil
 }
 
-func getHostParameters() *vmhost.VMHostParameters {
+func getHostParameters(enableEpochs config.EnableEpochs) *vmhost.VMHostParameters {
 	return &vmhost.VMHostParameters{
-		VMType:             []byte{5, 0},
-		BlockGasLimit:      uint64(10000000),
-		GasSchedule:        config.MakeGasMap(1, 1),
-		ProtectedKeyPrefix: []byte("E" + "L" + "R" + "O" + "N" + "D"),
-		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
-			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag
-			},
-		},
+		VMType:              []byte{5, 0},
+		BlockGasLimit:       uint64(10000000),
+		GasSchedule:         config.MakeGasMap(1, 1),
+		ProtectedKeyPrefix:  []byte("E" + "L" + "R" + "O" + "N" + "D"),
+		EnableEpochsHandler: hostCore.NewEnableEpochsHandler(enableEpochs),
+	}
+}
+
+// debugInfo reports the details of the last execution
+func (w *world) debugInfo() *DebugInfo {
+	peakMemoryPages := w.runtime.PeakMemoryPages()
+	return &DebugInfo{
+		PeakMemoryPages: peakMemoryPages,
+		PeakMemoryBytes: peakMemoryPages * vmhost.WASMPageSize,
 	}
 }
 
@@ -72,7 +77,7 @@ func (w *world) deploySmartContract(request DeployRequest) *DeployResponse {
 	}
 
 	response := &DeployResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.debugInfo())
 	response.Error = err
 	response.ContractAddress = w.blockchainHook.LastCreatedContractAddress
 	response.ContractAddressHex = toHex(response.ContractAddress)
@@ -89,7 +94,7 @@ func (w *world) upgradeSmartContract(request UpgradeRequest) *UpgradeResponse {
 	}
 
 	response := &UpgradeResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.debugInfo())
 	response.Error = err
 
 	return response
@@ -105,8 +110,9 @@ func (w *world) runSmartContract(request RunRequest) *RunResponse {
 	}
 
 	response := &RunResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.debugInfo())
 	response.Error = err
+	request.decodeOutput(&response.ContractResponseBase)
 
 	return response
 }
@@ -118,8 +124,9 @@ func (w *world) querySmartContract(request QueryRequest) *QueryResponse {
 	vmOutput, err := w.vm.RunSmartContractCall(input)
 
 	response := &QueryResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.debugInfo())
 	response.Error = err
+	request.decodeOutput(&response.ContractResponseBase)
 
 	return response
 }